@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/changelog"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+)
+
+// changelogRelease is a past release rendered in the regenerated changelog.
+type changelogRelease struct {
+	tag   parser.SemverTag
+	notes string
+}
+
+func NewChangelogCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var prereleasesFlag bool
+
+	changelogCmd := &cobra.Command{
+		Use:   "changelog <REPOSITORY_PATH_OR_URL>",
+		Short: "Regenerate the whole changelog of a Git repository from its existing releases",
+		Long:  "Walk every semantic version tag of the given repository, of every project if executed in a monorepo, and render a changelog section per release listing the commits since the previous one. The changelog is written to the changelog path, replacing it, or printed out if the path is empty",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx.Rules, err = configureRules(ctx)
+			if err != nil {
+				return fmt.Errorf("loading rules configuration: %w", err)
+			}
+
+			ctx.CommitPattern, err = configureCommitPattern(ctx)
+			if err != nil {
+				return fmt.Errorf("loading commit pattern configuration: %w", err)
+			}
+
+			ctx.Projects, err = configureProjects(ctx)
+			if err != nil {
+				return fmt.Errorf("loading projects configuration: %w", err)
+			}
+
+			ctx.TagIgnorePatterns, err = configureTagIgnorePatterns(ctx)
+			if err != nil {
+				return fmt.Errorf("loading tag ignore patterns configuration: %w", err)
+			}
+
+			ctx.IssuePattern, err = configureIssuePattern(ctx)
+			if err != nil {
+				return fmt.Errorf("loading issue pattern configuration: %w", err)
+			}
+
+			links, err := repositoryLinks(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("configuring repository links: %w", err)
+			}
+
+			cmdCtx, cancel := commandContext(cmd, ctx)
+			defer cancel()
+
+			origin, err := newRemote(cmdCtx, ctx)
+			if err != nil {
+				return fmt.Errorf("configuring remote: %w", err)
+			}
+
+			repository, err := origin.Clone(cmdCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("cloning Git repository: %w", err)
+			}
+
+			projects := ctx.Projects
+			if len(projects) == 0 {
+				projects = []monorepo.Project{{}}
+			}
+
+			p := parser.New(ctx)
+			defer p.Close()
+
+			var releases []changelogRelease
+
+			for _, project := range projects {
+				tags, err := p.SemverTags(repository, project)
+				if err != nil {
+					return fmt.Errorf("fetching semver tags: %w", err)
+				}
+
+				var previous *parser.SemverTag
+
+				for _, semverTag := range tags {
+					if semverTag.Version.Prerelease != "" && !prereleasesFlag {
+						continue
+					}
+
+					from := plumbing.ZeroHash
+					notesOptions := []changelog.OptionFunc{changelog.WithLinks(links)}
+
+					if previous != nil {
+						from = previous.Hash
+						notesOptions = append(notesOptions, changelog.WithPreviousTag(previous.Name))
+					}
+
+					commits, err := p.Commits(cmdCtx, repository, project, from, semverTag.Hash)
+					if err != nil {
+						return fmt.Errorf("fetching commits of tag %q: %w", semverTag.Name, err)
+					}
+
+					releases = append(releases, changelogRelease{
+						tag:   semverTag,
+						notes: changelog.Render(semverTag.Name, semverTag.Date, commits, notesOptions...),
+					})
+
+					previous = &semverTag
+				}
+			}
+
+			// Releases of every project are listed together, from the most recent to the oldest, those released at the
+			// same time being sorted by version
+			slices.Reverse(releases)
+			sort.SliceStable(releases, func(i, j int) bool {
+				return releases[i].tag.Date.After(releases[j].tag.Date)
+			})
+
+			sections := make([]string, 0, len(releases))
+			for _, release := range releases {
+				sections = append(sections, release.notes)
+			}
+
+			document := changelog.Document(sections)
+
+			if ctx.ChangelogPathFlag == "" {
+				_, err = fmt.Fprint(cmd.OutOrStdout(), document)
+				return err
+			}
+
+			if err = os.WriteFile(ctx.ChangelogPathFlag, []byte(document), 0o644); err != nil {
+				return fmt.Errorf("writing changelog: %w", err)
+			}
+
+			ctx.Logger.Info().Str("path", ctx.ChangelogPathFlag).Int("releases", len(releases)).Msg("changelog regenerated")
+
+			return nil
+		},
+	}
+
+	changelogCmd.Flags().BoolVar(&prereleasesFlag, "prereleases", false, "Render a section for every prerelease, which are otherwise folded into the following release")
+
+	return changelogCmd
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/changelog"
+)
+
+func TestChangelogCmd(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, nil)
+
+	tags := []struct {
+		message string
+		tagName string
+	}{
+		{message: "feat: add foo", tagName: "v0.1.0"},
+		{message: "fix: fix foo", tagName: "v0.1.1-rc.1"},
+		{message: "fix: fix bar\n\nCloses #12", tagName: "v0.1.1"},
+		{message: "feat: add baz"},
+	}
+
+	for _, item := range tags {
+		hash, err := testRepository.AddCommitWithMessage(item.message)
+		checkErr(t, err, "adding commit")
+
+		if item.tagName == "" {
+			continue
+		}
+
+		err = testRepository.AddTag(item.tagName, hash)
+		checkErr(t, err, "adding tag")
+	}
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("changelog", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content := string(out)
+
+	assert.True(strings.HasPrefix(content, changelog.Header+"\n\n## v0.1.1 ("), "most recent release should come first")
+	assert.Contains(content, "- fix foo (")
+	assert.Contains(content, "- fix bar (")
+	assert.Contains(content, ", closes #12\n")
+	assert.Contains(content, "## v0.1.0 (")
+	assert.Less(strings.Index(content, "fix bar"), strings.Index(content, "## v0.1.0"), "commits should be listed under their release")
+	assert.NotContains(content, "v0.1.1-rc.1", "prereleases should be folded into the following release")
+	assert.NotContains(content, "add baz", "unreleased commits should not be listed")
+
+	changelogPath := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	err = os.WriteFile(changelogPath, []byte("outdated"), 0o644)
+	checkErr(t, err, "writing changelog")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		ChangelogPathConfiguration: changelogPath,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = ExecuteCommand(th.Cmd, "changelog", "--prereleases", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	written, err := os.ReadFile(changelogPath)
+	checkErr(t, err, "reading changelog")
+
+	assert.NotContains(string(written), "outdated", "changelog should be regenerated from scratch")
+	assert.Contains(string(written), "## v0.1.1-rc.1 (")
+	assert.Less(strings.Index(string(written), "## v0.1.1 ("), strings.Index(string(written), "## v0.1.1-rc.1 ("))
+	assert.Less(strings.Index(string(written), "fix bar"), strings.Index(string(written), "## v0.1.1-rc.1 ("), "prerelease commits should be listed under the prerelease")
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/output"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/scheme"
+)
+
+type compareOutput struct {
+	From        string                `json:"from"`
+	To          string                `json:"to"`
+	Project     string                `json:"project,omitempty"`
+	Version     string                `json:"version"`
+	NextVersion string                `json:"next-version"`
+	Bump        string                `json:"bump"`
+	Commits     []output.CommitReport `json:"commits"`
+}
+
+func NewCompareCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var jsonFlag bool
+
+	compareCmd := &cobra.Command{
+		Use:   "compare <REPOSITORY_PATH_OR_URL> <FROM> [TO]",
+		Short: "List the commits between two revisions of a Git repository and the release they imply",
+		Long:  "Classify the commits between two revisions of the given repository, such as two tags or a tag and HEAD (default), for every project if executed in a monorepo, and print out the version bump they would trigger, without tagging anything",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			from, to := args[1], "HEAD"
+			if len(args) == 3 {
+				to = args[2]
+			}
+
+			ctx.Rules, err = configureRules(ctx)
+			if err != nil {
+				return fmt.Errorf("loading rules configuration: %w", err)
+			}
+
+			ctx.CommitPattern, err = configureCommitPattern(ctx)
+			if err != nil {
+				return fmt.Errorf("loading commit pattern configuration: %w", err)
+			}
+
+			ctx.CommitVerifier, err = configureCommitVerifier(ctx)
+			if err != nil {
+				return fmt.Errorf("loading signature policy configuration: %w", err)
+			}
+
+			ctx.Scheme, err = scheme.New(ctx.VersionSchemeFlag, ctx.CalVerFormatFlag)
+			if err != nil {
+				return fmt.Errorf("loading versioning scheme configuration: %w", err)
+			}
+
+			ctx.Projects, err = configureProjects(ctx)
+			if err != nil {
+				return fmt.Errorf("loading projects configuration: %w", err)
+			}
+
+			ctx.TagIgnorePatterns, err = configureTagIgnorePatterns(ctx)
+			if err != nil {
+				return fmt.Errorf("loading tag ignore patterns configuration: %w", err)
+			}
+
+			cmdCtx, cancel := commandContext(cmd, ctx)
+			defer cancel()
+
+			origin, err := newRemote(cmdCtx, ctx)
+			if err != nil {
+				return fmt.Errorf("configuring remote: %w", err)
+			}
+
+			repository, err := origin.Clone(cmdCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("cloning Git repository: %w", err)
+			}
+
+			projects := ctx.Projects
+			if len(projects) == 0 {
+				projects = []monorepo.Project{{}}
+			}
+
+			p := parser.New(ctx)
+			defer p.Close()
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+
+			for _, project := range projects {
+				comparison, err := p.Compare(cmdCtx, repository, project, from, to)
+				if err != nil {
+					return fmt.Errorf("comparing revisions: %w", err)
+				}
+
+				bump, err := output.Bump(output.Release{
+					NewRelease:      len(comparison.Commits) > 0,
+					Version:         comparison.NextVersion.String(),
+					PreviousVersion: comparison.Version.String(),
+				})
+				if err != nil {
+					return fmt.Errorf("computing bump: %w", err)
+				}
+
+				commits := commitReports(comparison.Report)
+
+				if jsonFlag {
+					err = encoder.Encode(compareOutput{
+						From:        from,
+						To:          to,
+						Project:     project.Name,
+						Version:     comparison.Version.String(),
+						NextVersion: comparison.NextVersion.String(),
+						Bump:        bump,
+						Commits:     commits,
+					})
+					if err != nil {
+						return fmt.Errorf("encoding output: %w", err)
+					}
+
+					continue
+				}
+
+				header := fmt.Sprintf("%s...%s", from, to)
+				if project.Name != "" {
+					header += " (" + project.Name + ")"
+				}
+
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s: bump=%s version=%s next-version=%s\n", header, bump, comparison.Version, comparison.NextVersion)
+
+				for _, commit := range commits {
+					_, _ = fmt.Fprintln(cmd.OutOrStdout(), "  "+commit.String())
+				}
+			}
+
+			return nil
+		},
+	}
+
+	compareCmd.Flags().BoolVar(&jsonFlag, "json", false, "Print the comparison as JSON")
+
+	return compareCmd
+}
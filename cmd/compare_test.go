@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/output"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func TestCompareCmd_PlainOutput(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v1.2.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	for _, commitType := range []string{"fix", "chore", "feat"} {
+		_, err = testRepository.AddCommit(commitType)
+		checkErr(t, err, "adding commit")
+	}
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("compare", testRepository.Path, "v1.2.0")
+	checkErr(t, err, "executing command")
+
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+
+	if assert.Len(lines, 4) {
+		assert.Equal("v1.2.0...HEAD: bump=minor version=1.2.0 next-version=1.3.0", lines[0])
+		assert.Contains(lines[1], " fix rule=patch bump=patch")
+		assert.Contains(lines[2], " chore ignored: "+parser.ReasonSkippedByRule)
+		assert.Contains(lines[3], " feat rule=minor bump=minor")
+	}
+
+	exists, err := tag.Exists(testRepository.Repository, "v1.3.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.False(exists, "compare command should not tag the repository")
+}
+
+func TestCompareCmd_JSONOutput(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	first, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	fix, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	_, err = testRepository.AddCommit("feat!")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("compare", testRepository.Path, first.Hash().String(), fix.String(), "--json")
+	checkErr(t, err, "executing command")
+
+	actualOut := compareOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	want := compareOutput{
+		From:        first.Hash().String(),
+		To:          fix.String(),
+		Version:     "0.0.0",
+		NextVersion: "0.0.1",
+		Bump:        output.BumpPatch,
+		Commits:     []output.CommitReport{{Hash: fix.String(), Type: "fix", Rule: "patch", Bump: "patch"}},
+	}
+
+	assert.Equal(want, actualOut, "commits after the second revision should be excluded")
+}
+
+func TestCompareCmd_InvalidRevision(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+
+	_, err := th.ExecuteCommand("compare", testRepository.Path, "v9.9.9")
+	assert.ErrorIs(err, parser.ErrInvalidRevision)
+}
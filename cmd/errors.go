@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/ci"
+	"github.com/s0ders/go-semver-release/v6/internal/commit"
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
+	"github.com/s0ders/go-semver-release/v6/internal/gpg"
+	"github.com/s0ders/go-semver-release/v6/internal/hook"
+	"github.com/s0ders/go-semver-release/v6/internal/logging"
+	"github.com/s0ders/go-semver-release/v6/internal/mirror"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/oci"
+	"github.com/s0ders/go-semver-release/v6/internal/output"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/plugin"
+	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/scheme"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+// Codes of the errors reported when a command fails.
+const (
+	ErrorCodeUnknown               = "ERR_UNKNOWN"
+	ErrorCodeInvalidConfiguration  = "ERR_INVALID_CONFIGURATION"
+	ErrorCodeInvalidRules          = "ERR_INVALID_RULES"
+	ErrorCodeInvalidBranches       = "ERR_INVALID_BRANCHES"
+	ErrorCodeInvalidMonorepo       = "ERR_INVALID_MONOREPO"
+	ErrorCodeUnknownRepository     = "ERR_UNKNOWN_FORGE_REPOSITORY"
+	ErrorCodeRepositoryNotFound    = "ERR_REPOSITORY_NOT_FOUND"
+	ErrorCodeEmptyRepository       = "ERR_EMPTY_REPOSITORY"
+	ErrorCodeNoHead                = "ERR_NO_HEAD"
+	ErrorCodeAuthentication        = "ERR_AUTHENTICATION"
+	ErrorCodeSigningKey            = "ERR_SIGNING_KEY"
+	ErrorCodeDirtyWorktree         = "ERR_DIRTY_WORKTREE"
+	ErrorCodeUnsyncedBranch        = "ERR_UNSYNCED_BRANCH"
+	ErrorCodeShallowHistory        = "ERR_SHALLOW_HISTORY"
+	ErrorCodeInvalidRevision       = "ERR_INVALID_REVISION"
+	ErrorCodeNonConventionalCommit = "ERR_NON_CONVENTIONAL_COMMIT"
+	ErrorCodeInvalidReleaseAs      = "ERR_INVALID_RELEASE_AS"
+	ErrorCodeUnverifiedCommit      = "ERR_UNVERIFIED_COMMIT"
+	ErrorCodeNoRelease             = "ERR_NO_RELEASE"
+	ErrorCodeNoReleaseInRange      = "ERR_NO_RELEASE_IN_RANGE"
+	ErrorCodeTagExists             = "ERR_TAG_EXISTS"
+	ErrorCodeLocked                = "ERR_LOCKED"
+	ErrorCodeVersionNotGreater     = "ERR_VERSION_NOT_GREATER"
+	ErrorCodeHookFailed            = "ERR_HOOK_FAILED"
+	ErrorCodePluginFailed          = "ERR_PLUGIN_FAILED"
+	ErrorCodeAPI                   = "ERR_API"
+	ErrorCodeLintFailed            = "ERR_LINT_FAILED"
+	ErrorCodeUnverifiedTags        = "ERR_UNVERIFIED_TAGS"
+	ErrorCodeNoSemverTag           = "ERR_NO_SEMVER_TAG"
+	ErrorCodeTimeout               = "ERR_TIMEOUT"
+	ErrorCodeCanceled              = "ERR_CANCELED"
+)
+
+// ErrorReport is the machine-readable description of the failure of a command.
+type ErrorReport struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Hint suggests how to fix the failure, empty if there is no general advice for it.
+	Hint string `json:"hint,omitempty"`
+}
+
+// errorCode is the code, and hint, of the errors wrapping one of its targets.
+type errorCode struct {
+	targets []error
+	code    string
+	hint    string
+}
+
+// errorCodes are matched in order, the first one whose target is wrapped by an error gives its code.
+var errorCodes = []errorCode{
+	{
+		targets: []error{ErrNoRelease},
+		code:    ErrorCodeNoRelease,
+		hint:    "no commit since the latest release triggers a new one, unset --fail-on-no-release to succeed in this case",
+	},
+	{
+		targets: []error{tag.ErrTagAlreadyExists, remote.ErrTagConflict},
+		code:    ErrorCodeTagExists,
+		hint:    "the release tag already exists on the remote, use --force to move it",
+	},
+	{
+		targets: []error{remote.ErrLocked},
+		code:    ErrorCodeLocked,
+		hint:    "another run holds the lock of the remote, increase --lock-timeout or delete refs/go-semver-release/lock from the remote if an interrupted run left it",
+	},
+	{
+		targets: []error{tag.ErrVersionNotGreater},
+		code:    ErrorCodeVersionNotGreater,
+		hint:    "the released version must be greater than the latest one, check --set-version and the Release-As footers",
+	},
+	{
+		targets: []error{parser.ErrNoReleaseInRange},
+		code:    ErrorCodeNoReleaseInRange,
+		hint:    "the next version of the maintenance branch is out of its range, check the range of the branch",
+	},
+	{
+		targets: []error{parser.ErrNonConventionalCommit, parser.ErrUnknownCommitType},
+		code:    ErrorCodeNonConventionalCommit,
+		hint:    "a commit does not follow the Conventional Commits specification, unset --strict to ignore such commits",
+	},
+	{
+		targets: []error{parser.ErrInvalidReleaseAs},
+		code:    ErrorCodeInvalidReleaseAs,
+		hint:    "a Release-As footer does not hold a valid semantic version",
+	},
+	{
+		targets: []error{parser.ErrUnverifiedCommit},
+		code:    ErrorCodeUnverifiedCommit,
+		hint:    "a commit triggering the release is not signed by a trusted key, check the signature policy and the trusted keys",
+	},
+	{
+		targets: []error{hook.ErrHookFailed},
+		code:    ErrorCodeHookFailed,
+		hint:    "a hook exited with a non-zero code, its output is written to the standard error",
+	},
+	{
+		targets: []error{plugin.ErrPluginFailed, plugin.ErrInvalidVersion},
+		code:    ErrorCodePluginFailed,
+		hint:    "a plugin failed, its output is written to the standard error",
+	},
+	{
+		targets: []error{ErrLintFailed},
+		code:    ErrorCodeLintFailed,
+	},
+	{
+		targets: []error{ErrUnverifiedTags},
+		code:    ErrorCodeUnverifiedTags,
+	},
+	{
+		targets: []error{ErrNoSemverTag},
+		code:    ErrorCodeNoSemverTag,
+	},
+	{
+		targets: []error{ErrDirtyWorktree, remote.ErrDirtyWorktree},
+		code:    ErrorCodeDirtyWorktree,
+		hint:    "commit or stash the changes of the worktree before releasing",
+	},
+	{
+		targets: []error{remote.ErrUnsyncedBranch},
+		code:    ErrorCodeUnsyncedBranch,
+		hint:    "push or pull the release branch so that it matches its upstream before releasing",
+	},
+	{
+		targets: []error{rule.ErrInvalidCommitType, rule.ErrInvalidReleaseType, rule.ErrDuplicateReleaseRule, rule.ErrNoRules, rule.ErrUnknownFormat, rule.ErrUnknownPreset, rule.ErrInvalidRulesFile},
+		code:    ErrorCodeInvalidRules,
+		hint:    "check the release rules, each commit type can only be given a single release type",
+	},
+	{
+		targets: []error{branch.ErrNoBranch, branch.ErrNoName, branch.ErrInvalidPrereleaseIdentifier, branch.ErrInvalidRange},
+		code:    ErrorCodeInvalidBranches,
+		hint:    "check the branches configuration, at least one branch with a name is required",
+	},
+	{
+		targets: []error{monorepo.ErrNoProjects, monorepo.ErrNoName, monorepo.ErrNoPath, monorepo.ErrUnknownDependency, monorepo.ErrDependencyCycle, monorepo.ErrInvalidPrereleaseID, monorepo.ErrInvalidInitialVersion},
+		code:    ErrorCodeInvalidMonorepo,
+		hint:    "check the monorepo configuration, every project needs a name and a path",
+	},
+	{
+		targets: []error{ErrNoGitHubRepository, ErrNoBitbucketRepository, ErrNoGiteaRepository, ErrNoGitLabProject},
+		code:    ErrorCodeUnknownRepository,
+		hint:    "set the repository of the forge explicitly, it cannot be deduced from the repository URL",
+	},
+	{
+		targets: []error{
+			ErrConflictingSignKeys, ErrSignedLightweightTags, ErrInvalidInitialVersion, ErrInvalidIgnorePattern,
+			ErrInvalidIssuePattern, ErrCommitReleaseCommit, ErrIncompleteGitHubApp, ErrConflictingTokens,
+			ErrConflictingAPITags, ErrInvalidSignPolicy, ErrInvalidForceBump, ErrInvalidSetVersion,
+			ErrConflictingOverrides, ErrNoDockerImage, ErrNoVerificationKeys, ErrNoServeEndpoint, ErrNoServeRepositories,
+			ErrInvalidTagDate, ErrInvalidCACert, ErrNoLedgerBranch, ErrConflictingSecrets, ErrSeveralStdinSecrets,
+			ErrLightweightTagNotes,
+			output.ErrInvalidFormat, logging.ErrInvalidLevel, logging.ErrInvalidFormat, scheme.ErrUnknownScheme,
+			scheme.ErrInvalidCalVerFormat, parser.ErrInvalidCommitPattern, parser.ErrInvalidBuildMetadata,
+			forge.ErrInvalidTemplate, ci.ErrUnknownProvider, hook.ErrInvalidStep, plugin.ErrNoPath,
+			plugin.ErrInvalidPhase, oci.ErrInvalidImage, mirror.ErrNoURL, mirror.ErrDuplicateName,
+		},
+		code: ErrorCodeInvalidConfiguration,
+		hint: "check the flags, environment variables and configuration file",
+	},
+	{
+		targets: []error{
+			gpg.ErrPassphraseRequired, gpg.ErrKeyNotFound, gpg.ErrInvalidKeyID, gpg.ErrKeyExpired, gpg.ErrKeyRevoked,
+			gpg.ErrCannotSign,
+		},
+		code: ErrorCodeSigningKey,
+		hint: "check the signing key, its validity and its passphrase",
+	},
+	{
+		targets: []error{transport.ErrAuthenticationRequired, transport.ErrAuthorizationFailed, oci.ErrUnauthorized},
+		code:    ErrorCodeAuthentication,
+		hint:    "check the access token, or the SSH key, and its permissions",
+	},
+	{
+		targets: []error{git.ErrRepositoryNotExists, transport.ErrRepositoryNotFound},
+		code:    ErrorCodeRepositoryNotFound,
+		hint:    "check the repository path or URL",
+	},
+	{
+		targets: []error{transport.ErrEmptyRemoteRepository},
+		code:    ErrorCodeEmptyRepository,
+		hint:    "the repository has no commit yet",
+	},
+	{
+		targets: []error{commit.ErrShallowHistory},
+		code:    ErrorCodeShallowHistory,
+		hint:    "fetch the whole history of the repository (e.g., fetch-depth: 0 on GitHub Actions)",
+	},
+	{
+		targets: []error{parser.ErrInvalidRevision, parser.ErrCommitNotOnBranch},
+		code:    ErrorCodeInvalidRevision,
+		hint:    "check the --from, --to and --commit revisions",
+	},
+	{
+		targets: []error{plumbing.ErrReferenceNotFound},
+		code:    ErrorCodeNoHead,
+		hint:    "the repository has no commit or a release branch does not exist, check the branches configuration and the remote",
+	},
+	{
+		targets: []error{restapi.ErrUnexpectedResponse},
+		code:    ErrorCodeAPI,
+		hint:    "the forge API rejected a request, check the access token and its permissions",
+	},
+	{
+		targets: []error{context.DeadlineExceeded},
+		code:    ErrorCodeTimeout,
+		hint:    "the command took longer than --timeout",
+	},
+	{
+		targets: []error{context.Canceled},
+		code:    ErrorCodeCanceled,
+	},
+}
+
+// NewErrorReport returns the report of the given error returned by a command.
+func NewErrorReport(err error) ErrorReport {
+	report := ErrorReport{
+		Code:    ErrorCodeUnknown,
+		Message: err.Error(),
+	}
+
+	for _, errorCode := range errorCodes {
+		for _, target := range errorCode.targets {
+			if errors.Is(err, target) {
+				report.Code = errorCode.code
+				report.Hint = errorCode.hint
+
+				return report
+			}
+		}
+	}
+
+	return report
+}
+
+// WriteError writes the report of the given error returned by a command as a JSON object, nothing if the error is
+// nil.
+func WriteError(w io.Writer, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return json.NewEncoder(w).Encode(NewErrorReport(err))
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gpg"
+	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func TestErrors_NewErrorReport(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		err  error
+		code string
+	}
+
+	tests := []test{
+		{err: ErrNoRelease, code: ErrorCodeNoRelease},
+		{err: fmt.Errorf("checking release: %w: %q", tag.ErrTagAlreadyExists, "v1.0.0"), code: ErrorCodeTagExists},
+		{err: fmt.Errorf("pushing tag to remote: %w", remote.ErrTagConflict), code: ErrorCodeTagExists},
+		{err: fmt.Errorf("loading rules configuration: %w", rule.ErrDuplicateReleaseRule), code: ErrorCodeInvalidRules},
+		{err: fmt.Errorf("checking out to gitBranch %q: %w", "main", plumbing.ErrReferenceNotFound), code: ErrorCodeNoHead},
+		{err: fmt.Errorf("cloning Git repository: %w", transport.ErrAuthenticationRequired), code: ErrorCodeAuthentication},
+		{err: ErrConflictingOverrides, code: ErrorCodeInvalidConfiguration},
+		{err: fmt.Errorf("loading armored key: %w: subkey %s expired on %s", gpg.ErrKeyExpired, "ABCD", "2024-01-01"), code: ErrorCodeSigningKey},
+		{err: fmt.Errorf("computing new semver: %w", context.DeadlineExceeded), code: ErrorCodeTimeout},
+		{err: errors.New("something unexpected"), code: ErrorCodeUnknown},
+	}
+
+	for _, tc := range tests {
+		report := NewErrorReport(tc.err)
+
+		assert.Equal(tc.code, report.Code, tc.err.Error())
+		assert.Equal(tc.err.Error(), report.Message)
+	}
+}
+
+func TestErrors_WriteError(t *testing.T) {
+	assert := assertion.New(t)
+
+	buf := new(bytes.Buffer)
+
+	err := WriteError(buf, nil)
+	checkErr(t, err, "writing nil error")
+	assert.Empty(buf.String(), "nothing should be written without error")
+
+	err = WriteError(buf, fmt.Errorf("checking release: %w: %q", tag.ErrTagAlreadyExists, "v1.0.0"))
+	checkErr(t, err, "writing error")
+
+	var report ErrorReport
+
+	err = json.Unmarshal(buf.Bytes(), &report)
+	checkErr(t, err, "decoding report")
+
+	assert.Equal(ErrorCodeTagExists, report.Code)
+	assert.Equal(`checking release: tag already exists: "v1.0.0"`, report.Message)
+	assert.NotEmpty(report.Hint)
+}
+
+func TestErrors_ReleaseCmd(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:   `[{"name": "master"}]`,
+		SetVersionConfiguration: "1.0.0",
+		ForceBumpConfiguration:  "major",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+
+	assert.Equal(ErrorCodeInvalidConfiguration, NewErrorReport(err).Code)
+	assert.NotContains(string(out), "Usage:", "usage should not be printed out once the arguments are parsed")
+	assert.NotContains(string(out), "Error:", "errors should only be reported by WriteError")
+}
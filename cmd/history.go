@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/ledger"
+)
+
+var ErrNoLedgerBranch = errors.New("ledger branch must be set")
+
+func NewHistoryCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var (
+		jsonFlag    bool
+		projectFlag string
+	)
+
+	historyCmd := &cobra.Command{
+		Use:   "history <REPOSITORY_PATH_OR_URL>",
+		Short: "Print the release ledger of a Git repository",
+		Long:  "Print the release decisions recorded in the release ledger of the given repository, oldest first, including the dry-runs",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if ctx.LedgerBranchFlag == "" {
+				return ErrNoLedgerBranch
+			}
+
+			cmdCtx, cancel := commandContext(cmd, ctx)
+			defer cancel()
+
+			origin, err := newRemote(cmdCtx, ctx)
+			if err != nil {
+				return fmt.Errorf("configuring remote: %w", err)
+			}
+
+			repository, err := origin.Clone(cmdCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("cloning Git repository: %w", err)
+			}
+
+			entries, err := ledger.New(repository, ctx.LedgerBranchFlag, ctx.RemoteNameFlag).Entries()
+			if err != nil {
+				return fmt.Errorf("reading release ledger: %w", err)
+			}
+
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+
+			for _, entry := range entries {
+				if projectFlag != "" && entry.Project != projectFlag {
+					continue
+				}
+
+				if jsonFlag {
+					if err = encoder.Encode(entry); err != nil {
+						return fmt.Errorf("encoding output: %w", err)
+					}
+
+					continue
+				}
+
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s command=%s project=%s branch=%s previous-version=%s version=%s new-release=%t commit=%s actor=%s rules-digest=%s dry-run=%t\n",
+					entry.Time.Format(time.RFC3339), entry.Command, entry.Project, entry.Branch, entry.PreviousVersion, entry.Version,
+					entry.NewRelease, entry.Commit, entry.Actor, entry.RulesDigest, entry.DryRun)
+			}
+
+			return nil
+		},
+	}
+
+	historyCmd.Flags().BoolVar(&jsonFlag, "json", false, "Print the release decisions as JSON")
+	historyCmd.Flags().StringVar(&projectFlag, "project", "", "Only print the release decisions of the given monorepo project")
+
+	return historyCmd
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/ledger"
+)
+
+func TestHistoryCmd_Ledger(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	t.Setenv("GITHUB_ACTOR", "octocat")
+
+	for _, dryRun := range []string{"true", "false"} {
+		th := NewTestHelper(t)
+		err := th.SetFlags(map[string]string{
+			BranchesConfiguration:     `[{"name": "master"}]`,
+			LedgerBranchConfiguration: "releases",
+			DryRunConfiguration:       dryRun,
+		})
+		checkErr(t, err, "setting flags")
+
+		_, err = th.ExecuteCommand("release", testRepository.Path)
+		checkErr(t, err, "releasing")
+	}
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	th := NewTestHelper(t)
+	err = th.SetFlag(LedgerBranchConfiguration, "releases")
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("history", testRepository.Path, "--json")
+	checkErr(t, err, "printing history")
+
+	var entries []ledger.Entry
+
+	decoder := json.NewDecoder(bytes.NewReader(out))
+	for decoder.More() {
+		var entry ledger.Entry
+
+		err = decoder.Decode(&entry)
+		checkErr(t, err, "decoding entry")
+
+		entries = append(entries, entry)
+	}
+
+	if assert.Len(entries, 2, "dry-run and release should have been recorded") {
+		assert.True(entries[0].DryRun)
+		assert.False(entries[1].DryRun)
+
+		for _, entry := range entries {
+			assert.Equal("release", entry.Command)
+			assert.Equal("octocat", entry.Actor)
+			assert.Equal("0.1.0", entry.Version)
+			assert.True(entry.NewRelease)
+			assert.Equal(head.Hash().String(), entry.Commit)
+			assert.NotEmpty(entry.RulesDigest)
+		}
+	}
+
+	th = NewTestHelper(t)
+
+	_, err = th.ExecuteCommand("history", testRepository.Path)
+	assert.ErrorIs(err, ErrNoLedgerBranch)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+)
+
+var ErrNoSemverTag = errors.New("no semver tag found")
+
+type latestOutput struct {
+	Version string `json:"version"`
+	Tag     string `json:"tag"`
+	Commit  string `json:"commit"`
+	Project string `json:"project,omitempty"`
+}
+
+func NewLatestCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var jsonFlag bool
+
+	latestCmd := &cobra.Command{
+		Use:   "latest <REPOSITORY_PATH_OR_URL>",
+		Short: "Print the current semantic version of a Git repository",
+		Long:  "Print the highest semantic version among the existing tags of the given repository, and of every project if executed in a monorepo, respecting the tag prefix and the tag ignore patterns, without parsing the commit history",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx.Projects, err = configureProjects(ctx)
+			if err != nil {
+				return fmt.Errorf("loading projects configuration: %w", err)
+			}
+
+			ctx.TagIgnorePatterns, err = configureTagIgnorePatterns(ctx)
+			if err != nil {
+				return fmt.Errorf("loading tag ignore patterns configuration: %w", err)
+			}
+
+			cmdCtx, cancel := commandContext(cmd, ctx)
+			defer cancel()
+
+			origin, err := newRemote(cmdCtx, ctx)
+			if err != nil {
+				return fmt.Errorf("configuring remote: %w", err)
+			}
+
+			repository, err := origin.Clone(cmdCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("cloning Git repository: %w", err)
+			}
+
+			projects := ctx.Projects
+			if len(projects) == 0 {
+				projects = []monorepo.Project{{}}
+			}
+
+			p := parser.New(ctx)
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+
+			var found bool
+
+			for _, project := range projects {
+				latestTag, err := p.FetchLatestSemverTag(repository, project)
+				if err != nil {
+					return fmt.Errorf("fetching latest semver tag: %w", err)
+				}
+
+				// Projects that were never released are omitted
+				if latestTag == nil {
+					ctx.Logger.Debug().Str("project", project.Name).Msg("no semver tag found")
+					continue
+				}
+
+				found = true
+
+				if !jsonFlag {
+					_, _ = fmt.Fprintln(cmd.OutOrStdout(), latestTag.Version.String())
+					continue
+				}
+
+				err = encoder.Encode(latestOutput{
+					Version: latestTag.Version.String(),
+					Tag:     latestTag.Name,
+					Commit:  latestTag.Hash.String(),
+					Project: project.Name,
+				})
+				if err != nil {
+					return fmt.Errorf("encoding output: %w", err)
+				}
+			}
+
+			if !found {
+				return ErrNoSemverTag
+			}
+
+			return nil
+		},
+	}
+
+	latestCmd.Flags().BoolVar(&jsonFlag, "json", false, "Print the current semantic versions as JSON")
+
+	return latestCmd
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestLatestCmd_PlainOutput(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	for _, tagName := range []string{"v1.2.0", "v1.10.0", "nightly-v2.0.0", "foo-v3.0.0"} {
+		err = testRepository.AddTag(tagName, head.Hash())
+		checkErr(t, err, "adding tag")
+	}
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlag(TagIgnorePatternConfiguration, "^nightly-")
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("latest", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("1.10.0\n", string(out), "latest command should only print the highest version with the tag prefix")
+}
+
+func TestLatestCmd_JSONOutput(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v1.2.3", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("latest", testRepository.Path, "--json")
+	checkErr(t, err, "executing command")
+
+	actualOut := latestOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal(latestOutput{Version: "1.2.3", Tag: "v1.2.3", Commit: head.Hash().String()}, actualOut)
+}
+
+func TestLatestCmd_NoTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+
+	_, err := th.ExecuteCommand("latest", testRepository.Path)
+	assert.ErrorIs(err, ErrNoSemverTag)
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/ci"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+)
+
+var ErrLintFailed = errors.New("commit messages do not follow the Conventional Commits specification")
+
+func NewLintCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var (
+		messageFlag  string
+		githubPRFlag bool
+	)
+
+	lintCmd := &cobra.Command{
+		Use:   "lint [REPOSITORY_PATH_OR_URL]",
+		Short: "Check that commit messages follow the Conventional Commits specification",
+		Long:  "Lint the commits of the given repository, from the latest semver tag (or --from) to HEAD (or --to), or a single message given using --message or on the standard input if no repository is given, using the parser and the release rules that compute releases. Violations are printed out with their line and column, and the command fails if there is any",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx.Rules, err = configureRules(ctx)
+			if err != nil {
+				return fmt.Errorf("loading rules configuration: %w", err)
+			}
+
+			ctx.CommitPattern, err = configureCommitPattern(ctx)
+			if err != nil {
+				return fmt.Errorf("loading commit pattern configuration: %w", err)
+			}
+
+			p := parser.New(ctx)
+			defer p.Close()
+
+			if githubPRFlag {
+				title, err := ci.GitHubPullRequestTitle()
+				if err != nil {
+					return fmt.Errorf("reading pull request title: %w", err)
+				}
+
+				// The title becomes the header of the squashed commit, the body checks do not apply to it
+				violations := p.Lint(title)
+
+				for _, violation := range violations {
+					annotation := ci.GitHubAnnotation{
+						Level:   "error",
+						Title:   "Invalid pull request title",
+						Line:    violation.Line,
+						Column:  violation.Column,
+						Message: fmt.Sprintf("%s (%q)", violation.Message, title),
+					}
+
+					_, _ = fmt.Fprintln(cmd.OutOrStdout(), annotation.String())
+				}
+
+				if len(violations) > 0 {
+					return fmt.Errorf("%w: pull request title", ErrLintFailed)
+				}
+
+				return nil
+			}
+
+			if len(args) == 0 {
+				message := messageFlag
+
+				if !cmd.Flags().Changed("message") {
+					content, err := io.ReadAll(cmd.InOrStdin())
+					if err != nil {
+						return fmt.Errorf("reading commit message: %w", err)
+					}
+
+					message = string(content)
+				}
+
+				violations := p.Lint(message)
+
+				for _, violation := range violations {
+					_, _ = fmt.Fprintln(cmd.OutOrStdout(), violation.String())
+				}
+
+				if len(violations) > 0 {
+					return ErrLintFailed
+				}
+
+				return nil
+			}
+
+			ctx.TagIgnorePatterns, err = configureTagIgnorePatterns(ctx)
+			if err != nil {
+				return fmt.Errorf("loading tag ignore patterns configuration: %w", err)
+			}
+
+			cmdCtx, cancel := commandContext(cmd, ctx)
+			defer cancel()
+
+			origin, err := newRemote(cmdCtx, ctx)
+			if err != nil {
+				return fmt.Errorf("configuring remote: %w", err)
+			}
+
+			repository, err := origin.Clone(cmdCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("cloning Git repository: %w", err)
+			}
+
+			results, err := p.LintHistory(cmdCtx, repository, ctx.FromFlag, ctx.ToFlag)
+			if err != nil {
+				return fmt.Errorf("linting commit history: %w", err)
+			}
+
+			for _, result := range results {
+				for _, violation := range result.Violations {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s:%s (%q)\n", result.Hash.String()[:7], violation, result.Subject)
+				}
+			}
+
+			if len(results) > 0 {
+				return fmt.Errorf("%w: %d commits", ErrLintFailed, len(results))
+			}
+
+			return nil
+		},
+	}
+
+	lintCmd.Flags().BoolVar(&githubPRFlag, "github-pr", false, "Lint the title of the pull request that triggered the GitHub Actions workflow, read from GITHUB_EVENT_PATH, and print violations as GitHub error annotations")
+	lintCmd.Flags().StringVar(&messageFlag, "message", "", "Commit message to lint instead of the commits of a repository, read from the standard input if not set")
+
+	return lintCmd
+}
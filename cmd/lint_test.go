@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/ci"
+)
+
+func TestLintCmd_Message(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("lint", "--message", "feat(api): add foo")
+	checkErr(t, err, "executing command")
+
+	assert.Empty(out, "valid message should not be reported")
+
+	th = NewTestHelper(t)
+
+	out, err = th.ExecuteCommand("lint", "--message", "feat:add foo")
+	assert.ErrorIs(err, ErrLintFailed)
+	assert.Contains(string(out), `1:6: expected a space after ":"`)
+}
+
+func TestLintCmd_Stdin(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+	th.Cmd.SetIn(strings.NewReader("fix: fix foo\nbody\n"))
+
+	out, err := th.ExecuteCommand("lint")
+	assert.ErrorIs(err, ErrLintFailed)
+	assert.Contains(string(out), "2:1: expected a blank line between the header and the body")
+}
+
+func TestLintCmd_Repository(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	// Commits released before the latest tag, such as the non-conventional first commit, are not linted
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v0.1.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+
+	_, err = th.ExecuteCommand("lint", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	hash, err := testRepository.AddCommitWithMessage("update readme")
+	checkErr(t, err, "adding commit")
+
+	th = NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("lint", testRepository.Path)
+	assert.ErrorIs(err, ErrLintFailed)
+	assert.Contains(string(out), hash.String()[:7]+`:1:1: unknown commit type "update"`)
+}
+
+func TestLintCmd_GitHubPullRequest(t *testing.T) {
+	assert := assertion.New(t)
+
+	path := filepath.Join(t.TempDir(), "event.json")
+	t.Setenv("GITHUB_EVENT_PATH", path)
+
+	err := os.WriteFile(path, []byte(`{"pull_request": {"title": "feat(api): add foo"}}`), 0o644)
+	checkErr(t, err, "writing event file")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("lint", "--github-pr")
+	checkErr(t, err, "executing command")
+
+	assert.Empty(out, "valid title should not be reported")
+
+	err = os.WriteFile(path, []byte(`{"pull_request": {"title": "Add foo"}}`), 0o644)
+	checkErr(t, err, "writing event file")
+
+	th = NewTestHelper(t)
+
+	out, err = th.ExecuteCommand("lint", "--github-pr")
+	assert.ErrorIs(err, ErrLintFailed)
+	assert.Contains(string(out), `::error title=Invalid pull request title,line=1,col=1::unknown commit type "Add"`)
+
+	err = os.WriteFile(path, []byte(`{"ref": "refs/heads/main"}`), 0o644)
+	checkErr(t, err, "writing event file")
+
+	th = NewTestHelper(t)
+
+	_, err = th.ExecuteCommand("lint", "--github-pr")
+	assert.ErrorIs(err, ci.ErrNoGitHubPullRequest)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+)
+
+type nextOutput struct {
+	Version    string `json:"version"`
+	Branch     string `json:"branch"`
+	Project    string `json:"project,omitempty"`
+	NewRelease bool   `json:"new-release"`
+}
+
+func NewNextCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var jsonFlag bool
+
+	nextCmd := &cobra.Command{
+		Use:   "next <REPOSITORY_PATH_OR_URL>",
+		Short: "Print the next semantic version of a Git repository",
+		Long:  "Compute and print the next semantic version of the given release branches and projects if executed in a monorepo, without tagging the repository nor generating any CI output",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := configureRelease(ctx)
+			if err != nil {
+				return err
+			}
+
+			cmdCtx, cancel := commandContext(cmd, ctx)
+			defer cancel()
+
+			origin, err := newRemote(cmdCtx, ctx)
+			if err != nil {
+				return fmt.Errorf("configuring remote: %w", err)
+			}
+
+			repository, err := origin.Clone(cmdCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("cloning Git repository: %w", err)
+			}
+
+			p, outputs, err := runParser(cmdCtx, ctx, origin, repository)
+			if err != nil {
+				return fmt.Errorf("computing new semver: %w", err)
+			}
+			defer p.Close()
+
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+
+			for _, output := range outputs {
+				if !jsonFlag {
+					_, _ = fmt.Fprintln(cmd.OutOrStdout(), output.Semver.String())
+					continue
+				}
+
+				err = encoder.Encode(nextOutput{
+					Version:    output.Semver.String(),
+					Branch:     output.Branch,
+					Project:    output.Project.Name,
+					NewRelease: output.NewRelease,
+				})
+				if err != nil {
+					return fmt.Errorf("encoding output: %w", err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	nextCmd.Flags().BoolVar(&jsonFlag, "json", false, "Print the next semantic versions as JSON")
+
+	return nextCmd
+}
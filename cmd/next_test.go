@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func TestNextCmd_PlainOutput(t *testing.T) {
+	assert := assertion.New(t)
+
+	commits := []string{
+		"fix",  // 0.0.1
+		"feat", // 0.1.0
+	}
+
+	testRepository := NewTestRepository(t, commits)
+
+	th := NewTestHelper(t)
+	err := th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("next", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("0.1.0\n", string(out), "next command should only print the version")
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.False(exists, "next command should not tag the repository")
+}
+
+func TestNextCmd_JSONOutput(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat!"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("next", testRepository.Path, "--json")
+	checkErr(t, err, "executing command")
+
+	actualOut := nextOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal(nextOutput{Version: "1.0.0", Branch: "master", NewRelease: true}, actualOut)
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/channel"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/output"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+)
+
+func NewPromoteCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var channelFlag string
+
+	promoteCmd := &cobra.Command{
+		Use:   "promote <REPOSITORY_PATH_OR_URL>",
+		Short: "Promote the latest prerelease of a Git repository to another channel",
+		Long:  "Record the latest prerelease (e.g., 1.2.0-rc.3), of every project if executed in a monorepo, as distributed on the given channel in the channel notes of its tag, without tagging a new version",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx.Projects, err = configureProjects(ctx)
+			if err != nil {
+				return fmt.Errorf("loading projects configuration: %w", err)
+			}
+
+			ctx.TagIgnorePatterns, err = configureTagIgnorePatterns(ctx)
+			if err != nil {
+				return fmt.Errorf("loading tag ignore patterns configuration: %w", err)
+			}
+
+			writer, err := output.NewWriter(ctx.OutputFormatFlag, cmd.OutOrStdout())
+			if err != nil {
+				return fmt.Errorf("configuring output: %w", err)
+			}
+
+			cmdCtx, cancel := commandContext(cmd, ctx)
+			defer cancel()
+
+			origin, err := newRemote(cmdCtx, ctx)
+			if err != nil {
+				return fmt.Errorf("configuring remote: %w", err)
+			}
+
+			repository, err := origin.Clone(cmdCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("cloning Git repository: %w", err)
+			}
+
+			err = origin.FetchNotes(cmdCtx, channel.NotesRef)
+			if err != nil {
+				return fmt.Errorf("fetching release channels: %w", err)
+			}
+
+			channels, err := channel.Open(repository)
+			if err != nil {
+				return err
+			}
+
+			projects := ctx.Projects
+			if len(projects) == 0 {
+				projects = []monorepo.Project{{}}
+			}
+
+			p := parser.New(ctx)
+
+			var promoted bool
+
+			for _, project := range projects {
+				latestTag, err := p.FetchLatestSemverTag(repository, project)
+				if err != nil {
+					return fmt.Errorf("fetching latest semver tag: %w", err)
+				}
+
+				releaseOutput := output.Release{Project: project.Name, Channel: channelFlag}
+
+				if latestTag == nil || latestTag.Version.Prerelease == "" {
+					releaseOutput.Message = "no prerelease to promote"
+
+					if latestTag != nil {
+						releaseOutput.Version = latestTag.Version.String()
+					}
+
+					if err = writer.Write(releaseOutput); err != nil {
+						return fmt.Errorf("writing output: %w", err)
+					}
+
+					continue
+				}
+
+				releaseOutput.Version = latestTag.Version.String()
+
+				added, err := channels.Add(latestTag.Name, channelFlag)
+				if err != nil {
+					return fmt.Errorf("recording release channel: %w", err)
+				}
+
+				switch {
+				case !added:
+					releaseOutput.Message = "prerelease already promoted"
+				case ctx.DryRunFlag:
+					releaseOutput.Message = "dry-run enabled, prerelease can be promoted"
+				default:
+					releaseOutput.Message = "prerelease promoted"
+					promoted = true
+
+					ctx.Logger.Debug().Str("tag", latestTag.Name).Str("channel", channelFlag).Msg("prerelease promoted")
+				}
+
+				if err = writer.Write(releaseOutput); err != nil {
+					return fmt.Errorf("writing output: %w", err)
+				}
+			}
+
+			if promoted {
+				err = saveChannels(cmdCtx, ctx, origin, channels)
+				if err != nil {
+					return err
+				}
+			}
+
+			return writer.Flush()
+		},
+	}
+
+	promoteCmd.Flags().StringVar(&channelFlag, "channel", branch.StableChannel, "Channel to which the prerelease is promoted")
+
+	return promoteCmd
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/channel"
+)
+
+func TestPromoteCmd_RecordedChannels(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:       `[{"name": "master", "prerelease": true, "channel": "beta"}]`,
+		RecordChannelsConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "releasing prerelease")
+
+	channels, err := channel.Open(testRepository.Repository)
+	checkErr(t, err, "opening channels")
+
+	recorded, err := channels.Get("v0.1.0-master.1")
+	checkErr(t, err, "getting channels")
+	assert.Equal([]string{"beta"}, recorded, "channel of the prerelease should have been recorded")
+
+	th = NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("promote", testRepository.Path)
+	checkErr(t, err, "promoting prerelease")
+
+	actualOut := cmdOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal(cmdOutput{Message: "prerelease promoted", Version: "0.1.0-master.1"}, actualOut)
+
+	channels, err = channel.Open(testRepository.Repository)
+	checkErr(t, err, "opening channels")
+
+	recorded, err = channels.Get("v0.1.0-master.1")
+	checkErr(t, err, "getting channels")
+	assert.Equal([]string{"beta", "stable"}, recorded, "stable channel should have been added to the prerelease")
+
+	th = NewTestHelper(t)
+
+	out, err = th.ExecuteCommand("promote", testRepository.Path)
+	checkErr(t, err, "promoting prerelease again")
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("prerelease already promoted", actualOut.Message)
+}
+
+func TestPromoteCmd_NoPrerelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v1.1.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("promote", testRepository.Path, "--channel", "lts")
+	checkErr(t, err, "executing command")
+
+	actualOut := cmdOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal(cmdOutput{Message: "no prerelease to promote", Version: "1.1.0"}, actualOut)
+
+	_, err = testRepository.Reference(channel.NotesRef, true)
+	assert.Error(err, "no channel should have been recorded")
+}
@@ -3,22 +3,99 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/spf13/cobra"
 
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/attestation"
+	"github.com/s0ders/go-semver-release/v6/internal/bitbucket"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/changelog"
+	"github.com/s0ders/go-semver-release/v6/internal/channel"
+	"github.com/s0ders/go-semver-release/v6/internal/chat"
 	"github.com/s0ders/go-semver-release/v6/internal/ci"
+	"github.com/s0ders/go-semver-release/v6/internal/commit"
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
+	"github.com/s0ders/go-semver-release/v6/internal/gitea"
+	"github.com/s0ders/go-semver-release/v6/internal/github"
+	"github.com/s0ders/go-semver-release/v6/internal/gitlab"
 	"github.com/s0ders/go-semver-release/v6/internal/gpg"
+	"github.com/s0ders/go-semver-release/v6/internal/hook"
+	"github.com/s0ders/go-semver-release/v6/internal/ledger"
+	"github.com/s0ders/go-semver-release/v6/internal/mirror"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/oci"
+	"github.com/s0ders/go-semver-release/v6/internal/output"
 	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/plugin"
 	"github.com/s0ders/go-semver-release/v6/internal/remote"
 	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/scheme"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
 	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/internal/webhook"
+)
+
+const (
+	gpgPassphraseEnv     = "GO_SEMVER_RELEASE_GPG_PASSPHRASE"
+	gpgPrivateKeyEnv     = "GO_SEMVER_RELEASE_GPG_PRIVATE_KEY"
+	sshAuthPassphraseEnv = "GO_SEMVER_RELEASE_SSH_AUTH_PASSPHRASE"
+	dockerPasswordEnv    = "GO_SEMVER_RELEASE_DOCKER_PASSWORD"
+)
+
+const releaseCommitMessage = "chore(release): %s [skip ci]"
+
+// githubAppUsername is the username GitHub expects along installation tokens.
+const githubAppUsername = "x-access-token"
+
+var (
+	ErrConflictingSignKeys   = errors.New("GPG and SSH signing keys cannot be used together")
+	ErrSignedLightweightTags = errors.New("lightweight tags cannot be signed")
+	ErrLightweightTagNotes   = errors.New("lightweight tags cannot hold release notes")
+	ErrNoRelease             = errors.New("no new release found")
+	ErrDirtyWorktree         = errors.New("worktree has uncommitted changes")
+	ErrInvalidInitialVersion = errors.New("invalid initial version")
+	ErrInvalidIgnorePattern  = errors.New("invalid tag ignore pattern")
+	ErrInvalidIssuePattern   = errors.New("invalid issue pattern")
+	ErrCommitReleaseCommit   = errors.New("release commit cannot be used along with an explicit commit")
+	ErrIncompleteGitHubApp   = errors.New("GitHub App ID, installation ID and private key path must all be set")
+	ErrConflictingTokens     = errors.New("access token and GitHub App cannot be used together")
+	ErrNoGitHubRepository    = errors.New("GitHub repository cannot be deduced from the repository URL, it must be set")
+	ErrNoBitbucketRepository = errors.New("Bitbucket repository cannot be deduced from the repository URL, it must be set")
+	ErrNoGiteaRepository     = errors.New("Gitea repository cannot be deduced from the repository URL, it must be set")
+	ErrNoGitLabProject       = errors.New("GitLab project cannot be deduced from the repository URL, it must be set")
+	ErrConflictingAPITags    = errors.New("tags cannot be created through both the GitHub and GitLab APIs")
+	ErrInvalidSignPolicy     = errors.New("invalid signature policy")
+	ErrInvalidForceBump      = errors.New("invalid forced bump, expected major, minor or patch")
+	ErrInvalidSetVersion     = errors.New("invalid set version")
+	ErrConflictingOverrides  = errors.New("forced bump and set version cannot be used together")
+	ErrNoDockerImage         = errors.New("Docker image must be set to retag it")
+	ErrInvalidTagDate        = errors.New("invalid tag date, expected now or commit")
+	ErrInvalidCACert         = errors.New("CA certificate file contains no PEM certificate")
+)
+
+// Dates of the annotated release tags.
+const (
+	tagDateNow    = "now"
+	tagDateCommit = "commit"
 )
 
 func NewReleaseCmd(ctx *appcontext.AppContext) *cobra.Command {
@@ -33,84 +110,441 @@ func NewReleaseCmd(ctx *appcontext.AppContext) *cobra.Command {
 				origin     *remote.Remote
 			)
 
-			entity, err := configureGPGKey(ctx)
+			entity, sshSigner, err := configureSigning(ctx, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			if ctx.LightweightTagsFlag && ctx.TagReleaseNotesFlag {
+				return ErrLightweightTagNotes
+			}
+
+			if ctx.GitHubAPITagsFlag && ctx.GitLabAPITagsFlag {
+				return ErrConflictingAPITags
+			}
+
+			err = configureTagDate(ctx)
+			if err != nil {
+				return err
+			}
+
+			err = configureRelease(ctx)
+			if err != nil {
+				return err
+			}
+
+			dockerImage, err := configureDockerImage(ctx)
 			if err != nil {
-				return fmt.Errorf("configuring GPG key: %w", err)
+				return fmt.Errorf("loading Docker image configuration: %w", err)
 			}
 
-			ctx.Rules, err = configureRules(ctx)
+			writer, err := output.NewWriter(ctx.OutputFormatFlag, cmd.OutOrStdout())
 			if err != nil {
-				return fmt.Errorf("loading rules configuration: %w", err)
+				return fmt.Errorf("configuring output: %w", err)
 			}
 
-			ctx.Branches, err = configureBranches(ctx)
+			providers, err := ci.Providers(ctx.CIProviderFlag)
 			if err != nil {
-				return fmt.Errorf("loading branches configuration: %w", err)
+				return fmt.Errorf("configuring ci output: %w", err)
 			}
 
-			ctx.Projects, err = configureProjects(ctx)
+			links, err := repositoryLinks(ctx, args[0])
 			if err != nil {
-				return fmt.Errorf("loading projects configuration: %w", err)
+				return fmt.Errorf("configuring release notes links: %w", err)
 			}
 
-			origin = remote.New(ctx.RemoteNameFlag, ctx.AccessTokenFlag)
+			cmdCtx, cancel := commandContext(cmd, ctx)
+			defer cancel()
+
+			origin, err = newRemote(cmdCtx, ctx)
+			if err != nil {
+				return fmt.Errorf("configuring remote: %w", err)
+			}
 
-			repository, err = origin.Clone(args[0])
+			repository, err = origin.Clone(cmdCtx, args[0])
 			if err != nil {
 				return fmt.Errorf("cloning Git repository: %w", err)
 			}
 
-			outputs, err := parser.New(ctx).Run(context.Background(), repository)
+			if ctx.LockFlag && !ctx.DryRunFlag {
+				unlock, err := lockRemote(cmdCtx, ctx, origin)
+				if err != nil {
+					return err
+				}
+				defer unlock()
+			}
+
+			if ctx.CacheFlag {
+				// The cache only speeds up the analysis, the release does not depend on it
+				err = origin.FetchNotes(cmdCtx, parser.NotesRef)
+				if err != nil {
+					ctx.Logger.Warn().Err(err).Msg("failed to fetch analysis cache")
+				}
+			}
+
+			p, outputs, err := runParser(cmdCtx, ctx, origin, repository)
 			if err != nil {
 				return fmt.Errorf("computing new semver: %w", err)
 			}
+			defer p.Close()
 
-			tagger := tag.NewTagger(ctx.GitNameFlag, ctx.GitEmailFlag, tag.WithTagPrefix(ctx.TagPrefixFlag), tag.WithSignKey(entity))
+			channels, err := openChannels(cmdCtx, ctx, origin, repository)
+			if err != nil {
+				return err
+			}
 
-			for _, output := range outputs {
-				semver := output.Semver
-				release := output.NewRelease
-				commitHash := output.CommitHash
-				project := output.Project.Name
+			var pusher tagPusher = origin
 
-				err = ci.GenerateGitHubOutput(semver, output.Branch, ci.WithNewRelease(release), ci.WithTagPrefix(ctx.TagPrefixFlag), ci.WithProject(project))
+			if ctx.GitHubAPITagsFlag {
+				pusher, err = newGitHubTagPusher(ctx, origin, repository, args[0])
 				if err != nil {
-					return fmt.Errorf("generating github output: %w", err)
+					return fmt.Errorf("configuring GitHub API: %w", err)
 				}
+			}
+
+			forges, err := newForgeReleases(ctx, origin, args[0])
+			if err != nil {
+				return err
+			}
 
-				logEvent := ctx.Logger.Info()
-				logEvent.Bool("new-release", release)
-				logEvent.Str("version", semver.String())
-				logEvent.Str("branch", output.Branch)
+			if ctx.GitLabAPITagsFlag {
+				pusher = gitlab.NewTagPusher(forges.gitlab, repository, forges.gitlabProject)
+			}
 
-				if project != "" {
-					logEvent.Str("project", project)
+			pusher, err = newMirrorPusher(ctx, pusher, origin)
+			if err != nil {
+				return fmt.Errorf("configuring mirrors: %w", err)
+			}
+
+			notifier := webhook.NewNotifier(ctx.WebhookURLsFlag, webhook.WithSecret(ctx.WebhookSecretFlag), webhook.WithRetries(ctx.WebhookRetriesFlag, time.Second))
+
+			chatNotifier := chat.NewNotifier(chat.WithSlack(ctx.SlackWebhookFlag), chat.WithTeams(ctx.TeamsWebhookFlag), chat.WithDiscord(ctx.DiscordWebhookFlag))
+
+			registry := oci.NewClient(oci.WithCredentials(ctx.DockerUsernameFlag, ctx.DockerPassword))
+
+			hooks := hook.NewRunner(ctx.Hooks, hook.WithOutput(diagnosticOutput(cmd, ctx)), hook.WithDir(commandsDir(args[0])))
+			plugins := plugin.NewRunner(ctx.Plugins, plugin.WithOutput(diagnosticOutput(cmd, ctx)), plugin.WithDir(commandsDir(args[0])), plugin.WithDryRun(ctx.DryRunFlag))
+
+			released, tagged := false, false
+
+			// decisions are recorded in the release ledger once every project is released
+			var decisions []ledger.Entry
 
+			tagger := newTagger(ctx, tag.WithTagPrefix(ctx.TagPrefixFlag), tag.WithSignKey(entity), tag.WithSSHSigner(sshSigner), tag.WithForce(ctx.ForceFlag), tag.WithLightweight(ctx.LightweightTagsFlag))
+
+			for _, parserOutput := range outputs {
+				semver := parserOutput.Semver
+				release := parserOutput.NewRelease
+				commitHash := parserOutput.CommitHash
+				project := parserOutput.Project.Name
+
+				tagPrefix := parserOutput.TagPrefix
+
+				tagger.SetTagPrefix(tagPrefix)
+
+				pluginRelease := newPluginRelease(args[0], parserOutput)
+
+				if !parserOutput.Skipped {
+					version, err := plugins.Analyze(cmdCtx, pluginRelease)
+					if err != nil {
+						return fmt.Errorf("analyzing release with plugins: %w", err)
+					}
+
+					if version != nil {
+						semver, release = version, true
+						parserOutput.Semver, parserOutput.NewRelease = version, true
+						pluginRelease.Version, pluginRelease.NewRelease = version.String(), true
+					}
+				}
+
+				err = ci.Generate(cmd.OutOrStdout(), providers, semver, parserOutput.Branch, ci.WithNewRelease(release), ci.WithTagPrefix(tagPrefix), ci.WithProject(project))
+				if err != nil {
+					return fmt.Errorf("generating ci output: %w", err)
+				}
+
+				if project != "" {
 					tagger.SetProjectName(project)
 				}
 
+				summary := ci.GitHubSummary{
+					Semver:         semver,
+					PreviousSemver: parserOutput.PreviousSemver,
+					Commits:        parserOutput.Commits,
+					Branch:         parserOutput.Branch,
+					TagPrefix:      tagPrefix,
+					ProjectName:    project,
+					NewRelease:     release,
+				}
+
+				if release && !ctx.DryRunFlag {
+					summary.TagURL = ci.GitHubTagURL(tagger.Format(semver))
+				}
+
+				err = ci.GenerateGitHubSummary(summary)
+				if err != nil {
+					return fmt.Errorf("generating github summary: %w", err)
+				}
+
+				releaseOutput := newReleaseOutput(ctx, parserOutput)
+
+				var dockerTags []string
+
+				if release && dockerImage != nil {
+					versions, err := tagger.Versions(repository)
+					if err != nil {
+						return fmt.Errorf("fetching released versions: %w", err)
+					}
+
+					dockerTags = oci.Tags(semver, versions, latestRelease(ctx, parserOutput))
+
+					for _, dockerTag := range dockerTags {
+						releaseOutput.DockerTags = append(releaseOutput.DockerTags, dockerImage.Reference(dockerTag))
+					}
+				}
+
+				if ctx.LedgerBranchFlag != "" {
+					rulesDigest, err := attestation.RulesDigest(releaseRules(ctx, parserOutput))
+					if err != nil {
+						return fmt.Errorf("computing rules digest: %w", err)
+					}
+
+					decisions = append(decisions, newLedgerEntry(ctx, "release", releaseOutput, commitHash, rulesDigest))
+				}
+
+				err = writer.Write(releaseOutput)
+				if err != nil {
+					return fmt.Errorf("writing output: %w", err)
+				}
+
+				if ctx.DryRunFlag && ctx.ReportFlag {
+					err = writer.WriteReport(releaseOutput, commitReports(parserOutput.Report))
+					if err != nil {
+						return fmt.Errorf("writing report: %w", err)
+					}
+				}
+
+				if !release {
+					continue
+				}
+
+				released = true
+
+				notesOptions := []changelog.OptionFunc{changelog.WithLinks(links)}
+
+				if parserOutput.PreviousSemver != nil {
+					notesOptions = append(notesOptions, changelog.WithPreviousTag(tagger.Format(parserOutput.PreviousSemver)))
+				}
+
+				notes := changelog.Render(tagger.Format(semver), now(ctx), parserOutput.Commits, notesOptions...)
+
+				pluginRelease.Tag = tagger.Format(semver)
+				pluginRelease.Changelog = notes
+
+				err = plugins.Run(cmdCtx, plugin.Verify, pluginRelease)
+				if err != nil {
+					return fmt.Errorf("verifying release with plugins: %w", err)
+				}
+
+				if ctx.DryRunFlag {
+					// The preview is not machine output, it would break the parsing of the releases
+					if ctx.ChangelogPathFlag != "" {
+						_, _ = fmt.Fprint(diagnosticOutput(cmd, ctx), notes)
+					}
+
+					continue
+				}
+
+				// Checked before the release commit so that nothing is pushed if the release cannot be tagged
+				err = tagger.CheckRelease(repository, semver, parserOutput.PreviousSemver)
+				if err != nil {
+					return fmt.Errorf("checking release: %w", err)
+				}
+
+				// The worktree is only checked before the first tag, the changelog of a release may be written to it
+				err = checkLocalRepository(ctx, args[0], parserOutput.Branch, !tagged)
+				if err != nil {
+					return fmt.Errorf("checking local repository: %w", err)
+				}
+
+				hookRelease := hook.Release{
+					Version: semver.String(),
+					Tag:     tagger.Format(semver),
+					Branch:  parserOutput.Branch,
+					Project: project,
+				}
+
+				if parserOutput.PreviousSemver != nil {
+					hookRelease.PreviousVersion = parserOutput.PreviousSemver.String()
+				}
+
+				releaseCommit := ctx.ChangelogPathFlag != "" && ctx.ReleaseCommitFlag
+				prepareHooks, preparePlugins := hooks, plugins
+
+				var worktree *git.Worktree
+
+				if releaseCommit {
+					worktree, err = checkoutRelease(ctx, repository, parserOutput.Branch)
+					if err != nil {
+						return fmt.Errorf("checking out release branch: %w", err)
+					}
+
+					// The files bumped while preparing the release are committed along with the changelog
+					dir := worktree.Filesystem.Root()
+					prepareHooks = hook.NewRunner(ctx.Hooks, hook.WithOutput(diagnosticOutput(cmd, ctx)), hook.WithDir(dir))
+					preparePlugins = plugin.NewRunner(ctx.Plugins, plugin.WithOutput(diagnosticOutput(cmd, ctx)), plugin.WithDir(dir), plugin.WithDryRun(ctx.DryRunFlag))
+				}
+
+				err = prepareHooks.Run(cmdCtx, hook.PreTag, hookRelease)
+				if err != nil {
+					return fmt.Errorf("running hooks: %w", err)
+				}
+
+				err = preparePlugins.Run(cmdCtx, plugin.Prepare, pluginRelease)
+				if err != nil {
+					return fmt.Errorf("preparing release with plugins: %w", err)
+				}
+
 				switch {
-				case !release:
-					logEvent.Msg("no new release")
-				case release && ctx.DryRunFlag:
-					logEvent.Msg("dry-run enabled, next release found")
-				default:
-					logEvent.Msg("new release found")
-
-					err = tagger.TagRepository(repository, semver, commitHash)
+				case releaseCommit:
+					commitHash, err = commitRelease(ctx, worktree, tagger.Format(semver), notes, entity, sshSigner)
+					if err != nil {
+						return fmt.Errorf("creating release commit: %w", err)
+					}
+
+					err = origin.PushBranch(cmdCtx, parserOutput.Branch)
 					if err != nil {
-						return fmt.Errorf("tagging repository: %w", err)
+						return fmt.Errorf("pushing release commit to remote: %w", err)
 					}
 
-					ctx.Logger.Debug().Str("tag", tagger.Format(semver)).Msg("new tag added to repository")
+					ctx.Logger.Debug().Str("commit", commitHash.String()).Msg("release commit pushed")
+				case ctx.ChangelogPathFlag != "":
+					err = changelog.Write(ctx.ChangelogPathFlag, notes)
+					if err != nil {
+						return fmt.Errorf("writing changelog: %w", err)
+					}
+
+					ctx.Logger.Debug().Str("path", ctx.ChangelogPathFlag).Msg("changelog updated")
+				}
+
+				tagNotes := ""
+				if ctx.TagReleaseNotesFlag {
+					tagNotes = notes
+				}
+
+				err = tagger.TagRepositoryWithNotes(repository, semver, commitHash, tagNotes)
+				if err != nil {
+					return fmt.Errorf("tagging repository: %w", err)
+				}
+
+				tagged = true
+
+				ctx.Logger.Debug().Str("tag", tagger.Format(semver)).Msg("new tag added to repository")
+
+				if ctx.ForceFlag {
+					err = pusher.ForcePushTag(cmdCtx, tagger.Format(semver))
+				} else {
+					err = pusher.PushTag(cmdCtx, tagger.Format(semver))
+				}
+				if err != nil {
+					return fmt.Errorf("pushing tag to remote: %w", err)
+				}
+
+				ctx.Metrics.Release()
+
+				if channels != nil {
+					_, err = channels.Add(tagger.Format(semver), parserOutput.Channel)
+					if err != nil {
+						return fmt.Errorf("recording release channel: %w", err)
+					}
+				}
 
-					err = origin.PushTag(tagger.Format(semver))
+				if ctx.AttestationDirFlag != "" {
+					paths, err := writeAttestation(ctx, args[0], tagger.Format(semver), commitHash, parserOutput, entity)
 					if err != nil {
-						return fmt.Errorf("pushing tag to remote: %w", err)
+						return fmt.Errorf("writing release attestation: %w", err)
 					}
+
+					ctx.Logger.Debug().Strs("paths", paths).Msg("release attestation written")
+				}
+
+				err = hooks.Run(cmdCtx, hook.PostTag, hookRelease)
+				if err != nil {
+					return fmt.Errorf("running hooks: %w", err)
+				}
+
+				published := publishedRelease{
+					output:     parserOutput,
+					tagName:    tagger.Format(semver),
+					commitHash: commitHash,
+					notes:      notes,
+				}
+
+				err = forges.publish(cmdCtx, ctx, published)
+				if err != nil {
+					return err
+				}
+
+				pluginRelease.Commit = commitHash.String()
+
+				err = plugins.Run(cmdCtx, plugin.Publish, pluginRelease)
+				if err != nil {
+					return fmt.Errorf("publishing release with plugins: %w", err)
+				}
+
+				err = moveTagAliases(cmdCtx, ctx, repository, tagger, pusher, published)
+				if err != nil {
+					return err
+				}
+
+				err = retagDockerImage(cmdCtx, ctx, registry, dockerImage, dockerTags)
+				if err != nil {
+					return err
+				}
+
+				err = notifyRelease(cmdCtx, ctx, notifier, chatNotifier, chatRelease(links, tagger, parserOutput), args[0], published)
+				if err != nil {
+					return err
+				}
+
+				err = plugins.Run(cmdCtx, plugin.Notify, pluginRelease)
+				if err != nil {
+					return fmt.Errorf("notifying release with plugins: %w", err)
+				}
+
+				err = hooks.Run(cmdCtx, hook.PostRelease, hookRelease)
+				if err != nil {
+					return fmt.Errorf("running hooks: %w", err)
+				}
+			}
+
+			if ctx.CacheFlag && !ctx.DryRunFlag {
+				saveCache(cmdCtx, ctx, repository, origin, p)
+			}
+
+			if channels != nil && tagged {
+				err = saveChannels(cmdCtx, ctx, origin, channels)
+				if err != nil {
+					return err
 				}
 			}
 
+			err = saveLedger(cmdCtx, ctx, origin, repository, decisions)
+			if err != nil {
+				return err
+			}
+
+			err = writer.Flush()
+			if err != nil {
+				return fmt.Errorf("writing output: %w", err)
+			}
+
+			if ctx.FailOnNoReleaseFlag && !released {
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+
+				return ErrNoRelease
+			}
+
 			return nil
 		},
 	}
@@ -118,69 +552,1591 @@ func NewReleaseCmd(ctx *appcontext.AppContext) *cobra.Command {
 	return releaseCmd
 }
 
-func configureRules(ctx *appcontext.AppContext) (rule.Rules, error) {
-	flag := ctx.RulesFlag
+// configureSigning returns the GPG key or the SSH signer, if any, signing the release tags and commits. Only one of them
+// can be configured, and lightweight tags cannot be signed.
+func configureSigning(ctx *appcontext.AppContext, stdin io.Reader) (*openpgp.Entity, *ssh.Signer, error) {
+	entity, err := configureGPGKey(ctx, stdin)
+	if err != nil {
+		return nil, nil, fmt.Errorf("configuring GPG key: %w", err)
+	}
 
-	if flag.String() == "{}" {
-		return rule.Default, nil
+	sshSigner, err := configureSSHKey(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("configuring SSH key: %w", err)
 	}
 
-	rulesJSON := map[string][]string(flag)
+	if entity != nil && sshSigner != nil {
+		return nil, nil, ErrConflictingSignKeys
+	}
 
-	unmarshalledRules, err := rule.Unmarshall(rulesJSON)
-	if err != nil {
-		return unmarshalledRules, fmt.Errorf("parsing rules configuration: %w", err)
+	if ctx.LightweightTagsFlag && (entity != nil || sshSigner != nil) {
+		return nil, nil, ErrSignedLightweightTags
 	}
 
-	return unmarshalledRules, nil
+	return entity, sshSigner, nil
 }
 
-func configureBranches(ctx *appcontext.AppContext) ([]branch.Branch, error) {
-	branchesJSON := []map[string]any(ctx.BranchesFlag)
+// newReleaseOutput returns the output of the given parser output, the Docker tags of the release being left to the
+// caller.
+func newReleaseOutput(ctx *appcontext.AppContext, parserOutput parser.ComputeNewSemverOutput) output.Release {
+	releaseOutput := output.Release{
+		NewRelease: parserOutput.NewRelease,
+		Version:    parserOutput.Semver.String(),
+		Branch:     parserOutput.Branch,
+		Channel:    parserOutput.Channel,
+		Project:    parserOutput.Project.Name,
+		ReleaseAs:  parserOutput.ReleaseAs,
+		Forced:     parserOutput.Forced,
+		BumpedBy:   parserOutput.BumpedBy,
+		Issues:     parserOutput.References(),
+	}
 
-	unmarshalledBranches, err := branch.Unmarshall(branchesJSON)
-	if err != nil {
-		return nil, fmt.Errorf("parsing branches configuration: %w", err)
+	if parserOutput.PreviousSemver != nil {
+		releaseOutput.PreviousVersion = parserOutput.PreviousSemver.String()
 	}
 
-	return unmarshalledBranches, nil
+	if ctx.FromFlag != "" || ctx.ToFlag != "" {
+		if !parserOutput.From.IsZero() {
+			releaseOutput.From = parserOutput.From.String()
+		}
+		releaseOutput.To = parserOutput.To.String()
+	}
+
+	switch {
+	case parserOutput.Skipped:
+		releaseOutput.Message = fmt.Sprintf("release skipped, %s file found", parser.SkipReleaseFile)
+	case !parserOutput.NewRelease:
+		releaseOutput.Message = "no new release"
+	case ctx.DryRunFlag:
+		releaseOutput.Message = "dry-run enabled, next release found"
+	case parserOutput.Forced:
+		releaseOutput.Message = "new release forced"
+	default:
+		releaseOutput.Message = "new release found"
+	}
+
+	return releaseOutput
 }
 
-func configureProjects(ctx *appcontext.AppContext) ([]monorepo.Project, error) {
-	flag := ctx.MonorepositoryFlag
+// publishedRelease is the state of a tagged release shared by the steps publishing it.
+type publishedRelease struct {
+	output     parser.ComputeNewSemverOutput
+	tagName    string
+	commitHash plumbing.Hash
+	notes      string
+}
 
-	if flag.String() == "[]" {
-		return nil, nil
+// forgeReleases holds the clients of the forges the releases are published to, nil for the forges whose releases are
+// not enabled.
+type forgeReleases struct {
+	gitlab              *gitlab.Client
+	gitlabProject       gitlab.Project
+	gitea               *gitea.Client
+	giteaRepository     gitea.Repository
+	bitbucket           *bitbucket.Client
+	bitbucketRepository bitbucket.Repository
+}
+
+// newForgeReleases returns the clients of the forges the releases of the repository at the given URL are published to,
+// configured from the given AppContext. The GitLab client is also configured if the tags are created through the GitLab
+// API.
+func newForgeReleases(ctx *appcontext.AppContext, origin *remote.Remote, url string) (*forgeReleases, error) {
+	var (
+		forges forgeReleases
+		err    error
+	)
+
+	if ctx.GitLabAPITagsFlag || ctx.GitLabReleaseFlag {
+		forges.gitlab, forges.gitlabProject, err = newGitLabClient(ctx, origin, url)
+		if err != nil {
+			return nil, fmt.Errorf("configuring GitLab API: %w", err)
+		}
 	}
 
-	monorepoJSON := []map[string]string(flag)
+	if ctx.GiteaReleaseFlag {
+		forges.gitea, forges.giteaRepository, err = newGiteaClient(ctx, origin, url)
+		if err != nil {
+			return nil, fmt.Errorf("configuring Gitea API: %w", err)
+		}
+	}
 
-	projects, err := monorepo.Unmarshall(monorepoJSON)
-	if err != nil {
-		return nil, fmt.Errorf("parsing monorepository projects configuration: %w", err)
+	if ctx.BitbucketReleaseFlag {
+		forges.bitbucket, forges.bitbucketRepository, err = newBitbucketClient(ctx, origin, url)
+		if err != nil {
+			return nil, fmt.Errorf("configuring Bitbucket API: %w", err)
+		}
 	}
 
-	return projects, nil
+	return &forges, nil
 }
 
-func configureGPGKey(ctx *appcontext.AppContext) (*openpgp.Entity, error) {
-	flag := ctx.GPGKeyPathFlag
+// publish creates the given release on every forge whose releases are enabled, with its changelog as description.
+func (f *forgeReleases) publish(cmdCtx context.Context, ctx *appcontext.AppContext, release publishedRelease) error {
+	if ctx.GitLabReleaseFlag {
+		err := f.gitlab.CreateRelease(cmdCtx, f.gitlabProject, gitlab.Release{
+			TagName:     release.tagName,
+			Name:        release.tagName,
+			Description: release.notes,
+		})
+		if err != nil {
+			return fmt.Errorf("creating GitLab release: %w", err)
+		}
 
-	if flag == "" {
-		return nil, nil
+		ctx.Logger.Debug().Str("tag", release.tagName).Msg("GitLab release created")
+	}
+
+	if ctx.GiteaReleaseFlag {
+		err := f.gitea.CreateRelease(cmdCtx, f.giteaRepository, gitea.Release{
+			TagName:    release.tagName,
+			Name:       release.tagName,
+			Body:       release.notes,
+			Prerelease: release.output.Semver.Prerelease != "",
+		})
+		if err != nil {
+			return fmt.Errorf("creating Gitea release: %w", err)
+		}
+
+		ctx.Logger.Debug().Str("tag", release.tagName).Msg("Gitea release created")
+	}
+
+	if ctx.BitbucketReleaseFlag {
+		err := f.bitbucket.PublishRelease(cmdCtx, f.bitbucketRepository, release.commitHash.String(), release.tagName, release.notes)
+		if err != nil {
+			return fmt.Errorf("publishing Bitbucket release: %w", err)
+		}
+
+		ctx.Logger.Debug().Str("tag", release.tagName).Msg("Bitbucket release published")
 	}
 
-	ctx.Logger.Debug().Str("path", ctx.GPGKeyPathFlag).Msg("using the following armored key for signing")
+	return nil
+}
+
+// moveTagAliases creates, or moves, the major and minor alias tags of the given release and pushes them, if the tag
+// aliases are enabled.
+func moveTagAliases(cmdCtx context.Context, ctx *appcontext.AppContext, repository *git.Repository, tagger *tag.Tagger, pusher tagPusher, release publishedRelease) error {
+	if !ctx.TagAliasesFlag {
+		return nil
+	}
 
-	armoredKeyFile, err := os.ReadFile(ctx.GPGKeyPathFlag)
+	aliases, err := tagger.AliasRepository(repository, release.output.Semver, release.commitHash)
 	if err != nil {
-		return nil, fmt.Errorf("reading armored key: %w", err)
+		return fmt.Errorf("adding tag aliases to repository: %w", err)
+	}
+
+	for _, alias := range aliases {
+		err = pusher.ForcePushTag(cmdCtx, alias)
+		if err != nil {
+			return fmt.Errorf("pushing tag alias to remote: %w", err)
+		}
+
+		ctx.Logger.Debug().Str("tag", alias).Msg("tag alias moved")
 	}
 
-	entity, err := gpg.FromArmored(bytes.NewReader(armoredKeyFile))
+	return nil
+}
+
+// retagDockerImage tags the source tag of the given Docker image with the given tags of a release, if a source tag is
+// configured.
+func retagDockerImage(cmdCtx context.Context, ctx *appcontext.AppContext, registry *oci.Client, image *oci.Image, tags []string) error {
+	if ctx.DockerSourceTagFlag == "" {
+		return nil
+	}
+
+	err := registry.Retag(cmdCtx, *image, ctx.DockerSourceTagFlag, tags)
 	if err != nil {
-		return nil, fmt.Errorf("loading armored key: %w", err)
+		return fmt.Errorf("retagging Docker image: %w", err)
 	}
 
-	return entity, nil
+	references := make([]string, len(tags))
+	for i, dockerTag := range tags {
+		references[i] = image.Reference(dockerTag)
+	}
+
+	ctx.Logger.Debug().Strs("tags", references).Msg("Docker image retagged")
+
+	return nil
+}
+
+// notifyRelease notifies the webhooks and posts the given summary to the chat applications of the given release of
+// the repository at the given URL, if they are configured.
+func notifyRelease(cmdCtx context.Context, ctx *appcontext.AppContext, notifier *webhook.Notifier, chatNotifier *chat.Notifier, summary chat.Release, url string, release publishedRelease) error {
+	if len(ctx.WebhookURLsFlag) > 0 {
+		payload := webhook.Payload{
+			Repository: url,
+			Branch:     release.output.Branch,
+			Channel:    release.output.Channel,
+			Project:    release.output.Project.Name,
+			Tag:        release.tagName,
+			Version:    release.output.Semver.String(),
+			Commit:     release.commitHash.String(),
+			Changelog:  release.notes,
+			Issues:     release.output.References(),
+		}
+
+		if release.output.PreviousSemver != nil {
+			payload.PreviousVersion = release.output.PreviousSemver.String()
+		}
+
+		err := notifier.Notify(cmdCtx, payload)
+		if err != nil {
+			return fmt.Errorf("notifying webhooks: %w", err)
+		}
+
+		ctx.Logger.Debug().Str("tag", release.tagName).Msg("webhooks notified")
+	}
+
+	if chatNotifier.Enabled() {
+		err := chatNotifier.Notify(cmdCtx, summary)
+		if err != nil {
+			return fmt.Errorf("posting release to chat: %w", err)
+		}
+
+		ctx.Logger.Debug().Str("tag", release.tagName).Msg("release posted to chat")
+	}
+
+	return nil
+}
+
+// newRemote returns the remote of the repository to analyze, configured from the given AppContext. If a GitHub
+// App is configured, an installation token is minted and used instead of the access token. Without access token, the
+// credentials of HTTP remotes are asked to the Git credential helpers unless disabled.
+func newRemote(cmdCtx context.Context, ctx *appcontext.AppContext) (*remote.Remote, error) {
+	token := ctx.AccessTokenFlag
+
+	options := []remote.OptionFunc{
+		remote.WithDepth(ctx.CloneDepthFlag),
+		remote.WithPushRetries(ctx.PushRetriesFlag, time.Second),
+		remote.WithSSHKey(ctx.SSHAuthKeyPathFlag, ctx.SSHAuthPassphrase),
+	}
+
+	if ctx.SSHKnownHostsPathFlag != "" {
+		options = append(options, remote.WithSSHKnownHosts(ctx.SSHKnownHostsPathFlag))
+	}
+
+	if ctx.BitbucketUsernameFlag != "" {
+		options = append(options, remote.WithUsername(ctx.BitbucketUsernameFlag))
+	}
+
+	if ctx.CredentialHelperFlag {
+		options = append(options, remote.WithCredentialHelper())
+	}
+
+	if ctx.InsecureHostKeyFlag {
+		ctx.Logger.Warn().Msg("SSH host keys are not checked")
+		options = append(options, remote.WithInsecureIgnoreHostKey())
+	}
+
+	if ctx.CACertFlag != "" {
+		caBundle, err := readCACert(ctx.CACertFlag)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, remote.WithCABundle(caBundle))
+	}
+
+	if ctx.InsecureTLSFlag {
+		ctx.Logger.Warn().Msg("TLS certificates are not verified")
+		options = append(options, remote.WithInsecureSkipTLSVerify())
+	}
+
+	app, err := configureGitHubApp(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("configuring GitHub App: %w", err)
+	}
+
+	if app != nil {
+		httpClient, err := newHTTPClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		client := github.NewClient(github.WithAPIURL(ctx.GitHubAPIURLFlag), github.WithHTTPClient(httpClient))
+
+		installationToken, err := client.InstallationToken(cmdCtx, *app)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx.Logger.Debug().Time("expires_at", installationToken.ExpiresAt).Msg("minted GitHub App installation token")
+
+		token = installationToken.Token
+		options = append(options, remote.WithUsername(githubAppUsername))
+	}
+
+	return remote.New(ctx.RemoteNameFlag, token, options...), nil
+}
+
+// readCACert reads the PEM bundle of CA certificates at the given path, ErrInvalidCACert is returned if it contains no
+// certificate.
+func readCACert(path string) ([]byte, error) {
+	caBundle, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA certificate: %w", err)
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCACert, path)
+	}
+
+	return caBundle, nil
+}
+
+// newHTTPClient returns the HTTP client of the forge APIs, configured with the same TLS settings as the remote: the CA
+// certificates set in the AppContext are trusted in addition to the system ones, unless certificates are not verified
+// at all. Proxies are set by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables, as for the remote.
+func newHTTPClient(ctx *appcontext.AppContext) (*http.Client, error) {
+	if ctx.CACertFlag == "" && !ctx.InsecureTLSFlag {
+		return http.DefaultClient, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: ctx.InsecureTLSFlag}
+
+	if ctx.CACertFlag != "" {
+		caBundle, err := readCACert(ctx.CACertFlag)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs, err = x509.SystemCertPool()
+		if err != nil {
+			tlsConfig.RootCAs = x509.NewCertPool()
+		}
+
+		tlsConfig.RootCAs.AppendCertsFromPEM(caBundle)
+	}
+
+	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
+	httpTransport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: httpTransport}, nil
+}
+
+// tagPusher publishes the local tags of the repository to its remote.
+type tagPusher interface {
+	PushTag(ctx context.Context, tagName string) error
+	ForcePushTag(ctx context.Context, tagName string) error
+}
+
+// lockRemote acquires the lock of the remote, waiting for it at most the lock timeout if any, then fetches the remote
+// again so that the releases made by the runs that held the lock in the meantime are known. The returned function
+// releases the lock.
+func lockRemote(cmdCtx context.Context, ctx *appcontext.AppContext, origin *remote.Remote) (func(), error) {
+	lockCtx, cancel := context.WithCancel(cmdCtx)
+	if ctx.LockTimeoutFlag > 0 {
+		lockCtx, cancel = context.WithTimeout(cmdCtx, ctx.LockTimeoutFlag)
+	}
+	defer cancel()
+
+	err := origin.Lock(lockCtx, lockOwner())
+	if err != nil {
+		return nil, fmt.Errorf("acquiring remote lock: %w", err)
+	}
+
+	ctx.Logger.Debug().Msg("remote lock acquired")
+
+	unlock := func() {
+		// The lock is released even if the command timed out or was interrupted
+		err := origin.Unlock(context.WithoutCancel(cmdCtx))
+		if err != nil {
+			ctx.Logger.Warn().Err(err).Msg("failed to release remote lock")
+		}
+	}
+
+	err = origin.Refresh(cmdCtx)
+	if err != nil {
+		unlock()
+		return nil, fmt.Errorf("fetching remote after acquiring lock: %w", err)
+	}
+
+	return unlock, nil
+}
+
+// lockOwner describes the run holding the lock of the remote: the host it runs on and its process ID.
+func lockOwner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown host"
+	}
+
+	return fmt.Sprintf("%s (pid %d)", host, os.Getpid())
+}
+
+// mirrorPusher is a tagPusher pushing the tags to the mirrors once they are pushed by the wrapped tagPusher. Every
+// mirror is pushed to, even if another one failed, and the outcome for each one is logged. Failing to push to an
+// optional mirror is only logged as a warning, the failures of the other mirrors are returned.
+type mirrorPusher struct {
+	tagPusher
+	ctx     *appcontext.AppContext
+	configs []mirror.Config
+	mirrors []*remote.Remote
+}
+
+// newMirrorPusher returns a tagPusher also pushing to the mirrors of the configuration, added as remotes of the
+// repository cloned by origin, or the given tagPusher itself if there is no mirror.
+func newMirrorPusher(ctx *appcontext.AppContext, pusher tagPusher, origin *remote.Remote) (tagPusher, error) {
+	if len(ctx.Mirrors) == 0 {
+		return pusher, nil
+	}
+
+	p := &mirrorPusher{tagPusher: pusher, ctx: ctx, configs: ctx.Mirrors}
+
+	for _, config := range ctx.Mirrors {
+		var options []remote.OptionFunc
+
+		if config.Username != "" {
+			options = append(options, remote.WithUsername(config.Username))
+		}
+
+		m, err := origin.Mirror(config.Name, config.URL, config.Token(), options...)
+		if err != nil {
+			return nil, err
+		}
+
+		p.mirrors = append(p.mirrors, m)
+	}
+
+	return p, nil
+}
+
+func (p *mirrorPusher) PushTag(ctx context.Context, tagName string) error {
+	if err := p.tagPusher.PushTag(ctx, tagName); err != nil {
+		return err
+	}
+
+	return p.pushMirrors(ctx, tagName, false)
+}
+
+func (p *mirrorPusher) ForcePushTag(ctx context.Context, tagName string) error {
+	if err := p.tagPusher.ForcePushTag(ctx, tagName); err != nil {
+		return err
+	}
+
+	return p.pushMirrors(ctx, tagName, true)
+}
+
+func (p *mirrorPusher) pushMirrors(ctx context.Context, tagName string, force bool) error {
+	var errs []error
+
+	for i, m := range p.mirrors {
+		config := p.configs[i]
+
+		var err error
+
+		if force {
+			err = m.ForcePushTag(ctx, tagName)
+		} else {
+			err = m.PushTag(ctx, tagName)
+		}
+
+		switch {
+		case err == nil:
+			p.ctx.Logger.Info().Str("mirror", config.Name).Str("tag", tagName).Msg("tag pushed to mirror")
+		case config.Optional:
+			p.ctx.Logger.Warn().Err(err).Str("mirror", config.Name).Str("tag", tagName).Msg("failed to push tag to optional mirror")
+		default:
+			p.ctx.Logger.Error().Err(err).Str("mirror", config.Name).Str("tag", tagName).Msg("failed to push tag to mirror")
+			errs = append(errs, fmt.Errorf("pushing to mirror %q: %w", config.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// newGitHubTagPusher returns a tagPusher creating the tags through the GitHub REST API, authenticated with the access
+// token of the given remote. The GitHub repository is the one set in the AppContext, or else the one of the
+// GITHUB_REPOSITORY environment variable set by GitHub Actions, or else the one of the given repository URL.
+func newGitHubTagPusher(ctx *appcontext.AppContext, origin *remote.Remote, repository *git.Repository, url string) (*github.TagPusher, error) {
+	name := ctx.GitHubRepositoryFlag
+
+	if name == "" {
+		name = os.Getenv("GITHUB_REPOSITORY")
+	}
+
+	if name == "" {
+		if endpoint, err := transport.NewEndpoint(url); err == nil && endpoint.Protocol != "file" {
+			name = url
+		}
+	}
+
+	if name == "" {
+		return nil, ErrNoGitHubRepository
+	}
+
+	githubRepository, err := github.ParseRepository(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Logger.Debug().Str("repository", githubRepository.String()).Msg("creating tags through the GitHub API")
+
+	httpClient, err := newHTTPClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client := github.NewClient(github.WithAPIURL(ctx.GitHubAPIURLFlag), github.WithToken(origin.Token()), github.WithHTTPClient(httpClient))
+
+	return github.NewTagPusher(client, repository, githubRepository), nil
+}
+
+// newGitLabClient returns a client of the GitLab REST API, authenticated with the access token of the given remote or
+// else with the CI_JOB_TOKEN environment variable set by GitLab CI/CD, along with the GitLab project. The project is
+// the one set in the AppContext, or else the one of the CI_PROJECT_ID environment variable set by GitLab CI/CD, or else
+// the one of the given repository URL.
+func newGitLabClient(ctx *appcontext.AppContext, origin *remote.Remote, url string) (*gitlab.Client, gitlab.Project, error) {
+	name := ctx.GitLabProjectFlag
+
+	if name == "" {
+		name = os.Getenv("CI_PROJECT_ID")
+	}
+
+	if name == "" {
+		if endpoint, err := transport.NewEndpoint(url); err == nil && endpoint.Protocol != "file" {
+			name = url
+		}
+	}
+
+	if name == "" {
+		return nil, "", ErrNoGitLabProject
+	}
+
+	project, err := gitlab.ParseProject(name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiURL := ctx.GitLabAPIURLFlag
+
+	if apiURL == "" {
+		apiURL = os.Getenv("CI_API_V4_URL")
+	}
+
+	httpClient, err := newHTTPClient(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	options := []gitlab.OptionFunc{gitlab.WithAPIURL(apiURL), gitlab.WithHTTPClient(httpClient)}
+
+	switch jobToken := os.Getenv("CI_JOB_TOKEN"); {
+	case origin.Token() != "":
+		options = append(options, gitlab.WithToken(origin.Token()))
+	case jobToken != "":
+		options = append(options, gitlab.WithJobToken(jobToken))
+	}
+
+	ctx.Logger.Debug().Str("project", string(project)).Msg("using the GitLab API")
+
+	return gitlab.NewClient(options...), project, nil
+}
+
+// newBitbucketClient returns a client of the REST API of Bitbucket Cloud, or of the Bitbucket Server instance set in
+// the AppContext, authenticated with the access token of the given remote, along with the repository of the releases.
+// The repository is the one set in the AppContext, or else the one of the BITBUCKET_REPO_FULL_NAME environment
+// variable set by Bitbucket Pipelines, or else the one of the given repository URL.
+func newBitbucketClient(ctx *appcontext.AppContext, origin *remote.Remote, url string) (*bitbucket.Client, bitbucket.Repository, error) {
+	name := ctx.BitbucketRepositoryFlag
+
+	if name == "" {
+		name = os.Getenv("BITBUCKET_REPO_FULL_NAME")
+	}
+
+	if name == "" {
+		if endpoint, err := transport.NewEndpoint(url); err == nil && endpoint.Protocol != "file" {
+			name = url
+		}
+	}
+
+	if name == "" {
+		return nil, bitbucket.Repository{}, ErrNoBitbucketRepository
+	}
+
+	repository, err := bitbucket.ParseRepository(name)
+	if err != nil {
+		return nil, bitbucket.Repository{}, err
+	}
+
+	ctx.Logger.Debug().Str("repository", repository.String()).Msg("using the Bitbucket API")
+
+	httpClient, err := newHTTPClient(ctx)
+	if err != nil {
+		return nil, bitbucket.Repository{}, err
+	}
+
+	client := bitbucket.NewClient(
+		bitbucket.WithServer(ctx.BitbucketServerURLFlag),
+		bitbucket.WithCredentials(ctx.BitbucketUsernameFlag, origin.Token()),
+		bitbucket.WithHTTPClient(httpClient),
+	)
+
+	return client, repository, nil
+}
+
+// newGiteaClient returns a client of the REST API of a Gitea or Forgejo instance, authenticated with the access token
+// of the given remote, along with the repository of the releases. The URL of the instance and the repository are the
+// ones set in the AppContext, or else the ones of the given repository URL.
+func newGiteaClient(ctx *appcontext.AppContext, origin *remote.Remote, url string) (*gitea.Client, gitea.Repository, error) {
+	instanceURL := ctx.GiteaURLFlag
+
+	if instanceURL == "" {
+		var err error
+
+		instanceURL, err = gitea.InstanceURL(url)
+		if err != nil {
+			return nil, gitea.Repository{}, err
+		}
+	}
+
+	name := ctx.GiteaRepositoryFlag
+
+	if name == "" {
+		if endpoint, err := transport.NewEndpoint(url); err == nil && endpoint.Protocol != "file" {
+			name = url
+		}
+	}
+
+	if name == "" {
+		return nil, gitea.Repository{}, ErrNoGiteaRepository
+	}
+
+	repository, err := gitea.ParseRepository(name)
+	if err != nil {
+		return nil, gitea.Repository{}, err
+	}
+
+	ctx.Logger.Debug().Str("url", instanceURL).Str("repository", repository.String()).Msg("using the Gitea API")
+
+	httpClient, err := newHTTPClient(ctx)
+	if err != nil {
+		return nil, gitea.Repository{}, err
+	}
+
+	return gitea.NewClient(instanceURL, gitea.WithToken(origin.Token()), gitea.WithHTTPClient(httpClient)), repository, nil
+}
+
+// configureGitHubApp returns the GitHub App configured by the given AppContext, nil if none is.
+func configureGitHubApp(ctx *appcontext.AppContext) (*github.App, error) {
+	flags := []string{ctx.GitHubAppIDFlag, ctx.GitHubInstallationIDFlag, ctx.GitHubAppKeyPathFlag}
+
+	if !slices.ContainsFunc(flags, func(flag string) bool { return flag != "" }) {
+		return nil, nil
+	}
+
+	if slices.Contains(flags, "") {
+		return nil, ErrIncompleteGitHubApp
+	}
+
+	if ctx.AccessTokenFlag != "" {
+		return nil, ErrConflictingTokens
+	}
+
+	content, err := os.ReadFile(ctx.GitHubAppKeyPathFlag)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key: %w", err)
+	}
+
+	key, err := github.ParsePrivateKey(content)
+	if err != nil {
+		return nil, fmt.Errorf("loading private key: %w", err)
+	}
+
+	return &github.App{
+		ID:             ctx.GitHubAppIDFlag,
+		InstallationID: ctx.GitHubInstallationIDFlag,
+		PrivateKey:     key,
+	}, nil
+}
+
+// runParser computes the new semantic versions of the given repository. Shallow clones are deepened, and the analysis
+// restarted, until the history needed by the analysis has been fetched. The returned parser must be closed by the caller.
+func runParser(cmdCtx context.Context, ctx *appcontext.AppContext, origin *remote.Remote, repository *git.Repository) (*parser.Parser, []parser.ComputeNewSemverOutput, error) {
+	for {
+		p := parser.New(ctx)
+
+		start := time.Now()
+
+		outputs, err := p.Run(cmdCtx, repository)
+		if !errors.Is(err, commit.ErrShallowHistory) {
+			if err != nil {
+				_ = p.Close()
+				return nil, nil, err
+			}
+
+			ctx.Metrics.Analysis(time.Since(start), walkedCommits(outputs))
+
+			return p, outputs, nil
+		}
+
+		_ = p.Close()
+
+		ctx.Logger.Debug().Err(err).Msg("deepening shallow clone")
+
+		if deepenErr := origin.Deepen(cmdCtx); deepenErr != nil {
+			if errors.Is(deepenErr, remote.ErrCompleteHistory) {
+				return nil, nil, err
+			}
+
+			return nil, nil, deepenErr
+		}
+	}
+}
+
+// walkedCommits returns the number of commits considered by the analyses of the given outputs.
+func walkedCommits(outputs []parser.ComputeNewSemverOutput) int {
+	var n int
+
+	for _, output := range outputs {
+		n += len(output.Report)
+	}
+
+	return n
+}
+
+// saveCache stores the analysis cache of the given parser as Git notes and pushes them to the remote. Failures are only
+// logged since the cache is not needed for the release.
+func saveCache(cmdCtx context.Context, ctx *appcontext.AppContext, repository *git.Repository, origin *remote.Remote, p *parser.Parser) {
+	signature := object.Signature{
+		Name:  ctx.GitNameFlag,
+		Email: ctx.GitEmailFlag,
+		When:  now(ctx),
+	}
+
+	err := p.SaveCache(repository, signature)
+	if err != nil {
+		ctx.Logger.Warn().Err(err).Msg("failed to save analysis cache")
+		return
+	}
+
+	err = origin.PushNotes(cmdCtx, parser.NotesRef)
+	if err != nil {
+		ctx.Logger.Warn().Err(err).Msg("failed to push analysis cache")
+		return
+	}
+
+	ctx.Logger.Debug().Msg("analysis cache pushed")
+}
+
+// openChannels fetches and returns the channels recorded for the release tags of the remote, nil if channels are not
+// recorded or if the run is a dry-run.
+func openChannels(cmdCtx context.Context, ctx *appcontext.AppContext, origin *remote.Remote, repository *git.Repository) (*channel.Channels, error) {
+	if !ctx.RecordChannelsFlag || ctx.DryRunFlag {
+		return nil, nil
+	}
+
+	err := origin.FetchNotes(cmdCtx, channel.NotesRef)
+	if err != nil {
+		return nil, fmt.Errorf("fetching release channels: %w", err)
+	}
+
+	return channel.Open(repository)
+}
+
+// saveChannels commits the channels recorded so far and pushes them to the remote.
+func saveChannels(cmdCtx context.Context, ctx *appcontext.AppContext, origin *remote.Remote, channels *channel.Channels) error {
+	signature := object.Signature{
+		Name:  ctx.GitNameFlag,
+		Email: ctx.GitEmailFlag,
+		When:  now(ctx),
+	}
+
+	err := channels.Commit(signature)
+	if err != nil {
+		return fmt.Errorf("committing release channels: %w", err)
+	}
+
+	err = origin.PushNotes(cmdCtx, channel.NotesRef)
+	if err != nil {
+		return fmt.Errorf("pushing release channels: %w", err)
+	}
+
+	ctx.Logger.Debug().Msg("release channels pushed")
+
+	return nil
+}
+
+// configureRelease loads the rules, branches, projects and initial version configuration into the given AppContext.
+func configureRelease(ctx *appcontext.AppContext) (err error) {
+	// The release commit is added on top of the release branch, not of the commit being released
+	if ctx.CommitFlag != "" && ctx.ReleaseCommitFlag {
+		return ErrCommitReleaseCommit
+	}
+
+	ctx.Rules, err = configureRules(ctx)
+	if err != nil {
+		return fmt.Errorf("loading rules configuration: %w", err)
+	}
+
+	ctx.Branches, err = configureBranches(ctx)
+	if err != nil {
+		return fmt.Errorf("loading branches configuration: %w", err)
+	}
+
+	ctx.Projects, err = configureProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("loading projects configuration: %w", err)
+	}
+
+	ctx.InitialVersion, err = configureInitialVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("loading initial version configuration: %w", err)
+	}
+
+	ctx.Scheme, err = scheme.New(ctx.VersionSchemeFlag, ctx.CalVerFormatFlag)
+	if err != nil {
+		return fmt.Errorf("loading versioning scheme configuration: %w", err)
+	}
+
+	ctx.SetVersion, err = configureVersionOverride(ctx)
+	if err != nil {
+		return fmt.Errorf("loading version override configuration: %w", err)
+	}
+
+	ctx.TagIgnorePatterns, err = configureTagIgnorePatterns(ctx)
+	if err != nil {
+		return fmt.Errorf("loading tag ignore patterns configuration: %w", err)
+	}
+
+	ctx.CommitPattern, err = configureCommitPattern(ctx)
+	if err != nil {
+		return fmt.Errorf("loading commit pattern configuration: %w", err)
+	}
+
+	ctx.CommitVerifier, err = configureCommitVerifier(ctx)
+	if err != nil {
+		return fmt.Errorf("loading signature policy configuration: %w", err)
+	}
+
+	// Build metadata are rendered along each release, the template is checked beforehand to fail early
+	if _, err = parser.ParseBuildMetadata(ctx.BuildMetadataFlag); err != nil {
+		return fmt.Errorf("loading build metadata configuration: %w", err)
+	}
+
+	ctx.IssuePattern, err = configureIssuePattern(ctx)
+	if err != nil {
+		return fmt.Errorf("loading issue pattern configuration: %w", err)
+	}
+
+	ctx.Hooks, err = hook.Unmarshall(ctx.HooksFlag)
+	if err != nil {
+		return fmt.Errorf("loading hooks configuration: %w", err)
+	}
+
+	ctx.Plugins, err = plugin.Unmarshall(ctx.PluginsFlag)
+	if err != nil {
+		return fmt.Errorf("loading plugins configuration: %w", err)
+	}
+
+	ctx.Mirrors, err = mirror.Unmarshall(ctx.MirrorsFlag)
+	if err != nil {
+		return fmt.Errorf("loading mirrors configuration: %w", err)
+	}
+
+	return nil
+}
+
+func configureRules(ctx *appcontext.AppContext) (rule.Rules, error) {
+	var (
+		rules      rule.Rules
+		configured bool
+		err        error
+	)
+
+	switch {
+	case ctx.RulesPathFlag != "":
+		ctx.Logger.Debug().Str("path", ctx.RulesPathFlag).Msg("using the following rules file")
+
+		rules, err = rule.FromFile(ctx.RulesPathFlag)
+		if err != nil {
+			return rules, fmt.Errorf("loading rules file: %w", err)
+		}
+
+		configured = true
+	case ctx.RulesFlag.String() != "{}":
+		rules, err = rule.Unmarshall(map[string][]string(ctx.RulesFlag))
+		if err != nil {
+			return rules, fmt.Errorf("parsing rules configuration: %w", err)
+		}
+
+		configured = true
+	}
+
+	if ctx.RulePresetFlag == "" {
+		if !configured {
+			return rule.Default, nil
+		}
+
+		return rules, nil
+	}
+
+	preset, err := rule.Preset(ctx.RulePresetFlag)
+	if err != nil {
+		return preset, fmt.Errorf("%w %q, expected one of %s", err, ctx.RulePresetFlag, strings.Join(rule.PresetNames(), ", "))
+	}
+
+	// Rules configured by the user override those of the preset, commit type by commit type
+	return rule.Merge(preset, rules), nil
+}
+
+func configureBranches(ctx *appcontext.AppContext) ([]branch.Branch, error) {
+	branchesJSON := []map[string]any(ctx.BranchesFlag)
+
+	unmarshalledBranches, err := branch.Unmarshall(branchesJSON)
+	if err != nil {
+		return nil, fmt.Errorf("parsing branches configuration: %w", err)
+	}
+
+	return unmarshalledBranches, nil
+}
+
+func configureProjects(ctx *appcontext.AppContext) ([]monorepo.Project, error) {
+	flag := ctx.MonorepositoryFlag
+
+	if flag.String() == "[]" {
+		return nil, nil
+	}
+
+	monorepoJSON := []map[string]any(flag)
+
+	projects, err := monorepo.Unmarshall(monorepoJSON)
+	if err != nil {
+		return nil, fmt.Errorf("parsing monorepository projects configuration: %w", err)
+	}
+
+	return projects, nil
+}
+
+func configureInitialVersion(ctx *appcontext.AppContext) (*semver.Version, error) {
+	flag := ctx.InitialVersionFlag
+
+	if flag == "" {
+		return nil, nil
+	}
+
+	version, err := semver.NewFromString(flag)
+	if err != nil || version.String() != flag {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidInitialVersion, flag)
+	}
+
+	return version, nil
+}
+
+// configureDockerImage returns the Docker image whose tags are computed for every new release, nil if none is set.
+func configureDockerImage(ctx *appcontext.AppContext) (*oci.Image, error) {
+	if ctx.DockerImageFlag == "" {
+		if ctx.DockerSourceTagFlag != "" {
+			return nil, ErrNoDockerImage
+		}
+
+		return nil, nil
+	}
+
+	image, err := oci.ParseImage(ctx.DockerImageFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+// latestRelease returns true if the release of the given output is the latest stable one, i.e., a release of the
+// stable channel that is not made on a maintenance branch.
+func latestRelease(ctx *appcontext.AppContext, parserOutput parser.ComputeNewSemverOutput) bool {
+	if parserOutput.Channel != branch.StableChannel {
+		return false
+	}
+
+	for _, b := range ctx.Branches {
+		if b.Name == parserOutput.Branch {
+			return b.Range == nil
+		}
+	}
+
+	return true
+}
+
+func configureVersionOverride(ctx *appcontext.AppContext) (*semver.Version, error) {
+	if ctx.ForceBumpFlag != "" && ctx.SetVersionFlag != "" {
+		return nil, ErrConflictingOverrides
+	}
+
+	switch ctx.ForceBumpFlag {
+	case "", "major", "minor", "patch":
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidForceBump, ctx.ForceBumpFlag)
+	}
+
+	flag := ctx.SetVersionFlag
+
+	if flag == "" {
+		return nil, nil
+	}
+
+	version, err := semver.NewFromString(flag)
+	if err != nil || version.String() != flag {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSetVersion, flag)
+	}
+
+	return version, nil
+}
+
+func configureTagIgnorePatterns(ctx *appcontext.AppContext) ([]*regexp.Regexp, error) {
+	patterns := make([]*regexp.Regexp, 0, len(ctx.TagIgnorePatternsFlag))
+
+	for _, flag := range ctx.TagIgnorePatternsFlag {
+		pattern, err := regexp.Compile(flag)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidIgnorePattern, flag, err)
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+func configureCommitPattern(ctx *appcontext.AppContext) (*regexp.Regexp, error) {
+	flag := ctx.CommitPatternFlag
+
+	if flag == "" {
+		return nil, nil
+	}
+
+	pattern, err := parser.CompileCommitPattern(flag)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %w", flag, err)
+	}
+
+	return pattern, nil
+}
+
+func configureCommitVerifier(ctx *appcontext.AppContext) (*commit.Verifier, error) {
+	switch ctx.SignaturePolicyFlag {
+	case "":
+		return nil, nil
+	case parser.SignaturePolicyFail, parser.SignaturePolicyExclude:
+	default:
+		return nil, fmt.Errorf("%w %q, expected either %q or %q", ErrInvalidSignPolicy, ctx.SignaturePolicyFlag, parser.SignaturePolicyFail, parser.SignaturePolicyExclude)
+	}
+
+	if ctx.SignatureKeyringFlag == "" && ctx.SignatureSignersFlag == "" {
+		return nil, ErrNoVerificationKeys
+	}
+
+	verifier := &commit.Verifier{}
+
+	if ctx.SignatureKeyringFlag != "" {
+		keyring, err := os.Open(ctx.SignatureKeyringFlag)
+		if err != nil {
+			return nil, fmt.Errorf("opening GPG keyring: %w", err)
+		}
+
+		defer func() {
+			_ = keyring.Close()
+		}()
+
+		verifier.KeyRing, err = gpg.ReadKeyRing(keyring)
+		if err != nil {
+			return nil, fmt.Errorf("loading GPG keyring: %w", err)
+		}
+	}
+
+	if ctx.SignatureSignersFlag != "" {
+		allowedSigners, err := os.ReadFile(ctx.SignatureSignersFlag)
+		if err != nil {
+			return nil, fmt.Errorf("reading SSH allowed signers: %w", err)
+		}
+
+		verifier.SSHKeys, err = ssh.ParseAllowedSigners(bytes.NewReader(allowedSigners))
+		if err != nil {
+			return nil, fmt.Errorf("loading SSH allowed signers: %w", err)
+		}
+	}
+
+	return verifier, nil
+}
+
+func configureIssuePattern(ctx *appcontext.AppContext) (*regexp.Regexp, error) {
+	flag := ctx.IssuePatternFlag
+
+	if flag == "" {
+		return nil, nil
+	}
+
+	pattern, err := regexp.Compile(flag)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrInvalidIssuePattern, flag, err)
+	}
+
+	return pattern, nil
+}
+
+// configureGPGKey returns the signing key read from the armored key file, from stdin if the key path is "-", or from the
+// GO_SEMVER_RELEASE_GPG_PRIVATE_KEY environment variable, nil if none is set. The key is decrypted if needed.
+func configureGPGKey(ctx *appcontext.AppContext, stdin io.Reader) (*openpgp.Entity, error) {
+	var (
+		armoredKeyFile []byte
+		err            error
+	)
+
+	switch ctx.GPGKeyPathFlag {
+	case "":
+		armoredKeyFile = []byte(os.Getenv(gpgPrivateKeyEnv))
+		if len(armoredKeyFile) == 0 {
+			return nil, nil
+		}
+
+		ctx.Logger.Debug().Str("env", gpgPrivateKeyEnv).Msg("using the armored key of the environment variable for signing")
+	case "-":
+		ctx.Logger.Debug().Msg("using the armored key read from stdin for signing")
+		armoredKeyFile, err = io.ReadAll(stdin)
+	default:
+		ctx.Logger.Debug().Str("path", ctx.GPGKeyPathFlag).Msg("using the following armored key for signing")
+		armoredKeyFile, err = os.ReadFile(ctx.GPGKeyPathFlag)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading armored key: %w", err)
+	}
+
+	options := []gpg.OptionFunc{gpg.WithTime(now(ctx))}
+
+	if ctx.GPGKeyIDFlag != "" {
+		options = append(options, gpg.WithKeyID(ctx.GPGKeyIDFlag))
+	}
+
+	if ctx.GPGKeyEmailFlag != "" {
+		options = append(options, gpg.WithEmail(ctx.GPGKeyEmailFlag))
+	}
+
+	entity, err := gpg.FromArmored(bytes.NewReader(armoredKeyFile), options...)
+	if err != nil {
+		return nil, fmt.Errorf("loading armored key: %w", err)
+	}
+
+	if key, ok := entity.SigningKey(now(ctx)); ok {
+		ctx.Logger.Debug().Str("fingerprint", fmt.Sprintf("%X", key.PublicKey.Fingerprint)).Msg("signing with the following key")
+	}
+
+	if !gpg.IsEncrypted(entity) {
+		return entity, nil
+	}
+
+	passphrase, err := configureGPGPassphrase(ctx, stdin)
+	if err != nil {
+		return nil, fmt.Errorf("reading passphrase: %w", err)
+	}
+
+	if err = gpg.Decrypt(entity, passphrase); err != nil {
+		return nil, fmt.Errorf("decrypting armored key: %w", err)
+	}
+
+	return entity, nil
+}
+
+// configureGPGPassphrase returns the passphrase of the GPG key read from the passphrase file, from stdin if the
+// passphrase file is "-", or from the GO_SEMVER_RELEASE_GPG_PASSPHRASE environment variable.
+func configureGPGPassphrase(ctx *appcontext.AppContext, stdin io.Reader) ([]byte, error) {
+	if ctx.GPGPassphraseFileFlag == "" {
+		return []byte(os.Getenv(gpgPassphraseEnv)), nil
+	}
+
+	return readSecret(ctx, "GPG key passphrase", ctx.GPGPassphraseFileFlag, stdin)
+}
+
+// commitReports converts the commits classification of the parser to their output representation.
+func commitReports(report []parser.CommitReport) []output.CommitReport {
+	commits := make([]output.CommitReport, len(report))
+
+	for i, entry := range report {
+		commits[i] = output.CommitReport{
+			Hash:     entry.Hash.String(),
+			Type:     entry.Type,
+			Scope:    entry.Scope,
+			Breaking: entry.Breaking,
+			Rule:     entry.Rule,
+			Bump:     entry.Bump,
+			Ignored:  entry.Ignored,
+			Reason:   entry.Reason,
+		}
+	}
+
+	return commits
+}
+
+// checkoutRelease checks out the given release branch in the worktree of the given cloned repository, so that a release
+// commit can be made on top of it. ErrDirtyWorktree is returned if the worktree has uncommitted changes.
+func checkoutRelease(ctx *appcontext.AppContext, repository *git.Repository, branchName string) (*git.Worktree, error) {
+	localBranchRef := plumbing.NewBranchReferenceName(branchName)
+
+	// The local branch may be a symbolic reference to the remote branch, it is replaced by a hash reference so that
+	// it can move forward without moving the remote branch
+	ref, err := repository.Storer.Reference(localBranchRef)
+	if err != nil || ref.Type() != plumbing.HashReference {
+		remoteRef, err := repository.Reference(plumbing.NewRemoteReferenceName(ctx.RemoteNameFlag, branchName), true)
+		if err != nil {
+			return nil, fmt.Errorf("fetching remote branch %q: %w", branchName, err)
+		}
+
+		err = repository.Storer.SetReference(plumbing.NewHashReference(localBranchRef, remoteRef.Hash()))
+		if err != nil {
+			return nil, fmt.Errorf("creating local branch %q: %w", branchName, err)
+		}
+	}
+
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	err = worktree.Checkout(&git.CheckoutOptions{Branch: localBranchRef})
+	if err != nil {
+		return nil, fmt.Errorf("checking out to release branch: %w", err)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("fetching worktree status: %w", err)
+	}
+
+	if !status.IsClean() {
+		return nil, ErrDirtyWorktree
+	}
+
+	return worktree, nil
+}
+
+// commitRelease adds the changelog section of a release to the changelog file of the given worktree, checked out by
+// checkoutRelease, and commits it along with the other files modified in the worktree, such as the version files
+// bumped by the hooks and plugins preparing the release. The commit is signed with the given GPG or SSH key, if any,
+// like the release tag. The hash of the release commit is returned.
+func commitRelease(ctx *appcontext.AppContext, worktree *git.Worktree, tagName, notes string, entity *openpgp.Entity, sshSigner *ssh.Signer) (plumbing.Hash, error) {
+	changelogPath := filepath.Clean(ctx.ChangelogPathFlag)
+
+	err := changelog.Write(filepath.Join(worktree.Filesystem.Root(), changelogPath), notes)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("writing changelog: %w", err)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching worktree status: %w", err)
+	}
+
+	// Ignored files are not part of the status, build outputs of the hooks are therefore not committed
+	for path, fileStatus := range status {
+		switch fileStatus.Worktree {
+		case git.Unmodified:
+			continue
+		case git.Deleted:
+			_, err = worktree.Remove(path)
+		default:
+			_, err = worktree.Add(path)
+		}
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("adding %q to worktree: %w", path, err)
+		}
+	}
+
+	signature := &object.Signature{
+		Name:  ctx.GitNameFlag,
+		Email: ctx.GitEmailFlag,
+		When:  now(ctx),
+	}
+
+	options := &git.CommitOptions{
+		Author:    signature,
+		Committer: signature,
+		SignKey:   entity,
+	}
+
+	if sshSigner != nil {
+		options.Signer = sshCommitSigner{signer: sshSigner}
+	}
+
+	commitHash, err := worktree.Commit(fmt.Sprintf(releaseCommitMessage, tagName), options)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("committing changelog: %w", err)
+	}
+
+	return commitHash, nil
+}
+
+// sshCommitSigner signs commits with an SSH key, Git storing SSH signatures in the same header as PGP signatures.
+type sshCommitSigner struct {
+	signer *ssh.Signer
+}
+
+func (s sshCommitSigner) Sign(message io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(message)
+	if err != nil {
+		return nil, fmt.Errorf("reading commit: %w", err)
+	}
+
+	signature, err := s.signer.Sign(content)
+	if err != nil {
+		return nil, fmt.Errorf("signing commit: %w", err)
+	}
+
+	return []byte(signature), nil
+}
+
+func configureSSHKey(ctx *appcontext.AppContext) (*ssh.Signer, error) {
+	flag := ctx.SSHKeyPathFlag
+
+	if flag == "" {
+		return nil, nil
+	}
+
+	ctx.Logger.Debug().Str("path", ctx.SSHKeyPathFlag).Msg("using the following SSH key for signing")
+
+	keyFile, err := os.ReadFile(ctx.SSHKeyPathFlag)
+	if err != nil {
+		return nil, fmt.Errorf("reading SSH key: %w", err)
+	}
+
+	signer, err := ssh.FromPEM(bytes.NewReader(keyFile))
+	if err != nil {
+		return nil, fmt.Errorf("loading SSH key: %w", err)
+	}
+
+	return signer, nil
+}
+
+// chatRelease returns the summary of the release of the given parser output posted to the chat applications. The
+// links to the release are only added if the URLs of the pages of the repository are known.
+func chatRelease(links forge.Repository, tagger *tag.Tagger, parserOutput parser.ComputeNewSemverOutput) chat.Release {
+	tagName := tagger.Format(parserOutput.Semver)
+
+	release := chat.Release{
+		Tag:      tagName,
+		URL:      links.TagURL(tagName),
+		Sections: changelog.Sections(parserOutput.Commits),
+	}
+
+	if parserOutput.PreviousSemver != nil {
+		release.CompareURL = links.CompareURL(tagger.Format(parserOutput.PreviousSemver), tagName)
+	}
+
+	return release
+}
+
+// repositoryLinks returns the repository whose pages are linked from the release notes, deduced from the URL of the
+// repository to release, or from the URL of its remote if it is a local one, and the URL templates.
+func repositoryLinks(ctx *appcontext.AppContext, repositoryURL string) (forge.Repository, error) {
+	remoteURL := repositoryURL
+
+	if local, err := git.PlainOpen(repositoryURL); err == nil {
+		if gitRemote, err := local.Remote(ctx.RemoteNameFlag); err == nil && len(gitRemote.Config().URLs) > 0 {
+			remoteURL = gitRemote.Config().URLs[0]
+		}
+	}
+
+	repository, _ := forge.Detect(remoteURL)
+
+	return repository.WithTemplates(forge.Templates{
+		Commit:      ctx.CommitURLTemplateFlag,
+		Compare:     ctx.CompareURLTemplateFlag,
+		PullRequest: ctx.PRURLTemplateFlag,
+		Issue:       ctx.IssueURLTemplateFlag,
+	})
+}
+
+// writeAttestation writes the provenance attestation of the release of the given parser output, tagged with the given
+// tag, to the attestation directory. The attestation is signed with the given GPG key, if any.
+func writeAttestation(ctx *appcontext.AppContext, repositoryURL, tagName string, commitHash plumbing.Hash, parserOutput parser.ComputeNewSemverOutput, signKey *openpgp.Entity) ([]string, error) {
+	rules := releaseRules(ctx, parserOutput)
+
+	statement, err := attestation.New(attestation.Release{
+		Repository:  repositoryURL,
+		Commit:      commitHash.String(),
+		Tag:         tagName,
+		Version:     parserOutput.Semver.String(),
+		Branch:      parserOutput.Branch,
+		Project:     parserOutput.Project.Name,
+		Rules:       rules,
+		ToolVersion: cmdVersion,
+		Date:        now(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attestation.Write(ctx.AttestationDirFlag, statement, signKey)
+}
+
+// releaseRules returns the release rules used to compute the version of the given output, those of its project if set.
+func releaseRules(ctx *appcontext.AppContext, parserOutput parser.ComputeNewSemverOutput) rule.Rules {
+	if parserOutput.Project.Rules != nil {
+		return *parserOutput.Project.Rules
+	}
+
+	return ctx.Rules
+}
+
+// newLedgerEntry returns the entry of the release ledger recording the release decision of the given command, as
+// reported by the given output.
+func newLedgerEntry(ctx *appcontext.AppContext, command string, release output.Release, commitHash plumbing.Hash, rulesDigest string) ledger.Entry {
+	entry := ledger.Entry{
+		Time:            now(ctx).UTC(),
+		Actor:           ledger.Actor(),
+		Command:         command,
+		Project:         release.Project,
+		Branch:          release.Branch,
+		PreviousVersion: release.PreviousVersion,
+		Version:         release.Version,
+		NewRelease:      release.NewRelease,
+		RulesDigest:     rulesDigest,
+		DryRun:          ctx.DryRunFlag,
+	}
+
+	if !commitHash.IsZero() {
+		entry.Commit = commitHash.String()
+	}
+
+	return entry
+}
+
+// saveLedger appends the given entries to the release ledger and pushes it to the remote. If the push is rejected
+// because the ledger was updated concurrently, the remote ledger is fetched again and the entries are appended on top of
+// it, as many times as pushes are retried. Nothing is done if no ledger is configured.
+func saveLedger(cmdCtx context.Context, ctx *appcontext.AppContext, origin *remote.Remote, repository *git.Repository, entries []ledger.Entry) error {
+	if ctx.LedgerBranchFlag == "" || len(entries) == 0 {
+		return nil
+	}
+
+	l := ledger.New(repository, ctx.LedgerBranchFlag, ctx.RemoteNameFlag)
+
+	signature := object.Signature{
+		Name:  ctx.GitNameFlag,
+		Email: ctx.GitEmailFlag,
+		When:  now(ctx),
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := l.Append(signature, entries...)
+		if err != nil {
+			return fmt.Errorf("appending to release ledger: %w", err)
+		}
+
+		err = origin.PushBranch(cmdCtx, ctx.LedgerBranchFlag)
+		if err == nil {
+			break
+		}
+
+		if attempt >= ctx.PushRetriesFlag {
+			return fmt.Errorf("pushing release ledger: %w", err)
+		}
+
+		ctx.Logger.Warn().Err(err).Msg("release ledger push rejected, appending again")
+
+		err = origin.Refresh(cmdCtx)
+		if err != nil {
+			return fmt.Errorf("fetching release ledger: %w", err)
+		}
+	}
+
+	ctx.Logger.Debug().Int("entries", len(entries)).Str("branch", ctx.LedgerBranchFlag).Msg("release ledger updated")
+
+	return nil
+}
+
+// checkLocalRepository checks, if the released repository is a local one, that its worktree is clean and that the given
+// release branch is in sync with its upstream, when required by the configuration. The worktree is only checked if
+// checkWorktree is true.
+func checkLocalRepository(ctx *appcontext.AppContext, repositoryPath, branchName string, checkWorktree bool) error {
+	if _, err := git.PlainOpen(repositoryPath); err != nil {
+		return nil
+	}
+
+	if ctx.RequireCleanFlag && checkWorktree {
+		if err := remote.CheckClean(repositoryPath); err != nil {
+			return err
+		}
+	}
+
+	if ctx.RequireSyncedFlag {
+		if err := remote.CheckSynced(repositoryPath, branchName, ctx.RemoteNameFlag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// configureTagDate checks that the tag date is either the current date or the committer date of the tagged commit.
+func configureTagDate(ctx *appcontext.AppContext) error {
+	switch ctx.TagDateFlag {
+	case "", tagDateNow, tagDateCommit:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidTagDate, ctx.TagDateFlag)
+	}
+}
+
+// now returns the current date given by the clock of the context.
+func now(ctx *appcontext.AppContext) time.Time {
+	if ctx.Clock != nil {
+		return ctx.Clock()
+	}
+
+	return time.Now()
+}
+
+// newTagger returns the tagger of the release tags, identified by the tagger name and email, or by the Git ones if not
+// set, and dated by the clock of the context or by the tagged commit.
+func newTagger(ctx *appcontext.AppContext, options ...tag.OptionFunc) *tag.Tagger {
+	name, email := ctx.GitNameFlag, ctx.GitEmailFlag
+
+	if ctx.TaggerNameFlag != "" {
+		name = ctx.TaggerNameFlag
+	}
+
+	if ctx.TaggerEmailFlag != "" {
+		email = ctx.TaggerEmailFlag
+	}
+
+	options = append(options, tag.WithClock(func() time.Time { return now(ctx) }), tag.WithCommitDate(ctx.TagDateFlag == tagDateCommit))
+
+	return tag.NewTagger(name, email, options...)
+}
+
+// commandsDir returns the directory in which the hooks and the plugins are run: the repository to release if it is a
+// local one, the current directory otherwise.
+func commandsDir(repositoryPath string) string {
+	if info, err := os.Stat(repositoryPath); err == nil && info.IsDir() {
+		return repositoryPath
+	}
+
+	return ""
+}
+
+// newPluginRelease returns the description of the release of the given parser output given to the plugins.
+func newPluginRelease(repositoryURL string, parserOutput parser.ComputeNewSemverOutput) plugin.Release {
+	release := plugin.Release{
+		Repository: repositoryURL,
+		Branch:     parserOutput.Branch,
+		Channel:    parserOutput.Channel,
+		Project:    parserOutput.Project.Name,
+		Version:    parserOutput.Semver.String(),
+		Commit:     parserOutput.CommitHash.String(),
+		NewRelease: parserOutput.NewRelease,
+		Commits:    make([]plugin.Commit, 0, len(parserOutput.Commits)),
+	}
+
+	if parserOutput.PreviousSemver != nil {
+		release.PreviousVersion = parserOutput.PreviousSemver.String()
+	}
+
+	for _, commit := range parserOutput.Commits {
+		release.Commits = append(release.Commits, plugin.Commit{
+			Hash:        commit.Hash.String(),
+			Type:        commit.Type,
+			Scope:       commit.Scope,
+			Description: commit.Description,
+			Breaking:    commit.Breaking,
+			References:  commit.References,
+		})
+	}
+
+	return release
 }
@@ -3,23 +3,59 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	assertion "github.com/stretchr/testify/assert"
+	cryptossh "golang.org/x/crypto/ssh"
 
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/attestation"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/ci"
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
 	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+	"github.com/s0ders/go-semver-release/v6/internal/gpg"
+	"github.com/s0ders/go-semver-release/v6/internal/hook"
+	"github.com/s0ders/go-semver-release/v6/internal/logging"
+	"github.com/s0ders/go-semver-release/v6/internal/mirror"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/output"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/plugin"
+	"github.com/s0ders/go-semver-release/v6/internal/remote"
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
 	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/scheme"
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
 	"github.com/s0ders/go-semver-release/v6/internal/tag"
+	"github.com/s0ders/go-semver-release/v6/internal/webhook"
 )
 
 type cmdOutput struct {
@@ -27,6 +63,8 @@ type cmdOutput struct {
 	Branch     string `json:"branch"`
 	Version    string `json:"version"`
 	Project    string `json:"project"`
+	From       string `json:"from"`
+	To         string `json:"to"`
 	NewRelease bool   `json:"new-release"`
 }
 
@@ -104,8 +142,8 @@ rules:
 	}
 
 	alphaCommits := []string{
-		"fix",  // 1.2.3-alpha
-		"feat", // 1.3.0-alpha
+		"fix",  // 1.2.3-alpha.1
+		"feat", // 1.3.0-alpha.1
 	}
 
 	testRepository, err := gittest.NewRepository()
@@ -139,7 +177,7 @@ rules:
 
 	expectedMasterVersion := "1.2.2"
 	expectedMasterTag := "v" + expectedMasterVersion
-	expectedAlphaVersion := "1.3.0-alpha"
+	expectedAlphaVersion := "1.3.0-alpha.1"
 	expectedAlphaTag := "v" + expectedAlphaVersion
 
 	expectedOutputs := []cmdOutput{
@@ -409,7 +447,7 @@ func TestReleaseCmd_MultiBranchRelease(t *testing.T) {
 	}
 
 	th := NewTestHelper(t)
-	err = th.SetFlag(BranchesConfiguration, `[{"name": "master"}, {"name": "rc", "prerelease": true}]`)
+	err = th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
 	checkErr(t, err, "setting flags")
 
 	out, err := th.ExecuteCommand("release", testRepository.Path)
@@ -425,7 +463,7 @@ func TestReleaseCmd_MultiBranchRelease(t *testing.T) {
 		},
 		{
 			Message:    "new release found",
-			Version:    "2.1.1-rc",
+			Version:    "2.1.1-rc.1",
 			NewRelease: true,
 			Branch:     "rc",
 		},
@@ -511,7 +549,7 @@ func TestReleaseCmd_PrereleaseBranch(t *testing.T) {
 	out, err := th.ExecuteCommand("release", testRepository.Path)
 	checkErr(t, err, "executing command")
 
-	expectedVersion := "1.1.1-master"
+	expectedVersion := "1.1.1-master.1"
 	expectedTag := "v" + expectedVersion
 	expectedOut := cmdOutput{
 		Message:    "new release found",
@@ -569,33 +607,2078 @@ func TestReleaseCmd_DryRunRelease(t *testing.T) {
 	exists, err := tag.Exists(testRepository.Repository, expectedTag)
 	checkErr(t, err, "checking if tag exists")
 
-	assert.Equal(false, exists, "tag should not exist, running in dry-run mode")
+	assert.Equal(false, exists, "tag should not exist, running in dry-run mode")
+}
+
+func TestReleaseCmd_Changelog(t *testing.T) {
+	assert := assertion.New(t)
+
+	commits := []string{
+		"fix",  // 0.0.1
+		"feat", // 0.1.0
+	}
+
+	testRepository := NewTestRepository(t, commits)
+
+	changelogPath := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		ChangelogPathConfiguration: changelogPath,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err := os.ReadFile(changelogPath)
+	checkErr(t, err, "reading changelog")
+
+	assert.Contains(string(content), "## v0.1.0")
+	assert.Contains(string(content), "### Features")
+	assert.Contains(string(content), "### Fixes")
+}
+
+func TestReleaseCmd_DryRunChangelog(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	changelogPath := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		ChangelogPathConfiguration: changelogPath,
+		DryRunConfiguration:        "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), "## v0.1.0")
+	assert.NoFileExists(changelogPath, "changelog should not be written in dry-run mode")
+}
+
+func TestReleaseCmd_ChangelogLinks(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	// The links are deduced from the remote of local repositories
+	_, err := testRepository.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:owner/name.git"}})
+	checkErr(t, err, "creating remote")
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	changelogPath := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		ChangelogPathConfiguration: changelogPath,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err := os.ReadFile(changelogPath)
+	checkErr(t, err, "reading changelog")
+
+	assert.Contains(string(content), "(https://github.com/owner/name/commit/"+head.Hash().String()+")")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:           `[{"name": "master"}]`,
+		ChangelogPathConfiguration:      changelogPath,
+		CompareURLTemplateConfiguration: "https://git.example.com/diff/{{.From}}..{{.To}}",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err = os.ReadFile(changelogPath)
+	checkErr(t, err, "reading changelog")
+
+	assert.Contains(string(content), "**Full Changelog**: [v0.1.0...v0.1.1](https://git.example.com/diff/v0.1.0..v0.1.1)")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:          `[{"name": "master"}]`,
+		CommitURLTemplateConfiguration: "{{.Hash",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, forge.ErrInvalidTemplate)
+}
+
+func TestReleaseCmd_IssueReferences(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, nil)
+
+	_, err := testRepository.AddCommitWithMessage("feat: add foo\n\nCloses #12\nRelated to [PROJ-3]")
+	checkErr(t, err, "adding commit")
+
+	changelogPath := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:         `[{"name": "master"}]`,
+		ChangelogPathConfiguration:    changelogPath,
+		OutputFormatConfiguration:     "go-template={{ .Issues }}",
+		IssueURLTemplateConfiguration: "https://jira.example.com/browse/{{.Reference}}",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("[#12]\n", string(out), "output should list the referenced issues")
+
+	content, err := os.ReadFile(changelogPath)
+	checkErr(t, err, "reading changelog")
+
+	assert.Contains(string(content), ", closes [#12](https://jira.example.com/browse/#12)\n")
+
+	_, err = testRepository.AddCommitWithMessage("fix: fix bar\n\nCloses #13\nRelated to [PROJ-3]")
+	checkErr(t, err, "adding commit")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		OutputFormatConfiguration: "go-template={{ .Issues }}",
+		IssuePatternConfiguration: `\[(PROJ-\d+)\]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("[PROJ-3]\n", string(out), "custom pattern should replace the closing keywords")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		IssuePatternConfiguration: "PROJ-(",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrInvalidIssuePattern)
+}
+
+func TestReleaseCmd_CommitPattern(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, nil)
+
+	_, err := testRepository.AddCommitWithMessage("JIRA-12 feat add foo")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		OutputFormatConfiguration:  "go-template={{ .Version }}",
+		CommitPatternConfiguration: `^[A-Z]+-\d+ (?P<type>[a-z]+) `,
+		DryRunConfiguration:        "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("0.1.0\n", string(out))
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		CommitPatternConfiguration: `^[A-Z]+-\d+ [a-z]+ `,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, parser.ErrInvalidCommitPattern)
+}
+
+func TestReleaseCmd_InvalidBuildMetadata(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		BuildMetadataConfiguration: "{{ .Sha }}",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, parser.ErrInvalidBuildMetadata)
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.False(exists, "no tag should have been created")
+}
+
+func TestReleaseCmd_VersionOverride(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:  `[{"name": "master"}]`,
+		ForceBumpConfiguration: "major",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"version":"1.0.0"`)
+	assert.Contains(string(out), `"forced":true`)
+	assert.Contains(string(out), "new release forced")
+
+	exists, err := tag.Exists(testRepository.Repository, "v1.0.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "forced tag should have been created")
+
+	tests := []struct {
+		flags map[string]string
+		want  error
+	}{
+		{flags: map[string]string{ForceBumpConfiguration: "huge"}, want: ErrInvalidForceBump},
+		{flags: map[string]string{TagDateConfiguration: "yesterday"}, want: ErrInvalidTagDate},
+		{flags: map[string]string{MirrorsConfiguration: `[{"name": "mirror"}]`}, want: mirror.ErrNoURL},
+		{flags: map[string]string{SetVersionConfiguration: "v2"}, want: ErrInvalidSetVersion},
+		{flags: map[string]string{SetVersionConfiguration: "2.0.0", ForceBumpConfiguration: "minor"}, want: ErrConflictingOverrides},
+	}
+
+	for _, tc := range tests {
+		th = NewTestHelper(t)
+
+		tc.flags[BranchesConfiguration] = `[{"name": "master"}]`
+
+		err = th.SetFlags(tc.flags)
+		checkErr(t, err, "setting flags")
+
+		_, err = th.ExecuteCommand("release", testRepository.Path)
+		assert.ErrorIs(err, tc.want)
+	}
+}
+
+func TestReleaseCmd_VersionScheme(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat", "fix"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		VersionSchemeConfiguration: "calver",
+		CalVerFormatConfiguration:  "YY.MM.MICRO",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	// Commits of the test repository are made on January 1st, 2000
+	assert.Contains(string(out), `"version":"0.1.1"`)
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.1")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "CalVer tag should have been created")
+
+	tests := []struct {
+		flags map[string]string
+		want  error
+	}{
+		{flags: map[string]string{VersionSchemeConfiguration: "romver"}, want: scheme.ErrUnknownScheme},
+		{flags: map[string]string{VersionSchemeConfiguration: "calver", CalVerFormatConfiguration: "YYYY.MM"}, want: scheme.ErrInvalidCalVerFormat},
+	}
+
+	for _, tc := range tests {
+		th = NewTestHelper(t)
+
+		tc.flags[BranchesConfiguration] = `[{"name": "master"}]`
+
+		err = th.SetFlags(tc.flags)
+		checkErr(t, err, "setting flags")
+
+		_, err = th.ExecuteCommand("release", testRepository.Path)
+		assert.ErrorIs(err, tc.want)
+	}
+}
+
+func TestReleaseCmd_DockerTags(t *testing.T) {
+	assert := assertion.New(t)
+
+	const manifest = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json"}`
+
+	manifests := map[string]string{"edge": manifest}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		if username != "user" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		tag, found := strings.CutPrefix(r.URL.Path, "/v2/owner/app/manifests/")
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+			_, _ = w.Write([]byte(manifests[tag]))
+		case http.MethodPut:
+			content, _ := io.ReadAll(r.Body)
+			manifests[tag] = string(content)
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("GO_SEMVER_RELEASE_DOCKER_PASSWORD", "secret")
+
+	image := strings.TrimPrefix(server.URL, "http://") + "/owner/app"
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}]`,
+		DockerImageConfiguration:     image,
+		DockerSourceTagConfiguration: "edge",
+		DockerUsernameConfiguration:  "user",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), fmt.Sprintf(`"docker-tags":["%[1]s:0","%[1]s:0.1","%[1]s:0.1.0","%[1]s:latest"]`, image))
+
+	for _, tag := range []string{"0", "0.1", "0.1.0", "latest"} {
+		assert.Equal(manifest, manifests[tag], "image should have been retagged with %q", tag)
+	}
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}]`,
+		DockerSourceTagConfiguration: "edge",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrNoDockerImage)
+}
+
+func TestReleaseCmd_Logging(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:  `[{"name": "master"}]`,
+		LogLevelConfiguration:  "debug",
+		LogFormatConfiguration: "console",
+		DryRunConfiguration:    "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	th.Cmd.SetOut(stdout)
+	th.Cmd.SetErr(stderr)
+	th.Cmd.SetArgs([]string{"release", testRepository.Path})
+
+	err = th.Cmd.Execute()
+	checkErr(t, err, "executing command")
+
+	var out map[string]any
+
+	err = json.Unmarshal(stdout.Bytes(), &out)
+	checkErr(t, err, "standard output should only hold the release")
+
+	assert.Equal("0.1.0", out["version"])
+	assert.Contains(stderr.String(), " DBG ", "debug logs should be written to the standard error")
+	assert.NotContains(stderr.String(), `"version"`, "release should not be written to the standard error")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		LogLevelConfiguration: "trace",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, logging.ErrInvalidLevel)
+}
+
+func TestReleaseCmd_Quiet(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		HooksConfiguration:    `{"pre-tag": ["echo pre-tag"]}`,
+		QuietConfiguration:    "true",
+		"verbose":             "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	th.Cmd.SetOut(stdout)
+	th.Cmd.SetErr(stderr)
+	th.Cmd.SetArgs([]string{"release", testRepository.Path})
+
+	err = th.Cmd.Execute()
+	checkErr(t, err, "executing command")
+
+	var out map[string]any
+
+	err = json.Unmarshal(stdout.Bytes(), &out)
+	checkErr(t, err, "standard output should only hold the release")
+
+	assert.Equal("0.1.0", out["version"])
+	assert.Empty(stderr.String(), "nothing but the release should be printed out in quiet mode")
+}
+
+func TestReleaseCmd_Attestation(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	dir := t.TempDir()
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:       `[{"name": "master"}]`,
+		AttestationDirConfiguration: dir,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err := os.ReadFile(filepath.Join(dir, "v0.1.0.intoto.json"))
+	checkErr(t, err, "reading attestation")
+
+	var statement attestation.Statement
+
+	err = json.Unmarshal(content, &statement)
+	checkErr(t, err, "decoding attestation")
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	assert.Equal("v0.1.0", statement.Subject[0].Name)
+	assert.Equal(head.Hash().String(), statement.Subject[0].Digest["gitCommit"])
+	assert.Equal("0.1.0", statement.Predicate.BuildDefinition.ExternalParameters.Version)
+	assert.Equal("master", statement.Predicate.BuildDefinition.ExternalParameters.Branch)
+	assert.NoFileExists(filepath.Join(dir, "v0.1.0.intoto.json.asc"), "attestation should not be signed without GPG key")
+}
+
+func TestReleaseCmd_TagAliases(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat", "fix"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:   `[{"name": "master"}]`,
+		TagAliasesConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	for _, tagName := range []string{"v0.1.1", "v0", "v0.1"} {
+		exists, err := tag.Exists(testRepository.Repository, tagName)
+		checkErr(t, err, "checking if tag exists")
+		assert.True(exists, "tag %q should have been pushed", tagName)
+	}
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	reference, err := testRepository.Reference(plumbing.NewTagReferenceName("v0"), true)
+	checkErr(t, err, "fetching tag reference")
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.Equal(head.Hash(), tagObject.Target, "major alias should have been moved to the new release")
+}
+
+func TestReleaseCmd_ReleaseCommit(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	// A non-bare repository refuses pushes to its checked out branch
+	err := testRepository.CheckoutBranch("other")
+	checkErr(t, err, "checking out another branch")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		ChangelogPathConfiguration: "CHANGELOG.md",
+		ReleaseCommitConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	reference, err := testRepository.Reference(plumbing.NewBranchReferenceName("master"), true)
+	checkErr(t, err, "fetching branch reference")
+
+	releaseCommit, err := testRepository.CommitObject(reference.Hash())
+	checkErr(t, err, "fetching release commit")
+
+	assert.Equal("chore(release): v0.1.0 [skip ci]", releaseCommit.Message)
+
+	changelogFile, err := releaseCommit.File("CHANGELOG.md")
+	checkErr(t, err, "fetching changelog file")
+
+	content, err := changelogFile.Contents()
+	checkErr(t, err, "reading changelog file")
+
+	assert.Contains(content, "## v0.1.0")
+
+	tagReference, err := testRepository.Reference(plumbing.NewTagReferenceName("v0.1.0"), true)
+	checkErr(t, err, "fetching tag reference")
+
+	tagObject, err := testRepository.TagObject(tagReference.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.Equal(releaseCommit.Hash, tagObject.Target, "tag should point to the release commit")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), `"new-release":false`, "release commit should not trigger a release")
+}
+
+func TestReleaseCmd_ReleaseCommitBumpedFiles(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	err := testRepository.CheckoutBranch("other")
+	checkErr(t, err, "checking out another branch")
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, err, "generating ed25519 key")
+
+	block, err := cryptossh.MarshalPrivateKey(privateKey, "")
+	checkErr(t, err, "marshalling private key")
+
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+
+	err = os.WriteFile(keyPath, pem.EncodeToMemory(block), 0o600)
+	checkErr(t, err, "writing private key")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		ChangelogPathConfiguration: "CHANGELOG.md",
+		HooksConfiguration:         `{"pre-tag": ["echo $SEMVER_NEW_VERSION > VERSION"]}`,
+		ReleaseCommitConfiguration: "true",
+		SSHKeyPathConfiguration:    keyPath,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	reference, err := testRepository.Reference(plumbing.NewBranchReferenceName("master"), true)
+	checkErr(t, err, "fetching branch reference")
+
+	releaseCommit, err := testRepository.CommitObject(reference.Hash())
+	checkErr(t, err, "fetching release commit")
+
+	versionFile, err := releaseCommit.File("VERSION")
+	checkErr(t, err, "fetching bumped file")
+
+	content, err := versionFile.Contents()
+	checkErr(t, err, "reading bumped file")
+
+	assert.Equal("0.1.0\n", content, "file bumped by the hook should have been committed")
+
+	_, err = releaseCommit.File("CHANGELOG.md")
+	assert.NoError(err, "changelog should have been committed")
+
+	unsigned := &plumbing.MemoryObject{}
+	err = releaseCommit.EncodeWithoutSignature(unsigned)
+	checkErr(t, err, "encoding release commit")
+
+	reader, err := unsigned.Reader()
+	checkErr(t, err, "reading release commit")
+
+	message, err := io.ReadAll(reader)
+	checkErr(t, err, "reading release commit")
+
+	publicKey, err := cryptossh.NewPublicKey(privateKey.Public())
+	checkErr(t, err, "fetching public key")
+
+	err = ssh.Verify(publicKey, message, releaseCommit.PGPSignature)
+	assert.NoError(err, "release commit should have been signed with the SSH key")
+}
+
+func TestReleaseCmd_Timeout(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		TimeoutConfiguration:  "1ns",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, context.DeadlineExceeded, "command should have timed out")
+}
+
+func TestReleaseCmd_OutputFormat(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		DryRunConfiguration:       "true",
+		OutputFormatConfiguration: "go-template={{ .NewVersion }}",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal("0.1.0\n", string(out), "output should be formatted using the template")
+
+	err = th.SetFlag(OutputFormatConfiguration, "xml")
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, output.ErrInvalidFormat, "invalid output format should have been rejected")
+}
+
+func TestReleaseCmd_CIProvider(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		CIProviderConfiguration:   ci.ProviderTeamCity,
+		DryRunConfiguration:       "true",
+		OutputFormatConfiguration: "go-template={{ .NewVersion }}",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	want := "##teamcity[setParameter name='env.MASTER_SEMVER' value='v0.1.0']\n##teamcity[setParameter name='env.MASTER_NEW_RELEASE' value='true']\n0.1.0\n"
+	assert.Equal(want, string(out), "service messages should have been written")
+
+	err = th.SetFlag(CIProviderConfiguration, "jenkins")
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ci.ErrUnknownProvider, "unknown provider should have been rejected")
+}
+
+func TestReleaseCmd_CIProviderJSONOutput(t *testing.T) {
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	for _, provider := range []string{ci.ProviderTeamCity, ci.ProviderAzureDevOps} {
+		t.Run(provider, func(t *testing.T) {
+			assert := assertion.New(t)
+
+			th := NewTestHelper(t)
+			err := th.SetFlags(map[string]string{
+				BranchesConfiguration:     `[{"name": "master"}]`,
+				CIProviderConfiguration:   provider,
+				DryRunConfiguration:       "true",
+				OutputFormatConfiguration: output.FormatJSON,
+			})
+			checkErr(t, err, "setting flags")
+
+			stdout := new(bytes.Buffer)
+			th.Cmd.SetOut(stdout)
+			th.Cmd.SetErr(io.Discard)
+			th.Cmd.SetArgs([]string{"release", testRepository.Path})
+
+			err = th.Cmd.Execute()
+			checkErr(t, err, "executing command")
+
+			var messages, release []string
+
+			for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+				if strings.HasPrefix(line, "##") {
+					messages = append(messages, line)
+					continue
+				}
+
+				release = append(release, line)
+			}
+
+			assert.Len(messages, 2, "service messages should have been written to the standard output")
+
+			var releases any
+			err = json.Unmarshal([]byte(strings.Join(release, "\n")), &releases)
+			assert.NoError(err, "output should be valid JSON once the service messages are filtered out")
+		})
+	}
+}
+
+func TestReleaseCmd_Report(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat", "docs"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		DryRunConfiguration:       "true",
+		OutputFormatConfiguration: output.FormatText,
+		ReportConfiguration:       "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+
+	assert.Len(lines, 5, "output should contain the release and the classification of the three commits")
+	assert.Equal("commits considered on branch master:", lines[1])
+	assert.Contains(lines[2], "ignored: not a conventional commit")
+	assert.Contains(lines[3], "feat rule=minor bump=minor")
+	assert.Contains(lines[4], "docs ignored: commit type skipped by release rule")
+}
+
+func TestReleaseCmd_ReleaseNoNewVersion(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{})
+
+	th := NewTestHelper(t)
+	err := th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	expectedOut := cmdOutput{
+		Message:    "no new release",
+		NewRelease: false,
+		Branch:     "master",
+		Version:    "0.0.0",
+	}
+	actualOut := cmdOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "removing temporary directory")
+
+	assert.Equal(expectedOut, actualOut, "releaseCmd output should be equal")
+}
+
+func TestReleaseCmd_FailOnNoRelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}]`,
+		DryRunConfiguration:          "true",
+		FailOnNoReleaseConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrNoRelease, "command should have failed since there is no new release")
+	assert.Equal(ExitCodeNoRelease, ExitCode(err))
+	assert.NotContains(string(out), "Usage:", "usage should not be printed")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+	assert.Equal(ExitCodeSuccess, ExitCode(err))
+}
+
+func TestReleaseCmd_SkipReleaseFile(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	_, err := testRepository.AddCommitWithSpecificFile("fix", parser.SkipReleaseFile)
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	output, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	expectedOut := cmdOutput{
+		Message:    "release skipped, .skip-release file found",
+		Version:    "0.0.0",
+		NewRelease: false,
+		Branch:     "master",
+	}
+	actualOut := cmdOutput{}
+
+	err = json.Unmarshal(output, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal(expectedOut, actualOut, "releaseCmd output should be equal")
+}
+
+func TestReleaseCmd_InitialVersion(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat!"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:             `[{"name": "master"}]`,
+		DryRunConfiguration:               "true",
+		InitialVersionConfiguration:       "0.1.0",
+		MajorOnBreakingInDevConfiguration: "false",
+	})
+	checkErr(t, err, "setting flags")
+
+	output, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	actualOut := cmdOutput{}
+
+	err = json.Unmarshal(output, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("0.1.0", actualOut.Version, "first release should start at the initial version")
+}
+
+func TestReleaseCmd_InvalidInitialVersion(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:       `[{"name": "master"}]`,
+		InitialVersionConfiguration: "v1.0",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrInvalidInitialVersion, "command should have failed since the initial version is invalid")
+}
+
+func TestReleaseCmd_LightweightTags(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	// Lightweight tags created by other tools are considered as releases
+	_, err = testRepository.CreateTag("v0.0.1", head.Hash(), nil)
+	checkErr(t, err, "adding lightweight tag")
+
+	hash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}]`,
+		LightweightTagsConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	actual := cmdOutput{}
+	err = json.Unmarshal(out, &actual)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("0.0.2", actual.Version, "version should be bumped from the lightweight tag")
+
+	reference, err := testRepository.Tag("v0.0.2")
+	checkErr(t, err, "fetching tag")
+
+	assert.Equal(hash, reference.Hash(), "tag should be lightweight and point to the commit")
+}
+
+func TestReleaseCmd_TaggerIdentity(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	date := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	th := NewTestHelper(t)
+	th.Ctx.Clock = func() time.Time { return date }
+
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:    `[{"name": "master"}]`,
+		TaggerNameConfiguration:  "Release Bot",
+		TaggerEmailConfiguration: "bot@example.com",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	reference, err := testRepository.Tag("v0.1.0")
+	checkErr(t, err, "fetching tag")
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.Equal("Release Bot", tagObject.Tagger.Name, "tagger name should override the Git name")
+	assert.Equal("bot@example.com", tagObject.Tagger.Email, "tagger email should override the Git email")
+	assert.True(date.Equal(tagObject.Tagger.When), "tag should be dated by the clock")
+}
+
+func TestReleaseCmd_TagDate(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	commit, err := testRepository.CommitObject(head.Hash())
+	checkErr(t, err, "fetching commit")
+
+	th := NewTestHelper(t)
+	th.Ctx.Clock = func() time.Time { return commit.Committer.When.Add(24 * time.Hour) }
+
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		TagDateConfiguration:  "commit",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	reference, err := testRepository.Tag("v0.1.0")
+	checkErr(t, err, "fetching tag")
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.True(commit.Committer.When.Equal(tagObject.Tagger.When), "tag should be dated by the released commit")
+}
+
+func TestReleaseCmd_LocalRepositoryChecks(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	release := func(flags map[string]string) error {
+		th := NewTestHelper(t)
+
+		flags[BranchesConfiguration] = `[{"name": "master"}]`
+
+		err := th.SetFlags(flags)
+		checkErr(t, err, "setting flags")
+
+		_, err = th.ExecuteCommand("release", testRepository.Path)
+
+		return err
+	}
+
+	err := os.WriteFile(filepath.Join(testRepository.Path, "staged.txt"), []byte("staged"), 0o600)
+	checkErr(t, err, "writing file")
+
+	worktree, err := testRepository.Worktree()
+	checkErr(t, err, "fetching worktree")
+
+	_, err = worktree.Add("staged.txt")
+	checkErr(t, err, "staging file")
+
+	err = release(map[string]string{RequireCleanConfiguration: "true"})
+	assert.ErrorIs(err, remote.ErrDirtyWorktree, "dirty worktree should not be released")
+	assert.Equal(ErrorCodeDirtyWorktree, NewErrorReport(err).Code)
+
+	_, err = worktree.Remove("staged.txt")
+	checkErr(t, err, "unstaging file")
+
+	err = release(map[string]string{RequireSyncedConfiguration: "true"})
+	assert.ErrorIs(err, remote.ErrUnsyncedBranch, "branch without upstream should not be released")
+	assert.Equal(ErrorCodeUnsyncedBranch, NewErrorReport(err).Code)
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.False(exists, "refused release should not have been tagged")
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "master"), head.Hash()))
+	checkErr(t, err, "setting upstream")
+
+	err = release(map[string]string{RequireCleanConfiguration: "true", RequireSyncedConfiguration: "true"})
+	checkErr(t, err, "releasing clean and synced repository")
+
+	exists, err = tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "clean and synced repository should have been tagged")
+}
+
+func TestReleaseCmd_Mirrors(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+	mirrorRepository := NewTestRepository(t, nil)
+
+	err := mirrorRepository.StartHTTPServer(gittest.WithToken("mirror-token"))
+	checkErr(t, err, "starting mirror server")
+
+	t.Cleanup(func() {
+		_ = mirrorRepository.Remove()
+	})
+
+	t.Setenv("MIRROR_TOKEN", "mirror-token")
+
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MirrorsConfiguration:  fmt.Sprintf(`[{"name": "internal", "url": %q, "token-env": "MIRROR_TOKEN"}, {"url": %q, "optional": true}]`, mirrorRepository.RemoteURL, missing),
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "releasing with an optional mirror failing")
+
+	for _, repository := range []*gittest.TestRepository{testRepository, mirrorRepository} {
+		exists, err := tag.Exists(repository.Repository, "v0.1.0")
+		checkErr(t, err, "checking if tag exists")
+		assert.True(exists, "tag should have been pushed to the remote and the mirror")
+	}
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MirrorsConfiguration:  fmt.Sprintf(`[{"url": %q}]`, mirrorRepository.RemoteURL),
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, transport.ErrAuthorizationFailed, "release should fail if a required mirror fails")
+	assert.ErrorContains(err, `mirror "mirror-1"`)
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.1")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "tag should have been pushed to the remote before the mirrors")
+}
+
+func TestReleaseCmd_Lock(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	// Lock held by another run
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference(remote.LockRef, head.Hash()))
+	checkErr(t, err, "creating lock reference")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:    `[{"name": "master"}]`,
+		LockConfiguration:        "true",
+		LockTimeoutConfiguration: "100ms",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, remote.ErrLocked, "release should wait for the lock held by another run")
+	assert.Equal(ErrorCodeLocked, NewErrorReport(err).Code)
+
+	err = testRepository.Storer.RemoveReference(remote.LockRef)
+	checkErr(t, err, "removing lock reference")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		LockConfiguration:     "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "releasing with lock")
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "release should have been tagged")
+
+	_, err = testRepository.Reference(remote.LockRef, true)
+	assert.ErrorIs(err, plumbing.ErrReferenceNotFound, "lock should have been released")
+}
+
+func TestReleaseCmd_SignedLightweightTags(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix"})
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, err, "generating ed25519 key")
+
+	block, err := cryptossh.MarshalPrivateKey(privateKey, "")
+	checkErr(t, err, "marshalling private key")
+
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+
+	err = os.WriteFile(keyPath, pem.EncodeToMemory(block), 0o600)
+	checkErr(t, err, "writing private key")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}]`,
+		LightweightTagsConfiguration: "true",
+		SSHKeyPathConfiguration:      keyPath,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrSignedLightweightTags)
+}
+
+func TestReleaseCmd_TagReleaseNotes(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}]`,
+		TagReleaseNotesConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	reference, err := testRepository.Tag("v0.1.0")
+	checkErr(t, err, "fetching tag")
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	subject, body, found := strings.Cut(tagObject.Message, "\n\n")
+	assert.True(found, "tag message should have a body")
+	assert.Equal("v0.1.0", subject)
+	assert.Contains(body, "## v0.1.0")
+	assert.Contains(body, "### Features")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}]`,
+		LightweightTagsConfiguration: "true",
+		TagReleaseNotesConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrLightweightTagNotes)
+}
+
+func TestReleaseCmd_DetectTagPrefix(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("1.0.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}]`,
+		DetectTagPrefixConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	actual := cmdOutput{}
+	err = json.Unmarshal(out, &actual)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("1.0.1", actual.Version)
+
+	exists, err := tag.Exists(testRepository.Repository, "1.0.1")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.True(exists, "tag should have been created without prefix")
+}
+
+func TestReleaseCmd_Range(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{})
+
+	fromHash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	toHash, err := testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit")
+
+	_, err = testRepository.AddCommit("feat!")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		DryRunConfiguration:   "true",
+		FromConfiguration:     fromHash.String(),
+		ToConfiguration:       toHash.String(),
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	actual := cmdOutput{}
+	err = json.Unmarshal(out, &actual)
+	checkErr(t, err, "unmarshalling output")
+
+	want := cmdOutput{
+		Message:    "dry-run enabled, next release found",
+		Branch:     "master",
+		Version:    "0.1.0",
+		From:       fromHash.String(),
+		To:         toHash.String(),
+		NewRelease: true,
+	}
+
+	assert.Equal(want, actual, "only the commits of the range should be analyzed")
+}
+
+func TestReleaseCmd_Cache(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		CacheConfiguration:    "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	_, err = testRepository.Reference(parser.NotesRef, true)
+	checkErr(t, err, "fetching pushed notes reference")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		CacheConfiguration:    "true",
+		DryRunConfiguration:   "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	actual := cmdOutput{}
+	err = json.Unmarshal(out, &actual)
+	checkErr(t, err, "unmarshalling output")
+
+	want := cmdOutput{
+		Message:    "dry-run enabled, next release found",
+		Branch:     "master",
+		Version:    "0.1.1",
+		NewRelease: true,
+	}
+
+	assert.Equal(want, actual)
+}
+
+func TestReleaseCmd_CloneDepth(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat", "fix"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v0.1.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	for _, commitType := range []string{"fix", "chore", "fix", "feat", "fix"} {
+		_, err = testRepository.AddCommit(commitType)
+		checkErr(t, err, "adding commit")
+	}
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:   `[{"name": "master"}]`,
+		CloneDepthConfiguration: "1",
+		DryRunConfiguration:     "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	actual := cmdOutput{}
+	err = json.Unmarshal(out, &actual)
+	checkErr(t, err, "unmarshalling output")
+
+	want := cmdOutput{
+		Message:    "dry-run enabled, next release found",
+		Branch:     "master",
+		Version:    "0.2.1",
+		NewRelease: true,
+	}
+
+	assert.Equal(want, actual, "shallow clone should have been deepened up to the latest tag")
+}
+
+func TestReleaseCmd_GitHubApp(t *testing.T) {
+	assert := assertion.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	checkErr(t, err, "generating private key")
+
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+
+	err = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600)
+	checkErr(t, err, "writing private key")
+
+	minted := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/installations/42/access_tokens" {
+			http.NotFound(w, r)
+			return
+		}
+
+		minted++
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token": "ghs_token", "expires_at": "2030-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	// The access token may be set by other tests through the environment
+	t.Setenv("GO_SEMVER_RELEASE_ACCESS_TOKEN", "")
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:             `[{"name": "master"}]`,
+		DryRunConfiguration:               "true",
+		GitHubAPIURLConfiguration:         server.URL,
+		GitHubAppIDConfiguration:          "1234",
+		GitHubInstallationIDConfiguration: "42",
+		GitHubAppKeyPathConfiguration:     keyPath,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal(1, minted, "an installation token should have been minted")
+}
+
+func TestReleaseCmd_GitHubApp_Invalid(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := appcontext.New()
+
+	ctx.GitHubAppIDFlag = "1234"
+
+	_, err := configureGitHubApp(ctx)
+	assert.ErrorIs(err, ErrIncompleteGitHubApp)
+
+	ctx.GitHubInstallationIDFlag = "42"
+	ctx.GitHubAppKeyPathFlag = "./does/not/exist"
+	ctx.AccessTokenFlag = "secret"
+
+	_, err = configureGitHubApp(ctx)
+	assert.ErrorIs(err, ErrConflictingTokens)
+
+	ctx.AccessTokenFlag = ""
+
+	_, err = configureGitHubApp(ctx)
+	assert.ErrorContains(err, "reading private key")
+
+	ctx.GitHubAppKeyPathFlag = filepath.Join(t.TempDir(), "app.pem")
+
+	err = os.WriteFile(ctx.GitHubAppKeyPathFlag, []byte("not a key"), 0o600)
+	checkErr(t, err, "writing private key")
+
+	_, err = configureGitHubApp(ctx)
+	assert.ErrorContains(err, "loading private key")
+
+	ctx.GitHubAppIDFlag = ""
+	ctx.GitHubInstallationIDFlag = ""
+	ctx.GitHubAppKeyPathFlag = ""
+
+	app, err := configureGitHubApp(ctx)
+	checkErr(t, err, "configuring GitHub App")
+
+	assert.Nil(app, "no GitHub App should be configured")
+}
+
+func TestReleaseCmd_GitHubAPITags(t *testing.T) {
+	assert := assertion.New(t)
+
+	var refs []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/name/git/tags":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"sha": "4b825dc642cb6eb9a060e54bf8d69288fbee4904"}`))
+		case "/repos/owner/name/git/refs":
+			var body struct {
+				Ref string `json:"ref"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			refs = append(refs, body.Ref)
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:         `[{"name": "master"}]`,
+		GitHubAPITagsConfiguration:    "true",
+		GitHubAPIURLConfiguration:     server.URL,
+		GitHubRepositoryConfiguration: "owner/name",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal([]string{"refs/tags/v0.1.0"}, refs, "tag should have been created through the API")
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.False(exists, "tag should not have been pushed")
+}
+
+func TestReleaseCmd_GitHubAPITags_NoRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("GITHUB_REPOSITORY", "")
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		GitHubAPITagsConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrNoGitHubRepository, "GitHub repository cannot be deduced from a local path")
+}
+
+func TestReleaseCmd_GitLab(t *testing.T) {
+	assert := assertion.New(t)
+
+	var (
+		tags     []string
+		releases []map[string]string
+		tokens   []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		tokens = append(tokens, r.Header.Get("JOB-TOKEN"))
+
+		switch r.URL.EscapedPath() {
+		case "/projects/group%2Fname/repository/tags":
+			tags = append(tags, body["tag_name"])
+		case "/projects/group%2Fname/releases":
+			releases = append(releases, body)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// The access token may be set by other tests through the environment
+	t.Setenv("GO_SEMVER_RELEASE_ACCESS_TOKEN", "")
+	t.Setenv("CI_JOB_TOKEN", "job-token")
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		GitLabAPITagsConfiguration: "true",
+		GitLabAPIURLConfiguration:  server.URL,
+		GitLabProjectConfiguration: "group/name",
+		GitLabReleaseConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	assert.Equal([]string{"v0.1.0"}, tags, "tag should have been created through the API")
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.False(exists, "tag should not have been pushed")
+
+	if assert.Len(releases, 1, "release should have been created") {
+		assert.Equal("v0.1.0", releases[0]["tag_name"])
+		assert.Contains(releases[0]["description"], "### Features", "release notes should have been attached")
+	}
+
+	assert.Equal([]string{"job-token", "job-token"}, tokens, "job token should have been used")
+}
+
+func TestReleaseCmd_GitLab_NoProject(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("CI_PROJECT_ID", "")
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		GitLabReleaseConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrNoGitLabProject, "GitLab project cannot be deduced from a local path")
+}
+
+func TestReleaseCmd_Gitea(t *testing.T) {
+	assert := assertion.New(t)
+
+	var releases []map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/repos/owner/name/releases" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		releases = append(releases, body)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:        `[{"name": "master"}]`,
+		GiteaReleaseConfiguration:    "true",
+		GiteaRepositoryConfiguration: "owner/name",
+		GiteaURLConfiguration:        server.URL,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.True(exists, "tag should have been pushed before creating the release")
+
+	if assert.Len(releases, 1, "release should have been created") {
+		assert.Equal("v0.1.0", releases[0]["tag_name"])
+		assert.Contains(releases[0]["body"], "### Features", "release notes should have been attached")
+		assert.Nil(releases[0]["prerelease"], "stable releases should not be marked as prereleases")
+	}
+}
+
+func TestReleaseCmd_Gitea_NoRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		GiteaReleaseConfiguration: "true",
+		GiteaURLConfiguration:     "https://codeberg.org",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrNoGiteaRepository, "Gitea repository cannot be deduced from a local path")
+}
+
+func TestReleaseCmd_Bitbucket(t *testing.T) {
+	assert := assertion.New(t)
+
+	var statuses []map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/rest/build-status/1.0/commits/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		body["commit"] = strings.TrimPrefix(r.URL.Path, "/rest/build-status/1.0/commits/")
+		statuses = append(statuses, body)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:            `[{"name": "master"}]`,
+		BitbucketReleaseConfiguration:    "true",
+		BitbucketRepositoryConfiguration: "PROJECT/name",
+		BitbucketServerURLConfiguration:  server.URL,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	if assert.Len(statuses, 1, "release should have been published") {
+		assert.Equal("SUCCESSFUL", statuses[0]["state"])
+		assert.Equal("Release v0.1.0", statuses[0]["name"])
+		assert.Equal(head.Hash().String(), statuses[0]["commit"], "build status should have been set on the tagged commit")
+	}
+}
+
+func TestReleaseCmd_Webhook(t *testing.T) {
+	assert := assertion.New(t)
+
+	var payloads []webhook.Payload
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Header.Get(webhook.SignatureHeader) != webhook.Sign("secret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var payload webhook.Payload
+		_ = json.Unmarshal(body, &payload)
+
+		payloads = append(payloads, payload)
+	}))
+	defer server.Close()
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		WebhookSecretConfiguration: "secret",
+		WebhookURLConfiguration:    server.URL,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	if assert.Len(payloads, 1, "webhook should have been notified") {
+		assert.Equal("v0.1.0", payloads[0].Tag)
+		assert.Equal("0.1.0", payloads[0].Version)
+		assert.Equal("master", payloads[0].Branch)
+		assert.Equal(testRepository.Path, payloads[0].Repository)
+		assert.Equal(head.Hash().String(), payloads[0].Commit)
+		assert.Contains(payloads[0].Changelog, "### Features", "changelog should have been sent")
+	}
+
+	testRepository = NewTestRepository(t, []string{"feat"})
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:       `[{"name": "master"}]`,
+		WebhookRetriesConfiguration: "0",
+		WebhookURLConfiguration:     server.URL,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, restapi.ErrUnexpectedResponse, "unsigned payload should have been rejected")
+}
+
+func TestReleaseCmd_Chat(t *testing.T) {
+	assert := assertion.New(t)
+
+	var messages []map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&message)
+
+		messages = append(messages, message)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:       `[{"name": "master"}]`,
+		DiscordWebhookConfiguration: server.URL,
+		SlackWebhookConfiguration:   server.URL,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	if assert.Len(messages, 2, "Slack and Discord should have been notified") {
+		assert.Equal("Released v0.1.0", messages[0]["text"])
+		assert.Contains(messages[1], "embeds")
+	}
+}
+
+func TestReleaseCmd_Hooks(t *testing.T) {
+	assert := assertion.New(t)
+
+	outputPath := filepath.Join(t.TempDir(), "hooks")
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		HooksConfiguration:    fmt.Sprintf(`{"pre-tag": ["echo pre-tag $SEMVER_NEW_VERSION >> %[1]s"], "post-release": ["echo post-release $SEMVER_TAG >> %[1]s"]}`, outputPath),
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	content, err := os.ReadFile(outputPath)
+	checkErr(t, err, "reading hooks output")
+
+	assert.Equal("pre-tag 0.1.0\npost-release v0.1.0\n", string(content))
+}
+
+func TestReleaseCmd_Hooks_FailedPreTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		HooksConfiguration:    `{"pre-tag": ["exit 1"]}`,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, hook.ErrHookFailed)
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.False(exists, "release should have been aborted")
+}
+
+func TestReleaseCmd_Hooks_InvalidStep(t *testing.T) {
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		HooksConfiguration:    `{"pre-push": ["exit 1"]}`,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assertion.ErrorIs(t, err, hook.ErrInvalidStep)
+}
+
+func TestReleaseCmd_Plugins(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := t.TempDir()
+	pluginPath := filepath.Join(dir, "plugin")
+	recordPath := filepath.Join(dir, "record")
+
+	// The plugin records the phases it is run for and overrides the next version
+	script := fmt.Sprintf(`#!/bin/sh
+request=$(cat)
+echo "$request" | grep -o '"phase":"[a-z]*"' >> %s
+case "$request" in
+*'"phase":"analyze"'*) echo '{"version": "3.0.0"}' ;;
+esac
+`, recordPath)
+
+	err := os.WriteFile(pluginPath, []byte(script), 0o755)
+	checkErr(t, err, "writing plugin")
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		PluginsConfiguration:  fmt.Sprintf(`[{"path": %q}]`, pluginPath),
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	exists, err := tag.Exists(testRepository.Repository, "v3.0.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.True(exists, "version should have been overridden by the plugin")
+
+	content, err := os.ReadFile(recordPath)
+	checkErr(t, err, "reading record")
+
+	assert.Equal("\"phase\":\"analyze\"\n\"phase\":\"verify\"\n\"phase\":\"prepare\"\n\"phase\":\"publish\"\n\"phase\":\"notify\"\n", string(content))
+}
+
+func TestReleaseCmd_Plugins_FailedVerify(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		PluginsConfiguration:  `[{"path": "false", "phases": ["verify"]}]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, plugin.ErrPluginFailed)
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.False(exists, "release should have been aborted")
+}
+
+func TestReleaseCmd_DetachedHead(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	breakingHash, err := testRepository.AddCommit("feat!")
+	checkErr(t, err, "adding commit")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	// CI systems check out the commit to release, which is not necessarily the head of the branch, in a detached HEAD
+	worktree, err := testRepository.Worktree()
+	checkErr(t, err, "fetching worktree")
+
+	err = worktree.Checkout(&git.CheckoutOptions{Hash: breakingHash})
+	checkErr(t, err, "detaching head")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		CommitConfiguration:   "HEAD",
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	actual := cmdOutput{}
+	err = json.Unmarshal(out, &actual)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal("1.0.0", actual.Version)
+
+	reference, err := testRepository.Tag("v1.0.0")
+	checkErr(t, err, "fetching tag")
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.Equal(breakingHash, tagObject.Target, "checked out commit should have been tagged")
+}
+
+func TestReleaseCmd_CommitWithReleaseCommit(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:      `[{"name": "master"}]`,
+		ChangelogPathConfiguration: "CHANGELOG.md",
+		CommitConfiguration:        "HEAD",
+		ReleaseCommitConfiguration: "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrCommitReleaseCommit)
 }
 
-func TestReleaseCmd_ReleaseNoNewVersion(t *testing.T) {
+func TestReleaseCmd_ExistingTag(t *testing.T) {
 	assert := assertion.New(t)
 
 	testRepository := NewTestRepository(t, []string{})
 
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	// Ignored tags are not considered as releases but still prevent the release from being tagged
+	err = testRepository.AddTag("v0.0.1", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	hash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
 	th := NewTestHelper(t)
-	err := th.SetFlag(BranchesConfiguration, `[{"name": "master"}]`)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:         `[{"name": "master"}]`,
+		TagIgnorePatternConfiguration: `^v0\.0\.1$`,
+	})
 	checkErr(t, err, "setting flags")
 
-	out, err := th.ExecuteCommand("release", testRepository.Path)
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, tag.ErrTagAlreadyExists, "command should have failed since the tag already exists")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:         `[{"name": "master"}]`,
+		TagIgnorePatternConfiguration: `^v0\.0\.1$`,
+		ForceConfiguration:            "true",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
 	checkErr(t, err, "executing command")
 
-	expectedOut := cmdOutput{
-		Message:    "no new release",
-		NewRelease: false,
-		Branch:     "master",
-		Version:    "0.0.0",
-	}
-	actualOut := cmdOutput{}
+	reference, err := testRepository.Tag("v0.0.1")
+	checkErr(t, err, "fetching tag")
 
-	err = json.Unmarshal(out, &actualOut)
-	checkErr(t, err, "removing temporary directory")
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, err, "fetching tag object")
 
-	assert.Equal(expectedOut, actualOut, "releaseCmd output should be equal")
+	assert.Equal(hash, tagObject.Target, "tag should have been replaced")
+}
+
+func TestReleaseCmd_InvalidTagIgnorePattern(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"fix"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration:         `[{"name": "master"}]`,
+		TagIgnorePatternConfiguration: "nightly-(",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	assert.ErrorIs(err, ErrInvalidIgnorePattern)
 }
 
 func TestReleaseCmd_ReadOnlyGitHubOutput(t *testing.T) {
@@ -791,6 +2874,75 @@ func TestReleaseCmd_Monorepo(t *testing.T) {
 	checkErr(t, err, "scanning error")
 }
 
+func TestReleaseCmd_Monorepo_ProjectTagPrefix(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{})
+
+	_, err := testRepository.AddCommitWithSpecificFile("feat", "./foo/foo.txt")
+	checkErr(t, err, "adding commit")
+	_, err = testRepository.AddCommitWithSpecificFile("feat", "./bar/bar.txt")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		MonorepoConfiguration: `[{"name": "foo", "path": "foo", "tag-prefix": ""}, {"name": "bar", "path": "bar", "initial-version": "1.0.0"}]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	for _, tagName := range []string{"foo-0.1.0", "bar-v1.0.0"} {
+		exists, err := tag.Exists(testRepository.Repository, tagName)
+		checkErr(t, err, "checking if tag exists")
+		assert.True(exists, "tag %q should have been pushed", tagName)
+	}
+}
+
+func TestReleaseCmd_Monorepo_Manifest(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{})
+
+	fooHash, err := testRepository.AddCommitWithSpecificFile("fix", "./foo/foo.txt")
+	checkErr(t, err, "adding commit")
+
+	err = testRepository.AddTag("foo-v1.0.0", fooHash)
+	checkErr(t, err, "adding tag")
+
+	_, err = testRepository.AddCommitWithSpecificFile("feat", "./foo/foo.txt")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:     `[{"name": "master"}]`,
+		DryRunConfiguration:       "true",
+		MonorepoConfiguration:     `[{"name": "foo", "path": "foo"}, {"name": "bar", "path": "bar"}]`,
+		OutputFormatConfiguration: output.FormatManifest,
+	})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("release", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	var manifest output.Manifest
+
+	err = json.Unmarshal(out, &manifest)
+	checkErr(t, err, "unmarshalling manifest")
+
+	want := output.Manifest{
+		Releases: []output.ManifestEntry{
+			{Project: "foo", Branch: "master", Channel: "stable", PreviousVersion: "1.0.0", NextVersion: "1.1.0", Bump: output.BumpMinor, NewRelease: true},
+			{Project: "bar", Branch: "master", Channel: "stable", NextVersion: "0.0.0", Bump: output.BumpNone},
+		},
+		Released: []string{"foo"},
+	}
+
+	assert.Equal(want, manifest, "manifest should list every project")
+}
+
 func TestReleaseCmd_Monorepo_MixedRelease(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -866,6 +3018,78 @@ func TestReleaseCmd_ConfigureRules_DefaultRules(t *testing.T) {
 	assert.Equal(rule.Default, rules)
 }
 
+func TestReleaseCmd_ConfigureRules_RulesFile(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := appcontext.New()
+
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+
+	err := os.WriteFile(rulesPath, []byte("minor:\n  - feat\n  - fix\n"), 0o644)
+	checkErr(t, err, "writing rules file")
+
+	ctx.RulesFlag = map[string][]string{"patch": {"fix"}}
+	ctx.RulesPathFlag = rulesPath
+
+	rules, err := configureRules(ctx)
+	checkErr(t, err, "configuring rules")
+
+	assert.Equal(rule.Rules{Map: map[string]string{"feat": "minor", "fix": "minor"}}, rules, "rules file should override rules flag")
+}
+
+func TestReleaseCmd_ConfigureRules_Preset(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := appcontext.New()
+
+	ctx.RulePresetFlag = "minimal"
+
+	rules, err := configureRules(ctx)
+	checkErr(t, err, "configuring rules")
+
+	assert.Equal(rule.Rules{Map: map[string]string{"feat": "minor", "fix": "patch"}}, rules)
+
+	ctx.RulesFlag = map[string][]string{"patch": {"perf"}, "none": {"fix"}}
+
+	rules, err = configureRules(ctx)
+	checkErr(t, err, "configuring rules")
+
+	assert.Equal(rule.Rules{Map: map[string]string{"feat": "minor", "fix": rule.None, "perf": "patch"}}, rules, "rules flag should override preset")
+
+	ctx.RulePresetFlag = "unknown"
+
+	_, err = configureRules(ctx)
+	assert.ErrorIs(err, rule.ErrUnknownPreset)
+}
+
+func TestReleaseCmd_ConfigureCommitVerifier(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := appcontext.New()
+
+	verifier, err := configureCommitVerifier(ctx)
+	checkErr(t, err, "configuring commit verifier")
+	assert.Nil(verifier, "no verifier should be configured without policy")
+
+	ctx.SignaturePolicyFlag = "warn"
+
+	_, err = configureCommitVerifier(ctx)
+	assert.ErrorIs(err, ErrInvalidSignPolicy)
+
+	ctx.SignaturePolicyFlag = parser.SignaturePolicyExclude
+
+	_, err = configureCommitVerifier(ctx)
+	assert.ErrorIs(err, ErrNoVerificationKeys)
+
+	allowedSigners := filepath.Join(t.TempDir(), "allowed_signers")
+
+	err = os.WriteFile(allowedSigners, []byte("# no signer yet\n"), 0o644)
+	checkErr(t, err, "writing allowed signers")
+
+	ctx.SignatureSignersFlag = allowedSigners
+
+	verifier, err = configureCommitVerifier(ctx)
+	checkErr(t, err, "configuring commit verifier")
+	assert.NotNil(verifier)
+}
+
 func TestReleaseCmd_ConfigureBranches_NoBranches(t *testing.T) {
 	assert := assertion.New(t)
 	ctx := appcontext.New()
@@ -911,7 +3135,7 @@ func TestReleaseCmd_InvalidMonorepoProjects(t *testing.T) {
 	assert := assertion.New(t)
 	ctx := appcontext.New()
 
-	ctx.MonorepositoryFlag = []map[string]string{{"path": "foo"}}
+	ctx.MonorepositoryFlag = []map[string]any{{"path": "foo"}}
 
 	_, err := configureProjects(ctx)
 	assert.ErrorIs(err, monorepo.ErrNoName, "should have failed parsing project with no name")
@@ -923,7 +3147,7 @@ func TestReleaseCmd_InvalidArmoredKeyPath(t *testing.T) {
 
 	ctx.GPGKeyPathFlag = "./does/not/exist"
 
-	_, err := configureGPGKey(ctx)
+	_, err := configureGPGKey(ctx, strings.NewReader(""))
 
 	assert.ErrorContains(err, "reading armored key", "should have failed trying to open non existing armored GPG key")
 }
@@ -960,10 +3184,180 @@ func TestReleaseCmd_InvalidArmoredKeyContent(t *testing.T) {
 
 	ctx.GPGKeyPathFlag = keyFilePath
 
-	_, err = configureGPGKey(ctx)
+	_, err = configureGPGKey(ctx, strings.NewReader(""))
 	assert.ErrorContains(err, "loading armored key", "should have failed trying to read armored key ring from empty file")
 }
 
+func TestReleaseCmd_ArmoredKeySelection(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := appcontext.New()
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, err, "creating openpgp entity")
+
+	buf := new(bytes.Buffer)
+
+	armorWriter, err := armor.Encode(buf, openpgp.PrivateKeyType, map[string]string{})
+	checkErr(t, err, "encoding armor")
+
+	err = entity.SerializePrivateWithoutSigning(armorWriter, nil)
+	checkErr(t, err, "serializing private key")
+
+	err = armorWriter.Close()
+	checkErr(t, err, "closing armor writer")
+
+	keyFilePath := filepath.Join(t.TempDir(), "key.asc")
+
+	err = os.WriteFile(keyFilePath, buf.Bytes(), 0o600)
+	checkErr(t, err, "writing key file")
+
+	ctx.GPGKeyPathFlag = keyFilePath
+	ctx.GPGKeyEmailFlag = "john.doe@example.com"
+
+	actualEntity, err := configureGPGKey(ctx, strings.NewReader(""))
+	checkErr(t, err, "configuring GPG key")
+	assert.Equal(entity.PrimaryKey.Fingerprint, actualEntity.PrimaryKey.Fingerprint)
+
+	ctx.GPGKeyEmailFlag = "jane.doe@example.com"
+
+	_, err = configureGPGKey(ctx, strings.NewReader(""))
+	assert.ErrorIs(err, gpg.ErrKeyNotFound, "should have failed selecting unknown key")
+}
+
+func TestReleaseCmd_ArmoredKeySources(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := appcontext.New()
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, err, "creating openpgp entity")
+
+	buf := new(bytes.Buffer)
+
+	armorWriter, err := armor.Encode(buf, openpgp.PrivateKeyType, map[string]string{})
+	checkErr(t, err, "encoding armor")
+
+	err = entity.SerializePrivateWithoutSigning(armorWriter, nil)
+	checkErr(t, err, "serializing private key")
+
+	err = armorWriter.Close()
+	checkErr(t, err, "closing armor writer")
+
+	t.Setenv(gpgPrivateKeyEnv, "")
+
+	actualEntity, err := configureGPGKey(ctx, strings.NewReader(""))
+	checkErr(t, err, "configuring GPG key")
+	assert.Nil(actualEntity, "no key should be configured")
+
+	// Key from stdin
+	ctx.GPGKeyPathFlag = "-"
+
+	actualEntity, err = configureGPGKey(ctx, bytes.NewReader(buf.Bytes()))
+	checkErr(t, err, "configuring GPG key from stdin")
+	assert.Equal(entity.PrimaryKey.Fingerprint, actualEntity.PrimaryKey.Fingerprint)
+
+	// Key from environment variable
+	ctx.GPGKeyPathFlag = ""
+	t.Setenv(gpgPrivateKeyEnv, buf.String())
+
+	actualEntity, err = configureGPGKey(ctx, strings.NewReader(""))
+	checkErr(t, err, "configuring GPG key from environment variable")
+	assert.Equal(entity.PrimaryKey.Fingerprint, actualEntity.PrimaryKey.Fingerprint)
+
+	// The key path takes precedence over the environment variable
+	ctx.GPGKeyPathFlag = "./does/not/exist"
+
+	_, err = configureGPGKey(ctx, strings.NewReader(""))
+	assert.ErrorContains(err, "reading armored key", "key path should have been read")
+}
+
+func TestReleaseCmd_EncryptedArmoredKey(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := appcontext.New()
+
+	passphrase := "passphrase"
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, err, "creating openpgp entity")
+
+	err = entity.EncryptPrivateKeys([]byte(passphrase), nil)
+	checkErr(t, err, "encrypting private keys")
+
+	dir := t.TempDir()
+	keyFilePath := filepath.Join(dir, "key.asc")
+
+	keyFile, err := os.Create(keyFilePath)
+	checkErr(t, err, "creating key file")
+
+	armorWriter, err := armor.Encode(keyFile, openpgp.PrivateKeyType, map[string]string{})
+	checkErr(t, err, "encoding armor")
+
+	err = entity.SerializePrivateWithoutSigning(armorWriter, nil)
+	checkErr(t, err, "serializing private key")
+
+	err = armorWriter.Close()
+	checkErr(t, err, "closing armor writer")
+
+	err = keyFile.Close()
+	checkErr(t, err, "closing key file")
+
+	ctx.GPGKeyPathFlag = keyFilePath
+
+	_, err = configureGPGKey(ctx, strings.NewReader(""))
+	assert.ErrorIs(err, gpg.ErrPassphraseRequired, "should have failed decrypting key without passphrase")
+
+	// Passphrase from stdin
+	ctx.GPGPassphraseFileFlag = "-"
+
+	actualEntity, err := configureGPGKey(ctx, strings.NewReader(passphrase+"\n"))
+	checkErr(t, err, "configuring GPG key from stdin passphrase")
+	assert.False(gpg.IsEncrypted(actualEntity), "key should have been decrypted")
+
+	// Passphrase from file
+	passphraseFilePath := filepath.Join(dir, "passphrase")
+
+	err = os.WriteFile(passphraseFilePath, []byte(passphrase), 0o600)
+	checkErr(t, err, "writing passphrase file")
+
+	ctx.GPGPassphraseFileFlag = passphraseFilePath
+
+	actualEntity, err = configureGPGKey(ctx, strings.NewReader(""))
+	checkErr(t, err, "configuring GPG key from passphrase file")
+	assert.False(gpg.IsEncrypted(actualEntity), "key should have been decrypted")
+
+	// Passphrase from environment variable
+	ctx.GPGPassphraseFileFlag = ""
+	t.Setenv(gpgPassphraseEnv, passphrase)
+
+	actualEntity, err = configureGPGKey(ctx, strings.NewReader(""))
+	checkErr(t, err, "configuring GPG key from environment passphrase")
+	assert.False(gpg.IsEncrypted(actualEntity), "key should have been decrypted")
+
+	t.Setenv(gpgPassphraseEnv, "wrong")
+
+	_, err = configureGPGKey(ctx, strings.NewReader(""))
+	assert.ErrorContains(err, "decrypting armored key", "should have failed decrypting key with wrong passphrase")
+}
+
+func TestReleaseCmd_InvalidSSHKey(t *testing.T) {
+	assert := assertion.New(t)
+	ctx := appcontext.New()
+
+	ctx.SSHKeyPathFlag = "./does/not/exist"
+
+	_, err := configureSSHKey(ctx)
+	assert.ErrorContains(err, "reading SSH key", "should have failed trying to open non existing SSH key")
+
+	keyFilePath := filepath.Join(t.TempDir(), "id_ed25519")
+
+	err = os.WriteFile(keyFilePath, []byte("not a key"), 0o600)
+	checkErr(t, err, "writing SSH key")
+
+	ctx.SSHKeyPathFlag = keyFilePath
+
+	_, err = configureSSHKey(ctx)
+	assert.ErrorContains(err, "loading SSH key", "should have failed trying to parse invalid SSH key")
+}
+
 // Test utilities
 func NewTestRepository(t *testing.T, commits []string) *gittest.TestRepository {
 	testRepository, err := gittest.NewRepository()
@@ -1035,3 +3429,124 @@ func checkErr(t *testing.T, err error, message string) {
 		t.Fatalf("%s: %s", message, err)
 	}
 }
+
+func TestReleaseCmd_TLS(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	err := testRepository.StartHTTPServer()
+	checkErr(t, err, "starting HTTP server")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	target, err := url.Parse(testRepository.RemoteURL)
+	checkErr(t, err, "parsing remote URL")
+
+	server := httptest.NewTLSServer(httputil.NewSingleHostReverseProxy(&url.URL{Scheme: target.Scheme, Host: target.Host}))
+	defer server.Close()
+
+	remoteURL := server.URL + target.Path
+
+	caCertPath := filepath.Join(t.TempDir(), "ca.pem")
+	err = os.WriteFile(caCertPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600)
+	checkErr(t, err, "writing CA certificate")
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.pem")
+	err = os.WriteFile(invalidPath, []byte("not a certificate"), 0o600)
+	checkErr(t, err, "writing invalid CA certificate")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", remoteURL)
+	assert.ErrorAs(err, &x509.UnknownAuthorityError{}, "certificate of an unknown authority should have been rejected")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		CACertConfiguration:   invalidPath,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", remoteURL)
+	assert.ErrorIs(err, ErrInvalidCACert)
+	assert.Equal(ErrorCodeInvalidConfiguration, NewErrorReport(err).Code)
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+		CACertConfiguration:   caCertPath,
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", remoteURL)
+	checkErr(t, err, "releasing with CA certificate")
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "tag should have been pushed over TLS")
+
+	// Forge API clients share the TLS settings of the remote
+	th.Ctx.CACertFlag = caCertPath
+
+	httpClient, err := newHTTPClient(th.Ctx)
+	checkErr(t, err, "creating HTTP client")
+
+	res, err := httpClient.Get(server.URL + target.Path + "/info/refs?service=git-upload-pack")
+	checkErr(t, err, "requesting TLS server with CA certificate")
+	_ = res.Body.Close()
+
+	assert.Equal(http.StatusOK, res.StatusCode)
+}
+
+func TestReleaseCmd_CredentialHelper(t *testing.T) {
+	assert := assertion.New(t)
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	err := testRepository.StartHTTPServer(gittest.WithBasicAuth("user", "secret"))
+	checkErr(t, err, "starting HTTP server")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	gitConfig := filepath.Join(t.TempDir(), "gitconfig")
+	err = os.WriteFile(gitConfig, []byte(`[credential]
+	helper = "!f() { test \"$1\" = get && echo username=user && echo password=secret; }; f"
+`), 0o600)
+	checkErr(t, err, "writing Git configuration")
+
+	t.Setenv("GIT_CONFIG_GLOBAL", gitConfig)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GO_SEMVER_RELEASE_ACCESS_TOKEN", "")
+
+	th := NewTestHelper(t)
+	err = th.SetFlags(map[string]string{
+		BranchesConfiguration:         `[{"name": "master"}]`,
+		CredentialHelperConfiguration: "false",
+	})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.RemoteURL)
+	assert.Equal(ErrorCodeAuthentication, NewErrorReport(err).Code, "credential helper should not be used if disabled")
+
+	th = NewTestHelper(t)
+	err = th.SetFlags(map[string]string{BranchesConfiguration: `[{"name": "master"}]`})
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("release", testRepository.RemoteURL)
+	checkErr(t, err, "releasing with credential helper")
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "tag should have been pushed with the credentials of the helper")
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
@@ -14,8 +19,18 @@ import (
 
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/ci"
+	"github.com/s0ders/go-semver-release/v6/internal/github"
+	"github.com/s0ders/go-semver-release/v6/internal/gitlab"
+	"github.com/s0ders/go-semver-release/v6/internal/hook"
+	"github.com/s0ders/go-semver-release/v6/internal/logging"
+	"github.com/s0ders/go-semver-release/v6/internal/mirror"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/output"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/plugin"
 	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/scheme"
 )
 
 const (
@@ -23,60 +38,328 @@ const (
 	configFileFormat  = "yaml"
 )
 
+// Sources of the configuration values, by order of precedence.
 const (
-	AccessTokenConfiguration   = "access-token"
-	BranchesConfiguration      = "branches"
-	BuildMetadataConfiguration = "build-metadata"
-	DryRunConfiguration        = "dry-run"
-	GitEmailConfiguration      = "git-email"
-	GitNameConfiguration       = "git-name"
-	GPGPathConfiguration       = "gpg-key-path"
-	MonorepoConfiguration      = "monorepo"
-	RemoteNameConfiguration    = "remote-name"
-	RulesConfiguration         = "rules"
-	TagPrefixConfiguration     = "tag-prefix"
+	SourceFlag = "flag"
+	SourceEnv  = "env"
+	SourceFile = "file"
+)
+
+var (
+	ErrConflictingSecrets  = errors.New("secret cannot be given both as is and as a file")
+	ErrSeveralStdinSecrets = errors.New("only one secret can be read from stdin")
+)
+
+// Exit codes returned by the program.
+const (
+	ExitCodeSuccess   = 0
+	ExitCodeError     = 1
+	ExitCodeNoRelease = 2
+)
+
+const (
+	AccessTokenConfiguration           = "access-token"
+	AccessTokenFileConfiguration       = "access-token-file"
+	AttestationDirConfiguration        = "attestation-dir"
+	BitbucketReleaseConfiguration      = "bitbucket-release"
+	BitbucketRepositoryConfiguration   = "bitbucket-repository"
+	BitbucketServerURLConfiguration    = "bitbucket-server-url"
+	BitbucketUsernameConfiguration     = "bitbucket-username"
+	BreakingKeywordsConfiguration      = "breaking-keywords"
+	BranchesConfiguration              = "branches"
+	BuildMetadataConfiguration         = "build-metadata"
+	CACertConfiguration                = "ca-cert"
+	CacheConfiguration                 = "cache"
+	CalVerFormatConfiguration          = "calver-format"
+	ChangelogPathConfiguration         = "changelog-path"
+	CIProviderConfiguration            = "ci-provider"
+	CloneDepthConfiguration            = "clone-depth"
+	CredentialHelperConfiguration      = "credential-helper"
+	CommitConfiguration                = "commit"
+	CommitPatternConfiguration         = "commit-pattern"
+	CommitURLTemplateConfiguration     = "commit-url-template"
+	ConfigConfiguration                = "config"
+	CompareURLTemplateConfiguration    = "compare-url-template"
+	DetectTagPrefixConfiguration       = "detect-tag-prefix"
+	DiscordWebhookConfiguration        = "discord-webhook-url"
+	DockerImageConfiguration           = "docker-image"
+	DockerPasswordFileConfiguration    = "docker-password-file"
+	DockerSourceTagConfiguration       = "docker-source-tag"
+	DockerUsernameConfiguration        = "docker-username"
+	DryRunConfiguration                = "dry-run"
+	ExcludePathsConfiguration          = "exclude-paths"
+	FailOnNoReleaseConfiguration       = "fail-on-no-release"
+	FirstParentConfiguration           = "first-parent"
+	ForceConfiguration                 = "force"
+	ForceBumpConfiguration             = "force-bump"
+	FromConfiguration                  = "from"
+	GitEmailConfiguration              = "git-email"
+	GitNameConfiguration               = "git-name"
+	GiteaReleaseConfiguration          = "gitea-release"
+	GiteaRepositoryConfiguration       = "gitea-repository"
+	GiteaURLConfiguration              = "gitea-url"
+	GitHubAPITagsConfiguration         = "github-api-tags"
+	GitHubAPIURLConfiguration          = "github-api-url"
+	GitHubAppIDConfiguration           = "github-app-id"
+	GitHubInstallationIDConfiguration  = "github-app-installation-id"
+	GitHubAppKeyPathConfiguration      = "github-app-key-path"
+	GitHubRepositoryConfiguration      = "github-repository"
+	GitLabAPITagsConfiguration         = "gitlab-api-tags"
+	GitLabAPIURLConfiguration          = "gitlab-api-url"
+	GitLabProjectConfiguration         = "gitlab-project"
+	GitLabReleaseConfiguration         = "gitlab-release"
+	GPGKeyEmailConfiguration           = "gpg-key-email"
+	GPGKeyIDConfiguration              = "gpg-key-id"
+	GPGPathConfiguration               = "gpg-key-path"
+	GPGPassphraseFileConfiguration     = "gpg-passphrase-file"
+	HooksConfiguration                 = "hooks"
+	IgnoreAuthorsConfiguration         = "ignore-authors"
+	IgnoreExclamationConfiguration     = "ignore-exclamation-mark"
+	InitialVersionConfiguration        = "initial-version"
+	InsecureHostKeyConfiguration       = "insecure-ignore-host-key"
+	InsecureTLSConfiguration           = "insecure-skip-tls-verify"
+	IssuePatternConfiguration          = "issue-pattern"
+	IssueURLTemplateConfiguration      = "issue-url-template"
+	LedgerBranchConfiguration          = "ledger-branch"
+	LightweightTagsConfiguration       = "lightweight-tags"
+	LockConfiguration                  = "lock"
+	LockTimeoutConfiguration           = "lock-timeout"
+	LogFormatConfiguration             = "log-format"
+	LogLevelConfiguration              = "log-level"
+	MajorOnBreakingInDevConfiguration  = "major-on-breaking-in-dev"
+	MirrorsConfiguration               = "mirrors"
+	MonorepoConfiguration              = "monorepo"
+	OnlyAuthorsConfiguration           = "only-authors"
+	OutputFormatConfiguration          = "output-format"
+	PathsConfiguration                 = "paths"
+	PluginsConfiguration               = "plugins"
+	PRURLTemplateConfiguration         = "pull-request-url-template"
+	PushRetriesConfiguration           = "push-retries"
+	QuietConfiguration                 = "quiet"
+	RecordChannelsConfiguration        = "record-channels"
+	ReleaseCommitConfiguration         = "release-commit"
+	RemoteNameConfiguration            = "remote-name"
+	RequireCleanConfiguration          = "require-clean"
+	RequireSyncedConfiguration         = "require-synced"
+	ReportConfiguration                = "report"
+	RulePresetConfiguration            = "rule-preset"
+	RulesConfiguration                 = "rules"
+	RulesPathConfiguration             = "rules-path"
+	SetVersionConfiguration            = "set-version"
+	SignatureKeyringConfiguration      = "signature-gpg-keyring"
+	SignaturePolicyConfiguration       = "signature-policy"
+	SignatureSignersConfiguration      = "signature-ssh-allowed-signers"
+	SkipReleaseMarkersConfiguration    = "skip-release-markers"
+	SlackWebhookConfiguration          = "slack-webhook-url"
+	SSHAuthKeyPathConfiguration        = "ssh-auth-key-path"
+	SSHAuthPassphraseFileConfiguration = "ssh-auth-passphrase-file"
+	SSHKeyPathConfiguration            = "ssh-key-path"
+	SSHKnownHostsPathConfiguration     = "ssh-known-hosts-path"
+	SquashedCommitsConfiguration       = "squashed-commits"
+	StrictConfiguration                = "strict"
+	TagAliasesConfiguration            = "tag-aliases"
+	TagDateConfiguration               = "tag-date"
+	TagIgnorePatternConfiguration      = "tag-ignore-pattern"
+	TaggerEmailConfiguration           = "tagger-email"
+	TaggerNameConfiguration            = "tagger-name"
+	TeamsWebhookConfiguration          = "teams-webhook-url"
+	TimeoutConfiguration               = "timeout"
+	ToConfiguration                    = "to"
+	TagPrefixConfiguration             = "tag-prefix"
+	TagReleaseNotesConfiguration       = "tag-release-notes"
+	VersionSchemeConfiguration         = "version-scheme"
+	WebhookRetriesConfiguration        = "webhook-retries"
+	WebhookSecretConfiguration         = "webhook-secret"
+	WebhookSecretFileConfiguration     = "webhook-secret-file"
+	WebhookURLConfiguration            = "webhook-url"
 )
 
 func NewRootCommand(ctx *appcontext.AppContext) *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "go-semver-release",
 		Short: "go-semver-release - Automate semantic versioning of Git repositories",
-		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			ctx.Logger = zerolog.New(cmd.OutOrStdout()).Level(zerolog.InfoLevel)
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+			// The arguments are valid by now, the usage would only clutter the report of the failure
+			cmd.SilenceUsage = true
+
+			ctx.Logger, err = configureLogger(cmd, ctx)
+			if err != nil {
+				return err
+			}
+
+			err = initializeConfig(cmd, ctx)
+			if err != nil {
+				return err
+			}
 
-			if ctx.VerboseFlag {
-				ctx.Logger = ctx.Logger.Level(zerolog.DebugLevel)
+			err = configureSecrets(ctx, cmd.InOrStdin())
+			if err != nil {
+				return err
 			}
 
-			return initializeConfig(cmd, ctx)
+			// The logging flags may have been set by the configuration file or the environment, and the secrets are
+			// now known so that they can be redacted
+			ctx.Logger, err = configureLogger(cmd, ctx)
+			return err
 		},
 		TraverseChildren: true,
+		// Errors are reported by WriteError
+		SilenceErrors: true,
 	}
 
 	rootCmd.PersistentFlags().StringVar(&ctx.AccessTokenFlag, AccessTokenConfiguration, "", "Access token used to push tag to Git remote")
-	rootCmd.PersistentFlags().VarP(&ctx.BranchesFlag, BranchesConfiguration, "b", "An array of branches such as [{\"name\": \"main\"}, {\"name\": \"rc\", \"prerelease\": true}]")
-	rootCmd.PersistentFlags().StringVar(&ctx.BuildMetadataFlag, BuildMetadataConfiguration, "", "Build metadata (e.g. build number) that will be appended to the SemVer")
-	rootCmd.PersistentFlags().StringVar(&ctx.CfgFileFlag, "config", "", "Configuration file path (default \"./"+defaultConfigFile+"."+configFileFormat+"\")")
+	rootCmd.PersistentFlags().StringVar(&ctx.AccessTokenFileFlag, AccessTokenFileConfiguration, "", "Path to a file containing the access token, \"-\" reads it from stdin, so that it does not appear in the process arguments")
+	rootCmd.PersistentFlags().StringVar(&ctx.AttestationDirFlag, AttestationDirConfiguration, "", "Directory to which the SLSA provenance attestation of every new release is written, signed with the GPG key if any")
+	rootCmd.PersistentFlags().BoolVar(&ctx.BitbucketReleaseFlag, BitbucketReleaseConfiguration, false, "Publish every new release as a Bitbucket build status of the tagged commit linking to its release notes")
+	rootCmd.PersistentFlags().StringVar(&ctx.BitbucketRepositoryFlag, BitbucketRepositoryConfiguration, "", "Bitbucket repository (e.g., workspace/name or project/name) of the releases, deduced from the BITBUCKET_REPO_FULL_NAME environment variable or the repository URL if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.BitbucketServerURLFlag, BitbucketServerURLConfiguration, "", "URL of the Bitbucket Server or Data Center instance, Bitbucket Cloud if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.BitbucketUsernameFlag, BitbucketUsernameConfiguration, "", "Bitbucket username sent along the access token when it is an app password")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.BreakingKeywordsFlag, BreakingKeywordsConfiguration, nil, "Additional markers of breaking changes starting a line of the commit body (e.g., \"MAJOR:\" or \"BACKWARDS INCOMPATIBLE\"), followed by the breaking change description")
+	rootCmd.PersistentFlags().VarP(&ctx.BranchesFlag, BranchesConfiguration, "b", "An array of branches such as [{\"name\": \"main\"}, {\"name\": \"next\", \"prerelease\": \"rc\", \"channel\": \"next\"}]")
+	rootCmd.PersistentFlags().StringVar(&ctx.BuildMetadataFlag, BuildMetadataConfiguration, "", "Build metadata (e.g. build number) that will be appended to the SemVer, optionally a Go template such as {{ .ShortSHA }}.{{ .RunNumber }}")
+	rootCmd.PersistentFlags().StringVar(&ctx.CACertFlag, CACertConfiguration, "", "Path to a PEM file of CA certificates trusted, in addition to the system ones, by HTTPS remotes and forge APIs")
+	rootCmd.PersistentFlags().BoolVar(&ctx.CacheFlag, CacheConfiguration, false, "Cache the analysis of the commit history in Git notes so that subsequent runs only analyze new commits")
+	rootCmd.PersistentFlags().StringVar(&ctx.CalVerFormatFlag, CalVerFormatConfiguration, scheme.DefaultCalVerFormat, "Format of the versions of the calver versioning scheme (e.g., YYYY.MM.MICRO or YY.MM.MICRO)")
+	rootCmd.PersistentFlags().StringVar(&ctx.ChangelogPathFlag, ChangelogPathConfiguration, "", "Path to a Markdown changelog file to which new releases are added")
+	rootCmd.PersistentFlags().StringVar(&ctx.CIProviderFlag, CIProviderConfiguration, ci.ProviderAuto, "CI provider for which to generate an output, either auto, github, teamcity, azure-devops or none")
+	rootCmd.PersistentFlags().IntVar(&ctx.CloneDepthFlag, CloneDepthConfiguration, 0, "Number of commits fetched when cloning the repository, deepened until the history needed is fetched, full clone if zero")
+	rootCmd.PersistentFlags().BoolVar(&ctx.CredentialHelperFlag, CredentialHelperConfiguration, true, "Ask the Git credential helpers for the credentials of HTTP remotes when no access token is set")
+	rootCmd.PersistentFlags().StringVar(&ctx.CommitFlag, CommitConfiguration, "", "Commit to release instead of the head of the release branches (e.g., a commit hash or HEAD for the checked out commit), it must be reachable from the release branches")
+	rootCmd.PersistentFlags().StringVar(&ctx.CommitPatternFlag, CommitPatternConfiguration, "", "Regular expression matched against commit headers instead of the Conventional Commits grammar, capturing the commit type in a \"type\" named group and optionally \"scope\", \"breaking\" and \"description\" groups")
+	rootCmd.PersistentFlags().StringVar(&ctx.CommitURLTemplateFlag, CommitURLTemplateConfiguration, "", "Go template of the URL of the commits linked from the release notes (e.g., {{.URL}}/commit/{{.Hash}}), deduced from the repository URL if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.CompareURLTemplateFlag, CompareURLTemplateConfiguration, "", "Go template of the URL comparing two releases linked from the release notes (e.g., {{.URL}}/compare/{{.From}}...{{.To}}), deduced from the repository URL if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.CfgFileFlag, ConfigConfiguration, "", "Configuration file path (default \"./"+defaultConfigFile+"."+configFileFormat+"\")")
+	rootCmd.PersistentFlags().BoolVar(&ctx.DetectTagPrefixFlag, DetectTagPrefixConfiguration, false, "Use the prefix of the tag of the highest version found in the repository instead of the tag prefix flag")
+	rootCmd.PersistentFlags().StringVar(&ctx.DiscordWebhookFlag, DiscordWebhookConfiguration, "", "Discord webhook URL to which a summary of every new release is posted")
+	rootCmd.PersistentFlags().StringVar(&ctx.DockerImageFlag, DockerImageConfiguration, "", "Docker image (e.g., ghcr.io/owner/app) whose tags matching every new release (e.g., 1, 1.2, 1.2.3 and latest) are added to the output")
+	rootCmd.PersistentFlags().StringVar(&ctx.DockerPasswordFileFlag, DockerPasswordFileConfiguration, "", "Path to a file containing the password of the Docker registry, \"-\" reads it from stdin, the GO_SEMVER_RELEASE_DOCKER_PASSWORD environment variable if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.DockerSourceTagFlag, DockerSourceTagConfiguration, "", "Existing tag of the Docker image (e.g., sha-abc1234) retagged with the tags of every new release through the registry API")
+	rootCmd.PersistentFlags().StringVar(&ctx.DockerUsernameFlag, DockerUsernameConfiguration, "", "Username authenticating to the Docker registry, along the password of the GO_SEMVER_RELEASE_DOCKER_PASSWORD environment variable")
 	rootCmd.PersistentFlags().BoolVarP(&ctx.DryRunFlag, DryRunConfiguration, "d", false, "Only compute the next SemVer, do not push any tag")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.ExcludePathsFlag, ExcludePathsConfiguration, nil, "Glob patterns of paths whose changes never trigger a release (e.g., docs/**)")
+	rootCmd.PersistentFlags().BoolVar(&ctx.FailOnNoReleaseFlag, FailOnNoReleaseConfiguration, false, "Exit with code 2 if no new release is found")
+	rootCmd.PersistentFlags().BoolVar(&ctx.FirstParentFlag, FirstParentConfiguration, false, "Only follow the first parent of merge commits when parsing the commit history")
+	rootCmd.PersistentFlags().BoolVar(&ctx.ForceFlag, ForceConfiguration, false, "Replace the release tag if it already exists and skip the check that the new version is greater than the latest one")
+	rootCmd.PersistentFlags().StringVar(&ctx.ForceBumpFlag, ForceBumpConfiguration, "", "Bump the latest version as given (major, minor or patch) whatever the commits, for exceptional releases")
+	rootCmd.PersistentFlags().StringVar(&ctx.FromFlag, FromConfiguration, "", "Revision (e.g., a tag, a branch or a commit hash) at which the analysis of the history stops, the latest SemVer tag if empty")
 	rootCmd.PersistentFlags().StringVar(&ctx.GitEmailFlag, GitEmailConfiguration, "go-semver@release.ci", "Email used in semantic version tags")
 	rootCmd.PersistentFlags().StringVar(&ctx.GitNameFlag, GitNameConfiguration, "Go Semver Release", "Name used in semantic version tags")
-	rootCmd.PersistentFlags().StringVar(&ctx.GPGKeyPathFlag, GPGPathConfiguration, "", "Path to an armored GPG key used to sign produced tags")
+	rootCmd.PersistentFlags().BoolVar(&ctx.GiteaReleaseFlag, GiteaReleaseConfiguration, false, "Create a Gitea or Forgejo release, whose description is the release notes, for every new release")
+	rootCmd.PersistentFlags().StringVar(&ctx.GiteaRepositoryFlag, GiteaRepositoryConfiguration, "", "Gitea or Forgejo repository (e.g., owner/name) of the releases, deduced from the repository URL if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.GiteaURLFlag, GiteaURLConfiguration, "", "URL of the Gitea or Forgejo instance (e.g., https://codeberg.org), deduced from the repository URL if empty")
+	rootCmd.PersistentFlags().BoolVar(&ctx.GitHubAPITagsFlag, GitHubAPITagsConfiguration, false, "Create the tags through the GitHub REST API instead of pushing them, for repositories whose tag creation is restricted")
+	rootCmd.PersistentFlags().StringVar(&ctx.GitHubAPIURLFlag, GitHubAPIURLConfiguration, github.DefaultAPIURL, "URL of the GitHub REST API, to set for GitHub Enterprise Server (e.g., https://github.example.com/api/v3)")
+	rootCmd.PersistentFlags().StringVar(&ctx.GitHubAppIDFlag, GitHubAppIDConfiguration, "", "App ID, or client ID, of the GitHub App whose installation tokens are used instead of the access token")
+	rootCmd.PersistentFlags().StringVar(&ctx.GitHubInstallationIDFlag, GitHubInstallationIDConfiguration, "", "ID of the installation of the GitHub App on the repository owner")
+	rootCmd.PersistentFlags().StringVar(&ctx.GitHubAppKeyPathFlag, GitHubAppKeyPathConfiguration, "", "Path to the PEM private key of the GitHub App")
+	rootCmd.PersistentFlags().StringVar(&ctx.GitHubRepositoryFlag, GitHubRepositoryConfiguration, "", "GitHub repository (e.g., owner/name) in which tags are created through the API, deduced from the repository URL or the GITHUB_REPOSITORY environment variable if empty")
+	rootCmd.PersistentFlags().BoolVar(&ctx.GitLabAPITagsFlag, GitLabAPITagsConfiguration, false, "Create the tags through the GitLab REST API instead of pushing them, for projects whose tags are protected")
+	rootCmd.PersistentFlags().StringVar(&ctx.GitLabAPIURLFlag, GitLabAPIURLConfiguration, "", "URL of the GitLab REST API, the CI_API_V4_URL environment variable or "+gitlab.DefaultAPIURL+" if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.GitLabProjectFlag, GitLabProjectConfiguration, "", "ID or path (e.g., group/name) of the GitLab project, deduced from the CI_PROJECT_ID environment variable or the repository URL if empty")
+	rootCmd.PersistentFlags().BoolVar(&ctx.GitLabReleaseFlag, GitLabReleaseConfiguration, false, "Create a GitLab release, whose description is the release notes, for every new release")
+	rootCmd.PersistentFlags().StringVar(&ctx.GPGKeyEmailFlag, GPGKeyEmailConfiguration, "", "Email of the key to use when the armored GPG keyring contains several keys")
+	rootCmd.PersistentFlags().StringVar(&ctx.GPGKeyIDFlag, GPGKeyIDConfiguration, "", "ID or fingerprint of the key, or subkey, to use when the armored GPG keyring contains several keys")
+	rootCmd.PersistentFlags().StringVar(&ctx.GPGKeyPathFlag, GPGPathConfiguration, "", "Path to an armored GPG key used to sign produced tags, \"-\" reads it from stdin, the GO_SEMVER_RELEASE_GPG_PRIVATE_KEY environment variable if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.GPGPassphraseFileFlag, GPGPassphraseFileConfiguration, "", "Path to a file containing the passphrase of the GPG key, \"-\" reads it from stdin")
+	rootCmd.PersistentFlags().Var(&ctx.HooksFlag, HooksConfiguration, "A hashmap of shell commands run at the pre-tag, post-tag and post-release steps of every release such as {\"pre-tag\": [\"make check\"]}")
+	rootCmd.PersistentFlags().StringVar(&ctx.InitialVersionFlag, InitialVersionConfiguration, "", "Version of the first release (e.g., 1.0.0), computed from the commit history if empty")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.IgnoreAuthorsFlag, IgnoreAuthorsConfiguration, nil, "Names or emails of the authors whose commits cannot trigger a release (e.g., dependabot[bot]), \"*\" matching any characters")
+	rootCmd.PersistentFlags().BoolVar(&ctx.IgnoreExclamationFlag, IgnoreExclamationConfiguration, false, "Do not consider the \"!\" after the commit type a breaking change, only footers and breaking change keywords")
+	rootCmd.PersistentFlags().BoolVar(&ctx.InsecureHostKeyFlag, InsecureHostKeyConfiguration, false, "Accept any host key from SSH remotes instead of checking it against the known hosts")
+	rootCmd.PersistentFlags().BoolVar(&ctx.InsecureTLSFlag, InsecureTLSConfiguration, false, "Accept any TLS certificate from HTTPS remotes and forge APIs instead of verifying it")
+	rootCmd.PersistentFlags().StringVar(&ctx.IssuePatternFlag, IssuePatternConfiguration, "", "Regular expression of the references to issues in commit bodies (e.g., \\[(PROJ-\\d+)\\]), references introduced by closing keywords such as \"Closes #123\" if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.IssueURLTemplateFlag, IssueURLTemplateConfiguration, "", "Go template of the URL of the issues linked from the release notes (e.g., https://jira.example.com/browse/{{.Reference}}), deduced from the repository URL if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.LedgerBranchFlag, LedgerBranchConfiguration, "", "Orphan branch of the release ledger, to which every release decision, dry-runs included, is appended, disabled if empty")
+	rootCmd.PersistentFlags().BoolVar(&ctx.LightweightTagsFlag, LightweightTagsConfiguration, false, "Create lightweight tags instead of annotated tags, cannot be used along with a signing key")
+	rootCmd.PersistentFlags().BoolVar(&ctx.LockFlag, LockConfiguration, false, "Hold a lock on the remote while releasing so that concurrent runs release one after the other")
+	rootCmd.PersistentFlags().DurationVar(&ctx.LockTimeoutFlag, LockTimeoutConfiguration, 5*time.Minute, "Maximum duration to wait for the lock of the remote held by another run, no limit if zero")
+	rootCmd.PersistentFlags().StringVar(&ctx.LogFormatFlag, LogFormatConfiguration, logging.FormatJSON, "Format of the logs written to the standard error, either json or console")
+	rootCmd.PersistentFlags().StringVar(&ctx.LogLevelFlag, LogLevelConfiguration, logging.LevelInfo, "Minimum level of the logs written to the standard error, either debug, info, warn or error")
+	rootCmd.PersistentFlags().BoolVar(&ctx.MajorOnBreakingInDevFlag, MajorOnBreakingInDevConfiguration, true, "Bump the major version on breaking changes while in initial development (i.e., 0.y.z), only the minor version is bumped if false")
+	rootCmd.PersistentFlags().Var(&ctx.MirrorsFlag, MirrorsConfiguration, "An array of remotes to which the release tags are also pushed such as [{\"url\": \"https://mirror.example.com/repository.git\", \"token-env\": \"MIRROR_TOKEN\", \"optional\": true}]")
 	rootCmd.PersistentFlags().Var(&ctx.MonorepositoryFlag, MonorepoConfiguration, "An array of branches such as [{\"name\": \"foo\", \"path\": \"./foo/\"}]")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.OnlyAuthorsFlag, OnlyAuthorsConfiguration, nil, "Names or emails of the only authors whose commits can trigger a release, \"*\" matching any characters, every author if empty")
+	rootCmd.PersistentFlags().StringVarP(&ctx.OutputFormatFlag, OutputFormatConfiguration, "o", output.FormatJSON, "Output format, either json, yaml, text, manifest, matrix or go-template=<TEMPLATE>")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.PathsFlag, PathsConfiguration, nil, "Glob patterns of paths whose changes can trigger a release, every path if empty")
+	rootCmd.PersistentFlags().Var(&ctx.PluginsFlag, PluginsConfiguration, "An array of plugins run at every phase of the releases such as [{\"path\": \"./plugin\", \"phases\": [\"publish\"]}]")
+	rootCmd.PersistentFlags().StringVar(&ctx.PRURLTemplateFlag, PRURLTemplateConfiguration, "", "Go template of the URL of the pull requests linked from the release notes (e.g., {{.URL}}/pull/{{.Number}}), deduced from the repository URL if empty")
+	rootCmd.PersistentFlags().IntVar(&ctx.PushRetriesFlag, PushRetriesConfiguration, 3, "Number of times a failed tag push is retried, with an exponential backoff")
+	rootCmd.PersistentFlags().BoolVarP(&ctx.QuietFlag, QuietConfiguration, "q", false, "Only print out the machine-readable output, without any log nor hook, plugin or changelog preview output, takes precedence over the log level")
+	rootCmd.PersistentFlags().BoolVar(&ctx.RecordChannelsFlag, RecordChannelsConfiguration, false, "Record the channel of every new release in Git notes so that prereleases can later be promoted to another channel")
+	rootCmd.PersistentFlags().BoolVar(&ctx.ReleaseCommitFlag, ReleaseCommitConfiguration, false, "Commit the changelog, and the files modified by the pre-tag hooks and prepare plugins, to the release branch, with a \"[skip ci]\" message, and tag this commit")
 	rootCmd.PersistentFlags().StringVar(&ctx.RemoteNameFlag, RemoteNameConfiguration, "origin", "Name of the Git repository remote")
+	rootCmd.PersistentFlags().BoolVar(&ctx.RequireCleanFlag, RequireCleanConfiguration, false, "Refuse to release a local repository whose worktree has uncommitted changes")
+	rootCmd.PersistentFlags().BoolVar(&ctx.RequireSyncedFlag, RequireSyncedConfiguration, false, "Refuse to release a branch of a local repository that is behind or ahead of its upstream")
+	rootCmd.PersistentFlags().BoolVar(&ctx.ReportFlag, ReportConfiguration, false, "In dry-run mode, print out how every commit considered was classified")
+	rootCmd.PersistentFlags().StringVar(&ctx.RulePresetFlag, RulePresetConfiguration, "", "Built-in release rules (conventional, angular, strict or minimal) overridden by the rules configuration, commit type by commit type")
 	rootCmd.PersistentFlags().Var(&ctx.RulesFlag, RulesConfiguration, "A hashmap of array such as {\"minor\": [\"feat\"], \"patch\": [\"fix\", \"perf\"]} ]")
-	rootCmd.PersistentFlags().StringVar(&ctx.TagPrefixFlag, TagPrefixConfiguration, "v", "Prefix added to the version tag name")
-	rootCmd.PersistentFlags().BoolVarP(&ctx.VerboseFlag, "verbose", "v", false, "Verbose output")
+	rootCmd.PersistentFlags().StringVar(&ctx.RulesPathFlag, RulesPathConfiguration, "", "Path to a JSON, YAML or TOML release rules file, overrides the rules flag")
+	rootCmd.PersistentFlags().StringVar(&ctx.SetVersionFlag, SetVersionConfiguration, "", "Version of the release (e.g., 2.0.0) whatever the commits, for exceptional releases")
+	rootCmd.PersistentFlags().StringVar(&ctx.SignatureKeyringFlag, SignatureKeyringConfiguration, "", "Path to an armored GPG public keyring trusted to sign the commits triggering a release")
+	rootCmd.PersistentFlags().StringVar(&ctx.SignaturePolicyFlag, SignaturePolicyConfiguration, "", "Require the commits triggering a release to be signed by a trusted key, unverified commits either fail the run (fail) or are excluded (exclude)")
+	rootCmd.PersistentFlags().StringVar(&ctx.SignatureSignersFlag, SignatureSignersConfiguration, "", "Path to an SSH allowed signers file listing the public keys trusted to sign the commits triggering a release")
+	rootCmd.PersistentFlags().StringSliceVar(&ctx.SkipReleaseMarkersFlag, SkipReleaseMarkersConfiguration, parser.DefaultSkipReleaseMarkers, "Markers excluding a commit from the release when found in its subject or footers")
+	rootCmd.PersistentFlags().StringVar(&ctx.SlackWebhookFlag, SlackWebhookConfiguration, "", "Slack incoming webhook URL to which a summary of every new release is posted")
+	rootCmd.PersistentFlags().StringVar(&ctx.SSHAuthKeyPathFlag, SSHAuthKeyPathConfiguration, "", "Path to an SSH private key used to authenticate to SSH remotes, the SSH agent is used if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.SSHAuthPassphraseFileFlag, SSHAuthPassphraseFileConfiguration, "", "Path to a file containing the passphrase of the SSH authentication key, \"-\" reads it from stdin, the GO_SEMVER_RELEASE_SSH_AUTH_PASSPHRASE environment variable if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.SSHKeyPathFlag, SSHKeyPathConfiguration, "", "Path to an OpenSSH private key used to sign produced tags")
+	rootCmd.PersistentFlags().StringVar(&ctx.SSHKnownHostsPathFlag, SSHKnownHostsPathConfiguration, "", "Path to the known_hosts file used to check the host keys of SSH remotes")
+	rootCmd.PersistentFlags().BoolVar(&ctx.SquashedCommitsFlag, SquashedCommitsConfiguration, false, "Also parse the Conventional Commits listed in the body of squashed commits")
+	rootCmd.PersistentFlags().BoolVar(&ctx.StrictFlag, StrictConfiguration, false, "Fail if a commit does not follow the Conventional Commits specification")
+	rootCmd.PersistentFlags().BoolVar(&ctx.TagAliasesFlag, TagAliasesConfiguration, false, "Create or move the major and minor version alias tags (e.g., v1 and v1.2) along with the release tag, if it is the highest release of their line")
+	rootCmd.PersistentFlags().StringVar(&ctx.TagDateFlag, TagDateConfiguration, tagDateNow, "Date of the annotated release tags, either \"now\" or \"commit\" for the committer date of the tagged commit")
+	rootCmd.PersistentFlags().StringArrayVar(&ctx.TagIgnorePatternsFlag, TagIgnorePatternConfiguration, nil, "Regular expression of tag names ignored when looking for the latest SemVer tag (e.g., ^nightly-), can be repeated")
+	rootCmd.PersistentFlags().StringVar(&ctx.TeamsWebhookFlag, TeamsWebhookConfiguration, "", "Microsoft Teams incoming webhook URL to which a summary of every new release is posted")
+	rootCmd.PersistentFlags().StringVar(&ctx.TagPrefixFlag, TagPrefixConfiguration, "v", "Prefix added to the version tag name, only tags with this prefix are considered as releases")
+	rootCmd.PersistentFlags().BoolVar(&ctx.TagReleaseNotesFlag, TagReleaseNotesConfiguration, false, "Embed the release notes of every new release in the message of its annotated tag, after the tag name")
+	rootCmd.PersistentFlags().StringVar(&ctx.TaggerEmailFlag, TaggerEmailConfiguration, "", "Email of the tagger of the release tags, the Git email if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.TaggerNameFlag, TaggerNameConfiguration, "", "Name of the tagger of the release tags, the Git name if empty")
+	rootCmd.PersistentFlags().DurationVar(&ctx.TimeoutFlag, TimeoutConfiguration, 0, "Maximum duration of the command (e.g., 5m), no limit if zero")
+	rootCmd.PersistentFlags().StringVar(&ctx.ToFlag, ToConfiguration, "", "Revision (e.g., a tag, a branch or a commit hash) from which the history is analyzed, the head of the release branch if empty")
+	rootCmd.PersistentFlags().StringVar(&ctx.VersionSchemeFlag, VersionSchemeConfiguration, scheme.SemVerName, "Versioning scheme of the released versions, either semver or calver")
+	rootCmd.PersistentFlags().BoolVarP(&ctx.VerboseFlag, "verbose", "v", false, "Verbose output, shorthand for --log-level debug")
+	rootCmd.PersistentFlags().IntVar(&ctx.WebhookRetriesFlag, WebhookRetriesConfiguration, 3, "Number of times a failed webhook notification is retried")
+	rootCmd.PersistentFlags().StringVar(&ctx.WebhookSecretFlag, WebhookSecretConfiguration, "", "Secret used to sign the webhook payloads with HMAC-SHA256")
+	rootCmd.PersistentFlags().StringVar(&ctx.WebhookSecretFileFlag, WebhookSecretFileConfiguration, "", "Path to a file containing the webhook secret, \"-\" reads it from stdin, so that it does not appear in the process arguments")
+	rootCmd.PersistentFlags().StringArrayVar(&ctx.WebhookURLsFlag, WebhookURLConfiguration, nil, "URL notified with a JSON payload of every new release, can be repeated")
 
 	releaseCmd := NewReleaseCmd(ctx)
+	changelogCmd := NewChangelogCmd(ctx)
+	compareCmd := NewCompareCmd(ctx)
+	nextCmd := NewNextCmd(ctx)
+	historyCmd := NewHistoryCmd(ctx)
+	latestCmd := NewLatestCmd(ctx)
+	lintCmd := NewLintCmd(ctx)
+	promoteCmd := NewPromoteCmd(ctx)
+	rulesCmd := NewRulesCmd(ctx)
+	serveCmd := NewServeCmd(ctx)
+	stableCmd := NewStableCmd(ctx)
+	verifyCmd := NewVerifyCmd(ctx)
 	versionCmd := NewVersionCmd()
 
 	rootCmd.AddCommand(releaseCmd)
+	rootCmd.AddCommand(changelogCmd)
+	rootCmd.AddCommand(compareCmd)
+	rootCmd.AddCommand(nextCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(latestCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(promoteCmd)
+	rootCmd.AddCommand(rulesCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(stableCmd)
+	rootCmd.AddCommand(verifyCmd)
 	rootCmd.AddCommand(versionCmd)
 
 	return rootCmd
 }
 
 func initializeConfig(cmd *cobra.Command, ctx *appcontext.AppContext) error {
+	// The configuration file path cannot come from the configuration file itself
+	if env := os.Getenv(envName(ConfigConfiguration)); env != "" && !cmd.Flags().Changed(ConfigConfiguration) {
+		ctx.CfgFileFlag = env
+	}
+
 	if ctx.CfgFileFlag != "" {
 		ctx.Viper.SetConfigFile(ctx.CfgFileFlag)
 	} else {
@@ -91,10 +374,8 @@ func initializeConfig(cmd *cobra.Command, ctx *appcontext.AppContext) error {
 	}
 	ctx.Logger.Debug().Str("path", absCfgPath).Msg("using the following configuration file")
 
-	ctx.Viper.SetEnvPrefix("GO_SEMVER_RELEASE")
-	ctx.Viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
-	ctx.Viper.AutomaticEnv()
-
+	// Environment variables are bound by bindFlags, Viper only reads the configuration file so that the file takes
+	// precedence over the environment
 	if err = ctx.Viper.ReadInConfig(); err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
 
@@ -103,7 +384,7 @@ func initializeConfig(cmd *cobra.Command, ctx *appcontext.AppContext) error {
 		}
 	}
 
-	if err = bindFlags(cmd, ctx.Viper); err != nil {
+	if ctx.ConfigSources, err = bindFlags(cmd, ctx.Viper); err != nil {
 		return err
 	}
 
@@ -111,10 +392,13 @@ func initializeConfig(cmd *cobra.Command, ctx *appcontext.AppContext) error {
 }
 
 // bindFlags binds Viper configuration value to their corresponding Cobra flag if, for a given configuration value,
-// the flag has not been set and the Viper configuration has been.
-func bindFlags(cmd *cobra.Command, v *viper.Viper) error {
+// the flag has not been set and the Viper configuration has been, the configuration file taking precedence over the
+// environment variables. It returns the source of every flag that is not left to its default value.
+func bindFlags(cmd *cobra.Command, v *viper.Viper) (map[string]string, error) {
 	var err error
 
+	sources := make(map[string]string)
+
 	cmd.Flags().VisitAll(func(f *pflag.Flag) {
 		if err != nil {
 			return
@@ -122,24 +406,203 @@ func bindFlags(cmd *cobra.Command, v *viper.Viper) error {
 
 		configName := f.Name
 
-		if !f.Changed && v.IsSet(configName) {
+		if f.Changed {
+			sources[configName] = SourceFlag
+			return
+		}
+
+		// The configuration file cannot give its own path
+		if configName != ConfigConfiguration && v.IsSet(configName) {
+			sources[configName] = SourceFile
+
 			val := v.Get(configName)
 
 			switch flagType := f.Value.(type) {
-			case *branch.Flag, *rule.Flag, *monorepo.Flag:
+			case *branch.Flag, *rule.Flag, *monorepo.Flag, *hook.Flag, *plugin.Flag, *mirror.Flag:
 				jsonStr, jsonErr := json.Marshal(val)
 				if jsonErr != nil {
 					err = fmt.Errorf("marshaling %q value: %w", configName, jsonErr)
 				}
 
 				err = flagType.Set(string(jsonStr))
+			case pflag.SliceValue:
+				err = flagType.Replace(v.GetStringSlice(configName))
 			default:
 				err = cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val))
 			}
 
 			f.Changed = true
+
+			return
+		}
+
+		// Environment variables are given as on the command line, lists being comma-separated and structures being
+		// JSON-encoded. Like Viper, empty variables are ignored.
+		if env := os.Getenv(envName(configName)); env != "" {
+			sources[configName] = SourceEnv
+			err = cmd.Flags().Set(f.Name, env)
+		}
+	})
+
+	return sources, err
+}
+
+// envName returns the name of the environment variable of the given configuration.
+func envName(configName string) string {
+	return "GO_SEMVER_RELEASE_" + strings.ToUpper(strings.ReplaceAll(configName, "-", "_"))
+}
+
+// configureSecrets reads the secrets whose file flag is set, "-" reading them from stdin, so that they do not appear
+// in the process arguments. The secrets without flag default to their environment variable.
+func configureSecrets(ctx *appcontext.AppContext, stdin io.Reader) error {
+	ctx.SSHAuthPassphrase = os.Getenv(sshAuthPassphraseEnv)
+	ctx.DockerPassword = os.Getenv(dockerPasswordEnv)
+
+	// The GPG key and its passphrase are only read by the signing commands, but they cannot share stdin with the other
+	// secrets
+	stdinSecrets := 0
+
+	for _, path := range []string{
+		ctx.AccessTokenFileFlag, ctx.WebhookSecretFileFlag, ctx.SSHAuthPassphraseFileFlag, ctx.DockerPasswordFileFlag,
+		ctx.GPGKeyPathFlag, ctx.GPGPassphraseFileFlag,
+	} {
+		if path == "-" {
+			stdinSecrets++
 		}
+	}
+
+	if stdinSecrets > 1 {
+		return ErrSeveralStdinSecrets
+	}
+
+	return readSecretFiles(ctx, stdin, []secretFile{
+		{name: AccessTokenConfiguration, path: ctx.AccessTokenFileFlag, value: &ctx.AccessTokenFlag},
+		{name: WebhookSecretConfiguration, path: ctx.WebhookSecretFileFlag, value: &ctx.WebhookSecretFlag},
+		{name: "SSH authentication key passphrase", path: ctx.SSHAuthPassphraseFileFlag, value: &ctx.SSHAuthPassphrase, overridable: true},
+		{name: "Docker password", path: ctx.DockerPasswordFileFlag, value: &ctx.DockerPassword, overridable: true},
 	})
+}
+
+// secretFile is a secret given as the path to the file containing it.
+type secretFile struct {
+	name string
+	// path is the path of the file, "-" for stdin, the secret is left as is if empty.
+	path  string
+	value *string
+	// overridable is whether the file takes precedence over a value already set, such as an environment variable,
+	// instead of conflicting with it.
+	overridable bool
+}
+
+// readSecretFiles sets the value of the given secrets to the content of their file. A secret cannot be given both as is
+// and as a file, and only one secret can be read from stdin.
+func readSecretFiles(ctx *appcontext.AppContext, stdin io.Reader, secrets []secretFile) error {
+	fromStdin := false
+
+	for _, secret := range secrets {
+		if secret.path == "" {
+			continue
+		}
+
+		if *secret.value != "" && !secret.overridable {
+			return fmt.Errorf("%w: %s", ErrConflictingSecrets, secret.name)
+		}
+
+		if secret.path == "-" {
+			if fromStdin {
+				return ErrSeveralStdinSecrets
+			}
+
+			fromStdin = true
+		}
+
+		value, err := readSecret(ctx, secret.name, secret.path, stdin)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", secret.name, err)
+		}
+
+		*secret.value = string(value)
+	}
+
+	return nil
+}
+
+// readSecret returns the secret read from the file at the given path, or from stdin if the path is "-", without its
+// trailing line break.
+func readSecret(ctx *appcontext.AppContext, name, path string, stdin io.Reader) ([]byte, error) {
+	var (
+		secret []byte
+		err    error
+	)
+
+	if path == "-" {
+		ctx.Logger.Debug().Str("secret", name).Msg("reading secret from stdin")
+		secret, err = io.ReadAll(stdin)
+	} else {
+		ctx.Logger.Debug().Str("secret", name).Str("path", path).Msg("reading secret from file")
+		secret, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimRight(secret, "\r\n"), nil
+}
+
+// ExitCode returns the exit code of the program for the given error returned by a command.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitCodeSuccess
+	case errors.Is(err, ErrNoRelease):
+		return ExitCodeNoRelease
+	default:
+		return ExitCodeError
+	}
+}
+
+// configureLogger returns the logger of the diagnostic output, written to the standard error of the command so that
+// the standard output only holds the machine-readable output.
+func configureLogger(cmd *cobra.Command, ctx *appcontext.AppContext) (zerolog.Logger, error) {
+	if ctx.QuietFlag {
+		return zerolog.Nop(), nil
+	}
+
+	level := ctx.LogLevelFlag
+
+	if ctx.VerboseFlag {
+		level = logging.LevelDebug
+	}
+
+	logger, err := logging.New(logging.Redact(cmd.ErrOrStderr(), secrets(ctx)...), level, ctx.LogFormatFlag)
+	if err != nil {
+		return zerolog.Nop(), fmt.Errorf("configuring logger: %w", err)
+	}
+
+	return logger, nil
+}
+
+// diagnosticOutput returns the writer of the diagnostic output of a command that is not a log, such as the output of
+// the hooks, which is discarded in quiet mode.
+func diagnosticOutput(cmd *cobra.Command, ctx *appcontext.AppContext) io.Writer {
+	if ctx.QuietFlag {
+		return io.Discard
+	}
+
+	return logging.Redact(cmd.ErrOrStderr(), secrets(ctx)...)
+}
+
+// secrets returns the secrets of the given AppContext redacted from the diagnostic output.
+func secrets(ctx *appcontext.AppContext) []string {
+	return []string{ctx.AccessTokenFlag, ctx.WebhookSecretFlag, ctx.SSHAuthPassphrase, ctx.DockerPassword}
+}
+
+// commandContext returns the context of a command, cancelled once the timeout configured in the given AppContext, if
+// any, is over.
+func commandContext(cmd *cobra.Command, ctx *appcontext.AppContext) (context.Context, context.CancelFunc) {
+	if ctx.TimeoutFlag <= 0 {
+		return context.WithCancel(cmd.Context())
+	}
 
-	return err
+	return context.WithTimeout(cmd.Context(), ctx.TimeoutFlag)
 }
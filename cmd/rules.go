@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+)
+
+func NewRulesCmd(ctx *appcontext.AppContext) *cobra.Command {
+	rulesCmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Inspect release rules files",
+	}
+
+	validateCmd := &cobra.Command{
+		Use:   "validate [FILE]",
+		Short: "Check a release rules file",
+		Long:  "Check the given release rules file, or the one given using --rules-path, without running a release. Every unknown release type, unknown commit type and duplicate commit type is printed out along its location in the file, and the command fails if there is any",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ctx.RulesPathFlag
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			if path == "" {
+				return fmt.Errorf("%w: no rules file given", rule.ErrInvalidRulesFile)
+			}
+
+			diagnostics, err := rule.Validate(path)
+			if err != nil {
+				return fmt.Errorf("validating rules file: %w", err)
+			}
+
+			for _, diagnostic := range diagnostics {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s:%s\n", path, diagnostic)
+			}
+
+			if len(diagnostics) > 0 {
+				return fmt.Errorf("%w: %d problem(s) found in %s", rule.ErrInvalidRulesFile, len(diagnostics), path)
+			}
+
+			return nil
+		},
+	}
+
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of release rules files",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, err := cmd.OutOrStdout().Write(rule.Schema)
+			return err
+		},
+	}
+
+	var jsonFlag bool
+
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective configuration and release rules",
+		Long:  "Print the settings that are not left to their default value, along with their source (flag, env or file), and the release rules resulting from the default rules, the rule preset and the configured rules, along with the source of every rule",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			rules, err := ruleTable(ctx)
+			if err != nil {
+				return fmt.Errorf("loading rules configuration: %w", err)
+			}
+
+			var settings []setting
+
+			cmd.InheritedFlags().VisitAll(func(f *pflag.Flag) {
+				source, ok := ctx.ConfigSources[f.Name]
+				if !ok {
+					return
+				}
+
+				value := f.Value.String()
+				if _, ok = secretSettings[f.Name]; ok {
+					value = redacted
+				}
+
+				settings = append(settings, setting{Name: f.Name, Value: value, Source: source})
+			})
+
+			if jsonFlag {
+				err = json.NewEncoder(cmd.OutOrStdout()).Encode(struct {
+					Settings []setting `json:"settings"`
+					Rules    []ruleRow `json:"rules"`
+				}{settings, rules})
+				if err != nil {
+					return fmt.Errorf("encoding output: %w", err)
+				}
+
+				return nil
+			}
+
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "settings:")
+
+			for _, s := range settings {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s=%s source=%s\n", s.Name, s.Value, s.Source)
+			}
+
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "rules:")
+
+			for _, r := range rules {
+				switch {
+				case r.ReleaseType != "":
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s=%s source=%s\n", r.CommitType, r.ReleaseType, r.Source)
+				case ctx.StrictFlag:
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s fails: no release rule in strict mode\n", r.CommitType)
+				default:
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s ignored: no release rule\n", r.CommitType)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	showCmd.Flags().BoolVar(&jsonFlag, "json", false, "Print the configuration as JSON")
+
+	rulesCmd.AddCommand(validateCmd)
+	rulesCmd.AddCommand(schemaCmd)
+	rulesCmd.AddCommand(showCmd)
+
+	return rulesCmd
+}
+
+// redacted replaces the value of the secret settings printed out.
+const redacted = "<redacted>"
+
+// secretSettings are the settings whose value is never printed out.
+var secretSettings = map[string]struct{}{
+	AccessTokenConfiguration:   {},
+	WebhookSecretConfiguration: {},
+}
+
+// setting is a configuration value and its source.
+type setting struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// ruleRow is the effective release rule of a commit type.
+type ruleRow struct {
+	CommitType string `json:"commit-type"`
+	// ReleaseType is empty if the commit type has no rule.
+	ReleaseType string `json:"release-type,omitempty"`
+	// Source is the configuration the rule comes from: the rules, the rules file, the rule preset or the default rules.
+	Source string `json:"source,omitempty"`
+}
+
+// ruleTable returns the effective release rule of every commit type, sorted alphabetically, each rule coming from the
+// configured rules, which override those of the preset, or from the default rules if neither is configured.
+func ruleTable(ctx *appcontext.AppContext) ([]ruleRow, error) {
+	effective, err := configureRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var configured rule.Rules
+
+	// configureRules already validated the configured rules
+	configuredSource := RulesConfiguration
+
+	switch {
+	case ctx.RulesPathFlag != "":
+		configured, _ = rule.FromFile(ctx.RulesPathFlag)
+		configuredSource = RulesPathConfiguration
+	case ctx.RulesFlag.String() != "{}":
+		configured, _ = rule.Unmarshall(map[string][]string(ctx.RulesFlag))
+	}
+
+	presetSource := "default"
+	if ctx.RulePresetFlag != "" {
+		presetSource = fmt.Sprintf("%s:%s", RulePresetConfiguration, ctx.RulePresetFlag)
+	}
+
+	commitTypes := rule.CommitTypes()
+
+	rows := make([]ruleRow, 0, len(commitTypes))
+
+	for _, commitType := range commitTypes {
+		row := ruleRow{CommitType: commitType}
+
+		if releaseType, ok := effective.Map[commitType]; ok {
+			row.ReleaseType = releaseType
+			row.Source = presetSource
+
+			if _, ok = configured.Map[commitType]; ok {
+				row.Source = configuredSource
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+)
+
+func TestRulesCmd_Validate(t *testing.T) {
+	assert := assertion.New(t)
+
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+
+	err := os.WriteFile(path, []byte("minor: [feat]\npatch: [fix, feat]\n"), 0o644)
+	checkErr(t, err, "writing rules file")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("rules", "validate", path)
+	assert.ErrorIs(err, rule.ErrInvalidRulesFile)
+	assert.Equal(ErrorCodeInvalidRules, NewErrorReport(err).Code)
+	assert.Contains(string(out), path+`:patch[1]: commit type "feat" is already given the minor release type`)
+
+	err = os.WriteFile(path, []byte("minor: [feat]\npatch: [fix]\n"), 0o644)
+	checkErr(t, err, "writing rules file")
+
+	th = NewTestHelper(t)
+
+	err = th.SetFlags(map[string]string{RulesPathConfiguration: path})
+	checkErr(t, err, "setting flags")
+
+	out, err = th.ExecuteCommand("rules", "validate")
+	checkErr(t, err, "executing command")
+	assert.Empty(out, "valid rules file should not be reported")
+}
+
+func TestRulesCmd_Schema(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("rules", "schema")
+	checkErr(t, err, "executing command")
+	assert.JSONEq(string(rule.Schema), string(out))
+}
+
+func TestRulesCmd_Show(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := t.TempDir()
+
+	rulesPath := filepath.Join(dir, "rules.yaml")
+
+	err := os.WriteFile(rulesPath, []byte("patch: [fix, perf]\nminor: [docs]\n"), 0o644)
+	checkErr(t, err, "writing rules file")
+
+	configPath := filepath.Join(dir, "config.yaml")
+
+	err = os.WriteFile(configPath, []byte("rule-preset: minimal\naccess-token: secret\n"), 0o644)
+	checkErr(t, err, "writing configuration file")
+
+	t.Setenv("GO_SEMVER_RELEASE_ACCESS_TOKEN", "")
+	t.Setenv("GO_SEMVER_RELEASE_STRICT", "true")
+
+	th := NewTestHelper(t)
+
+	err = th.SetFlags(map[string]string{"config": configPath, RulesPathConfiguration: rulesPath})
+	checkErr(t, err, "setting flags")
+
+	out, err := th.ExecuteCommand("rules", "show")
+	checkErr(t, err, "executing command")
+
+	assert.Contains(string(out), "  rule-preset=minimal source=file\n")
+	assert.Contains(string(out), "  rules-path="+rulesPath+" source=flag\n")
+	assert.Contains(string(out), "  strict=true source=env\n")
+	assert.Contains(string(out), "  access-token=<redacted> source=file\n")
+	assert.NotContains(string(out), "secret")
+	assert.Contains(string(out), "  docs=minor source=rules-path\n")
+	assert.Contains(string(out), "  feat=minor source=rule-preset:minimal\n")
+	assert.Contains(string(out), "  test fails: no release rule in strict mode\n")
+
+	t.Setenv("GO_SEMVER_RELEASE_STRICT", "")
+
+	th = NewTestHelper(t)
+
+	out, err = th.ExecuteCommand("rules", "show", "--json")
+	checkErr(t, err, "executing command")
+
+	var output struct {
+		Settings []setting `json:"settings"`
+		Rules    []ruleRow `json:"rules"`
+	}
+
+	err = json.Unmarshal(out, &output)
+	checkErr(t, err, "decoding output")
+
+	assert.Contains(output.Rules, ruleRow{CommitType: "revert", ReleaseType: "patch", Source: "default"})
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/metrics"
+	"github.com/s0ders/go-semver-release/v6/internal/server"
+)
+
+// shutdownTimeout is the time given to the in-flight webhook requests to complete when the server stops.
+const shutdownTimeout = 10 * time.Second
+
+var (
+	ErrNoServeEndpoint     = errors.New("a webhook secret or an API token is required")
+	ErrNoServeRepositories = errors.New("the URLs of the served repositories are required")
+)
+
+func NewServeCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var (
+		listenFlag       string
+		repositoriesFlag []string
+		secretFlag       string
+		secretFileFlag   string
+		apiTokenFlag     string
+		apiTokenFileFlag string
+		workersFlag      int
+		queueSizeFlag    int
+	)
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Release Git repositories when receiving their push webhooks and serve their next versions",
+		Long:  "Run an HTTP server receiving the push webhooks of GitHub and GitLab on " + server.WebhookPath + " if a secret is given. Every push to a release branch queues a release of the pushed repository, which is cloned, analyzed and tagged as the release command would, using the same configuration. Payloads must be signed with the secret. If an API token is given, the next versions of a repository and the classification of its commits are also served on " + server.AnalyzePath + " to the requests authenticated with the token",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			err := readSecretFiles(ctx, cmd.InOrStdin(), []secretFile{
+				{name: "secret", path: secretFileFlag, value: &secretFlag},
+				{name: "api-token", path: apiTokenFileFlag, value: &apiTokenFlag},
+			})
+			if err != nil {
+				return err
+			}
+
+			if secretFlag == "" && apiTokenFlag == "" {
+				return ErrNoServeEndpoint
+			}
+
+			if len(repositoriesFlag) == 0 {
+				return ErrNoServeRepositories
+			}
+
+			branches, err := configureBranches(ctx)
+			if err != nil {
+				return fmt.Errorf("loading branches configuration: %w", err)
+			}
+
+			names := make([]string, len(branches))
+			for i, b := range branches {
+				names[i] = b.Name
+			}
+
+			listener, err := net.Listen("tcp", listenFlag)
+			if err != nil {
+				return fmt.Errorf("listening on %q: %w", listenFlag, err)
+			}
+
+			cmdCtx := cmd.Context()
+
+			// The outputs of the concurrent jobs share the command output
+			out := &syncWriter{w: cmd.OutOrStdout()}
+
+			ctx.Metrics = metrics.New()
+
+			options := []server.OptionFunc{server.WithRepositories(repositoriesFlag), server.WithMetrics(ctx.Metrics)}
+
+			if secretFlag != "" {
+				queue := server.NewQueue(workersFlag, queueSizeFlag, releaseJob(ctx, out), ctx.Logger)
+				queue.Start(cmdCtx)
+				defer queue.Close()
+
+				options = append(options, server.WithWebhook(secretFlag, names, queue))
+			}
+
+			if apiTokenFlag != "" {
+				options = append(options, server.WithAnalyzer(apiTokenFlag, analyzeRepository(ctx)))
+			}
+
+			httpServer := &http.Server{
+				Handler:           server.Handler(ctx.Logger, options...),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			go func() {
+				<-cmdCtx.Done()
+
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+				defer cancel()
+
+				_ = httpServer.Shutdown(shutdownCtx)
+			}()
+
+			ctx.Logger.Info().Str("address", listener.Addr().String()).Msg("listening for webhooks")
+
+			err = httpServer.Serve(listener)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("serving webhooks: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	serveCmd.Flags().StringVar(&listenFlag, "listen", ":8080", "Address on which the webhooks are received")
+	serveCmd.Flags().StringSliceVar(&repositoriesFlag, "repositories", nil, "URLs of the repositories served (e.g., https://github.com/owner/repository.git), the webhooks and analysis requests of any other repository are rejected")
+	serveCmd.Flags().StringVar(&secretFlag, "secret", "", "Secret of the webhooks, used to verify the GitHub payload signatures and the GitLab tokens")
+	serveCmd.Flags().StringVar(&secretFileFlag, "secret-file", "", "Path to a file containing the secret of the webhooks, \"-\" reads it from stdin")
+	serveCmd.Flags().StringVar(&apiTokenFlag, "api-token", "", "Bearer token of the analysis requests, the analysis endpoint is disabled if empty")
+	serveCmd.Flags().StringVar(&apiTokenFileFlag, "api-token-file", "", "Path to a file containing the bearer token of the analysis requests, \"-\" reads it from stdin")
+	serveCmd.Flags().IntVar(&workersFlag, "workers", 2, "Number of releases run concurrently, the releases of a same repository are always run one at a time")
+	serveCmd.Flags().IntVar(&queueSizeFlag, "queue-size", 64, "Maximum number of pending releases, webhooks received when the queue is full are rejected")
+
+	return serveCmd
+}
+
+// releaseJob returns the handler running the release command on the repository of a job. Every job is given its own
+// copy of the application context, so that concurrent jobs do not share their configured state.
+func releaseJob(ctx *appcontext.AppContext, out io.Writer) server.HandlerFunc {
+	return func(jobCtx context.Context, job server.Job) error {
+		appCtx := *ctx
+		appCtx.Logger = ctx.Logger.With().Str("repository", job.Repository).Logger()
+
+		releaseCmd := NewReleaseCmd(&appCtx)
+		releaseCmd.SetOut(out)
+		releaseCmd.SetErr(out)
+		releaseCmd.SetArgs([]string{job.Repository})
+		releaseCmd.SilenceUsage = true
+		releaseCmd.SilenceErrors = true
+
+		err := releaseCmd.ExecuteContext(jobCtx)
+		if err != nil {
+			ctx.Metrics.Error(metrics.ErrorRelease)
+		}
+
+		return err
+	}
+}
+
+// analyzeRepository returns the function computing the next releases of the repository of an analysis request, as the
+// next command would, restricted to the requested branch if any. Local repositories cannot be analyzed.
+func analyzeRepository(ctx *appcontext.AppContext) server.AnalyzeFunc {
+	return func(requestCtx context.Context, request server.AnalyzeRequest) ([]server.Analysis, error) {
+		if commandsDir(request.Repository) != "" {
+			return nil, fmt.Errorf("%w: %q is not a remote repository", server.ErrInvalidRequest, request.Repository)
+		}
+
+		appCtx := *ctx
+
+		err := configureRelease(&appCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if request.Branch != "" {
+			i := slices.IndexFunc(appCtx.Branches, func(b branch.Branch) bool { return b.Name == request.Branch })
+			if i < 0 {
+				return nil, fmt.Errorf("%w: %q is not a release branch", server.ErrInvalidRequest, request.Branch)
+			}
+
+			appCtx.Branches = appCtx.Branches[i : i+1]
+		}
+
+		if appCtx.TimeoutFlag > 0 {
+			var cancel context.CancelFunc
+
+			requestCtx, cancel = context.WithTimeout(requestCtx, appCtx.TimeoutFlag)
+			defer cancel()
+		}
+
+		origin, err := newRemote(requestCtx, &appCtx)
+		if err != nil {
+			return nil, fmt.Errorf("configuring remote: %w", err)
+		}
+
+		repository, err := origin.Clone(requestCtx, request.Repository)
+		if err != nil {
+			return nil, fmt.Errorf("cloning Git repository: %w", err)
+		}
+
+		p, outputs, err := runParser(requestCtx, &appCtx, origin, repository)
+		if err != nil {
+			return nil, fmt.Errorf("computing new semver: %w", err)
+		}
+		defer p.Close()
+
+		releases := make([]server.Analysis, len(outputs))
+
+		for i, output := range outputs {
+			releases[i] = server.Analysis{
+				Version:    output.Semver.String(),
+				Branch:     output.Branch,
+				Channel:    output.Channel,
+				Project:    output.Project.Name,
+				NewRelease: output.NewRelease,
+				Commits:    commitReports(output.Report),
+			}
+
+			if output.PreviousSemver != nil {
+				releases[i].PreviousVersion = output.PreviousSemver.String()
+			}
+		}
+
+		return releases, nil
+	}
+}
+
+// syncWriter serializes the writes of concurrent jobs.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.w.Write(p)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/metrics"
+	"github.com/s0ders/go-semver-release/v6/internal/server"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func TestServeCmd_NoSecret(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+
+	_, err := th.ExecuteCommand("serve")
+	assert.ErrorIs(err, ErrNoServeEndpoint)
+}
+
+func TestServeCmd_NoRepositories(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+
+	_, err := th.ExecuteCommand("serve", "--secret", "secret")
+	assert.ErrorIs(err, ErrNoServeRepositories)
+}
+
+func TestServeCmd_ReleaseJob(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	out := new(bytes.Buffer)
+	th.Ctx.Logger = zerolog.New(out)
+	th.Ctx.Metrics = metrics.New()
+
+	job := releaseJob(th.Ctx, out)
+
+	err = job(context.Background(), server.Job{Repository: testRepository.Path, Branch: "master"})
+	checkErr(t, err, "running release job")
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking tag existence")
+	assert.True(exists, "release job should have tagged the repository")
+	assert.Contains(out.String(), `"version":"0.1.0"`)
+	assert.Nil(th.Ctx.Branches, "release job should not configure the shared context")
+
+	recorder := httptest.NewRecorder()
+	th.Ctx.Metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, server.MetricsPath, nil))
+
+	assert.Contains(recorder.Body.String(), "go_semver_release_analyses_total 1\n")
+	assert.Contains(recorder.Body.String(), "go_semver_release_releases_total 1\n")
+}
+
+func TestServeCmd_AnalyzeRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat", "docs"})
+
+	th := NewTestHelper(t)
+	err := th.SetFlags(map[string]string{
+		BranchesConfiguration: `[{"name": "master"}, {"name": "rc", "prerelease": true}]`,
+	})
+	checkErr(t, err, "setting flags")
+
+	analyze := analyzeRepository(th.Ctx)
+
+	releases, err := analyze(context.Background(), server.AnalyzeRequest{Repository: "file://" + testRepository.Path, Branch: "master"})
+	checkErr(t, err, "analyzing repository")
+
+	assert.Len(releases, 1)
+	assert.Equal("0.1.0", releases[0].Version)
+	assert.Equal("master", releases[0].Branch)
+	assert.True(releases[0].NewRelease)
+	assert.Empty(releases[0].PreviousVersion)
+	assert.Len(releases[0].Commits, 3)
+
+	exists, err := tag.Exists(testRepository.Repository, "v0.1.0")
+	checkErr(t, err, "checking tag existence")
+	assert.False(exists, "analysis should not tag the repository")
+
+	_, err = analyze(context.Background(), server.AnalyzeRequest{Repository: "file://" + testRepository.Path, Branch: "feature"})
+	assert.ErrorIs(err, server.ErrInvalidRequest, "unknown branch should be rejected")
+
+	_, err = analyze(context.Background(), server.AnalyzeRequest{Repository: testRepository.Path})
+	assert.ErrorIs(err, server.ErrInvalidRequest, "local repository should be rejected")
+}
+
+func TestServeCmd_SecretFiles(t *testing.T) {
+	assert := assertion.New(t)
+
+	th := NewTestHelper(t)
+
+	_, err := th.ExecuteCommand("serve", "--secret-file", "-", "--api-token-file", "-")
+	assert.ErrorIs(err, ErrSeveralStdinSecrets)
+
+	th = NewTestHelper(t)
+
+	_, err = th.ExecuteCommand("serve", "--secret", "secret", "--secret-file", "-")
+	assert.ErrorIs(err, ErrConflictingSecrets)
+}
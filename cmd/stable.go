@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/ledger"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/output"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func NewStableCmd(ctx *appcontext.AppContext) *cobra.Command {
+	stableCmd := &cobra.Command{
+		Use:   "stable <REPOSITORY_PATH_OR_URL>",
+		Short: "Promote the latest prerelease of a Git repository to a stable release",
+		Long:  "Tag the commit of the latest prerelease (e.g., 1.2.0-rc.3) with the corresponding stable version (e.g., 1.2.0), for every project if executed in a monorepo, without parsing the commit history",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entity, err := configureGPGKey(ctx, cmd.InOrStdin())
+			if err != nil {
+				return fmt.Errorf("configuring GPG key: %w", err)
+			}
+
+			sshSigner, err := configureSSHKey(ctx)
+			if err != nil {
+				return fmt.Errorf("configuring SSH key: %w", err)
+			}
+
+			if entity != nil && sshSigner != nil {
+				return ErrConflictingSignKeys
+			}
+
+			if ctx.LightweightTagsFlag && (entity != nil || sshSigner != nil) {
+				return ErrSignedLightweightTags
+			}
+
+			err = configureTagDate(ctx)
+			if err != nil {
+				return err
+			}
+
+			ctx.Projects, err = configureProjects(ctx)
+			if err != nil {
+				return fmt.Errorf("loading projects configuration: %w", err)
+			}
+
+			ctx.TagIgnorePatterns, err = configureTagIgnorePatterns(ctx)
+			if err != nil {
+				return fmt.Errorf("loading tag ignore patterns configuration: %w", err)
+			}
+
+			writer, err := output.NewWriter(ctx.OutputFormatFlag, cmd.OutOrStdout())
+			if err != nil {
+				return fmt.Errorf("configuring output: %w", err)
+			}
+
+			cmdCtx, cancel := commandContext(cmd, ctx)
+			defer cancel()
+
+			origin, err := newRemote(cmdCtx, ctx)
+			if err != nil {
+				return fmt.Errorf("configuring remote: %w", err)
+			}
+
+			repository, err := origin.Clone(cmdCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("cloning Git repository: %w", err)
+			}
+
+			channels, err := openChannels(cmdCtx, ctx, origin, repository)
+			if err != nil {
+				return err
+			}
+
+			projects := ctx.Projects
+			if len(projects) == 0 {
+				projects = []monorepo.Project{{}}
+			}
+
+			var promoted bool
+
+			var decisions []ledger.Entry
+
+			p := parser.New(ctx)
+			tagger := newTagger(ctx, tag.WithSignKey(entity), tag.WithSSHSigner(sshSigner), tag.WithForce(ctx.ForceFlag), tag.WithLightweight(ctx.LightweightTagsFlag))
+
+			for _, project := range projects {
+				latestTag, err := p.FetchLatestSemverTag(repository, project)
+				if err != nil {
+					return fmt.Errorf("fetching latest semver tag: %w", err)
+				}
+
+				releaseOutput := output.Release{Project: project.Name}
+
+				var prerelease *semver.Version
+
+				if latestTag != nil {
+					prerelease = latestTag.Version
+
+					releaseOutput.Version = prerelease.String()
+					releaseOutput.PreviousVersion = prerelease.String()
+				}
+
+				if prerelease == nil || prerelease.Prerelease == "" {
+					releaseOutput.Message = "no prerelease to promote"
+
+					if ctx.LedgerBranchFlag != "" {
+						decisions = append(decisions, newLedgerEntry(ctx, "stable", releaseOutput, plumbing.ZeroHash, ""))
+					}
+
+					if err = writer.Write(releaseOutput); err != nil {
+						return fmt.Errorf("writing output: %w", err)
+					}
+
+					continue
+				}
+
+				stable := *prerelease
+				stable.Prerelease = ""
+				stable.Metadata = ""
+
+				tagPrefix, err := p.TagPrefix(repository, project)
+				if err != nil {
+					return fmt.Errorf("fetching tag prefix: %w", err)
+				}
+
+				tagger.SetTagPrefix(tagPrefix)
+				tagger.SetProjectName(project.Name)
+
+				releaseOutput.NewRelease = true
+				releaseOutput.Version = stable.String()
+
+				if ctx.DryRunFlag {
+					releaseOutput.Message = "dry-run enabled, prerelease can be promoted"
+				} else {
+					releaseOutput.Message = "prerelease promoted"
+				}
+
+				if ctx.LedgerBranchFlag != "" {
+					decisions = append(decisions, newLedgerEntry(ctx, "stable", releaseOutput, latestTag.Hash, ""))
+				}
+
+				if err = writer.Write(releaseOutput); err != nil {
+					return fmt.Errorf("writing output: %w", err)
+				}
+
+				if ctx.DryRunFlag {
+					continue
+				}
+
+				err = tagger.CheckRelease(repository, &stable, prerelease)
+				if err != nil {
+					return fmt.Errorf("checking release: %w", err)
+				}
+
+				err = tagger.TagRepository(repository, &stable, latestTag.Hash)
+				if err != nil {
+					return fmt.Errorf("tagging repository: %w", err)
+				}
+
+				if ctx.ForceFlag {
+					err = origin.ForcePushTag(cmdCtx, tagger.Format(&stable))
+				} else {
+					err = origin.PushTag(cmdCtx, tagger.Format(&stable))
+				}
+				if err != nil {
+					return fmt.Errorf("pushing tag to remote: %w", err)
+				}
+
+				ctx.Logger.Debug().Str("prerelease", latestTag.Name).Str("tag", tagger.Format(&stable)).Msg("prerelease promoted")
+
+				if channels != nil {
+					_, err = channels.Add(tagger.Format(&stable), branch.StableChannel)
+					if err != nil {
+						return fmt.Errorf("recording release channel: %w", err)
+					}
+
+					promoted = true
+				}
+
+				if ctx.TagAliasesFlag {
+					aliases, err := tagger.AliasRepository(repository, &stable, latestTag.Hash)
+					if err != nil {
+						return fmt.Errorf("adding tag aliases to repository: %w", err)
+					}
+
+					for _, alias := range aliases {
+						err = origin.ForcePushTag(cmdCtx, alias)
+						if err != nil {
+							return fmt.Errorf("pushing tag alias to remote: %w", err)
+						}
+					}
+				}
+			}
+
+			if promoted {
+				err = saveChannels(cmdCtx, ctx, origin, channels)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = saveLedger(cmdCtx, ctx, origin, repository, decisions)
+			if err != nil {
+				return err
+			}
+
+			return writer.Flush()
+		},
+	}
+
+	return stableCmd
+}
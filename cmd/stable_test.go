@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func TestStableCmd_PromotePrerelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v1.2.0-rc.3", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("stable", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	actualOut := cmdOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal(cmdOutput{Message: "prerelease promoted", Version: "1.2.0", NewRelease: true}, actualOut)
+
+	reference, err := testRepository.Reference(plumbing.NewTagReferenceName("v1.2.0"), true)
+	checkErr(t, err, "fetching stable tag")
+
+	stableTag, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, err, "fetching stable tag object")
+
+	assert.Equal(head.Hash(), stableTag.Target, "stable tag should point to the prerelease commit")
+}
+
+func TestStableCmd_NoPrerelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v1.1.0-rc.1", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	err = testRepository.AddTag("v1.1.0", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("stable", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	actualOut := cmdOutput{}
+
+	err = json.Unmarshal(out, &actualOut)
+	checkErr(t, err, "unmarshalling output")
+
+	assert.Equal(cmdOutput{Message: "no prerelease to promote", Version: "1.1.0"}, actualOut)
+}
+
+func TestStableCmd_DryRun(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.AddTag("v2.0.0-beta.1", head.Hash())
+	checkErr(t, err, "adding tag")
+
+	th := NewTestHelper(t)
+	err = th.SetFlag(DryRunConfiguration, "true")
+	checkErr(t, err, "setting flags")
+
+	_, err = th.ExecuteCommand("stable", testRepository.Path)
+	checkErr(t, err, "executing command")
+
+	exists, err := tag.Exists(testRepository.Repository, "v2.0.0")
+	checkErr(t, err, "checking if tag exists")
+
+	assert.False(exists, "stable tag should not be created in dry-run mode")
+}
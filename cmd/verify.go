@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+const (
+	verifyStatusValid    = "valid"
+	verifyStatusUnsigned = "unsigned"
+	verifyStatusInvalid  = "invalid"
+)
+
+var (
+	ErrNoVerificationKeys = errors.New("a GPG keyring or an SSH allowed signers file is required")
+	ErrUnverifiedTags     = errors.New("some semver tags could not be verified")
+)
+
+func NewVerifyCmd(ctx *appcontext.AppContext) *cobra.Command {
+	var (
+		gpgKeyringFlag        string
+		sshAllowedSignersFlag string
+	)
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify <REPOSITORY_PATH_OR_URL>",
+		Short: "Verify the signatures of the semantic version tags of a Git repository",
+		Long:  "Verify the GPG or SSH signature of every semantic version tag of the given repository against trusted public keys and report unsigned or badly signed tags",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verifier, err := configureVerifier(gpgKeyringFlag, sshAllowedSignersFlag)
+			if err != nil {
+				return err
+			}
+
+			cmdCtx, cancel := commandContext(cmd, ctx)
+			defer cancel()
+
+			origin, err := newRemote(cmdCtx, ctx)
+			if err != nil {
+				return fmt.Errorf("configuring remote: %w", err)
+			}
+
+			repository, err := origin.Clone(cmdCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("cloning Git repository: %w", err)
+			}
+
+			references, err := semverTagReferences(repository)
+			if err != nil {
+				return err
+			}
+
+			var unverified int
+
+			// The results are machine output, printed out as JSON log events on the standard output
+			results := zerolog.New(cmd.OutOrStdout())
+
+			for _, reference := range references {
+				tagName := reference.Name().Short()
+
+				err = verifyTagReference(repository, verifier, reference)
+
+				logEvent := results.Info().Str("tag", tagName)
+
+				switch {
+				case err == nil:
+					logEvent.Str("status", verifyStatusValid).Msg("tag signature verified")
+				case errors.Is(err, tag.ErrUnsignedTag):
+					unverified++
+					logEvent.Str("status", verifyStatusUnsigned).Msg("tag is not signed")
+				case errors.Is(err, tag.ErrInvalidSignature):
+					unverified++
+					logEvent.Str("status", verifyStatusInvalid).Str("error", err.Error()).Msg("tag signature is invalid")
+				default:
+					return fmt.Errorf("verifying tag %q: %w", tagName, err)
+				}
+			}
+
+			if unverified > 0 {
+				return fmt.Errorf("%w: %d out of %d", ErrUnverifiedTags, unverified, len(references))
+			}
+
+			return nil
+		},
+	}
+
+	verifyCmd.Flags().StringVar(&gpgKeyringFlag, "gpg-keyring", "", "Path to an armored GPG public keyring trusted to sign tags")
+	verifyCmd.Flags().StringVar(&sshAllowedSignersFlag, "ssh-allowed-signers", "", "Path to an SSH allowed signers file listing the public keys trusted to sign tags")
+
+	return verifyCmd
+}
+
+func configureVerifier(gpgKeyringPath, sshAllowedSignersPath string) (*tag.Verifier, error) {
+	if gpgKeyringPath == "" && sshAllowedSignersPath == "" {
+		return nil, ErrNoVerificationKeys
+	}
+
+	verifier := &tag.Verifier{}
+
+	if gpgKeyringPath != "" {
+		keyring, err := os.ReadFile(gpgKeyringPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading GPG keyring: %w", err)
+		}
+
+		verifier.ArmoredKeyRing = string(keyring)
+	}
+
+	if sshAllowedSignersPath != "" {
+		allowedSigners, err := os.ReadFile(sshAllowedSignersPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading SSH allowed signers: %w", err)
+		}
+
+		verifier.SSHKeys, err = ssh.ParseAllowedSigners(bytes.NewReader(allowedSigners))
+		if err != nil {
+			return nil, fmt.Errorf("loading SSH allowed signers: %w", err)
+		}
+	}
+
+	return verifier, nil
+}
+
+// semverTagReferences returns the references of the tags whose name contains a semantic version, sorted by name.
+func semverTagReferences(repository *git.Repository) ([]*plumbing.Reference, error) {
+	tags, err := repository.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("fetching tags: %w", err)
+	}
+
+	var references []*plumbing.Reference
+
+	err = tags.ForEach(func(reference *plumbing.Reference) error {
+		if semver.Regex.MatchString(reference.Name().Short()) {
+			references = append(references, reference)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("iterating over tags: %w", err)
+	}
+
+	sort.Slice(references, func(i, j int) bool {
+		return references[i].Name().Short() < references[j].Name().Short()
+	})
+
+	return references, nil
+}
+
+// verifyTagReference verifies the signature of the tag a given reference points to, lightweight tags are considered
+// as unsigned.
+func verifyTagReference(repository *git.Repository, verifier *tag.Verifier, reference *plumbing.Reference) error {
+	tagObject, err := repository.TagObject(reference.Hash())
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return tag.ErrUnsignedTag
+	}
+	if err != nil {
+		return fmt.Errorf("fetching tag object: %w", err)
+	}
+
+	return verifier.Verify(tagObject)
+}
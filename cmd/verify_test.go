@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+type verifyOutput struct {
+	Tag    string `json:"tag"`
+	Status string `json:"status"`
+}
+
+func TestVerifyCmd_SSHSignedTags(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, err, "generating ed25519 key")
+
+	block, err := cryptossh.MarshalPrivateKey(privateKey, "")
+	checkErr(t, err, "marshalling private key")
+
+	signer, err := ssh.FromPEM(bytes.NewReader(pem.EncodeToMemory(block)))
+	checkErr(t, err, "loading private key")
+
+	allowedSignersPath := filepath.Join(t.TempDir(), "allowed_signers")
+
+	err = os.WriteFile(allowedSignersPath, append([]byte("go-semver@release.ci "), cryptossh.MarshalAuthorizedKey(signer.PublicKey())...), 0o644)
+	checkErr(t, err, "writing allowed signers")
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	tagger := tag.NewTagger("Go Semver Release", "go-semver@release.ci", tag.WithTagPrefix("v"), tag.WithSSHSigner(signer))
+
+	err = tagger.TagRepository(testRepository.Repository, &semver.Version{Major: 1}, head.Hash())
+	checkErr(t, err, "tagging repository")
+
+	th := NewTestHelper(t)
+
+	out, err := th.ExecuteCommand("verify", testRepository.Path, "--ssh-allowed-signers", allowedSignersPath)
+	checkErr(t, err, "executing command")
+
+	assert.Equal([]verifyOutput{{Tag: "v1.0.0", Status: verifyStatusValid}}, readVerifyOutput(t, out))
+
+	err = testRepository.AddTag("v0.1.0", head.Hash())
+	checkErr(t, err, "adding unsigned tag")
+
+	out, err = th.ExecuteCommand("verify", testRepository.Path, "--ssh-allowed-signers", allowedSignersPath)
+	assert.ErrorIs(err, ErrUnverifiedTags, "unsigned tag should have been reported")
+
+	want := []verifyOutput{
+		{Tag: "v0.1.0", Status: verifyStatusUnsigned},
+		{Tag: "v1.0.0", Status: verifyStatusValid},
+	}
+
+	assert.Equal(want, readVerifyOutput(t, out))
+}
+
+func TestVerifyCmd_NoKeys(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := NewTestRepository(t, []string{"feat"})
+
+	th := NewTestHelper(t)
+
+	_, err := th.ExecuteCommand("verify", testRepository.Path)
+	assert.ErrorIs(err, ErrNoVerificationKeys)
+}
+
+func readVerifyOutput(t *testing.T, out []byte) []verifyOutput {
+	t.Helper()
+
+	var outputs []verifyOutput
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		// Skips the error and usage printed by Cobra when the command fails
+		if !bytes.HasPrefix(scanner.Bytes(), []byte("{")) {
+			continue
+		}
+
+		var output verifyOutput
+
+		err := json.Unmarshal(scanner.Bytes(), &output)
+		checkErr(t, err, "unmarshalling output")
+
+		if output.Tag != "" {
+			outputs = append(outputs, output)
+		}
+	}
+
+	return outputs
+}
@@ -5,33 +5,174 @@
 package appcontext
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/commit"
+	"github.com/s0ders/go-semver-release/v6/internal/hook"
+	"github.com/s0ders/go-semver-release/v6/internal/metrics"
+	"github.com/s0ders/go-semver-release/v6/internal/mirror"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/plugin"
 	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/scheme"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
 )
 
 type AppContext struct {
-	Viper              *viper.Viper
-	Branches           []branch.Branch
-	Projects           []monorepo.Project
-	Rules              rule.Rules
-	BranchesFlag       branch.Flag
-	MonorepositoryFlag monorepo.Flag
-	RulesFlag          rule.Flag
-	Logger             zerolog.Logger
-	CfgFileFlag        string
-	GitNameFlag        string
-	GitEmailFlag       string
-	TagPrefixFlag      string
-	AccessTokenFlag    string
-	RemoteNameFlag     string
-	GPGKeyPathFlag     string
-	BuildMetadataFlag  string
-	DryRunFlag         bool
-	VerboseFlag        bool
+	Viper    *viper.Viper
+	Branches []branch.Branch
+	Projects []monorepo.Project
+	Rules    rule.Rules
+	Hooks    hook.Hooks
+	Plugins  []plugin.Config
+	Mirrors  []mirror.Config
+	// InitialVersion is the version of the first release, if not nil. Otherwise, the first release is computed from
+	// the commit history.
+	InitialVersion *semver.Version
+	// Scheme is the versioning scheme of the released versions, the Semantic Versioning if nil.
+	Scheme scheme.Scheme
+	// SetVersion is the version of every release, overriding the computed one, if not nil.
+	SetVersion *semver.Version
+	// CommitVerifier verifies the signatures of the commits triggering a release, nil if no signature policy is set.
+	CommitVerifier *commit.Verifier
+	// CommitPattern is the custom grammar of commit messages, the Conventional Commits specification if nil.
+	CommitPattern *regexp.Regexp
+	// IssuePattern is the pattern of the references to issues in commit bodies, the closing keywords if nil.
+	IssuePattern *regexp.Regexp
+	// Metrics records the analyses and releases in server mode, nil otherwise.
+	Metrics *metrics.Metrics
+	// Clock gives the date of the tags, release commits, changelogs and attestations, time.Now if nil.
+	Clock func() time.Time
+	// ConfigSources gives the source, a flag, an environment variable or the configuration file, of every setting that
+	// is not left to its default value.
+	ConfigSources map[string]string
+	// SSHAuthPassphrase is the passphrase of the SSH authentication key, read from its file or environment variable.
+	SSHAuthPassphrase string
+	// DockerPassword is the password authenticating to the Docker registry, read from its file or environment variable.
+	DockerPassword string
+	// TagIgnorePatterns are the patterns of the names of the tags ignored when looking for the latest semver tag.
+	TagIgnorePatterns         []*regexp.Regexp
+	BranchesFlag              branch.Flag
+	MonorepositoryFlag        monorepo.Flag
+	RulesFlag                 rule.Flag
+	HooksFlag                 hook.Flag
+	PluginsFlag               plugin.Flag
+	MirrorsFlag               mirror.Flag
+	Logger                    zerolog.Logger
+	TimeoutFlag               time.Duration
+	LockTimeoutFlag           time.Duration
+	CloneDepthFlag            int
+	PushRetriesFlag           int
+	WebhookRetriesFlag        int
+	CfgFileFlag               string
+	GitNameFlag               string
+	GitEmailFlag              string
+	LedgerBranchFlag          string
+	GiteaRepositoryFlag       string
+	GiteaURLFlag              string
+	GitHubAPIURLFlag          string
+	GitHubAppIDFlag           string
+	GitHubInstallationIDFlag  string
+	GitHubAppKeyPathFlag      string
+	GitHubRepositoryFlag      string
+	GitLabAPIURLFlag          string
+	GitLabProjectFlag         string
+	TagPrefixFlag             string
+	TaggerNameFlag            string
+	TaggerEmailFlag           string
+	TagDateFlag               string
+	AccessTokenFlag           string
+	AccessTokenFileFlag       string
+	AttestationDirFlag        string
+	BitbucketRepositoryFlag   string
+	BitbucketServerURLFlag    string
+	CACertFlag                string
+	BitbucketUsernameFlag     string
+	DiscordWebhookFlag        string
+	DockerImageFlag           string
+	DockerSourceTagFlag       string
+	DockerUsernameFlag        string
+	DockerPasswordFileFlag    string
+	SlackWebhookFlag          string
+	TeamsWebhookFlag          string
+	RemoteNameFlag            string
+	GPGKeyPathFlag            string
+	GPGKeyIDFlag              string
+	GPGKeyEmailFlag           string
+	GPGPassphraseFileFlag     string
+	SSHKeyPathFlag            string
+	SSHAuthKeyPathFlag        string
+	SSHAuthPassphraseFileFlag string
+	SSHKnownHostsPathFlag     string
+	BuildMetadataFlag         string
+	ChangelogPathFlag         string
+	CIProviderFlag            string
+	OutputFormatFlag          string
+	LogFormatFlag             string
+	LogLevelFlag              string
+	RulePresetFlag            string
+	RulesPathFlag             string
+	InitialVersionFlag        string
+	CommitFlag                string
+	CommitPatternFlag         string
+	CommitURLTemplateFlag     string
+	CompareURLTemplateFlag    string
+	PRURLTemplateFlag         string
+	IssuePatternFlag          string
+	IssueURLTemplateFlag      string
+	ForceBumpFlag             string
+	SetVersionFlag            string
+	VersionSchemeFlag         string
+	CalVerFormatFlag          string
+	FromFlag                  string
+	ToFlag                    string
+	WebhookSecretFlag         string
+	WebhookSecretFileFlag     string
+	SignaturePolicyFlag       string
+	SignatureKeyringFlag      string
+	SignatureSignersFlag      string
+	BreakingKeywordsFlag      []string
+	IgnoreAuthorsFlag         []string
+	OnlyAuthorsFlag           []string
+	PathsFlag                 []string
+	ExcludePathsFlag          []string
+	SkipReleaseMarkersFlag    []string
+	TagIgnorePatternsFlag     []string
+	WebhookURLsFlag           []string
+	BitbucketReleaseFlag      bool
+	CacheFlag                 bool
+	DetectTagPrefixFlag       bool
+	DryRunFlag                bool
+	FailOnNoReleaseFlag       bool
+	CredentialHelperFlag      bool
+	ForceFlag                 bool
+	FirstParentFlag           bool
+	GiteaReleaseFlag          bool
+	GitHubAPITagsFlag         bool
+	GitLabAPITagsFlag         bool
+	GitLabReleaseFlag         bool
+	IgnoreExclamationFlag     bool
+	InsecureHostKeyFlag       bool
+	InsecureTLSFlag           bool
+	LightweightTagsFlag       bool
+	LockFlag                  bool
+	MajorOnBreakingInDevFlag  bool
+	QuietFlag                 bool
+	RecordChannelsFlag        bool
+	ReleaseCommitFlag         bool
+	RequireCleanFlag          bool
+	RequireSyncedFlag         bool
+	ReportFlag                bool
+	SquashedCommitsFlag       bool
+	StrictFlag                bool
+	TagAliasesFlag            bool
+	TagReleaseNotesFlag       bool
+	VerboseFlag               bool
 }
 
 func New() *AppContext {
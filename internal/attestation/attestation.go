@@ -0,0 +1,200 @@
+// Package attestation provides the provenance attestations of releases, as in-toto statements following the SLSA
+// provenance format.
+package attestation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+)
+
+const (
+	StatementType   = "https://in-toto.io/Statement/v1"
+	PredicateType   = "https://slsa.dev/provenance/v1"
+	BuildType       = "https://github.com/s0ders/go-semver-release/release/v1"
+	BuilderID       = "https://github.com/s0ders/go-semver-release"
+	toolName        = "go-semver-release"
+	fileExtension   = ".intoto.json"
+	signatureSuffix = ".asc"
+)
+
+// Release describes the release attested by a statement.
+type Release struct {
+	// Repository is the URL, or the path, of the released repository.
+	Repository string
+	Commit     string
+	Tag        string
+	Version    string
+	Branch     string
+	Project    string
+	Rules      rule.Rules
+	// ToolVersion is the version of the program computing the release.
+	ToolVersion string
+	Date        time.Time
+}
+
+// Statement is an in-toto statement, see https://github.com/in-toto/attestation/blob/main/spec/v1/statement.md.
+type Statement struct {
+	Type          string     `json:"_type"`
+	Subject       []Subject  `json:"subject"`
+	PredicateType string     `json:"predicateType"`
+	Predicate     Provenance `json:"predicate"`
+}
+
+// Subject is an artifact described by a statement, the release tag identified by the commit it points to.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Provenance is a SLSA provenance predicate, see https://slsa.dev/spec/v1.0/provenance.
+type Provenance struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   ExternalParameters   `json:"externalParameters"`
+	InternalParameters   InternalParameters   `json:"internalParameters"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies"`
+}
+
+type ExternalParameters struct {
+	Repository string `json:"repository"`
+	Branch     string `json:"branch"`
+	Project    string `json:"project,omitempty"`
+	Version    string `json:"version"`
+	Tag        string `json:"tag"`
+}
+
+type InternalParameters struct {
+	// RulesDigest is the digest of the release rules used to compute the version.
+	RulesDigest map[string]string `json:"rulesDigest"`
+}
+
+type ResourceDescriptor struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+type RunDetails struct {
+	Builder  Builder  `json:"builder"`
+	Metadata Metadata `json:"metadata"`
+}
+
+type Builder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version"`
+}
+
+type Metadata struct {
+	StartedOn string `json:"startedOn"`
+}
+
+// New returns the provenance statement of the given release.
+func New(release Release) (Statement, error) {
+	rulesDigest, err := RulesDigest(release.Rules)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	commitDigest := map[string]string{"gitCommit": release.Commit}
+
+	statement := Statement{
+		Type:          StatementType,
+		Subject:       []Subject{{Name: release.Tag, Digest: commitDigest}},
+		PredicateType: PredicateType,
+		Predicate: Provenance{
+			BuildDefinition: BuildDefinition{
+				BuildType: BuildType,
+				ExternalParameters: ExternalParameters{
+					Repository: release.Repository,
+					Branch:     release.Branch,
+					Project:    release.Project,
+					Version:    release.Version,
+					Tag:        release.Tag,
+				},
+				InternalParameters: InternalParameters{
+					RulesDigest: map[string]string{"sha256": rulesDigest},
+				},
+				ResolvedDependencies: []ResourceDescriptor{
+					{URI: "git+" + release.Repository + "@refs/heads/" + release.Branch, Digest: commitDigest},
+				},
+			},
+			RunDetails: RunDetails{
+				Builder: Builder{
+					ID:      BuilderID,
+					Version: map[string]string{toolName: release.ToolVersion},
+				},
+				Metadata: Metadata{
+					StartedOn: release.Date.UTC().Format(time.RFC3339),
+				},
+			},
+		},
+	}
+
+	return statement, nil
+}
+
+// RulesDigest returns the hex-encoded SHA-256 digest of the JSON encoding of the given rules, whose commit types are
+// sorted so that the digest does not depend on the order in which the rules are configured.
+func RulesDigest(rules rule.Rules) (string, error) {
+	content, err := json.Marshal(rules.Map)
+	if err != nil {
+		return "", fmt.Errorf("encoding rules: %w", err)
+	}
+
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Write writes the given statement in the given directory, in a file named after the tag of the release (e.g.,
+// "v1.2.3.intoto.json"). If a signing key is given, an armored detached signature of the file is written next to it
+// (e.g., "v1.2.3.intoto.json.asc"). The paths of the written files are returned.
+func Write(dir string, statement Statement, signKey *openpgp.Entity) ([]string, error) {
+	content, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding statement: %w", err)
+	}
+
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating attestation directory: %w", err)
+	}
+
+	name := strings.ReplaceAll(statement.Subject[0].Name, "/", "-") + fileExtension
+	path := filepath.Join(dir, name)
+
+	if err = os.WriteFile(path, content, 0o644); err != nil {
+		return nil, fmt.Errorf("writing statement: %w", err)
+	}
+
+	paths := []string{path}
+
+	if signKey == nil {
+		return paths, nil
+	}
+
+	signature := new(bytes.Buffer)
+
+	if err = openpgp.ArmoredDetachSign(signature, signKey, bytes.NewReader(content), nil); err != nil {
+		return nil, fmt.Errorf("signing statement: %w", err)
+	}
+
+	if err = os.WriteFile(path+signatureSuffix, signature.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("writing statement signature: %w", err)
+	}
+
+	return append(paths, path+signatureSuffix), nil
+}
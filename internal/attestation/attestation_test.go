@@ -0,0 +1,109 @@
+package attestation
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gpg"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+)
+
+var release = Release{
+	Repository:  "https://github.com/owner/name.git",
+	Commit:      "0123456789abcdef0123456789abcdef01234567",
+	Tag:         "foo-v1.2.3",
+	Version:     "1.2.3",
+	Branch:      "main",
+	Project:     "foo",
+	Rules:       rule.Default,
+	ToolVersion: "6.0.0",
+	Date:        time.Date(2024, time.May, 17, 12, 0, 0, 0, time.UTC),
+}
+
+func TestAttestation_New(t *testing.T) {
+	assert := assertion.New(t)
+
+	statement, err := New(release)
+	checkErr(t, "creating statement", err)
+
+	digest, err := RulesDigest(rule.Default)
+	checkErr(t, "computing rules digest", err)
+
+	assert.Equal(StatementType, statement.Type)
+	assert.Equal(PredicateType, statement.PredicateType)
+	assert.Equal([]Subject{{Name: "foo-v1.2.3", Digest: map[string]string{"gitCommit": release.Commit}}}, statement.Subject)
+	assert.Equal(ExternalParameters{Repository: release.Repository, Branch: "main", Project: "foo", Version: "1.2.3", Tag: "foo-v1.2.3"}, statement.Predicate.BuildDefinition.ExternalParameters)
+	assert.Equal(digest, statement.Predicate.BuildDefinition.InternalParameters.RulesDigest["sha256"])
+	assert.Equal("git+https://github.com/owner/name.git@refs/heads/main", statement.Predicate.BuildDefinition.ResolvedDependencies[0].URI)
+	assert.Equal("6.0.0", statement.Predicate.RunDetails.Builder.Version["go-semver-release"])
+	assert.Equal("2024-05-17T12:00:00Z", statement.Predicate.RunDetails.Metadata.StartedOn)
+}
+
+func TestAttestation_RulesDigest(t *testing.T) {
+	assert := assertion.New(t)
+
+	a, err := RulesDigest(rule.Rules{Map: map[string]string{"feat": "minor", "fix": "patch"}})
+	checkErr(t, "computing rules digest", err)
+
+	b, err := RulesDigest(rule.Rules{Map: map[string]string{"fix": "patch", "feat": "minor"}})
+	checkErr(t, "computing rules digest", err)
+
+	c, err := RulesDigest(rule.Rules{Map: map[string]string{"feat": "patch", "fix": "patch"}})
+	checkErr(t, "computing rules digest", err)
+
+	assert.Equal(a, b, "digest should not depend on the order of the rules")
+	assert.NotEqual(a, c, "digest should depend on the rules")
+	assert.Len(a, 64)
+}
+
+func TestAttestation_Write(t *testing.T) {
+	assert := assertion.New(t)
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, "creating entity", err)
+
+	statement, err := New(release)
+	checkErr(t, "creating statement", err)
+
+	dir := filepath.Join(t.TempDir(), "attestations")
+
+	paths, err := Write(dir, statement, nil)
+	checkErr(t, "writing statement", err)
+
+	assert.Equal([]string{filepath.Join(dir, "foo-v1.2.3.intoto.json")}, paths)
+
+	paths, err = Write(dir, statement, entity)
+	checkErr(t, "writing signed statement", err)
+
+	assert.Equal([]string{filepath.Join(dir, "foo-v1.2.3.intoto.json"), filepath.Join(dir, "foo-v1.2.3.intoto.json.asc")}, paths)
+
+	content, err := os.ReadFile(paths[0])
+	checkErr(t, "reading statement", err)
+
+	var written Statement
+
+	err = json.Unmarshal(content, &written)
+	checkErr(t, "decoding statement", err)
+	assert.Equal(statement, written)
+
+	signature, err := os.ReadFile(paths[1])
+	checkErr(t, "reading signature", err)
+
+	signer, err := gpg.Verify(openpgp.EntityList{entity}, content, string(signature))
+	checkErr(t, "verifying signature", err)
+	assert.Equal(entity.PrimaryKey.Fingerprint, signer.PrimaryKey.Fingerprint)
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
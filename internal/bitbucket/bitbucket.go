@@ -0,0 +1,241 @@
+// Package bitbucket provides a minimal client of the REST APIs of Bitbucket Cloud and Bitbucket Server (or Data
+// Center).
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+const (
+	// DefaultAPIURL is the URL of the REST API of Bitbucket Cloud.
+	DefaultAPIURL = "https://api.bitbucket.org/2.0"
+	// cloudURL is the URL of the web interface of Bitbucket Cloud.
+	cloudURL = "https://bitbucket.org"
+	// serverCloneSegment is the first segment of the path of the HTTP clone URLs of Bitbucket Server.
+	serverCloneSegment = "scm"
+	// releaseStatusKey is the key of the build status of the releases.
+	releaseStatusKey = "go-semver-release"
+	// releaseNotesSuffix is appended to the tag name to name the release notes file of the Downloads section.
+	releaseNotesSuffix = "-release-notes.md"
+)
+
+var (
+	ErrInvalidRepository = errors.New("invalid Bitbucket repository")
+	ErrDownloadsServer   = errors.New("downloads are not supported by Bitbucket Server")
+)
+
+type Client struct {
+	httpClient *http.Client
+	apiURL     string
+	// serverURL is the URL of the Bitbucket Server instance, empty for Bitbucket Cloud
+	serverURL string
+	username  string
+	token     string
+}
+
+type OptionFunc func(c *Client)
+
+// WithServer targets the Bitbucket Server, or Data Center, instance at the given URL (e.g.,
+// "https://bitbucket.example.com") instead of Bitbucket Cloud.
+func WithServer(url string) OptionFunc {
+	return func(c *Client) {
+		if url != "" {
+			c.serverURL = strings.TrimSuffix(url, "/")
+			c.apiURL = c.serverURL + "/rest"
+		}
+	}
+}
+
+// WithCredentials authenticates the requests using the given username and app password, or the given access token if
+// the username is empty.
+func WithCredentials(username, token string) OptionFunc {
+	return func(c *Client) {
+		c.username = username
+		c.token = token
+	}
+}
+
+// WithHTTPClient sends the requests using the given HTTP client instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) OptionFunc {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func NewClient(options ...OptionFunc) *Client {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		apiURL:     DefaultAPIURL,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// Repository identifies a Bitbucket repository by its workspace, or project key for Bitbucket Server, and its slug.
+type Repository struct {
+	Owner string
+	Name  string
+}
+
+func (r Repository) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// ParseRepository parses a repository given either as "owner/name" or as the URL of the repository (e.g.,
+// "https://bitbucket.org/workspace/name.git" or "https://bitbucket.example.com/scm/project/name.git").
+func ParseRepository(s string) (Repository, error) {
+	path := s
+
+	if strings.Contains(s, "://") || strings.Contains(s, "@") {
+		endpoint, err := transport.NewEndpoint(s)
+		if err != nil {
+			return Repository{}, fmt.Errorf("%w: %w", ErrInvalidRepository, err)
+		}
+
+		path = strings.TrimPrefix(strings.TrimPrefix(endpoint.Path, "/"), serverCloneSegment+"/")
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	owner, name, ok := strings.Cut(path, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return Repository{}, fmt.Errorf("%w: %q is not of the form owner/name", ErrInvalidRepository, s)
+	}
+
+	return Repository{Owner: owner, Name: name}, nil
+}
+
+// BuildStatus is the status of a build of a commit, shown on the commit and its pull requests.
+type BuildStatus struct {
+	// Key identifies the build among the builds of the commit, a new status with the same key replaces the previous
+	// one.
+	Key         string `json:"key"`
+	State       string `json:"state"`
+	Name        string `json:"name,omitempty"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// States of a BuildStatus.
+const (
+	StateSuccessful = "SUCCESSFUL"
+	StateFailed     = "FAILED"
+	StateInProgress = "INPROGRESS"
+)
+
+// PublishRelease publishes the release of the given tag, made on the given commit, as a build status of the commit
+// linking to the release notes. On Bitbucket Cloud, the release notes are uploaded to the Downloads section of the
+// repository, the build status links to the tag otherwise.
+func (c *Client) PublishRelease(ctx context.Context, repository Repository, commit, tagName, notes string) error {
+	link := c.TagURL(repository, tagName)
+
+	if c.serverURL == "" {
+		var err error
+
+		name := strings.ReplaceAll(tagName, "/", "-") + releaseNotesSuffix
+
+		link, err = c.UploadDownload(ctx, repository, name, []byte(notes))
+		if err != nil {
+			return fmt.Errorf("uploading release notes: %w", err)
+		}
+	}
+
+	return c.SetBuildStatus(ctx, repository, commit, BuildStatus{
+		Key:         releaseStatusKey,
+		State:       StateSuccessful,
+		Name:        "Release " + tagName,
+		URL:         link,
+		Description: "Released " + tagName,
+	})
+}
+
+// SetBuildStatus adds, or replaces, the given build status of the given commit.
+func (c *Client) SetBuildStatus(ctx context.Context, repository Repository, commit string, status BuildStatus) error {
+	path := c.repositoryPath(repository) + "/commit/" + url.PathEscape(commit) + "/statuses/build"
+
+	if c.serverURL != "" {
+		path = "/build-status/1.0/commits/" + url.PathEscape(commit)
+	}
+
+	// Bitbucket Cloud responds with 201 when the status is added and 200 when it is replaced, Bitbucket Server with 204
+	if err := c.api().Do(ctx, http.MethodPost, path, status, nil, http.StatusOK, http.StatusCreated, http.StatusNoContent); err != nil {
+		return fmt.Errorf("setting build status of commit %q: %w", commit, err)
+	}
+
+	return nil
+}
+
+// UploadDownload uploads the given content as a file of the Downloads section of the repository, replacing any file
+// of the same name, and returns the URL of the file. Downloads are only supported by Bitbucket Cloud.
+func (c *Client) UploadDownload(ctx context.Context, repository Repository, name string, content []byte) (string, error) {
+	if c.serverURL != "" {
+		return "", ErrDownloadsServer
+	}
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("files", name)
+	if err != nil {
+		return "", fmt.Errorf("creating form: %w", err)
+	}
+
+	if _, err = part.Write(content); err != nil {
+		return "", fmt.Errorf("writing form: %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return "", fmt.Errorf("writing form: %w", err)
+	}
+
+	if err = c.api().Send(ctx, http.MethodPost, c.repositoryPath(repository)+"/downloads", writer.FormDataContentType(), body, nil, http.StatusCreated); err != nil {
+		return "", fmt.Errorf("uploading download %q: %w", name, err)
+	}
+
+	return fmt.Sprintf("%s/%s/%s/downloads/%s", cloudURL, url.PathEscape(repository.Owner), url.PathEscape(repository.Name), url.PathEscape(name)), nil
+}
+
+// TagURL returns the URL of the web page of the given tag.
+func (c *Client) TagURL(repository Repository, tagName string) string {
+	if c.serverURL != "" {
+		return fmt.Sprintf("%s/projects/%s/repos/%s/browse?at=%s", c.serverURL, url.PathEscape(repository.Owner), url.PathEscape(repository.Name), url.QueryEscape("refs/tags/"+tagName))
+	}
+
+	return fmt.Sprintf("%s/%s/%s/src/%s", cloudURL, url.PathEscape(repository.Owner), url.PathEscape(repository.Name), url.PathEscape(tagName))
+}
+
+// repositoryPath returns the API path of the given Bitbucket Cloud repository.
+func (c *Client) repositoryPath(repository Repository) string {
+	return fmt.Sprintf("/repositories/%s/%s", url.PathEscape(repository.Owner), url.PathEscape(repository.Name))
+}
+
+// api returns the REST client of the API, authenticated with the credentials of the client.
+func (c *Client) api() *restapi.Client {
+	api := restapi.New(c.apiURL)
+	api.HTTPClient = c.httpClient
+
+	switch {
+	case c.username != "":
+		api.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.token)))
+	case c.token != "":
+		api.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return api
+}
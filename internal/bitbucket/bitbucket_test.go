@@ -0,0 +1,145 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+func TestParseRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	tests := []string{
+		"owner/name",
+		"https://bitbucket.org/owner/name.git",
+		"https://user@bitbucket.org/owner/name.git",
+		"git@bitbucket.org:owner/name.git",
+		"https://bitbucket.example.com/scm/owner/name.git",
+		"ssh://git@bitbucket.example.com:7999/owner/name.git",
+	}
+
+	for _, s := range tests {
+		repository, err := ParseRepository(s)
+		checkErr(t, "parsing repository", err)
+
+		assert.Equal(Repository{Owner: "owner", Name: "name"}, repository, s)
+	}
+
+	for _, s := range []string{"name", "https://bitbucket.org/owner", "owner/group/name"} {
+		_, err := ParseRepository(s)
+		assert.ErrorIs(err, ErrInvalidRepository, s)
+	}
+}
+
+func TestClient_PublishRelease_Cloud(t *testing.T) {
+	assert := assertion.New(t)
+
+	var (
+		uploads  = make(map[string]string)
+		statuses []BuildStatus
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "app-password" {
+			http.Error(w, `{"type": "error", "error": {"message": "Access denied"}}`, http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/repositories/owner/name/downloads":
+			file, header, err := r.FormFile("files")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			content, _ := io.ReadAll(file)
+			uploads[header.Filename] = string(content)
+
+			w.WriteHeader(http.StatusCreated)
+		case "/repositories/owner/name/commit/abc123/statuses/build":
+			var status BuildStatus
+			_ = json.NewDecoder(r.Body).Decode(&status)
+
+			statuses = append(statuses, status)
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithCredentials("user", "app-password"))
+	client.apiURL = server.URL
+
+	err := client.PublishRelease(context.Background(), Repository{Owner: "owner", Name: "name"}, "abc123", "foo/v1.0.0", "## foo/v1.0.0")
+	checkErr(t, "publishing release", err)
+
+	assert.Equal(map[string]string{"foo-v1.0.0-release-notes.md": "## foo/v1.0.0"}, uploads, "release notes should have been uploaded")
+
+	want := BuildStatus{
+		Key:         releaseStatusKey,
+		State:       StateSuccessful,
+		Name:        "Release foo/v1.0.0",
+		URL:         "https://bitbucket.org/owner/name/downloads/foo-v1.0.0-release-notes.md",
+		Description: "Released foo/v1.0.0",
+	}
+
+	assert.Equal([]BuildStatus{want}, statuses, "build status should link to the release notes")
+
+	client = NewClient(WithCredentials("user", "wrong"))
+	client.apiURL = server.URL
+
+	err = client.PublishRelease(context.Background(), Repository{Owner: "owner", Name: "name"}, "abc123", "v1.0.0", "")
+	assert.ErrorIs(err, restapi.ErrUnexpectedResponse)
+	assert.ErrorContains(err, "Access denied")
+}
+
+func TestClient_PublishRelease_Server(t *testing.T) {
+	assert := assertion.New(t)
+
+	var statuses []BuildStatus
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.URL.Path != "/rest/build-status/1.0/commits/abc123" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var status BuildStatus
+		_ = json.NewDecoder(r.Body).Decode(&status)
+
+		statuses = append(statuses, status)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithServer(server.URL+"/"), WithCredentials("", "token"))
+
+	err := client.PublishRelease(context.Background(), Repository{Owner: "PROJECT", Name: "name"}, "abc123", "v1.0.0", "## v1.0.0")
+	checkErr(t, "publishing release", err)
+
+	if assert.Len(statuses, 1, "build status should have been set") {
+		assert.Equal(server.URL+"/projects/PROJECT/repos/name/browse?at=refs%2Ftags%2Fv1.0.0", statuses[0].URL, "build status should link to the tag")
+	}
+
+	_, err = client.UploadDownload(context.Background(), Repository{Owner: "PROJECT", Name: "name"}, "notes.md", nil)
+	assert.ErrorIs(err, ErrDownloadsServer)
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -4,18 +4,53 @@ package branch
 import (
 	"errors"
 	"fmt"
+	"regexp"
 )
 
+const StableChannel = "stable"
+
 var (
-	ErrNoBranch = errors.New("no branch configuration")
-	ErrNoName   = errors.New("no name in branch configuration")
+	ErrNoBranch                    = errors.New("no branch configuration")
+	ErrNoName                      = errors.New("no name in branch configuration")
+	ErrInvalidPrereleaseIdentifier = errors.New("invalid prerelease identifier in branch configuration")
+	ErrInvalidRange                = errors.New("invalid version range in branch configuration")
 )
 
+var prereleaseIdentifierRegex = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`)
+
 type Branch struct {
-	Name       string
+	Name         string
+	Channel      string
+	PrereleaseID string
+	// Range is the range of versions released from a maintenance branch, nil if the branch is not a maintenance branch.
+	Range      *Range
 	Prerelease bool
 }
 
+// PrereleaseIdentifier returns the identifier appended to the versions released from the branch, the branch name is
+// used if no identifier was configured.
+func (b Branch) PrereleaseIdentifier() string {
+	if b.PrereleaseID != "" {
+		return b.PrereleaseID
+	}
+
+	return b.Name
+}
+
+// ReleaseChannel returns the channel the versions released from the branch are distributed on. If no channel was
+// configured, prerelease branches are distributed on a channel named after their prerelease identifier and release
+// branches on the stable channel.
+func (b Branch) ReleaseChannel() string {
+	switch {
+	case b.Channel != "":
+		return b.Channel
+	case b.Prerelease:
+		return b.PrereleaseIdentifier()
+	default:
+		return StableChannel
+	}
+}
+
 // Unmarshall takes a raw Viper configuration and returns a slice of Branch representing a branch configuration.
 func Unmarshall(input []map[string]any) ([]Branch, error) {
 	if len(input) == 0 {
@@ -38,14 +73,47 @@ func Unmarshall(input []map[string]any) ([]Branch, error) {
 
 		branch := Branch{Name: stringName}
 
+		// Branches named after a range (e.g., "1.x") are maintenance branches
+		branch.Range, _ = ParseRange(stringName)
+
 		prerelease, ok := b["prerelease"]
 		if ok {
-			boolPrerelease, ok := prerelease.(bool)
+			switch p := prerelease.(type) {
+			case bool:
+				branch.Prerelease = p
+			case string:
+				if !ValidPrereleaseIdentifier(p) {
+					return nil, ErrInvalidPrereleaseIdentifier
+				}
+
+				branch.Prerelease = true
+				branch.PrereleaseID = p
+			default:
+				return nil, fmt.Errorf("could not assert that the \"prerelease\" property of the branch configuration is a bool or a string")
+			}
+		}
+
+		channel, ok := b["channel"]
+		if ok {
+			stringChannel, ok := channel.(string)
+			if !ok {
+				return nil, fmt.Errorf("could not assert that the \"channel\" property of the branch configuration is a string")
+			}
+
+			branch.Channel = stringChannel
+		}
+
+		versionRange, ok := b["range"]
+		if ok {
+			stringRange, ok := versionRange.(string)
 			if !ok {
-				return nil, fmt.Errorf("could not assert that the \"prerelease\" property of the branch configuration is a bool")
+				return nil, fmt.Errorf("could not assert that the \"range\" property of the branch configuration is a string")
 			}
 
-			branch.Prerelease = boolPrerelease
+			branch.Range, ok = ParseRange(stringRange)
+			if !ok {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidRange, stringRange)
+			}
 		}
 
 		branches[i] = branch
@@ -53,3 +121,9 @@ func Unmarshall(input []map[string]any) ([]Branch, error) {
 
 	return branches, nil
 }
+
+// ValidPrereleaseIdentifier returns true if the given identifier can be used in the prerelease component of a semantic
+// version.
+func ValidPrereleaseIdentifier(identifier string) bool {
+	return prereleaseIdentifierRegex.MatchString(identifier)
+}
@@ -35,10 +35,77 @@ func TestBranch_UnmarshallErrors(t *testing.T) {
 		{have: []map[string]any{}, want: ErrNoBranch},
 		{have: []map[string]any{{"prerelease": true}}, want: ErrNoName},
 		{have: []map[string]any{{"name": "alpha", "prerelease": true}}, want: nil},
+		{have: []map[string]any{{"name": "next", "prerelease": "rc"}}, want: nil},
+		{have: []map[string]any{{"name": "next", "prerelease": "rc/1"}}, want: ErrInvalidPrereleaseIdentifier},
+		{have: []map[string]any{{"name": "release-1", "range": "1.x"}}, want: nil},
+		{have: []map[string]any{{"name": "release-1", "range": "1"}}, want: ErrInvalidRange},
 	}
 
 	for _, tc := range tests {
 		_, err := Unmarshall(tc.have)
-		assert.Equal(tc.want, err)
+		assert.ErrorIs(err, tc.want)
+	}
+}
+
+func TestBranch_UnmarshallRanges(t *testing.T) {
+	assert := assertion.New(t)
+
+	have := []map[string]any{
+		{"name": "main"},
+		{"name": "1.x"},
+		{"name": "2.3.x"},
+		{"name": "release-4", "range": "4.x"},
+	}
+	want := []Branch{
+		{Name: "main"},
+		{Name: "1.x", Range: &Range{Major: 1, Minor: -1}},
+		{Name: "2.3.x", Range: &Range{Major: 2, Minor: 3}},
+		{Name: "release-4", Range: &Range{Major: 4, Minor: -1}},
+	}
+
+	branches, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling branches: %s", err)
+	}
+
+	assert.Equal(want, branches)
+}
+
+func TestBranch_UnmarshallChannels(t *testing.T) {
+	assert := assertion.New(t)
+
+	have := []map[string]any{
+		{"name": "main"},
+		{"name": "beta", "prerelease": true},
+		{"name": "next", "prerelease": "rc", "channel": "next"},
+	}
+	want := []Branch{
+		{Name: "main"},
+		{Name: "beta", Prerelease: true},
+		{Name: "next", Prerelease: true, PrereleaseID: "rc", Channel: "next"},
+	}
+
+	branches, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling branches: %s", err)
+	}
+
+	assert.Equal(want, branches)
+
+	type test struct {
+		branch     Branch
+		identifier string
+		channel    string
+	}
+
+	tests := []test{
+		{branch: branches[0], identifier: "main", channel: StableChannel},
+		{branch: branches[1], identifier: "beta", channel: "beta"},
+		{branch: branches[2], identifier: "rc", channel: "next"},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(tc.identifier, tc.branch.PrereleaseIdentifier())
+		assert.Equal(tc.channel, tc.branch.ReleaseChannel())
 	}
 }
@@ -0,0 +1,73 @@
+package branch
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+var rangeRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(?:(0|[1-9]\d*)\.)?x$`)
+
+// Range is the range of versions released from a maintenance branch, either every version of a major version (e.g.,
+// "1.x") or every version of a minor version (e.g., "2.3.x").
+type Range struct {
+	Major int
+	// Minor is negative if the range covers every minor version of the major version.
+	Minor int
+}
+
+// ParseRange returns the Range described by the given string, false if it does not describe a range.
+func ParseRange(str string) (*Range, bool) {
+	match := rangeRegex.FindStringSubmatch(str)
+	if match == nil {
+		return nil, false
+	}
+
+	// The regular expression guarantees that the components are valid numbers
+	major, _ := strconv.Atoi(match[1])
+
+	r := &Range{Major: major, Minor: -1}
+
+	if match[2] != "" {
+		r.Minor, _ = strconv.Atoi(match[2])
+	}
+
+	return r, true
+}
+
+// Contains returns true if the given version belongs to the range.
+func (r *Range) Contains(version *semver.Version) bool {
+	if version.Major != r.Major {
+		return false
+	}
+
+	return r.Minor < 0 || version.Minor == r.Minor
+}
+
+// Clamp returns the highest release type, lower or equal to the given one, that keeps versions in the range. Breaking
+// changes bump the minor version of a major range and every change bumps the patch version of a minor range. A nil
+// Range does not constrain the release type.
+func (r *Range) Clamp(releaseType string) string {
+	if r == nil {
+		return releaseType
+	}
+
+	switch {
+	case r.Minor >= 0 && (releaseType == "major" || releaseType == "minor"):
+		return "patch"
+	case releaseType == "major":
+		return "minor"
+	default:
+		return releaseType
+	}
+}
+
+func (r *Range) String() string {
+	if r.Minor < 0 {
+		return fmt.Sprintf("%d.x", r.Major)
+	}
+
+	return fmt.Sprintf("%d.%d.x", r.Major, r.Minor)
+}
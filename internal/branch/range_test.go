@@ -0,0 +1,69 @@
+package branch
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+func TestRange_ParseRange(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		have string
+		want *Range
+	}
+
+	tests := []test{
+		{have: "1.x", want: &Range{Major: 1, Minor: -1}},
+		{have: "0.x", want: &Range{Major: 0, Minor: -1}},
+		{have: "2.3.x", want: &Range{Major: 2, Minor: 3}},
+		{have: "main", want: nil},
+		{have: "x", want: nil},
+		{have: "1.2.3", want: nil},
+		{have: "01.x", want: nil},
+		{have: "release/1.x", want: nil},
+	}
+
+	for _, tc := range tests {
+		got, ok := ParseRange(tc.have)
+		assert.Equal(tc.want, got, "range: %q", tc.have)
+		assert.Equal(tc.want != nil, ok, "range: %q", tc.have)
+
+		if tc.want != nil {
+			assert.Equal(tc.have, got.String())
+		}
+	}
+}
+
+func TestRange_Contains(t *testing.T) {
+	assert := assertion.New(t)
+
+	major := &Range{Major: 1, Minor: -1}
+	minor := &Range{Major: 2, Minor: 3}
+
+	assert.True(major.Contains(&semver.Version{Major: 1, Minor: 7, Patch: 2}))
+	assert.False(major.Contains(&semver.Version{Major: 2}))
+	assert.True(minor.Contains(&semver.Version{Major: 2, Minor: 3, Patch: 9}))
+	assert.False(minor.Contains(&semver.Version{Major: 2, Minor: 4}))
+	assert.False(minor.Contains(&semver.Version{Major: 3, Minor: 3}))
+}
+
+func TestRange_Clamp(t *testing.T) {
+	assert := assertion.New(t)
+
+	var none *Range
+
+	major := &Range{Major: 1, Minor: -1}
+	minor := &Range{Major: 2, Minor: 3}
+
+	assert.Equal("major", none.Clamp("major"))
+	assert.Equal("minor", major.Clamp("major"))
+	assert.Equal("minor", major.Clamp("minor"))
+	assert.Equal("patch", major.Clamp("patch"))
+	assert.Equal("patch", minor.Clamp("major"))
+	assert.Equal("patch", minor.Clamp("minor"))
+	assert.Equal("patch", minor.Clamp("patch"))
+}
@@ -0,0 +1,256 @@
+// Package changelog provides functions to generate a Markdown changelog from the commits that triggered a release.
+package changelog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+)
+
+const (
+	Header          = "# Changelog"
+	shortHashLength = 7
+	breakingTitle   = "Breaking Changes"
+	otherTitle      = "Other Changes"
+)
+
+type group struct {
+	title      string
+	commitType string
+}
+
+// groups lists, in order of appearance, the sections of a release in the changelog. Commits whose type is not listed
+// here are rendered in the "Other Changes" section.
+var groups = []group{
+	{title: "Features", commitType: "feat"},
+	{title: "Fixes", commitType: "fix"},
+	{title: "Performance Improvements", commitType: "perf"},
+	{title: "Reverts", commitType: "revert"},
+}
+
+// Section is a group of commits of a release, such as its features or its fixes.
+type Section struct {
+	Title   string
+	Commits []parser.Commit
+}
+
+// Sections groups the given commits by type, in order of appearance in the changelog. Breaking changes come first,
+// and empty sections are omitted.
+func Sections(commits []parser.Commit) []Section {
+	var (
+		breaking []parser.Commit
+		byType   = make(map[string][]parser.Commit)
+		others   []parser.Commit
+	)
+
+	known := make(map[string]struct{}, len(groups))
+	for _, g := range groups {
+		known[g.commitType] = struct{}{}
+	}
+
+	for _, commit := range commits {
+		if commit.Breaking {
+			breaking = append(breaking, commit)
+			continue
+		}
+
+		if _, ok := known[commit.Type]; !ok {
+			others = append(others, commit)
+			continue
+		}
+
+		byType[commit.Type] = append(byType[commit.Type], commit)
+	}
+
+	sections := []Section{{Title: breakingTitle, Commits: breaking}}
+
+	for _, g := range groups {
+		sections = append(sections, Section{Title: g.title, Commits: byType[g.commitType]})
+	}
+
+	sections = append(sections, Section{Title: otherTitle, Commits: others})
+
+	return slices.DeleteFunc(sections, func(section Section) bool {
+		return len(section.Commits) == 0
+	})
+}
+
+// Links builds the URLs of the pages of the repository linked from the changelog. They are empty if unknown.
+type Links interface {
+	CommitURL(hash string) string
+	CompareURL(from, to string) string
+	// PullRequestURL returns the URL of the pull request referenced by the given reference found in a commit
+	// description (e.g., "#12"), empty if it is not a reference to a pull request.
+	PullRequestURL(reference string) string
+	// IssueURL returns the URL of the issue or ticket referenced in a commit body (e.g., "#12" or "JIRA-456"), empty if
+	// unknown.
+	IssueURL(reference string) string
+}
+
+// pullRequestReference matches the references to pull requests (e.g., "#12") or to merge requests (e.g., "!12") found
+// in commit descriptions, preceded by a space, a parenthesis or nothing.
+var pullRequestReference = regexp.MustCompile(`(^|[\s(])([#!]\d+)\b`)
+
+type renderer struct {
+	links       Links
+	previousTag string
+}
+
+type OptionFunc func(r *renderer)
+
+// WithLinks links the commits and pull requests of the changelog to their pages, and the release to the comparison
+// with the previous one.
+func WithLinks(links Links) OptionFunc {
+	return func(r *renderer) {
+		r.links = links
+	}
+}
+
+// WithPreviousTag sets the tag of the previous release, to which the release is compared if the changelog has links.
+func WithPreviousTag(tagName string) OptionFunc {
+	return func(r *renderer) {
+		r.previousTag = tagName
+	}
+}
+
+// Render returns the Markdown changelog section of a release named after the given tag, listing the given commits
+// grouped by type.
+func Render(tagName string, date time.Time, commits []parser.Commit, options ...OptionFunc) string {
+	r := new(renderer)
+
+	for _, option := range options {
+		option(r)
+	}
+
+	buf := new(strings.Builder)
+
+	_, _ = fmt.Fprintf(buf, "## %s (%s)\n", tagName, date.Format(time.DateOnly))
+
+	for _, section := range Sections(commits) {
+		r.writeSection(buf, section)
+	}
+
+	if r.links != nil && r.previousTag != "" {
+		if compareURL := r.links.CompareURL(r.previousTag, tagName); compareURL != "" {
+			_, _ = fmt.Fprintf(buf, "\n**Full Changelog**: [%s...%s](%s)\n", r.previousTag, tagName, compareURL)
+		}
+	}
+
+	return buf.String()
+}
+
+// Document returns a whole changelog made of the given release sections, from the most recent to the oldest.
+func Document(sections []string) string {
+	return Header + "\n\n" + strings.Join(sections, "\n")
+}
+
+// Write adds a release section at the top of the changelog file located at the given path, right after its header.
+// The file is created if it does not exist.
+func Write(path string, section string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("reading changelog: %w", err)
+	}
+
+	content = bytes.TrimPrefix(content, []byte(Header))
+	content = bytes.TrimLeft(content, "\n")
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(Header + "\n\n")
+	buf.WriteString(section)
+
+	if len(content) > 0 {
+		buf.WriteString("\n")
+		buf.Write(content)
+	}
+
+	if err = os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing changelog: %w", err)
+	}
+
+	return nil
+}
+
+func (r *renderer) writeSection(buf *strings.Builder, section Section) {
+	_, _ = fmt.Fprintf(buf, "\n### %s\n\n", section.Title)
+
+	for _, commit := range section.Commits {
+		buf.WriteString("- ")
+
+		if commit.Scope != "" {
+			_, _ = fmt.Fprintf(buf, "**%s:** ", commit.Scope)
+		}
+
+		_, _ = fmt.Fprintf(buf, "%s (%s)", r.description(commit.Description), r.commit(commit.Hash.String()))
+
+		if len(commit.References) > 0 {
+			_, _ = fmt.Fprintf(buf, ", closes %s", r.references(commit.References))
+		}
+
+		buf.WriteString("\n")
+
+		// Breaking changes descriptions may span over multiple lines, which are indented to stay in the list item
+		for _, breakingChange := range commit.BreakingChanges {
+			_, _ = fmt.Fprintf(buf, "  - %s\n", strings.ReplaceAll(breakingChange, "\n", "\n    "))
+		}
+	}
+}
+
+// commit returns the short hash of the given commit, linked to its page if known.
+func (r *renderer) commit(hash string) string {
+	short := hash[:shortHashLength]
+
+	if r.links == nil {
+		return short
+	}
+
+	if commitURL := r.links.CommitURL(hash); commitURL != "" {
+		return fmt.Sprintf("[%s](%s)", short, commitURL)
+	}
+
+	return short
+}
+
+// references returns the given references to issues, separated by commas and linked to their pages if known.
+func (r *renderer) references(references []string) string {
+	rendered := make([]string, 0, len(references))
+
+	for _, reference := range references {
+		if r.links != nil {
+			if issueURL := r.links.IssueURL(reference); issueURL != "" {
+				reference = fmt.Sprintf("[%s](%s)", reference, issueURL)
+			}
+		}
+
+		rendered = append(rendered, reference)
+	}
+
+	return strings.Join(rendered, ", ")
+}
+
+// description returns the given commit description whose references to pull requests are linked to their pages.
+func (r *renderer) description(description string) string {
+	if r.links == nil {
+		return description
+	}
+
+	return pullRequestReference.ReplaceAllStringFunc(description, func(match string) string {
+		submatches := pullRequestReference.FindStringSubmatch(match)
+		prefix, reference := submatches[1], submatches[2]
+
+		pullRequestURL := r.links.PullRequestURL(reference)
+		if pullRequestURL == "" {
+			return match
+		}
+
+		return fmt.Sprintf("%s[%s](%s)", prefix, reference, pullRequestURL)
+	})
+}
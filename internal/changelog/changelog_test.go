@@ -0,0 +1,151 @@
+package changelog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/forge"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+)
+
+var (
+	date  = time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC)
+	hashA = plumbing.NewHash("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	hashB = plumbing.NewHash("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	hashC = plumbing.NewHash("cccccccccccccccccccccccccccccccccccccccc")
+	hashD = plumbing.NewHash("dddddddddddddddddddddddddddddddddddddddd")
+)
+
+func TestChangelog_Render(t *testing.T) {
+	assert := assertion.New(t)
+
+	commits := []parser.Commit{
+		{Hash: hashA, Type: "feat", Description: "add foo"},
+		{Hash: hashB, Type: "fix", Scope: "api", Description: "fix bar", References: []string{"#7", "JIRA-456"}},
+		{Hash: hashC, Type: "feat", Description: "remove baz", Breaking: true, BreakingChanges: []string{"baz is removed", "qux is renamed\nto quux"}},
+		{Hash: hashD, Type: "refactor", Description: "rework qux"},
+	}
+
+	want := `## v1.0.0 (2024-03-02)
+
+### Breaking Changes
+
+- remove baz (ccccccc)
+  - baz is removed
+  - qux is renamed
+    to quux
+
+### Features
+
+- add foo (aaaaaaa)
+
+### Fixes
+
+- **api:** fix bar (bbbbbbb), closes #7, JIRA-456
+
+### Other Changes
+
+- rework qux (ddddddd)
+`
+
+	assert.Equal(want, Render("v1.0.0", date, commits))
+}
+
+func TestChangelog_Render_Links(t *testing.T) {
+	assert := assertion.New(t)
+
+	commits := []parser.Commit{
+		{Hash: hashA, Type: "feat", Description: "add foo (#12)"},
+		{Hash: hashB, Type: "fix", Description: "fix bar!1 and #baz, see #13 and !14", References: []string{"#7", "JIRA-456"}},
+	}
+
+	links := forge.Repository{Provider: forge.GitHub, URL: "https://github.com/o/n"}
+
+	want := `## v1.1.0 (2024-03-02)
+
+### Features
+
+- add foo ([#12](https://github.com/o/n/pull/12)) ([aaaaaaa](https://github.com/o/n/commit/aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa))
+
+### Fixes
+
+- fix bar!1 and #baz, see [#13](https://github.com/o/n/pull/13) and !14 ([bbbbbbb](https://github.com/o/n/commit/bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb)), closes [#7](https://github.com/o/n/issues/7), JIRA-456
+
+**Full Changelog**: [v1.0.0...v1.1.0](https://github.com/o/n/compare/v1.0.0...v1.1.0)
+`
+
+	assert.Equal(want, Render("v1.1.0", date, commits, WithLinks(links), WithPreviousTag("v1.0.0")))
+
+	want = `## v1.1.0 (2024-03-02)
+
+### Features
+
+- add foo (#12) (aaaaaaa)
+
+### Fixes
+
+- fix bar!1 and #baz, see #13 and !14 (bbbbbbb), closes #7, JIRA-456
+`
+
+	assert.Equal(want, Render("v1.1.0", date, commits, WithLinks(forge.Repository{}), WithPreviousTag("v1.0.0")), "unknown links should not be rendered")
+}
+
+func TestChangelog_Sections(t *testing.T) {
+	assert := assertion.New(t)
+
+	commits := []parser.Commit{
+		{Hash: hashA, Type: "fix", Description: "fix foo"},
+		{Hash: hashB, Type: "feat", Description: "add bar"},
+		{Hash: hashC, Type: "fix", Description: "fix baz"},
+	}
+
+	want := []Section{
+		{Title: "Features", Commits: []parser.Commit{commits[1]}},
+		{Title: "Fixes", Commits: []parser.Commit{commits[0], commits[2]}},
+	}
+
+	assert.Equal(want, Sections(commits), "empty sections should be omitted")
+	assert.Empty(Sections(nil))
+}
+
+func TestChangelog_Write(t *testing.T) {
+	assert := assertion.New(t)
+
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	first := Render("v0.1.0", date, []parser.Commit{{Hash: hashA, Type: "feat", Description: "add foo"}})
+	second := Render("v0.1.1", date, []parser.Commit{{Hash: hashB, Type: "fix", Description: "fix bar"}})
+
+	err := Write(path, first)
+	checkErr(t, "writing first section", err)
+
+	err = Write(path, second)
+	checkErr(t, "writing second section", err)
+
+	got, err := os.ReadFile(path)
+	checkErr(t, "reading changelog", err)
+
+	want := Header + "\n\n" + second + "\n" + first
+
+	assert.Equal(want, string(got))
+	assert.Equal(want, Document([]string{second, first}), "document should be equal to the changelog written section by section")
+}
+
+func TestChangelog_Write_InvalidPath(t *testing.T) {
+	assert := assertion.New(t)
+
+	err := Write(t.TempDir(), "## v1.0.0")
+	assert.ErrorContains(err, "reading changelog")
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
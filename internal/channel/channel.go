@@ -0,0 +1,106 @@
+// Package channel records the channels on which the released versions are distributed as Git notes, one note per
+// release tag, so that an existing version can later be promoted to another channel without being released again.
+package channel
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/s0ders/go-semver-release/v6/internal/notes"
+)
+
+// NotesRef is the reference of the Git notes recording the channels of the release tags.
+const NotesRef = plumbing.ReferenceName("refs/notes/go-semver-release-channels")
+
+// note is the content of the note of a release tag, as written by semantic-release.
+type note struct {
+	Channels []string `json:"channels"`
+}
+
+// Channels are the channels recorded for the release tags of a Git repository.
+type Channels struct {
+	repository *git.Repository
+	notes      *notes.Notes
+}
+
+// Open loads the channels recorded under NotesRef, which must have been fetched from the remote beforehand.
+func Open(repository *git.Repository) (*Channels, error) {
+	n, err := notes.Open(repository, NotesRef)
+	if err != nil {
+		return nil, fmt.Errorf("opening channel notes: %w", err)
+	}
+
+	return &Channels{repository: repository, notes: n}, nil
+}
+
+// Get returns the channels recorded for the given tag, none if the tag has no note.
+func (c *Channels) Get(tagName string) ([]string, error) {
+	hash, err := c.tagHash(tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	content, ok, err := c.notes.Get(hash)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var n note
+
+	if err = json.Unmarshal(content, &n); err != nil {
+		return nil, fmt.Errorf("decoding channel note of tag %q: %w", tagName, err)
+	}
+
+	return n.Channels, nil
+}
+
+// Add records the given channel for the given tag, in addition to the channels already recorded. False is returned
+// if the channel was already recorded. Channels are only stored under NotesRef once committed.
+func (c *Channels) Add(tagName, channel string) (bool, error) {
+	channels, err := c.Get(tagName)
+	if err != nil {
+		return false, err
+	}
+
+	if slices.Contains(channels, channel) {
+		return false, nil
+	}
+
+	content, err := json.Marshal(note{Channels: append(channels, channel)})
+	if err != nil {
+		return false, fmt.Errorf("encoding channel note of tag %q: %w", tagName, err)
+	}
+
+	hash, err := c.tagHash(tagName)
+	if err != nil {
+		return false, err
+	}
+
+	if err = c.notes.Set(hash, content); err != nil {
+		return false, fmt.Errorf("setting channel note of tag %q: %w", tagName, err)
+	}
+
+	return true, nil
+}
+
+// Commit stores the channels added so far under NotesRef, in a new commit made by the given signature. The notes
+// reference must then be pushed to the remote.
+func (c *Channels) Commit(signature object.Signature) error {
+	return c.notes.Commit(signature, "Record release channels")
+}
+
+// tagHash returns the hash the given tag points to, which is annotated by its note: the tag object of annotated tags
+// or the tagged commit of lightweight tags.
+func (c *Channels) tagHash(tagName string) (plumbing.Hash, error) {
+	ref, err := c.repository.Tag(tagName)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching tag %q: %w", tagName, err)
+	}
+
+	return ref.Hash(), nil
+}
@@ -0,0 +1,82 @@
+package channel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+)
+
+var signature = object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: time.Now()}
+
+func TestChannels_AddAndGet(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v1.0.0-rc.1", head)
+	checkErr(t, "adding tag", err)
+
+	err = testRepository.AddTag("v1.0.0", head)
+	checkErr(t, "adding tag", err)
+
+	channels, err := Open(testRepository.Repository)
+	checkErr(t, "opening channels", err)
+
+	recorded, err := channels.Get("v1.0.0-rc.1")
+	checkErr(t, "getting channels", err)
+	assert.Empty(recorded, "no channel should be recorded yet")
+
+	added, err := channels.Add("v1.0.0-rc.1", "rc")
+	checkErr(t, "adding channel", err)
+	assert.True(added)
+
+	err = channels.Commit(signature)
+	checkErr(t, "committing channels", err)
+
+	channels, err = Open(testRepository.Repository)
+	checkErr(t, "opening channels", err)
+
+	added, err = channels.Add("v1.0.0-rc.1", "rc")
+	checkErr(t, "adding channel", err)
+	assert.False(added, "channel already recorded should not be added again")
+
+	added, err = channels.Add("v1.0.0-rc.1", "stable")
+	checkErr(t, "adding channel", err)
+	assert.True(added)
+
+	err = channels.Commit(signature)
+	checkErr(t, "committing channels", err)
+
+	channels, err = Open(testRepository.Repository)
+	checkErr(t, "opening channels", err)
+
+	recorded, err = channels.Get("v1.0.0-rc.1")
+	checkErr(t, "getting channels", err)
+	assert.Equal([]string{"rc", "stable"}, recorded)
+
+	recorded, err = channels.Get("v1.0.0")
+	checkErr(t, "getting channels", err)
+	assert.Empty(recorded, "channels of another tag of the same commit should be distinct")
+
+	_, err = channels.Get("v2.0.0")
+	assert.Error(err, "unknown tag should not have channels")
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,164 @@
+// Package chat posts release summaries to the incoming webhooks of chat applications (i.e., Slack, Microsoft Teams and
+// Discord).
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/changelog"
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+// maxItems is the maximum number of commits listed per section, the chat applications limiting the size of messages.
+const maxItems = 10
+
+// Platform is a chat application.
+type Platform string
+
+const (
+	Slack   Platform = "Slack"
+	Teams   Platform = "Teams"
+	Discord Platform = "Discord"
+)
+
+// Release is the summary of a release posted to the chat applications.
+type Release struct {
+	Tag string
+	// URL is the URL of the page of the release tag, if known.
+	URL string
+	// CompareURL is the URL of the page comparing the release to the previous one, if known.
+	CompareURL string
+	Sections   []changelog.Section
+}
+
+func (r Release) title() string {
+	return "Released " + r.Tag
+}
+
+// link returns the URL the message links to, preferably the comparison with the previous release.
+func (r Release) link() string {
+	if r.CompareURL != "" {
+		return r.CompareURL
+	}
+
+	return r.URL
+}
+
+type target struct {
+	platform Platform
+	url      string
+}
+
+type Notifier struct {
+	httpClient *http.Client
+	targets    []target
+}
+
+type OptionFunc func(n *Notifier)
+
+// WithSlack posts the releases to the given Slack incoming webhook URL, ignored if empty.
+func WithSlack(url string) OptionFunc {
+	return withTarget(Slack, url)
+}
+
+// WithTeams posts the releases to the given Microsoft Teams incoming webhook, or workflow, URL, ignored if empty.
+func WithTeams(url string) OptionFunc {
+	return withTarget(Teams, url)
+}
+
+// WithDiscord posts the releases to the given Discord webhook URL, ignored if empty.
+func WithDiscord(url string) OptionFunc {
+	return withTarget(Discord, url)
+}
+
+func withTarget(platform Platform, url string) OptionFunc {
+	return func(n *Notifier) {
+		if url != "" {
+			n.targets = append(n.targets, target{platform: platform, url: url})
+		}
+	}
+}
+
+// WithHTTPClient sends the requests using the given HTTP client instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) OptionFunc {
+	return func(n *Notifier) {
+		n.httpClient = httpClient
+	}
+}
+
+func NewNotifier(options ...OptionFunc) *Notifier {
+	n := &Notifier{
+		httpClient: http.DefaultClient,
+	}
+
+	for _, option := range options {
+		option(n)
+	}
+
+	return n
+}
+
+// Enabled returns true if at least one chat application is notified.
+func (n *Notifier) Enabled() bool {
+	return len(n.targets) > 0
+}
+
+// Notify posts the given release to every chat application. A post failing does not prevent the other ones from being
+// made, the errors of all the failed posts are returned.
+func (n *Notifier) Notify(ctx context.Context, release Release) error {
+	var errs []error
+
+	for _, target := range n.targets {
+		var message any
+
+		switch target.platform {
+		case Slack:
+			message = SlackMessage(release)
+		case Teams:
+			message = TeamsMessage(release)
+		case Discord:
+			message = DiscordMessage(release)
+		}
+
+		api := restapi.New(target.url)
+		api.HTTPClient = n.httpClient
+
+		// Slack responds with 200, Teams workflows with 202 and Discord with 204
+		if err := api.Do(ctx, http.MethodPost, "", message, nil, http.StatusOK, http.StatusAccepted, http.StatusNoContent); err != nil {
+			errs = append(errs, fmt.Errorf("posting to %s: %w", target.platform, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// items returns the lines listing the commits of the given section, formatted by the given function, and a last line
+// counting the commits left out if there are more than maxItems.
+func items(section changelog.Section, format func(scope, description string) string) []string {
+	var lines []string
+
+	for i, commit := range section.Commits {
+		if i == maxItems {
+			lines = append(lines, fmt.Sprintf("… and %d more", len(section.Commits)-maxItems))
+			break
+		}
+
+		lines = append(lines, format(commit.Scope, commit.Description))
+	}
+
+	return lines
+}
+
+// truncate cuts the given text to the given number of characters, ending it with an ellipsis if it is cut.
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+
+	return strings.TrimSpace(string(runes[:length-1])) + "…"
+}
@@ -0,0 +1,158 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/changelog"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+var release = Release{
+	Tag:        "v1.1.0",
+	URL:        "https://github.com/owner/name/releases/tag/v1.1.0",
+	CompareURL: "https://github.com/owner/name/compare/v1.0.0...v1.1.0",
+	Sections: []changelog.Section{
+		{Title: "Features", Commits: []parser.Commit{{Type: "feat", Scope: "api", Description: "add <foo>"}}},
+		{Title: "Fixes", Commits: []parser.Commit{{Type: "fix", Description: "fix bar"}}},
+	},
+}
+
+func TestSlackMessage(t *testing.T) {
+	want := `{
+		"text": "Released v1.1.0",
+		"blocks": [
+			{"type": "header", "text": {"type": "plain_text", "text": "Released v1.1.0"}},
+			{"type": "section", "text": {"type": "mrkdwn", "text": "*Features*\n• *api:* add &lt;foo&gt;"}},
+			{"type": "section", "text": {"type": "mrkdwn", "text": "*Fixes*\n• fix bar"}},
+			{"type": "context", "elements": [{"type": "mrkdwn", "text": "<https://github.com/owner/name/compare/v1.0.0...v1.1.0|Full changelog>"}]}
+		]
+	}`
+
+	assertion.JSONEq(t, want, marshal(t, SlackMessage(release)))
+}
+
+func TestTeamsMessage(t *testing.T) {
+	want := `{
+		"type": "message",
+		"attachments": [{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": {
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type": "AdaptiveCard",
+				"version": "1.4",
+				"body": [
+					{"type": "TextBlock", "text": "Released v1.1.0", "size": "Large", "weight": "Bolder", "wrap": true},
+					{"type": "TextBlock", "text": "Features", "weight": "Bolder", "spacing": "Medium"},
+					{"type": "TextBlock", "text": "- **api:** add <foo>", "wrap": true},
+					{"type": "TextBlock", "text": "Fixes", "weight": "Bolder", "spacing": "Medium"},
+					{"type": "TextBlock", "text": "- fix bar", "wrap": true}
+				],
+				"actions": [{"type": "Action.OpenUrl", "title": "Full changelog", "url": "https://github.com/owner/name/compare/v1.0.0...v1.1.0"}]
+			}
+		}]
+	}`
+
+	assertion.JSONEq(t, want, marshal(t, TeamsMessage(release)))
+}
+
+func TestDiscordMessage(t *testing.T) {
+	assert := assertion.New(t)
+
+	want := `{
+		"embeds": [{
+			"title": "Released v1.1.0",
+			"url": "https://github.com/owner/name/compare/v1.0.0...v1.1.0",
+			"color": 3055683,
+			"fields": [
+				{"name": "Features", "value": "• **api:** add <foo>"},
+				{"name": "Fixes", "value": "• fix bar"}
+			]
+		}]
+	}`
+
+	assert.JSONEq(want, marshal(t, DiscordMessage(release)))
+
+	var commits []parser.Commit
+	for i := range maxItems + 2 {
+		commits = append(commits, parser.Commit{Type: "feat", Description: fmt.Sprintf("%d%s", i, strings.Repeat("a", 200))})
+	}
+
+	message := DiscordMessage(Release{Tag: "v2.0.0", Sections: []changelog.Section{{Title: "Features", Commits: commits}}}).(discordMessage)
+
+	if assert.Len(message.Embeds[0].Fields, 1) {
+		value := message.Embeds[0].Fields[0].Value
+
+		assert.LessOrEqual(len([]rune(value)), maxDiscordField, "field should have been truncated")
+		assert.True(strings.HasSuffix(value, "…"))
+	}
+
+	lines := items(changelog.Section{Commits: commits}, func(_, description string) string { return description[:1] })
+	assert.Equal([]string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "… and 2 more"}, lines)
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	assert := assertion.New(t)
+
+	posted := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		posted[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/slack":
+			_, _ = w.Write([]byte("ok"))
+		case "/teams":
+			w.WriteHeader(http.StatusAccepted)
+		case "/discord":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "invalid_token", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(WithSlack(server.URL+"/slack"), WithTeams(server.URL+"/teams"), WithDiscord(server.URL+"/discord"), WithSlack(""))
+	assert.True(notifier.Enabled())
+
+	err := notifier.Notify(context.Background(), release)
+	checkErr(t, "notifying chat applications", err)
+
+	assert.Equal(map[string]int{"/slack": 1, "/teams": 1, "/discord": 1}, posted)
+
+	err = NewNotifier(WithSlack(server.URL+"/revoked")).Notify(context.Background(), release)
+	assert.ErrorIs(err, restapi.ErrUnexpectedResponse)
+	assert.ErrorContains(err, "Slack")
+
+	assert.False(NewNotifier(WithTeams("")).Enabled())
+}
+
+func marshal(t *testing.T, v any) string {
+	t.Helper()
+
+	content, err := json.Marshal(v)
+	checkErr(t, "encoding message", err)
+
+	return string(content)
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
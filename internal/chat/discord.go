@@ -0,0 +1,52 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// maxDiscordField is the maximum length of the value of a Discord embed field.
+	maxDiscordField = 1024
+	// discordColor is the color of the left border of the embeds, green.
+	discordColor = 0x2ea043
+)
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title  string         `json:"title"`
+	URL    string         `json:"url,omitempty"`
+	Color  int            `json:"color"`
+	Fields []discordField `json:"fields,omitempty"`
+}
+
+type discordField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// DiscordMessage returns the message, holding an embed, summarizing the given release.
+func DiscordMessage(release Release) any {
+	embed := discordEmbed{
+		Title: release.title(),
+		URL:   release.link(),
+		Color: discordColor,
+	}
+
+	for _, section := range release.Sections {
+		lines := items(section, func(scope, description string) string {
+			if scope != "" {
+				return fmt.Sprintf("• **%s:** %s", scope, description)
+			}
+
+			return "• " + description
+		})
+
+		embed.Fields = append(embed.Fields, discordField{Name: section.Title, Value: truncate(strings.Join(lines, "\n"), maxDiscordField)})
+	}
+
+	return discordMessage{Embeds: []discordEmbed{embed}}
+}
@@ -0,0 +1,70 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxSlackText is the maximum length of the text of a Slack section block.
+const maxSlackText = 3000
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+type slackMessage struct {
+	// Text is the fallback text of the notifications.
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackMessage returns the Block Kit message summarizing the given release.
+func SlackMessage(release Release) any {
+	message := slackMessage{
+		Text: release.title(),
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: release.title()}},
+		},
+	}
+
+	for _, section := range release.Sections {
+		lines := items(section, func(scope, description string) string {
+			if scope != "" {
+				return fmt.Sprintf("• *%s:* %s", slackEscaper.Replace(scope), slackEscaper.Replace(description))
+			}
+
+			return "• " + slackEscaper.Replace(description)
+		})
+
+		text := fmt.Sprintf("*%s*\n%s", section.Title, strings.Join(lines, "\n"))
+
+		message.Blocks = append(message.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: truncate(text, maxSlackText)}})
+	}
+
+	if link := release.link(); link != "" {
+		message.Blocks = append(message.Blocks, slackBlock{
+			Type:     "context",
+			Elements: []slackText{{Type: "mrkdwn", Text: fmt.Sprintf("<%s|%s>", link, linkTitle(release))}},
+		})
+	}
+
+	return message
+}
+
+// linkTitle returns the title of the link of the message of the given release.
+func linkTitle(release Release) string {
+	if release.CompareURL != "" {
+		return "Full changelog"
+	}
+
+	return release.Tag
+}
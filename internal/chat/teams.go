@@ -0,0 +1,81 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.4"
+)
+
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string               `json:"$schema"`
+	Type    string               `json:"type"`
+	Version string               `json:"version"`
+	Body    []adaptiveCardText   `json:"body"`
+	Actions []adaptiveCardAction `json:"actions,omitempty"`
+}
+
+type adaptiveCardText struct {
+	Type    string `json:"type"`
+	Text    string `json:"text"`
+	Size    string `json:"size,omitempty"`
+	Weight  string `json:"weight,omitempty"`
+	Wrap    bool   `json:"wrap,omitempty"`
+	Spacing string `json:"spacing,omitempty"`
+}
+
+type adaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// TeamsMessage returns the message, holding an Adaptive Card, summarizing the given release.
+func TeamsMessage(release Release) any {
+	card := adaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    "AdaptiveCard",
+		Version: adaptiveCardVersion,
+		Body: []adaptiveCardText{
+			{Type: "TextBlock", Text: release.title(), Size: "Large", Weight: "Bolder", Wrap: true},
+		},
+	}
+
+	for _, section := range release.Sections {
+		lines := items(section, func(scope, description string) string {
+			if scope != "" {
+				return fmt.Sprintf("- **%s:** %s", scope, description)
+			}
+
+			return "- " + description
+		})
+
+		card.Body = append(card.Body,
+			adaptiveCardText{Type: "TextBlock", Text: section.Title, Weight: "Bolder", Spacing: "Medium"},
+			adaptiveCardText{Type: "TextBlock", Text: strings.Join(lines, "\n"), Wrap: true},
+		)
+	}
+
+	if link := release.link(); link != "" {
+		card.Actions = []adaptiveCardAction{{Type: "Action.OpenUrl", Title: linkTitle(release), URL: link}}
+	}
+
+	return teamsMessage{
+		Type:        "message",
+		Attachments: []teamsAttachment{{ContentType: adaptiveCardContentType, Content: card}},
+	}
+}
@@ -0,0 +1,34 @@
+package ci
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+var azureDevOpsReplacer = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", ";", "%3B", "]", "%5D")
+
+// AzureDevOps returns the output formatted as Azure DevOps logging commands setting pipeline variables, available to
+// the subsequent steps of the job.
+func (o Output) AzureDevOps() string {
+	str := new(strings.Builder)
+
+	for _, v := range o.variables() {
+		_, _ = fmt.Fprintf(str, "##vso[task.setvariable variable=%s]%s\n", azureDevOpsReplacer.Replace(v.key), azureDevOpsReplacer.Replace(v.value))
+	}
+
+	return str.String()
+}
+
+// GenerateAzureDevOpsOutput writes the Azure DevOps logging commands of the output to w.
+func GenerateAzureDevOpsOutput(w io.Writer, semver *semver.Version, branch string, options ...OptionFunc) error {
+	output := newOutput(semver, branch, options...)
+
+	if _, err := io.WriteString(w, output.AzureDevOps()); err != nil {
+		return fmt.Errorf("writing logging commands: %w", err)
+	}
+
+	return nil
+}
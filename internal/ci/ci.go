@@ -0,0 +1,138 @@
+// Package ci provides function to generate output for CI/CD tools.
+package ci
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+const (
+	ProviderAuto        = "auto"
+	ProviderGitHub      = "github"
+	ProviderTeamCity    = "teamcity"
+	ProviderAzureDevOps = "azure-devops"
+	ProviderNone        = "none"
+)
+
+var ErrUnknownProvider = errors.New("unknown CI provider")
+
+// Output holds the variables exposed to the subsequent steps of a CI pipeline.
+type Output struct {
+	Semver      *semver.Version
+	Branch      string
+	TagPrefix   string
+	ProjectName string
+	NewRelease  bool
+}
+
+type variable struct {
+	key   string
+	value string
+}
+
+// variables returns, in order, the name and value of every variable of the output.
+func (o Output) variables() []variable {
+	branch := strings.ToUpper(o.Branch)
+
+	vars := []variable{
+		{key: branch + "_SEMVER", value: o.TagPrefix + o.Semver.String()},
+		{key: branch + "_NEW_RELEASE", value: strconv.FormatBool(o.NewRelease)},
+	}
+
+	if o.ProjectName != "" {
+		vars = append(vars, variable{key: branch + "_PROJECT", value: o.ProjectName})
+	}
+
+	return vars
+}
+
+type OptionFunc func(*Output)
+
+func WithNewRelease(b bool) OptionFunc {
+	return func(o *Output) {
+		o.NewRelease = b
+	}
+}
+
+func WithTagPrefix(tagPrefix string) OptionFunc {
+	return func(o *Output) {
+		o.TagPrefix = tagPrefix
+	}
+}
+
+func WithProject(project string) OptionFunc {
+	return func(o *Output) {
+		o.ProjectName = project
+	}
+}
+
+// Providers returns the CI providers for which an output should be generated. If the given provider is "auto", the
+// providers are detected from the environment variables set by their runners.
+func Providers(provider string) ([]string, error) {
+	switch provider {
+	case ProviderGitHub, ProviderTeamCity, ProviderAzureDevOps:
+		return []string{provider}, nil
+	case ProviderNone:
+		return nil, nil
+	case ProviderAuto, "":
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, provider)
+	}
+
+	var providers []string
+
+	if _, ok := os.LookupEnv("GITHUB_OUTPUT"); ok {
+		providers = append(providers, ProviderGitHub)
+	}
+
+	if _, ok := os.LookupEnv("TEAMCITY_VERSION"); ok {
+		providers = append(providers, ProviderTeamCity)
+	}
+
+	if strings.EqualFold(os.Getenv("TF_BUILD"), "true") {
+		providers = append(providers, ProviderAzureDevOps)
+	}
+
+	return providers, nil
+}
+
+// Generate generates the output of the given CI providers. TeamCity service messages and Azure DevOps logging commands
+// are written to w, which should be the standard output read by the runner.
+func Generate(w io.Writer, providers []string, semver *semver.Version, branch string, options ...OptionFunc) error {
+	for _, provider := range providers {
+		var err error
+
+		switch provider {
+		case ProviderGitHub:
+			err = GenerateGitHubOutput(semver, branch, options...)
+		case ProviderTeamCity:
+			err = GenerateTeamCityOutput(w, semver, branch, options...)
+		case ProviderAzureDevOps:
+			err = GenerateAzureDevOpsOutput(w, semver, branch, options...)
+		default:
+			err = fmt.Errorf("%w: %q", ErrUnknownProvider, provider)
+		}
+
+		if err != nil {
+			return fmt.Errorf("generating %s output: %w", provider, err)
+		}
+	}
+
+	return nil
+}
+
+func newOutput(semver *semver.Version, branch string, options ...OptionFunc) Output {
+	output := Output{Semver: semver, Branch: branch}
+
+	for _, option := range options {
+		option(&output)
+	}
+
+	return output
+}
@@ -0,0 +1,80 @@
+package ci
+
+import (
+	"bytes"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+func TestCI_Providers(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("TEAMCITY_VERSION", "2024.03")
+	t.Setenv("TF_BUILD", "True")
+
+	providers, err := Providers(ProviderAuto)
+	checkErr(t, "detecting providers", err)
+	assert.Equal([]string{ProviderTeamCity, ProviderAzureDevOps}, providers, "providers should have been detected")
+
+	providers, err = Providers(ProviderGitHub)
+	checkErr(t, "selecting provider", err)
+	assert.Equal([]string{ProviderGitHub}, providers, "only the selected provider should be returned")
+
+	providers, err = Providers(ProviderNone)
+	checkErr(t, "disabling providers", err)
+	assert.Empty(providers, "no provider should be returned")
+
+	_, err = Providers("jenkins")
+	assert.ErrorIs(err, ErrUnknownProvider)
+}
+
+func TestCI_GenerateTeamCity(t *testing.T) {
+	assert := assertion.New(t)
+
+	buf := new(bytes.Buffer)
+	version := &semver.Version{Major: 1, Minor: 2, Patch: 3}
+
+	err := GenerateTeamCityOutput(buf, version, "main", WithNewRelease(true), WithTagPrefix("v"), WithProject("it's [foo]"))
+	checkErr(t, "generating teamcity output", err)
+
+	want := "##teamcity[setParameter name='env.MAIN_SEMVER' value='v1.2.3']\n" +
+		"##teamcity[setParameter name='env.MAIN_NEW_RELEASE' value='true']\n" +
+		"##teamcity[setParameter name='env.MAIN_PROJECT' value='it|'s |[foo|]']\n"
+
+	assert.Equal(want, buf.String(), "output should match")
+}
+
+func TestCI_GenerateAzureDevOps(t *testing.T) {
+	assert := assertion.New(t)
+
+	buf := new(bytes.Buffer)
+	version := &semver.Version{Major: 1, Minor: 2, Patch: 3}
+
+	err := GenerateAzureDevOpsOutput(buf, version, "main", WithNewRelease(false), WithTagPrefix("v"), WithProject("foo;bar"))
+	checkErr(t, "generating azure devops output", err)
+
+	want := "##vso[task.setvariable variable=MAIN_SEMVER]v1.2.3\n" +
+		"##vso[task.setvariable variable=MAIN_NEW_RELEASE]false\n" +
+		"##vso[task.setvariable variable=MAIN_PROJECT]foo%3Bbar\n"
+
+	assert.Equal(want, buf.String(), "output should match")
+}
+
+func TestCI_Generate(t *testing.T) {
+	assert := assertion.New(t)
+
+	buf := new(bytes.Buffer)
+	version := &semver.Version{Major: 1}
+
+	err := Generate(buf, []string{ProviderTeamCity, ProviderAzureDevOps}, version, "main")
+	checkErr(t, "generating output", err)
+
+	assert.Contains(buf.String(), "##teamcity[setParameter name='env.MAIN_SEMVER' value='1.0.0']")
+	assert.Contains(buf.String(), "##vso[task.setvariable variable=MAIN_SEMVER]1.0.0")
+
+	err = Generate(buf, []string{"jenkins"}, version, "main")
+	assert.ErrorIs(err, ErrUnknownProvider)
+}
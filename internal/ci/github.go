@@ -1,4 +1,3 @@
-// Package ci provides function to generate output for CI/CD tools.
 package ci
 
 import (
@@ -10,51 +9,16 @@ import (
 	"github.com/s0ders/go-semver-release/v6/internal/semver"
 )
 
-type GitHubOutput struct {
-	Semver      *semver.Version
-	Branch      string
-	TagPrefix   string
-	ProjectName string
-	NewRelease  bool
-}
-
-func (g GitHubOutput) String() string {
-	branch := strings.ToUpper(g.Branch)
-
-	versionKey := branch + "_SEMVER"
-	releaseKey := branch + "_NEW_RELEASE"
-	projectKey := branch + "_PROJECT"
-
-	str := "\n"
-
-	str += fmt.Sprintf("%s=%s\n", versionKey, g.TagPrefix+g.Semver.String())
-	str += fmt.Sprintf("%s=%t\n", releaseKey, g.NewRelease)
+// GitHub returns the output formatted as the content of a GitHub Actions output file.
+func (o Output) GitHub() string {
+	str := new(strings.Builder)
+	str.WriteString("\n")
 
-	if g.ProjectName != "" {
-		str += fmt.Sprintf("%s=%s\n", projectKey, g.ProjectName)
+	for _, v := range o.variables() {
+		_, _ = fmt.Fprintf(str, "%s=%s\n", v.key, v.value)
 	}
 
-	return str
-}
-
-type OptionFunc func(*GitHubOutput)
-
-func WithNewRelease(b bool) OptionFunc {
-	return func(o *GitHubOutput) {
-		o.NewRelease = b
-	}
-}
-
-func WithTagPrefix(tagPrefix string) OptionFunc {
-	return func(o *GitHubOutput) {
-		o.TagPrefix = tagPrefix
-	}
-}
-
-func WithProject(project string) OptionFunc {
-	return func(o *GitHubOutput) {
-		o.ProjectName = project
-	}
+	return str.String()
 }
 
 func GenerateGitHubOutput(semver *semver.Version, branch string, options ...OptionFunc) (err error) {
@@ -64,11 +28,7 @@ func GenerateGitHubOutput(semver *semver.Version, branch string, options ...Opti
 		return nil
 	}
 
-	output := &GitHubOutput{Semver: semver, Branch: branch}
-
-	for _, option := range options {
-		option(output)
-	}
+	output := newOutput(semver, branch, options...)
 
 	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
 	if err != nil {
@@ -79,7 +39,7 @@ func GenerateGitHubOutput(semver *semver.Version, branch string, options ...Opti
 		err = errors.Join(err, f.Close())
 	}()
 
-	_, err = f.WriteString(output.String())
+	_, err = f.WriteString(output.GitHub())
 	if err != nil {
 		return fmt.Errorf("writing to ci file: %w", err)
 	}
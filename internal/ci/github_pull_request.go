@@ -0,0 +1,90 @@
+package ci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	ErrNoGitHubEvent       = errors.New("GITHUB_EVENT_PATH is not set")
+	ErrNoGitHubPullRequest = errors.New("GitHub event is not a pull request event")
+)
+
+// githubEvent is the part of a GitHub Actions event payload describing a pull request.
+type githubEvent struct {
+	PullRequest *struct {
+		Title string `json:"title"`
+	} `json:"pull_request"`
+}
+
+// GitHubPullRequestTitle returns the title of the pull request that triggered the current GitHub Actions workflow,
+// read from the event payload file given by GITHUB_EVENT_PATH.
+func GitHubPullRequestTitle() (string, error) {
+	path, exists := os.LookupEnv("GITHUB_EVENT_PATH")
+	if !exists || path == "" {
+		return "", ErrNoGitHubEvent
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading event file: %w", err)
+	}
+
+	var event githubEvent
+
+	if err = json.Unmarshal(content, &event); err != nil {
+		return "", fmt.Errorf("decoding event file: %w", err)
+	}
+
+	if event.PullRequest == nil {
+		return "", ErrNoGitHubPullRequest
+	}
+
+	return event.PullRequest.Title, nil
+}
+
+// GitHubAnnotation is a GitHub Actions workflow command displaying a message on the summary of a workflow run.
+type GitHubAnnotation struct {
+	// Level is either "error", "warning" or "notice".
+	Level   string
+	Title   string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (a GitHubAnnotation) String() string {
+	var properties []string
+
+	if a.Title != "" {
+		properties = append(properties, "title="+escapeGitHubProperty(a.Title))
+	}
+
+	if a.Line > 0 {
+		properties = append(properties, fmt.Sprintf("line=%d", a.Line))
+	}
+
+	if a.Column > 0 {
+		properties = append(properties, fmt.Sprintf("col=%d", a.Column))
+	}
+
+	command := a.Level
+	if len(properties) > 0 {
+		command += " " + strings.Join(properties, ",")
+	}
+
+	return fmt.Sprintf("::%s::%s", command, escapeGitHubData(a.Message))
+}
+
+// escapeGitHubData escapes the message of a workflow command.
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeGitHubProperty escapes the value of a workflow command property.
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
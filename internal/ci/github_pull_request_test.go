@@ -0,0 +1,45 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestCI_GitHubPullRequestTitle(t *testing.T) {
+	assert := assertion.New(t)
+
+	path := filepath.Join(t.TempDir(), "event.json")
+
+	err := os.WriteFile(path, []byte(`{"action": "opened", "pull_request": {"number": 12, "title": "feat: add foo"}}`), 0o644)
+	checkErr(t, "writing event file", err)
+
+	t.Setenv("GITHUB_EVENT_PATH", path)
+
+	title, err := GitHubPullRequestTitle()
+	checkErr(t, "reading pull request title", err)
+
+	assert.Equal("feat: add foo", title)
+
+	err = os.WriteFile(path, []byte(`{"ref": "refs/heads/main"}`), 0o644)
+	checkErr(t, "writing event file", err)
+
+	_, err = GitHubPullRequestTitle()
+	assert.ErrorIs(err, ErrNoGitHubPullRequest)
+
+	t.Setenv("GITHUB_EVENT_PATH", "")
+
+	_, err = GitHubPullRequestTitle()
+	assert.ErrorIs(err, ErrNoGitHubEvent)
+}
+
+func TestCI_GitHubAnnotation(t *testing.T) {
+	assert := assertion.New(t)
+
+	annotation := GitHubAnnotation{Level: "error", Title: "Invalid title: foo, bar", Line: 1, Column: 5, Message: "100% wrong\nreally"}
+
+	assert.Equal("::error title=Invalid title%3A foo%2C bar,line=1,col=5::100%25 wrong%0Areally", annotation.String())
+	assert.Equal("::warning::foo", GitHubAnnotation{Level: "warning", Message: "foo"}.String())
+}
@@ -0,0 +1,127 @@
+package ci
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+// GitHubSummary is the Markdown summary of a release written to the GitHub Actions job summary.
+type GitHubSummary struct {
+	Semver         *semver.Version
+	PreviousSemver *semver.Version
+	Commits        []parser.Commit
+	Branch         string
+	TagPrefix      string
+	ProjectName    string
+	// TagURL is the URL of the release tag, no link is added to the summary if empty.
+	TagURL     string
+	NewRelease bool
+}
+
+func (g GitHubSummary) String() string {
+	buf := new(strings.Builder)
+
+	title := fmt.Sprintf("Release of branch `%s`", g.Branch)
+	if g.ProjectName != "" {
+		title += fmt.Sprintf(", project `%s`", g.ProjectName)
+	}
+
+	_, _ = fmt.Fprintf(buf, "### %s\n\n", title)
+
+	previous := "none"
+	if g.PreviousSemver != nil {
+		previous = fmt.Sprintf("`%s%s`", g.TagPrefix, g.PreviousSemver)
+	}
+
+	if !g.NewRelease {
+		_, _ = fmt.Fprintf(buf, "No new release, the latest version is %s.\n\n", previous)
+		return buf.String()
+	}
+
+	version := fmt.Sprintf("`%s%s`", g.TagPrefix, g.Semver)
+	if g.TagURL != "" {
+		version = fmt.Sprintf("[%s](%s)", version, g.TagURL)
+	}
+
+	buf.WriteString("| Previous version | New version |\n")
+	buf.WriteString("| ---------------- | ----------- |\n")
+	_, _ = fmt.Fprintf(buf, "| %s | %s |\n\n", previous, version)
+
+	counts := make(map[string]int)
+	breaking := 0
+
+	for _, commit := range g.Commits {
+		counts[commit.Type]++
+
+		if commit.Breaking {
+			breaking++
+		}
+	}
+
+	if len(counts) > 0 {
+		commitTypes := make([]string, 0, len(counts))
+		for commitType := range counts {
+			commitTypes = append(commitTypes, commitType)
+		}
+
+		sort.Strings(commitTypes)
+
+		buf.WriteString("| Commit type | Count |\n")
+		buf.WriteString("| ----------- | ----- |\n")
+
+		for _, commitType := range commitTypes {
+			_, _ = fmt.Fprintf(buf, "| `%s` | %d |\n", commitType, counts[commitType])
+		}
+
+		buf.WriteString("\n")
+	}
+
+	if breaking > 0 {
+		_, _ = fmt.Fprintf(buf, "This release contains %d breaking change(s).\n\n", breaking)
+	}
+
+	return buf.String()
+}
+
+// GitHubTagURL returns the URL of a given tag on GitHub based on the environment variables set by GitHub Actions, or an
+// empty string if they are not set.
+func GitHubTagURL(tagName string) string {
+	serverURL, repository := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY")
+
+	if serverURL == "" || repository == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s/releases/tag/%s", strings.TrimSuffix(serverURL, "/"), repository, tagName)
+}
+
+// GenerateGitHubSummary appends the given summary to the GitHub Actions job summary file, if any.
+func GenerateGitHubSummary(summary GitHubSummary) (err error) {
+	path, exists := os.LookupEnv("GITHUB_STEP_SUMMARY")
+
+	if !exists {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening summary file: %w", err)
+	}
+
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	_, err = f.WriteString(summary.String())
+	if err != nil {
+		return fmt.Errorf("writing to summary file: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,75 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+func TestCI_GenerateGitHubSummary(t *testing.T) {
+	assert := assertion.New(t)
+
+	summaryPath := filepath.Join(t.TempDir(), "summary.md")
+
+	t.Setenv("GITHUB_STEP_SUMMARY", summaryPath)
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "foo/bar")
+
+	summary := GitHubSummary{
+		Semver:         &semver.Version{Major: 2},
+		PreviousSemver: &semver.Version{Major: 1, Minor: 2, Patch: 3},
+		Commits: []parser.Commit{
+			{Type: "feat", Breaking: true},
+			{Type: "fix"},
+			{Type: "feat"},
+		},
+		Branch:      "main",
+		TagPrefix:   "v",
+		ProjectName: "foo",
+		TagURL:      GitHubTagURL("foo-v2.0.0"),
+		NewRelease:  true,
+	}
+
+	err := GenerateGitHubSummary(summary)
+	checkErr(t, "generating summary", err)
+
+	err = GenerateGitHubSummary(GitHubSummary{PreviousSemver: &semver.Version{Major: 1}, Branch: "rc", TagPrefix: "v"})
+	checkErr(t, "generating summary", err)
+
+	got, err := os.ReadFile(summaryPath)
+	checkErr(t, "reading summary file", err)
+
+	want := "### Release of branch `main`, project `foo`\n\n" +
+		"| Previous version | New version |\n" +
+		"| ---------------- | ----------- |\n" +
+		"| `v1.2.3` | [`v2.0.0`](https://github.com/foo/bar/releases/tag/foo-v2.0.0) |\n\n" +
+		"| Commit type | Count |\n" +
+		"| ----------- | ----- |\n" +
+		"| `feat` | 2 |\n" +
+		"| `fix` | 1 |\n\n" +
+		"This release contains 1 breaking change(s).\n\n" +
+		"### Release of branch `rc`\n\n" +
+		"No new release, the latest version is `v1.0.0`.\n\n"
+
+	assert.Equal(want, string(got), "summary should match")
+}
+
+func TestCI_GenerateGitHubSummary_NoEnvVar(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	err := os.Unsetenv("GITHUB_STEP_SUMMARY")
+	checkErr(t, "unsetting GITHUB_STEP_SUMMARY", err)
+
+	err = GenerateGitHubSummary(GitHubSummary{Semver: &semver.Version{}, Branch: "main"})
+	assert.NoError(err, "should not have tried to generate a summary")
+
+	t.Setenv("GITHUB_REPOSITORY", "")
+
+	assert.Equal("", GitHubTagURL("v1.0.0"), "tag URL should be empty outside of GitHub Actions")
+}
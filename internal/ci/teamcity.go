@@ -0,0 +1,34 @@
+package ci
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+var teamCityReplacer = strings.NewReplacer("|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]")
+
+// TeamCity returns the output formatted as TeamCity service messages setting build parameters. Parameters are
+// prefixed with "env." so that they are also exposed as environment variables to the subsequent build steps.
+func (o Output) TeamCity() string {
+	str := new(strings.Builder)
+
+	for _, v := range o.variables() {
+		_, _ = fmt.Fprintf(str, "##teamcity[setParameter name='env.%s' value='%s']\n", teamCityReplacer.Replace(v.key), teamCityReplacer.Replace(v.value))
+	}
+
+	return str.String()
+}
+
+// GenerateTeamCityOutput writes the TeamCity service messages of the output to w.
+func GenerateTeamCityOutput(w io.Writer, semver *semver.Version, branch string, options ...OptionFunc) error {
+	output := newOutput(semver, branch, options...)
+
+	if _, err := io.WriteString(w, output.TeamCity()); err != nil {
+		return fmt.Errorf("writing service messages: %w", err)
+	}
+
+	return nil
+}
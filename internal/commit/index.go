@@ -0,0 +1,125 @@
+package commit
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	commitgraphfmt "github.com/go-git/go-git/v5/plumbing/format/commitgraph/v2"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/object/commitgraph"
+)
+
+// Index loads the commits of a repository and keeps them in memory, so that commits walked several times (e.g., once
+// per release branch or project) are only read once. The parents and dates of the commits are read from the
+// commit-graph file of the repository when there is one, commits are then only decoded if they are returned by a
+// walker, not when they are only traversed.
+type Index struct {
+	nodes   commitgraph.CommitNodeIndex
+	graph   commitgraphfmt.Index
+	cache   map[plumbing.Hash]commitgraph.CommitNode
+	commits map[plumbing.Hash]*object.Commit
+	mu      sync.Mutex
+}
+
+// NewIndex returns an Index of the commits of the given repository, using its commit-graph file if any.
+func NewIndex(repository *git.Repository) *Index {
+	graph := openCommitGraph(repository)
+
+	return &Index{
+		nodes:   commitgraph.NewGraphCommitNodeIndex(graph, repository.Storer),
+		graph:   graph,
+		cache:   make(map[plumbing.Hash]commitgraph.CommitNode),
+		commits: make(map[plumbing.Hash]*object.Commit),
+	}
+}
+
+// newObjectIndex returns an Index reading the commits from the object storage of the given repository only.
+func newObjectIndex(repository *git.Repository) *Index {
+	return &Index{
+		nodes:   commitgraph.NewObjectCommitNodeIndex(repository.Storer),
+		cache:   make(map[plumbing.Hash]commitgraph.CommitNode),
+		commits: make(map[plumbing.Hash]*object.Commit),
+	}
+}
+
+// HasCommitGraph returns true if the index reads the commits from a commit-graph file.
+func (i *Index) HasCommitGraph() bool {
+	return i.graph != nil
+}
+
+// Close releases the commit-graph file, if any.
+func (i *Index) Close() error {
+	if i.graph == nil {
+		return nil
+	}
+
+	return i.graph.Close()
+}
+
+// Commit returns the commit of the given hash.
+func (i *Index) Commit(hash plumbing.Hash) (*object.Commit, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if c, ok := i.commits[hash]; ok {
+		return c, nil
+	}
+
+	n, err := i.node(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := n.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit %q: %w", hash, err)
+	}
+
+	i.commits[hash] = c
+
+	return c, nil
+}
+
+// Node returns the node of the given commit, which gives its parents and date without decoding it when read from the
+// commit-graph file.
+func (i *Index) Node(hash plumbing.Hash) (commitgraph.CommitNode, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.node(hash)
+}
+
+func (i *Index) node(hash plumbing.Hash) (commitgraph.CommitNode, error) {
+	if n, ok := i.cache[hash]; ok {
+		return n, nil
+	}
+
+	n, err := i.nodes.Get(hash)
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit %q: %w", hash, err)
+	}
+
+	i.cache[hash] = n
+
+	return n, nil
+}
+
+// openCommitGraph opens the commit-graph file, or chain of files, of the given repository. Nil is returned if the
+// repository has none, which is the case of repositories cloned by go-git, or if it cannot be read since commits can
+// always be read from the object storage instead.
+func openCommitGraph(repository *git.Repository) commitgraphfmt.Index {
+	storage, ok := repository.Storer.(interface{ Filesystem() billy.Filesystem })
+	if !ok || storage.Filesystem() == nil {
+		return nil
+	}
+
+	graph, err := commitgraphfmt.OpenChainOrFileIndex(storage.Filesystem())
+	if err != nil {
+		return nil
+	}
+
+	return graph
+}
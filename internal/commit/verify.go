@@ -0,0 +1,74 @@
+package commit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gpg"
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
+)
+
+const sshSignaturePrefix = "-----BEGIN SSH SIGNATURE-----"
+
+var (
+	ErrUnsignedCommit   = errors.New("commit is not signed")
+	ErrInvalidSignature = errors.New("commit signature is invalid")
+)
+
+// Verifier checks the GPG or SSH signature of commits against a set of trusted public keys.
+type Verifier struct {
+	KeyRing openpgp.EntityList
+	SSHKeys []cryptossh.PublicKey
+}
+
+// Verify checks that a given commit is signed by one of the trusted keys of the Verifier. ErrUnsignedCommit is returned
+// if the commit has no signature and ErrInvalidSignature if the signature cannot be verified.
+func (v *Verifier) Verify(commit *object.Commit) error {
+	if commit.PGPSignature == "" {
+		return ErrUnsignedCommit
+	}
+
+	unsigned := &plumbing.MemoryObject{}
+	if err := commit.EncodeWithoutSignature(unsigned); err != nil {
+		return fmt.Errorf("encoding commit: %w", err)
+	}
+
+	reader, err := unsigned.Reader()
+	if err != nil {
+		return fmt.Errorf("reading encoded commit: %w", err)
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("reading encoded commit: %w", err)
+	}
+
+	if strings.HasPrefix(commit.PGPSignature, sshSignaturePrefix) {
+		if len(v.SSHKeys) == 0 {
+			return fmt.Errorf("%w: no allowed SSH keys to verify SSH signature", ErrInvalidSignature)
+		}
+
+		if _, err = ssh.VerifyAny(v.SSHKeys, content, commit.PGPSignature); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+		}
+
+		return nil
+	}
+
+	if len(v.KeyRing) == 0 {
+		return fmt.Errorf("%w: no GPG keyring to verify GPG signature", ErrInvalidSignature)
+	}
+
+	if _, err = gpg.Verify(v.KeyRing, content, commit.PGPSignature); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return nil
+}
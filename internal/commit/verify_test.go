@@ -0,0 +1,114 @@
+package commit
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	assert := assertion.New(t)
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, "creating openpgp entity", err)
+
+	otherEntity, err := openpgp.NewEntity("Jane Doe", "", "jane.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, "creating openpgp entity", err)
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, "generating ed25519 key", err)
+
+	block, err := cryptossh.MarshalPrivateKey(privateKey, "")
+	checkErr(t, "marshalling private key", err)
+
+	sshSigner, err := ssh.FromPEM(bytes.NewReader(pem.EncodeToMemory(block)))
+	checkErr(t, "loading private key", err)
+
+	gpgSign := func(entity *openpgp.Entity) func([]byte) (string, error) {
+		return func(content []byte) (string, error) {
+			signature := new(bytes.Buffer)
+			err := openpgp.ArmoredDetachSign(signature, entity, bytes.NewReader(content), nil)
+			return signature.String(), err
+		}
+	}
+
+	verifier := &Verifier{
+		KeyRing: openpgp.EntityList{entity},
+		SSHKeys: []cryptossh.PublicKey{sshSigner.PublicKey()},
+	}
+
+	tests := map[string]struct {
+		sign func([]byte) (string, error)
+		want error
+	}{
+		"unsigned":         {sign: nil, want: ErrUnsignedCommit},
+		"trusted GPG key":  {sign: gpgSign(entity), want: nil},
+		"unknown GPG key":  {sign: gpgSign(otherEntity), want: ErrInvalidSignature},
+		"trusted SSH key":  {sign: sshSigner.Sign, want: nil},
+		"tampered content": {sign: func([]byte) (string, error) { return sshSigner.Sign([]byte("foo")) }, want: ErrInvalidSignature},
+	}
+
+	for name, tc := range tests {
+		commit := newSignedCommit(t, tc.sign)
+
+		err = verifier.Verify(commit)
+		if tc.want == nil {
+			assert.NoError(err, name)
+		} else {
+			assert.ErrorIs(err, tc.want, name)
+		}
+	}
+
+	err = (&Verifier{}).Verify(newSignedCommit(t, sshSigner.Sign))
+	assert.ErrorIs(err, ErrInvalidSignature, "SSH signature should not be verified without allowed keys")
+
+	err = (&Verifier{}).Verify(newSignedCommit(t, gpgSign(entity)))
+	assert.ErrorIs(err, ErrInvalidSignature, "GPG signature should not be verified without keyring")
+}
+
+// newSignedCommit returns a commit whose encoding, without signature, is signed using the given function, unsigned if
+// the function is nil.
+func newSignedCommit(t *testing.T, sign func([]byte) (string, error)) *object.Commit {
+	t.Helper()
+
+	signature := object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: time.Unix(1700000000, 0).UTC()}
+
+	commit := &object.Commit{
+		Author:    signature,
+		Committer: signature,
+		Message:   "feat: add foo\n",
+		TreeHash:  plumbing.NewHash("4b825dc642cb6eb9a060e54bf8d69288fbee4904"),
+	}
+
+	if sign == nil {
+		return commit
+	}
+
+	unsigned := &plumbing.MemoryObject{}
+	err := commit.EncodeWithoutSignature(unsigned)
+	checkErr(t, "encoding commit", err)
+
+	reader, err := unsigned.Reader()
+	checkErr(t, "reading encoded commit", err)
+
+	content, err := io.ReadAll(reader)
+	checkErr(t, "reading encoded commit", err)
+
+	commit.PGPSignature, err = sign(content)
+	checkErr(t, "signing commit", err)
+
+	return commit
+}
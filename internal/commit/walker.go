@@ -0,0 +1,272 @@
+// Package commit provides functions to walk through a Git repository commit history.
+package commit
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/object/commitgraph"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// ErrShallowHistory is returned when the history of a shallow repository does not contain every commit needed by the
+// walk, the repository must be deepened for the walk to complete.
+var ErrShallowHistory = errors.New("commit history is shallow")
+
+type mark uint8
+
+const (
+	seen mark = 1 << iota
+	popped
+	uninteresting
+)
+
+type OptionFunc func(w *Walker)
+
+// WithStopAt stops the traversal at the given commits, neither them nor their ancestors will be returned by the
+// walker.
+func WithStopAt(hashes ...plumbing.Hash) OptionFunc {
+	return func(w *Walker) {
+		w.stopAt = append(w.stopAt, hashes...)
+	}
+}
+
+// WithStopWhen stops the traversal at the commits for which the given function returns true, neither them nor their
+// ancestors will be returned by the walker, as if they were given to WithStopAt. The function is called with the hash of
+// every commit about to be returned, before any of its parents is read.
+func WithStopWhen(stop func(hash plumbing.Hash) (bool, error)) OptionFunc {
+	return func(w *Walker) {
+		w.stopWhen = stop
+	}
+}
+
+// WithFirstParentOnly only follows the first parent of merge commits, the commits of merged branches are not returned
+// by the walker.
+func WithFirstParentOnly() OptionFunc {
+	return func(w *Walker) {
+		w.firstParentOnly = true
+	}
+}
+
+// WithIndex reads the commits from the given index, which can be shared by several walkers of the same repository. By
+// default, the commits are read from the object storage of the repository.
+func WithIndex(index *Index) OptionFunc {
+	return func(w *Walker) {
+		w.index = index
+	}
+}
+
+// Walker iterates over the commits reachable from a given commit, from the most recent to the oldest according to
+// their committer date. Reached commits are marked so that every commit is read, queued and returned at most once,
+// however many paths lead to it (e.g., in criss-cross merge histories), which keeps the walk linear in the number of
+// commits on merge-heavy histories. Commits are identified by their hash, distinct commits sharing the same content
+// (e.g., cherry-picked copies) are all returned.
+//
+// Like "git rev-list" without "--topo-order", the order only relies on the committer dates: when clocks are skewed, a
+// commit dated after one of its children can be returned before that child.
+type Walker struct {
+	index           *Index
+	queue           commitQueue
+	marks           map[plumbing.Hash]mark
+	stopAt          []plumbing.Hash
+	stopWhen        func(hash plumbing.Hash) (bool, error)
+	interesting     int
+	firstParentOnly bool
+	shallow         bool
+}
+
+// NewWalker returns a Walker iterating over the commits reachable from the given commit hash.
+func NewWalker(repository *git.Repository, from plumbing.Hash, options ...OptionFunc) (*Walker, error) {
+	w := &Walker{
+		marks: make(map[plumbing.Hash]mark),
+	}
+
+	for _, option := range options {
+		option(w)
+	}
+
+	if w.index == nil {
+		w.index = newObjectIndex(repository)
+	}
+
+	shallows, err := repository.Storer.Shallow()
+	if err != nil {
+		return nil, fmt.Errorf("fetching shallow commits: %w", err)
+	}
+
+	w.shallow = len(shallows) > 0
+
+	if err := w.push(from, 0); err != nil {
+		return nil, err
+	}
+
+	for _, hash := range w.stopAt {
+		if err := w.markUninteresting(hash); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// Next returns the next commit of the history, io.EOF is returned once every commit has been walked. The error of the
+// given context is returned if it is done before the walk is over.
+func (w *Walker) Next(ctx context.Context) (*object.Commit, error) {
+	for w.interesting > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		n := heap.Pop(&w.queue).(commitgraph.CommitNode)
+		hash := n.ID()
+
+		m := w.marks[hash] | popped
+		w.marks[hash] = m
+
+		if m&uninteresting != 0 {
+			for _, parent := range n.ParentHashes() {
+				if err := w.markUninteresting(parent); err != nil {
+					return nil, err
+				}
+			}
+
+			continue
+		}
+
+		w.interesting--
+
+		if w.stopWhen != nil {
+			stop, err := w.stopWhen(hash)
+			if err != nil {
+				return nil, err
+			}
+
+			if stop {
+				w.marks[hash] = m | uninteresting
+
+				// The ancestors are only read to be excluded from the rest of the walk, if there is one
+				if w.interesting > 0 {
+					for _, parent := range n.ParentHashes() {
+						if err := w.markUninteresting(parent); err != nil {
+							return nil, err
+						}
+					}
+				}
+
+				continue
+			}
+		}
+
+		// Only the returned commits are decoded, traversed commits are only read from the index
+		c, err := w.index.Commit(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		parents := n.ParentHashes()
+		if w.firstParentOnly && len(parents) > 1 {
+			parents = parents[:1]
+		}
+
+		for _, parent := range parents {
+			if err := w.push(parent, 0); err != nil {
+				return nil, err
+			}
+		}
+
+		return c, nil
+	}
+
+	return nil, io.EOF
+}
+
+// ForEach calls the given function for every commit of the history. The iteration stops if the function returns an
+// error, storer.ErrStop stops the iteration without returning an error.
+func (w *Walker) ForEach(ctx context.Context, cb func(*object.Commit) error) error {
+	for {
+		c, err := w.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = cb(c); err != nil {
+			if errors.Is(err, storer.ErrStop) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// push adds a commit that has not been seen yet to the queue.
+func (w *Walker) push(hash plumbing.Hash, m mark) error {
+	if w.marks[hash]&seen != 0 {
+		return nil
+	}
+
+	n, err := w.index.Node(hash)
+	if w.shallow && errors.Is(err, plumbing.ErrObjectNotFound) {
+		return fmt.Errorf("%w: commit %q is missing", ErrShallowHistory, hash)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.marks[hash] = m | seen
+
+	if m&uninteresting == 0 {
+		w.interesting++
+	}
+
+	heap.Push(&w.queue, n)
+
+	return nil
+}
+
+// markUninteresting marks a commit, and by propagation its ancestors, as reachable from a commit at which the
+// traversal stops.
+func (w *Walker) markUninteresting(hash plumbing.Hash) error {
+	m, ok := w.marks[hash]
+	if !ok {
+		return w.push(hash, uninteresting)
+	}
+
+	if m&(uninteresting|popped) != 0 {
+		return nil
+	}
+
+	w.marks[hash] = m | uninteresting
+	w.interesting--
+
+	return nil
+}
+
+// commitQueue is a priority queue of commits ordered from the most recent to the oldest committer date.
+type commitQueue []commitgraph.CommitNode
+
+func (q commitQueue) Len() int { return len(q) }
+
+func (q commitQueue) Less(i, j int) bool { return q[i].CommitTime().After(q[j].CommitTime()) }
+
+func (q commitQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *commitQueue) Push(x any) { *q = append(*q, x.(commitgraph.CommitNode)) }
+
+func (q *commitQueue) Pop() any {
+	old := *q
+	n := len(old)
+	c := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+
+	return c
+}
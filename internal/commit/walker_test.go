@@ -0,0 +1,555 @@
+package commit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	commitgraph "github.com/go-git/go-git/v5/plumbing/format/commitgraph/v2"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+)
+
+func TestWalker_ForEach(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	first, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+	second, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	history := walk(t, testRepository, second)
+
+	assert.Len(history, 3, "history should contain every commit")
+	assert.Equal([]plumbing.Hash{second, first}, history[:2], "history should be ordered from most recent to oldest")
+}
+
+func TestWalker_StopAt(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	stop, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+	first, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+	second, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	history := walk(t, testRepository, second, WithStopAt(stop))
+
+	assert.Equal([]plumbing.Hash{second, first}, history, "history should stop at the given commit")
+}
+
+func TestWalker_StopAtStart(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+	stop, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	history := walk(t, testRepository, stop, WithStopAt(stop))
+
+	assert.Empty(history, "history should be empty when starting at the commit to stop at")
+}
+
+func TestWalker_StopAtWithMerge(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	// Feature branch forked before the stop commit and merged after it
+	err = testRepository.CheckoutBranch("feature")
+	checkErr(t, "creating feature branch", err)
+
+	featureCommit, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.Checkout("master")
+	checkErr(t, "checking out master", err)
+
+	stop, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+	masterCommit, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	merge, err := testRepository.Merge("feature")
+	checkErr(t, "merging feature branch", err)
+
+	history := walk(t, testRepository, merge, WithStopAt(stop))
+
+	assert.Equal([]plumbing.Hash{merge, masterCommit, featureCommit}, history, "history should only contain commits unreachable from the stop commit")
+}
+
+func TestWalker_StopWhen(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	// The fork point of the feature branch is an ancestor of the stop commit, reachable through the merge as well
+	err = testRepository.CheckoutBranch("feature")
+	checkErr(t, "creating feature branch", err)
+
+	featureCommit, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.Checkout("master")
+	checkErr(t, "checking out master", err)
+
+	stop, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+	masterCommit, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	merge, err := testRepository.Merge("feature")
+	checkErr(t, "merging feature branch", err)
+
+	var checked []plumbing.Hash
+
+	history := walk(t, testRepository, merge, WithStopWhen(func(hash plumbing.Hash) (bool, error) {
+		checked = append(checked, hash)
+		return hash == stop, nil
+	}))
+
+	assert.Equal([]plumbing.Hash{merge, masterCommit, featureCommit}, history, "history should only contain commits unreachable from the stop commit")
+	assert.Equal([]plumbing.Hash{merge, masterCommit, stop, featureCommit}, checked, "only the walked commits should be checked")
+
+	errLookup := errors.New("lookup failed")
+
+	walker, err := NewWalker(testRepository.Repository, merge, WithStopWhen(func(plumbing.Hash) (bool, error) {
+		return false, errLookup
+	}))
+	checkErr(t, "creating walker", err)
+
+	err = walker.ForEach(context.Background(), func(*object.Commit) error { return nil })
+	assert.ErrorIs(err, errLookup, "the error of the stop function should be returned")
+}
+
+func TestWalker_MergeHeavy(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	var head plumbing.Hash
+
+	// Every feature branch forks from the previous merge and is merged twice, so that most commits are reachable from
+	// several merge commits
+	for i := range 5 {
+		branchName := fmt.Sprintf("feature-%d", i)
+
+		err = testRepository.CheckoutBranch(branchName)
+		checkErr(t, "creating feature branch", err)
+
+		_, err = testRepository.AddCommit("feat")
+		checkErr(t, "adding commit", err)
+
+		err = testRepository.Checkout("master")
+		checkErr(t, "checking out master", err)
+
+		_, err = testRepository.AddCommit("fix")
+		checkErr(t, "adding commit", err)
+
+		_, err = testRepository.Merge(branchName)
+		checkErr(t, "merging feature branch", err)
+
+		head, err = testRepository.Merge(branchName)
+		checkErr(t, "merging feature branch", err)
+	}
+
+	walker, err := NewWalker(testRepository.Repository, head)
+	checkErr(t, "creating walker", err)
+
+	var history []*object.Commit
+
+	err = walker.ForEach(context.Background(), func(c *object.Commit) error {
+		history = append(history, c)
+		return nil
+	})
+	checkErr(t, "walking history", err)
+
+	// Initial commit plus, per feature branch, a feature commit, a fix commit and two merge commits
+	assert.Len(history, 1+5*4, "every commit should be returned once")
+
+	seen := make(map[plumbing.Hash]bool)
+
+	for i, c := range history {
+		assert.False(seen[c.Hash], "commit %q should only be returned once", c.Hash)
+		seen[c.Hash] = true
+
+		if i > 0 {
+			assert.False(c.Committer.When.After(history[i-1].Committer.When), "history should be ordered from most recent to oldest")
+		}
+	}
+}
+
+func TestWalker_CrissCross(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	base := head.Hash()
+
+	err = testRepository.CheckoutBranch("feature")
+	checkErr(t, "creating feature branch", err)
+
+	err = testRepository.Checkout("master")
+	checkErr(t, "checking out master", err)
+
+	masterCommit, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.CheckoutBranch("master-snapshot")
+	checkErr(t, "creating master snapshot branch", err)
+
+	err = testRepository.Checkout("feature")
+	checkErr(t, "checking out feature", err)
+
+	featureCommit, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	// A copy sharing the author, author date and message of the feature commit is a distinct commit
+	featureCopy, err := testRepository.CherryPick(featureCommit)
+	checkErr(t, "cherry-picking commit", err)
+
+	err = testRepository.CheckoutBranch("feature-snapshot")
+	checkErr(t, "creating feature snapshot branch", err)
+
+	// Criss-cross merge: both branches merge each other, then the feature branch is merged back into master
+	err = testRepository.Checkout("master")
+	checkErr(t, "checking out master", err)
+
+	masterMerge, err := testRepository.Merge("feature-snapshot")
+	checkErr(t, "merging feature branch", err)
+
+	err = testRepository.Checkout("feature")
+	checkErr(t, "checking out feature", err)
+
+	featureMerge, err := testRepository.Merge("master-snapshot")
+	checkErr(t, "merging master branch", err)
+
+	err = testRepository.Checkout("master")
+	checkErr(t, "checking out master", err)
+
+	merge, err := testRepository.Merge("feature")
+	checkErr(t, "merging feature branch", err)
+
+	want := []plumbing.Hash{merge, featureMerge, masterMerge, featureCopy, featureCommit, masterCommit, base}
+
+	history := walk(t, testRepository, merge)
+
+	assert.ElementsMatch(want, history, "every commit should be returned exactly once")
+}
+
+func TestWalker_FirstParentOnly(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.CheckoutBranch("feature")
+	checkErr(t, "creating feature branch", err)
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.Checkout("master")
+	checkErr(t, "checking out master", err)
+
+	masterCommit, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	merge, err := testRepository.Merge("feature")
+	checkErr(t, "merging feature branch", err)
+
+	history := walk(t, testRepository, merge, WithFirstParentOnly())
+
+	assert.Equal([]plumbing.Hash{merge, masterCommit, head.Hash()}, history, "history should not contain merged branch commits")
+}
+
+func TestWalker_Cancelled(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	walker, err := NewWalker(testRepository.Repository, head.Hash())
+	checkErr(t, "creating walker", err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = walker.ForEach(ctx, func(c *object.Commit) error {
+		return nil
+	})
+	assert.ErrorIs(err, context.Canceled, "walk should have been cancelled")
+}
+
+func TestWalker_CommitGraph(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	err = testRepository.CheckoutBranch("feature")
+	checkErr(t, "creating feature branch", err)
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.Checkout("master")
+	checkErr(t, "checking out master", err)
+
+	stop, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	merge, err := testRepository.Merge("feature")
+	checkErr(t, "merging feature branch", err)
+
+	// Added after the commit-graph file is written, hence read from the object storage
+	head, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	want := walk(t, testRepository, head, WithStopAt(stop))
+
+	writeCommitGraph(t, testRepository, merge)
+
+	index := NewIndex(testRepository.Repository)
+
+	t.Cleanup(func() {
+		_ = index.Close()
+	})
+
+	assert.True(index.HasCommitGraph(), "commit-graph file should have been found")
+
+	got := walk(t, testRepository, head, WithStopAt(stop), WithIndex(index))
+
+	assert.Equal(want, got, "history read from the commit-graph should match the history read from the objects")
+}
+
+func BenchmarkWalker(b *testing.B) {
+	const (
+		size = 1000
+		// A walk per release branch or project
+		walks = 10
+	)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(b, "creating repository", err)
+
+	b.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	var head plumbing.Hash
+
+	for range size {
+		head, err = testRepository.AddCommit("fix")
+		checkErr(b, "adding commit", err)
+	}
+
+	walkAll := func(b *testing.B, index func() *Index) {
+		for range b.N {
+			shared := index()
+
+			for range walks {
+				var options []OptionFunc
+				if shared != nil {
+					options = append(options, WithIndex(shared))
+				}
+
+				walker, err := NewWalker(testRepository.Repository, head, options...)
+				checkErr(b, "creating walker", err)
+
+				err = walker.ForEach(context.Background(), func(c *object.Commit) error {
+					return nil
+				})
+				checkErr(b, "walking history", err)
+			}
+
+			if shared != nil {
+				_ = shared.Close()
+			}
+		}
+	}
+
+	b.Run("objects", func(b *testing.B) {
+		walkAll(b, func() *Index { return nil })
+	})
+
+	b.Run("index", func(b *testing.B) {
+		walkAll(b, func() *Index { return NewIndex(testRepository.Repository) })
+	})
+
+	writeCommitGraph(b, testRepository, head)
+
+	b.Run("commit-graph", func(b *testing.B) {
+		walkAll(b, func() *Index { return NewIndex(testRepository.Repository) })
+	})
+}
+
+func BenchmarkWalker_Generated(b *testing.B) {
+	sizes := []gittest.GenerateOptions{
+		{Commits: 1000, Branches: 10, Merges: 5, Tags: 10},
+		{Commits: 10000, Branches: 50, Merges: 25, Tags: 100},
+	}
+
+	for _, opts := range sizes {
+		b.Run(fmt.Sprintf("%d-commits", opts.Commits), func(b *testing.B) {
+			testRepository, err := gittest.Generate(opts)
+			checkErr(b, "generating repository", err)
+
+			b.Cleanup(func() {
+				_ = testRepository.Remove()
+			})
+
+			head, err := testRepository.Head()
+			checkErr(b, "fetching head", err)
+
+			b.ResetTimer()
+
+			for range b.N {
+				walker, err := NewWalker(testRepository.Repository, head.Hash())
+				checkErr(b, "creating walker", err)
+
+				err = walker.ForEach(context.Background(), func(c *object.Commit) error {
+					return nil
+				})
+				checkErr(b, "walking history", err)
+			}
+		})
+	}
+}
+
+// writeCommitGraph writes the commit-graph file of the commits reachable from the given commit, as "git commit-graph
+// write" would.
+func writeCommitGraph(t testing.TB, testRepository *gittest.TestRepository, from plumbing.Hash) {
+	t.Helper()
+
+	walker, err := NewWalker(testRepository.Repository, from)
+	checkErr(t, "creating walker", err)
+
+	graph := commitgraph.NewMemoryIndex()
+
+	err = walker.ForEach(context.Background(), func(c *object.Commit) error {
+		graph.Add(c.Hash, &commitgraph.CommitData{
+			TreeHash:     c.TreeHash,
+			ParentHashes: c.ParentHashes,
+			When:         c.Committer.When,
+		})
+		return nil
+	})
+	checkErr(t, "walking history", err)
+
+	storage := testRepository.Storer.(*filesystem.Storage)
+
+	file, err := storage.Filesystem().Create(filepath.Join("objects", "info", "commit-graph"))
+	checkErr(t, "creating commit-graph file", err)
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	err = commitgraph.NewEncoder(file).Encode(graph)
+	checkErr(t, "encoding commit-graph", err)
+}
+
+func walk(t *testing.T, testRepository *gittest.TestRepository, from plumbing.Hash, options ...OptionFunc) []plumbing.Hash {
+	t.Helper()
+
+	walker, err := NewWalker(testRepository.Repository, from, options...)
+	checkErr(t, "creating walker", err)
+
+	var history []plumbing.Hash
+
+	err = walker.ForEach(context.Background(), func(c *object.Commit) error {
+		history = append(history, c.Hash)
+		return nil
+	})
+	checkErr(t, "walking history", err)
+
+	return history
+}
+
+func checkErr(t testing.TB, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,259 @@
+// Package forge builds the URLs of the web pages of repositories hosted on the common Git forges.
+package forge
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// Provider is a Git forge.
+type Provider string
+
+const (
+	GitHub    Provider = "github"
+	GitLab    Provider = "gitlab"
+	Gitea     Provider = "gitea"
+	Bitbucket Provider = "bitbucket"
+)
+
+var ErrInvalidTemplate = errors.New("invalid URL template")
+
+// hostProviders maps substrings of host names to the forge they most likely run.
+var hostProviders = []struct {
+	substring string
+	provider  Provider
+}{
+	{substring: "github", provider: GitHub},
+	{substring: "gitlab", provider: GitLab},
+	{substring: "bitbucket", provider: Bitbucket},
+	{substring: "gitea", provider: Gitea},
+	{substring: "forgejo", provider: Gitea},
+	{substring: "codeberg", provider: Gitea},
+}
+
+// Repository is a repository hosted on a Git forge. The URLs of its pages are built from the custom templates of the
+// repository, if any, or else from the conventions of its forge. They are empty if the forge is unknown and there is
+// no template.
+type Repository struct {
+	compareTemplate     *template.Template
+	commitTemplate      *template.Template
+	pullRequestTemplate *template.Template
+	issueTemplate       *template.Template
+	Provider            Provider
+	// URL is the URL of the home page of the repository (e.g., "https://github.com/owner/name").
+	URL string
+}
+
+// Templates are custom Go templates of the URLs of the pages of a repository, for self-hosted forges. They are given
+// the URL of the repository as {{.URL}}, and respectively the hash of the commit as {{.Hash}}, the compared revisions
+// as {{.From}} and {{.To}}, the number of the pull request as {{.Number}}, and the reference to the issue as
+// {{.Reference}} (e.g., "#12" or "JIRA-456") along with its number, if any, as {{.Number}}.
+type Templates struct {
+	Commit      string
+	Compare     string
+	PullRequest string
+	Issue       string
+}
+
+// templateData is given to the templates of the URLs.
+type templateData struct {
+	URL       string
+	Hash      string
+	From      string
+	To        string
+	Number    string
+	Reference string
+}
+
+// WithTemplates returns a copy of the repository whose URLs are built from the given templates, the empty ones being
+// ignored.
+func (r Repository) WithTemplates(templates Templates) (Repository, error) {
+	for _, t := range []struct {
+		name   string
+		text   string
+		parsed **template.Template
+	}{
+		{name: "commit", text: templates.Commit, parsed: &r.commitTemplate},
+		{name: "compare", text: templates.Compare, parsed: &r.compareTemplate},
+		{name: "pull request", text: templates.PullRequest, parsed: &r.pullRequestTemplate},
+		{name: "issue", text: templates.Issue, parsed: &r.issueTemplate},
+	} {
+		if t.text == "" {
+			continue
+		}
+
+		parsed, err := template.New(t.name).Option("missingkey=error").Parse(t.text)
+		if err != nil {
+			return r, fmt.Errorf("%w: %s: %w", ErrInvalidTemplate, t.name, err)
+		}
+
+		*t.parsed = parsed
+	}
+
+	return r, nil
+}
+
+// execute returns the URL built from the given template, empty if it fails.
+func (r Repository) execute(t *template.Template, data templateData) string {
+	data.URL = r.URL
+
+	buf := new(strings.Builder)
+	if err := t.Execute(buf, data); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+// Detect returns the repository of the given remote URL, given as an HTTP or SSH URL, and false if the remote is not
+// hosted on a recognized forge.
+func Detect(remoteURL string) (Repository, bool) {
+	endpoint, err := transport.NewEndpoint(remoteURL)
+	if err != nil || endpoint.Protocol == "file" || endpoint.Host == "" {
+		return Repository{}, false
+	}
+
+	path := strings.TrimSuffix(strings.Trim(endpoint.Path, "/"), ".git")
+	if path == "" {
+		return Repository{}, false
+	}
+
+	host := strings.ToLower(endpoint.Host)
+
+	// The web interface is assumed to be served over HTTPS on the default port, unless the remote is an HTTP one
+	base := "https://" + host
+
+	if endpoint.Protocol == "http" || endpoint.Protocol == "https" {
+		base = endpoint.Protocol + "://" + host
+
+		if endpoint.Port != 0 {
+			base = fmt.Sprintf("%s:%d", base, endpoint.Port)
+		}
+	}
+
+	for _, hostProvider := range hostProviders {
+		if strings.Contains(host, hostProvider.substring) {
+			return Repository{Provider: hostProvider.provider, URL: base + "/" + path}, true
+		}
+	}
+
+	return Repository{}, false
+}
+
+// CompareURL returns the URL of the page comparing the two given revisions (e.g., two tags).
+func (r Repository) CompareURL(from, to string) string {
+	if r.compareTemplate != nil {
+		return r.execute(r.compareTemplate, templateData{From: from, To: to})
+	}
+
+	from, to = url.PathEscape(from), url.PathEscape(to)
+
+	switch r.Provider {
+	case "":
+		return ""
+	case GitLab:
+		return fmt.Sprintf("%s/-/compare/%s...%s", r.URL, from, to)
+	case Bitbucket:
+		return fmt.Sprintf("%s/branches/compare/%s%%0D%s", r.URL, to, from)
+	default:
+		return fmt.Sprintf("%s/compare/%s...%s", r.URL, from, to)
+	}
+}
+
+// CommitURL returns the URL of the page of the given commit.
+func (r Repository) CommitURL(hash string) string {
+	if r.commitTemplate != nil {
+		return r.execute(r.commitTemplate, templateData{Hash: hash})
+	}
+
+	switch r.Provider {
+	case "":
+		return ""
+	case GitLab:
+		return r.URL + "/-/commit/" + hash
+	case Bitbucket:
+		return r.URL + "/commits/" + hash
+	default:
+		return r.URL + "/commit/" + hash
+	}
+}
+
+// PullRequestURL returns the URL of the page of the pull request of the given reference, such as "#12", or "!12" for
+// the merge requests of GitLab. It is empty if the reference is not one of a pull request of the forge.
+func (r Repository) PullRequestURL(reference string) string {
+	sigil := "#"
+	if r.Provider == GitLab {
+		sigil = "!"
+	}
+
+	number, ok := strings.CutPrefix(reference, sigil)
+	if !ok || number == "" {
+		return ""
+	}
+
+	if r.pullRequestTemplate != nil {
+		return r.execute(r.pullRequestTemplate, templateData{Number: number})
+	}
+
+	switch r.Provider {
+	case "":
+		return ""
+	case GitLab:
+		return r.URL + "/-/merge_requests/" + number
+	case Bitbucket:
+		return r.URL + "/pull-requests/" + number
+	case Gitea:
+		return r.URL + "/pulls/" + number
+	default:
+		return r.URL + "/pull/" + number
+	}
+}
+
+// IssueURL returns the URL of the page of the issue of the given reference, such as "#12". References to the tickets of
+// external trackers, such as "JIRA-456", only have a URL if the repository has an issue template.
+func (r Repository) IssueURL(reference string) string {
+	number, isNumber := strings.CutPrefix(reference, "#")
+	if !isNumber {
+		number = ""
+	}
+
+	if r.issueTemplate != nil {
+		return r.execute(r.issueTemplate, templateData{Number: number, Reference: reference})
+	}
+
+	if number == "" {
+		return ""
+	}
+
+	switch r.Provider {
+	case "":
+		return ""
+	case GitLab:
+		return r.URL + "/-/issues/" + number
+	default:
+		return r.URL + "/issues/" + number
+	}
+}
+
+// TagURL returns the URL of the page of the given tag.
+func (r Repository) TagURL(tagName string) string {
+	tagName = url.PathEscape(tagName)
+
+	switch r.Provider {
+	case "":
+		return ""
+	case GitLab:
+		return r.URL + "/-/tags/" + tagName
+	case Bitbucket:
+		return r.URL + "/src/" + tagName
+	case Gitea:
+		return r.URL + "/src/tag/" + tagName
+	default:
+		return r.URL + "/releases/tag/" + tagName
+	}
+}
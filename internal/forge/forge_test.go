@@ -0,0 +1,141 @@
+package forge
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	assert := assertion.New(t)
+
+	tests := map[string]Repository{
+		"https://github.com/owner/name.git":           {Provider: GitHub, URL: "https://github.com/owner/name"},
+		"git@github.com:owner/name.git":               {Provider: GitHub, URL: "https://github.com/owner/name"},
+		"https://user@gitlab.com/group/sub/name":      {Provider: GitLab, URL: "https://gitlab.com/group/sub/name"},
+		"ssh://git@codeberg.org/owner/name.git":       {Provider: Gitea, URL: "https://codeberg.org/owner/name"},
+		"https://bitbucket.org/workspace/name.git":    {Provider: Bitbucket, URL: "https://bitbucket.org/workspace/name"},
+		"https://GitHub.example.com/owner/name.git":   {Provider: GitHub, URL: "https://github.example.com/owner/name"},
+		"http://forgejo.example.com:3000/owner/name/": {Provider: Gitea, URL: "http://forgejo.example.com:3000/owner/name"},
+	}
+
+	for remoteURL, want := range tests {
+		repository, ok := Detect(remoteURL)
+		assert.True(ok, remoteURL)
+		assert.Equal(want, repository, remoteURL)
+	}
+
+	for _, remoteURL := range []string{"/path/to/repository", "https://git.example.com/owner/name.git", "https://github.com"} {
+		_, ok := Detect(remoteURL)
+		assert.False(ok, remoteURL)
+	}
+}
+
+func TestRepository_URLs(t *testing.T) {
+	assert := assertion.New(t)
+
+	type want struct {
+		compare     string
+		commit      string
+		tag         string
+		pullRequest string
+		issue       string
+	}
+
+	tests := map[Provider]want{
+		GitHub: {
+			compare:     "https://example.com/o/n/compare/v1.0.0...v1.1.0",
+			commit:      "https://example.com/o/n/commit/abc",
+			tag:         "https://example.com/o/n/releases/tag/foo%2Fv1.1.0",
+			pullRequest: "https://example.com/o/n/pull/12",
+			issue:       "https://example.com/o/n/issues/12",
+		},
+		GitLab: {
+			compare:     "https://example.com/o/n/-/compare/v1.0.0...v1.1.0",
+			commit:      "https://example.com/o/n/-/commit/abc",
+			tag:         "https://example.com/o/n/-/tags/foo%2Fv1.1.0",
+			pullRequest: "https://example.com/o/n/-/merge_requests/12",
+			issue:       "https://example.com/o/n/-/issues/12",
+		},
+		Gitea: {
+			compare:     "https://example.com/o/n/compare/v1.0.0...v1.1.0",
+			commit:      "https://example.com/o/n/commit/abc",
+			tag:         "https://example.com/o/n/src/tag/foo%2Fv1.1.0",
+			pullRequest: "https://example.com/o/n/pulls/12",
+			issue:       "https://example.com/o/n/issues/12",
+		},
+		Bitbucket: {
+			compare:     "https://example.com/o/n/branches/compare/v1.1.0%0Dv1.0.0",
+			commit:      "https://example.com/o/n/commits/abc",
+			tag:         "https://example.com/o/n/src/foo%2Fv1.1.0",
+			pullRequest: "https://example.com/o/n/pull-requests/12",
+			issue:       "https://example.com/o/n/issues/12",
+		},
+	}
+
+	for provider, want := range tests {
+		repository := Repository{Provider: provider, URL: "https://example.com/o/n"}
+
+		assert.Equal(want.compare, repository.CompareURL("v1.0.0", "v1.1.0"), provider)
+		assert.Equal(want.commit, repository.CommitURL("abc"), provider)
+		assert.Equal(want.tag, repository.TagURL("foo/v1.1.0"), provider)
+
+		reference := "#12"
+		if provider == GitLab {
+			reference = "!12"
+		}
+
+		assert.Equal(want.pullRequest, repository.PullRequestURL(reference), provider)
+		assert.Equal(want.issue, repository.IssueURL("#12"), provider)
+		assert.Empty(repository.IssueURL("JIRA-456"), provider)
+	}
+
+	assert.Empty(Repository{Provider: GitHub, URL: "https://github.com/o/n"}.PullRequestURL("!12"), "GitHub has no merge requests")
+	assert.Empty(Repository{Provider: GitLab, URL: "https://gitlab.com/o/n"}.PullRequestURL("#12"), "GitLab references issues with #")
+
+	var unknown Repository
+
+	assert.Empty(unknown.CompareURL("v1.0.0", "v1.1.0"))
+	assert.Empty(unknown.CommitURL("abc"))
+	assert.Empty(unknown.TagURL("v1.1.0"))
+	assert.Empty(unknown.PullRequestURL("#12"))
+	assert.Empty(unknown.IssueURL("#12"))
+}
+
+func TestRepository_WithTemplates(t *testing.T) {
+	assert := assertion.New(t)
+
+	repository, err := Repository{URL: "https://git.example.com/o/n"}.WithTemplates(Templates{
+		Commit:      "{{.URL}}/changeset/{{.Hash}}",
+		Compare:     "https://git.example.com/diff?from={{.From}}&to={{.To}}",
+		PullRequest: "{{.URL}}/reviews/{{.Number}}",
+		Issue:       "https://jira.example.com/browse/{{.Reference}}",
+	})
+	checkErr(t, "parsing templates", err)
+
+	assert.Equal("https://git.example.com/o/n/changeset/abc", repository.CommitURL("abc"))
+	assert.Equal("https://git.example.com/diff?from=v1.0.0&to=v1.1.0", repository.CompareURL("v1.0.0", "v1.1.0"))
+	assert.Equal("https://git.example.com/o/n/reviews/12", repository.PullRequestURL("#12"))
+	assert.Equal("https://jira.example.com/browse/JIRA-456", repository.IssueURL("JIRA-456"))
+
+	repository, err = Repository{Provider: GitHub, URL: "https://github.com/o/n"}.WithTemplates(Templates{Commit: "{{.URL}}/c/{{.Hash}}"})
+	checkErr(t, "parsing templates", err)
+
+	assert.Equal("https://github.com/o/n/c/abc", repository.CommitURL("abc"))
+	assert.Equal("https://github.com/o/n/pull/12", repository.PullRequestURL("#12"), "URLs without template should follow the forge conventions")
+
+	_, err = Repository{}.WithTemplates(Templates{Compare: "{{.From"})
+	assert.ErrorIs(err, ErrInvalidTemplate)
+
+	repository, err = Repository{}.WithTemplates(Templates{Commit: "{{.Unknown}}"})
+	checkErr(t, "parsing templates", err)
+
+	assert.Empty(repository.CommitURL("abc"), "failing templates should not build URLs")
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,150 @@
+// Package gitea provides a minimal client of the REST API of Gitea and Forgejo instances.
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+// apiPath is the path of the REST API relative to the URL of the instance.
+const apiPath = "/api/v1"
+
+var (
+	ErrInvalidRepository = errors.New("invalid Gitea repository")
+	ErrNoInstanceURL     = errors.New("no Gitea instance URL")
+)
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+}
+
+type OptionFunc func(c *Client)
+
+// WithToken authenticates the requests using the given access token.
+func WithToken(token string) OptionFunc {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient sends the requests using the given HTTP client instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) OptionFunc {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient returns a client of the instance at the given URL (e.g., "https://codeberg.org").
+func NewClient(baseURL string, options ...OptionFunc) *Client {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// Repository identifies a repository of a Gitea instance.
+type Repository struct {
+	Owner string
+	Name  string
+}
+
+func (r Repository) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+func (r Repository) path() string {
+	return fmt.Sprintf("/repos/%s/%s", url.PathEscape(r.Owner), url.PathEscape(r.Name))
+}
+
+// ParseRepository parses a repository given either as "owner/name" or as the URL of the repository (e.g.,
+// "https://codeberg.org/owner/name.git" or "git@codeberg.org:owner/name.git").
+func ParseRepository(s string) (Repository, error) {
+	path := s
+
+	if strings.Contains(s, "://") || strings.Contains(s, "@") {
+		endpoint, err := transport.NewEndpoint(s)
+		if err != nil {
+			return Repository{}, fmt.Errorf("%w: %w", ErrInvalidRepository, err)
+		}
+
+		path = endpoint.Path
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	owner, name, ok := strings.Cut(path, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return Repository{}, fmt.Errorf("%w: %q is not of the form owner/name", ErrInvalidRepository, s)
+	}
+
+	return Repository{Owner: owner, Name: name}, nil
+}
+
+// InstanceURL returns the URL of the instance hosting the repository of the given HTTP URL (e.g.,
+// "https://codeberg.org" for "https://codeberg.org/owner/name.git"). The URL of the instance cannot be deduced from
+// SSH URLs.
+func InstanceURL(repositoryURL string) (string, error) {
+	endpoint, err := transport.NewEndpoint(repositoryURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNoInstanceURL, err)
+	}
+
+	if endpoint.Protocol != "http" && endpoint.Protocol != "https" {
+		return "", fmt.Errorf("%w: %q is not an HTTP URL", ErrNoInstanceURL, repositoryURL)
+	}
+
+	instance := &url.URL{Scheme: endpoint.Protocol, Host: endpoint.Host}
+
+	if endpoint.Port != 0 {
+		instance.Host = fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port)
+	}
+
+	return instance.String(), nil
+}
+
+// Release is a release of a Gitea repository.
+type Release struct {
+	TagName string `json:"tag_name"`
+	Name    string `json:"name,omitempty"`
+	// Body is the Markdown release notes.
+	Body       string `json:"body,omitempty"`
+	Prerelease bool   `json:"prerelease,omitempty"`
+}
+
+// CreateRelease creates the given release, whose tag must already exist, in the given repository.
+func (c *Client) CreateRelease(ctx context.Context, repository Repository, release Release) error {
+	if err := c.do(ctx, http.MethodPost, repository.path()+"/releases", release, nil, http.StatusCreated); err != nil {
+		return fmt.Errorf("creating release %q: %w", release.TagName, err)
+	}
+
+	return nil
+}
+
+// do sends a request with the given method to the given API path, authenticated with the token of the client. See
+// restapi.Client.Do.
+func (c *Client) do(ctx context.Context, method, path string, body, result any, expectedStatus int) error {
+	api := restapi.New(c.baseURL + apiPath)
+	api.HTTPClient = c.httpClient
+
+	if c.token != "" {
+		api.Header.Set("Authorization", "token "+c.token)
+	}
+
+	return api.Do(ctx, method, path, body, result, expectedStatus)
+}
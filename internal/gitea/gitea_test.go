@@ -0,0 +1,98 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+func TestParseRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	for _, s := range []string{"owner/name", "https://codeberg.org/owner/name.git", "git@codeberg.org:owner/name.git"} {
+		repository, err := ParseRepository(s)
+		checkErr(t, "parsing repository", err)
+
+		assert.Equal(Repository{Owner: "owner", Name: "name"}, repository, s)
+	}
+
+	for _, s := range []string{"name", "https://codeberg.org/owner", "owner/group/name"} {
+		_, err := ParseRepository(s)
+		assert.ErrorIs(err, ErrInvalidRepository, s)
+	}
+}
+
+func TestInstanceURL(t *testing.T) {
+	assert := assertion.New(t)
+
+	tests := map[string]string{
+		"https://codeberg.org/owner/name.git":      "https://codeberg.org",
+		"http://gitea.example.com:3000/owner/name": "http://gitea.example.com:3000",
+	}
+
+	for repositoryURL, want := range tests {
+		instanceURL, err := InstanceURL(repositoryURL)
+		checkErr(t, "deducing instance URL", err)
+
+		assert.Equal(want, instanceURL)
+	}
+
+	for _, repositoryURL := range []string{"git@codeberg.org:owner/name.git", "/path/to/repository"} {
+		_, err := InstanceURL(repositoryURL)
+		assert.ErrorIs(err, ErrNoInstanceURL, repositoryURL)
+	}
+}
+
+func TestClient_CreateRelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	var releases []Release
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/repos/owner/name/releases" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "token secret" {
+			http.Error(w, `{"message": "token is required"}`, http.StatusUnauthorized)
+			return
+		}
+
+		var release Release
+		if err := json.NewDecoder(r.Body).Decode(&release); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		releases = append(releases, release)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	release := Release{TagName: "v1.0.0", Name: "v1.0.0", Body: "## v1.0.0"}
+
+	err := NewClient(server.URL+"/", WithToken("secret")).CreateRelease(context.Background(), Repository{Owner: "owner", Name: "name"}, release)
+	checkErr(t, "creating release", err)
+
+	assert.Equal([]Release{release}, releases)
+
+	err = NewClient(server.URL).CreateRelease(context.Background(), Repository{Owner: "owner", Name: "name"}, release)
+	assert.ErrorIs(err, restapi.ErrUnexpectedResponse)
+	assert.ErrorContains(err, "token is required")
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,155 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	assert := assertion.New(t)
+
+	key := newPrivateKey(t)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	checkErr(t, "encoding private key", err)
+
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "pkcs1", content: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})},
+		{name: "pkcs8", content: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsed, err := ParsePrivateKey(test.content)
+			checkErr(t, "parsing private key", err)
+
+			assert.True(key.Equal(parsed))
+		})
+	}
+
+	_, err = ParsePrivateKey([]byte("not a key"))
+	assert.ErrorIs(err, ErrInvalidPrivateKey)
+}
+
+func TestClient_InstallationToken(t *testing.T) {
+	assert := assertion.New(t)
+
+	key := newPrivateKey(t)
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/app/installations/42/access_tokens" {
+			http.NotFound(w, r)
+			return
+		}
+
+		claims, err := verifyJWT(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), &key.PublicKey)
+		if err != nil || claims["iss"] != "1234" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "A JSON web token could not be decoded"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(InstallationToken{Token: "ghs_token", ExpiresAt: expiresAt})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIURL(server.URL + "/"))
+
+	token, err := client.InstallationToken(context.Background(), App{ID: "1234", InstallationID: "42", PrivateKey: key})
+	checkErr(t, "minting installation token", err)
+
+	assert.Equal("ghs_token", token.Token)
+	assert.True(expiresAt.Equal(token.ExpiresAt))
+
+	_, err = client.InstallationToken(context.Background(), App{ID: "1234", InstallationID: "42", PrivateKey: newPrivateKey(t)})
+	assert.ErrorIs(err, restapi.ErrUnexpectedResponse, "a JWT signed by another key should be rejected")
+	assert.ErrorContains(err, "A JSON web token could not be decoded")
+}
+
+func TestApp_JWT(t *testing.T) {
+	assert := assertion.New(t)
+
+	key := newPrivateKey(t)
+	now := time.Now()
+
+	jwt, err := App{ID: "1234", PrivateKey: key}.jwt(now)
+	checkErr(t, "creating JWT", err)
+
+	claims, err := verifyJWT(jwt, &key.PublicKey)
+	checkErr(t, "verifying JWT", err)
+
+	assert.Equal("1234", claims["iss"])
+	assert.Less(claims["iat"], float64(now.Unix()), "the JWT should be issued in the past to allow for clock drift")
+	assert.LessOrEqual(claims["exp"], float64(now.Add(10*time.Minute).Unix()), "the JWT should expire within ten minutes")
+
+	_, err = App{ID: "1234"}.jwt(now)
+	assert.ErrorIs(err, ErrInvalidPrivateKey)
+}
+
+func newPrivateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	checkErr(t, "generating private key", err)
+
+	return key
+}
+
+func verifyJWT(jwt string, key *rsa.PublicKey) (map[string]any, error) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, err
+	}
+
+	content, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+
+	if err = json.Unmarshal(content, &claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,77 @@
+// Package github provides a minimal client of the GitHub REST API.
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+// DefaultAPIURL is the URL of the REST API of github.com, GitHub Enterprise Server instances serve it under
+// "https://<HOSTNAME>/api/v3".
+const DefaultAPIURL = "https://api.github.com"
+
+const apiVersion = "2022-11-28"
+
+type Client struct {
+	httpClient *http.Client
+	apiURL     string
+	token      string
+}
+
+type OptionFunc func(c *Client)
+
+// WithAPIURL sets the URL of the REST API, DefaultAPIURL is used if empty.
+func WithAPIURL(url string) OptionFunc {
+	return func(c *Client) {
+		if url != "" {
+			c.apiURL = strings.TrimSuffix(url, "/")
+		}
+	}
+}
+
+// WithToken authenticates the requests using the given token (e.g., a personal access token, an installation token or
+// a GitHub App JWT).
+func WithToken(token string) OptionFunc {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient sends the requests using the given HTTP client instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) OptionFunc {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func NewClient(options ...OptionFunc) *Client {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		apiURL:     DefaultAPIURL,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// do sends a request with the given method to the given API path, authenticated with the token of the client. See
+// restapi.Client.Do.
+func (c *Client) do(ctx context.Context, method, path string, body, result any, expectedStatus int) error {
+	api := restapi.New(c.apiURL)
+	api.HTTPClient = c.httpClient
+
+	api.Header.Set("Accept", "application/vnd.github+json")
+	api.Header.Set("X-GitHub-Api-Version", apiVersion)
+
+	if c.token != "" {
+		api.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return api.Do(ctx, method, path, body, result, expectedStatus)
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+var (
+	ErrInvalidRepository = errors.New("invalid GitHub repository")
+	ErrTagMismatch       = errors.New("tag object created by the GitHub API differs from the local one")
+)
+
+// Repository identifies a GitHub repository.
+type Repository struct {
+	Owner string
+	Name  string
+}
+
+func (r Repository) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// ParseRepository parses a repository given either as "owner/name" or as the URL of the repository (e.g.,
+// "https://github.com/owner/name.git" or "git@github.com:owner/name.git").
+func ParseRepository(s string) (Repository, error) {
+	path := s
+
+	if strings.Contains(s, "://") || strings.Contains(s, "@") {
+		endpoint, err := transport.NewEndpoint(s)
+		if err != nil {
+			return Repository{}, fmt.Errorf("%w: %w", ErrInvalidRepository, err)
+		}
+
+		path = endpoint.Path
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	owner, name, ok := strings.Cut(path, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return Repository{}, fmt.Errorf("%w: %q is not of the form owner/name", ErrInvalidRepository, s)
+	}
+
+	return Repository{Owner: owner, Name: name}, nil
+}
+
+// TagPusher publishes the tags of a local repository to a GitHub repository through the REST API instead of Git, which
+// is required when tag creation is restricted by rulesets to GitHub Apps.
+type TagPusher struct {
+	client     *Client
+	local      *git.Repository
+	repository Repository
+}
+
+func NewTagPusher(client *Client, local *git.Repository, repository Repository) *TagPusher {
+	return &TagPusher{
+		client:     client,
+		local:      local,
+		repository: repository,
+	}
+}
+
+// PushTag creates the given local tag on the GitHub repository, failing if it already exists.
+func (p *TagPusher) PushTag(ctx context.Context, tagName string) error {
+	return p.pushTag(ctx, tagName, false)
+}
+
+// ForcePushTag creates the given local tag on the GitHub repository, replacing it if it already exists.
+func (p *TagPusher) ForcePushTag(ctx context.Context, tagName string) error {
+	return p.pushTag(ctx, tagName, true)
+}
+
+// pushTag creates the tag object of an annotated local tag, if needed, then the tag reference pointing to it. Tag
+// objects are recreated byte for byte, signature included, so that they keep their hash and signed tags stay valid.
+func (p *TagPusher) pushTag(ctx context.Context, tagName string, force bool) error {
+	reference, err := p.local.Tag(tagName)
+	if err != nil {
+		return fmt.Errorf("fetching tag %q: %w", tagName, err)
+	}
+
+	sha := reference.Hash()
+
+	tag, err := p.local.TagObject(sha)
+	switch {
+	case errors.Is(err, plumbing.ErrObjectNotFound):
+		// Lightweight tags are only a reference to the tagged commit
+	case err != nil:
+		return fmt.Errorf("fetching tag object %q: %w", tagName, err)
+	default:
+		created, err := p.createTagObject(ctx, tag)
+		if err != nil {
+			return fmt.Errorf("creating tag object %q: %w", tagName, err)
+		}
+
+		if created != sha && tag.PGPSignature != "" {
+			return fmt.Errorf("%w: %q created as %s instead of %s", ErrTagMismatch, tagName, created, sha)
+		}
+
+		sha = created
+	}
+
+	if err = p.setReference(ctx, plumbing.NewTagReferenceName(tagName), sha, force); err != nil {
+		return fmt.Errorf("creating tag reference %q: %w", tagName, err)
+	}
+
+	return nil
+}
+
+func (p *TagPusher) createTagObject(ctx context.Context, tag *object.Tag) (plumbing.Hash, error) {
+	body := struct {
+		Tag     string `json:"tag"`
+		Message string `json:"message"`
+		Object  string `json:"object"`
+		Type    string `json:"type"`
+		Tagger  struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+			Date  string `json:"date"`
+		} `json:"tagger"`
+	}{
+		Tag: tag.Name,
+		// Signatures are stored after the message in tag objects
+		Message: tag.Message + tag.PGPSignature,
+		Object:  tag.Target.String(),
+		Type:    tag.TargetType.String(),
+	}
+
+	body.Tagger.Name = tag.Tagger.Name
+	body.Tagger.Email = tag.Tagger.Email
+	body.Tagger.Date = tag.Tagger.When.Format(time.RFC3339)
+
+	var result struct {
+		SHA string `json:"sha"`
+	}
+
+	path := fmt.Sprintf("/repos/%s/%s/git/tags", url.PathEscape(p.repository.Owner), url.PathEscape(p.repository.Name))
+
+	if err := p.client.do(ctx, http.MethodPost, path, body, &result, http.StatusCreated); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return plumbing.NewHash(result.SHA), nil
+}
+
+// setReference creates the given reference pointing to the given object. If forced, the reference is updated instead
+// when it already exists.
+func (p *TagPusher) setReference(ctx context.Context, name plumbing.ReferenceName, sha plumbing.Hash, force bool) error {
+	refsPath := fmt.Sprintf("/repos/%s/%s/git/refs", url.PathEscape(p.repository.Owner), url.PathEscape(p.repository.Name))
+
+	if force {
+		body := struct {
+			SHA   string `json:"sha"`
+			Force bool   `json:"force"`
+		}{
+			SHA:   sha.String(),
+			Force: true,
+		}
+
+		// The API responds with a validation error if the reference does not exist
+		err := p.client.do(ctx, http.MethodPatch, refsPath+"/"+strings.TrimPrefix(name.String(), "refs/"), body, nil, http.StatusOK)
+		if !restapi.HasStatus(err, http.StatusUnprocessableEntity) {
+			return err
+		}
+	}
+
+	body := struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	}{
+		Ref: name.String(),
+		SHA: sha.String(),
+	}
+
+	return p.client.do(ctx, http.MethodPost, refsPath, body, nil, http.StatusCreated)
+}
@@ -0,0 +1,287 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	assertion "github.com/stretchr/testify/assert"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func TestParseRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	tests := []string{
+		"owner/name",
+		"https://github.com/owner/name",
+		"https://github.com/owner/name.git",
+		"https://github.example.com/owner/name.git",
+		"git@github.com:owner/name.git",
+		"ssh://git@github.com/owner/name.git",
+	}
+
+	for _, test := range tests {
+		repository, err := ParseRepository(test)
+		checkErr(t, "parsing repository", err)
+
+		assert.Equal(Repository{Owner: "owner", Name: "name"}, repository, test)
+	}
+
+	for _, test := range []string{"name", "owner/", "https://github.com/owner", "https://example.com/group/subgroup/name.git"} {
+		_, err := ParseRepository(test)
+		assert.ErrorIs(err, ErrInvalidRepository, test)
+	}
+}
+
+func TestTagPusher_PushTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	remote := newFakeGitHub(t)
+
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, "generating SSH key", err)
+
+	tests := []struct {
+		name    string
+		version string
+		options []tag.OptionFunc
+	}{
+		{name: "annotated", version: "1.0.0"},
+		{name: "lightweight", version: "1.0.1", options: []tag.OptionFunc{tag.WithLightweight(true)}},
+		{name: "signed", version: "1.0.2", options: []tag.OptionFunc{tag.WithSSHSigner(newSSHSigner(t, signingKey))}},
+	}
+
+	pusher := NewTagPusher(NewClient(WithAPIURL(remote.server.URL), WithToken("token")), testRepository.Repository, Repository{Owner: "owner", Name: "name"})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Tag objects must be recreated with the same time zone to keep their hash
+			clock := tag.WithClock(func() time.Time {
+				return time.Date(2024, time.March, 1, 12, 0, 0, 0, time.FixedZone("", 2*60*60+30*60))
+			})
+
+			tagger := tag.NewTagger("Go Semver Release", "go-semver@release.ci", append(test.options, clock)...)
+
+			version, err := semver.NewFromString(test.version)
+			checkErr(t, "parsing version", err)
+
+			err = tagger.TagRepository(testRepository.Repository, version, hash)
+			checkErr(t, "tagging repository", err)
+
+			err = pusher.PushTag(context.Background(), test.version)
+			checkErr(t, "pushing tag", err)
+
+			local, err := testRepository.Tag(test.version)
+			checkErr(t, "fetching local tag", err)
+
+			created, err := remote.repository.Reference(plumbing.NewTagReferenceName(test.version), true)
+			checkErr(t, "fetching created tag", err)
+
+			assert.Equal(local.Hash(), created.Hash(), "tag should have been recreated identically")
+
+			err = pusher.PushTag(context.Background(), test.version)
+			assert.ErrorIs(err, restapi.ErrUnexpectedResponse, "existing tag should not be replaced")
+		})
+	}
+}
+
+func TestTagPusher_ForcePushTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	remote := newFakeGitHub(t)
+	pusher := NewTagPusher(NewClient(WithAPIURL(remote.server.URL)), testRepository.Repository, Repository{Owner: "owner", Name: "name"})
+
+	for range 2 {
+		hash, err := testRepository.AddCommit("feat")
+		checkErr(t, "adding commit", err)
+
+		_, err = testRepository.CreateTag("v1", hash, nil)
+		checkErr(t, "tagging repository", err)
+
+		err = pusher.ForcePushTag(context.Background(), "v1")
+		checkErr(t, "force pushing tag", err)
+
+		created, err := remote.repository.Reference(plumbing.NewTagReferenceName("v1"), true)
+		checkErr(t, "fetching created tag", err)
+
+		assert.Equal(hash, created.Hash(), "tag should have been moved")
+
+		err = testRepository.DeleteTag("v1")
+		checkErr(t, "deleting tag", err)
+	}
+}
+
+// fakeGitHub implements the Git database endpoints of the GitHub REST API on top of an in-memory repository.
+type fakeGitHub struct {
+	server     *httptest.Server
+	repository *git.Repository
+}
+
+func newFakeGitHub(t *testing.T) *fakeGitHub {
+	t.Helper()
+
+	repository, err := git.Init(memory.NewStorage(), nil)
+	checkErr(t, "creating repository", err)
+
+	f := &fakeGitHub{repository: repository}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /repos/owner/name/git/tags", f.createTag)
+	mux.HandleFunc("POST /repos/owner/name/git/refs", f.createRef)
+	mux.HandleFunc("PATCH /repos/owner/name/git/refs/tags/{tag}", f.updateRef)
+
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeGitHub) createTag(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Tag     string `json:"tag"`
+		Message string `json:"message"`
+		Object  string `json:"object"`
+		Type    string `json:"type"`
+		Tagger  struct {
+			Name  string    `json:"name"`
+			Email string    `json:"email"`
+			Date  time.Time `json:"date"`
+		} `json:"tagger"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	targetType, err := plumbing.ParseObjectType(body.Type)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tagObject := &object.Tag{
+		Name:       body.Tag,
+		Tagger:     object.Signature{Name: body.Tagger.Name, Email: body.Tagger.Email, When: body.Tagger.Date},
+		Message:    body.Message,
+		TargetType: targetType,
+		Target:     plumbing.NewHash(body.Object),
+	}
+
+	encoded := f.repository.Storer.NewEncodedObject()
+	if err = tagObject.Encode(encoded); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	hash, err := f.repository.Storer.SetEncodedObject(encoded)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte(`{"sha": "` + hash.String() + `"}`))
+}
+
+func (f *fakeGitHub) createRef(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := f.repository.Reference(plumbing.ReferenceName(body.Ref), false); err == nil {
+		http.Error(w, `{"message": "Reference already exists"}`, http.StatusUnprocessableEntity)
+		return
+	}
+
+	f.setRef(w, plumbing.ReferenceName(body.Ref), body.SHA, http.StatusCreated)
+}
+
+func (f *fakeGitHub) updateRef(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		SHA string `json:"sha"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	name := plumbing.NewTagReferenceName(r.PathValue("tag"))
+
+	if _, err := f.repository.Reference(name, false); err != nil {
+		http.Error(w, `{"message": "Reference does not exist"}`, http.StatusUnprocessableEntity)
+		return
+	}
+
+	f.setRef(w, name, body.SHA, http.StatusOK)
+}
+
+func (f *fakeGitHub) setRef(w http.ResponseWriter, name plumbing.ReferenceName, sha string, status int) {
+	if !strings.HasPrefix(name.String(), "refs/") {
+		http.Error(w, `{"message": "Invalid reference"}`, http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := f.repository.Storer.SetReference(plumbing.NewHashReference(name, plumbing.NewHash(sha))); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func newSSHSigner(t *testing.T, key any) *ssh.Signer {
+	t.Helper()
+
+	block, err := cryptossh.MarshalPrivateKey(key, "")
+	checkErr(t, "marshalling private key", err)
+
+	signer, err := ssh.FromPEM(bytes.NewReader(pem.EncodeToMemory(block)))
+	checkErr(t, "loading private key", err)
+
+	return signer
+}
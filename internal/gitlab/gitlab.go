@@ -0,0 +1,127 @@
+// Package gitlab provides a minimal client of the GitLab REST API.
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+// DefaultAPIURL is the URL of the REST API of gitlab.com, self-managed instances serve it under
+// "https://<HOSTNAME>/api/v4". GitLab CI/CD jobs expose it as the CI_API_V4_URL environment variable.
+const DefaultAPIURL = "https://gitlab.com/api/v4"
+
+var ErrInvalidProject = errors.New("invalid GitLab project")
+
+type Client struct {
+	httpClient *http.Client
+	apiURL     string
+	// header is the name of the header carrying the token, which depends on the kind of token
+	header string
+	token  string
+}
+
+type OptionFunc func(c *Client)
+
+// WithAPIURL sets the URL of the REST API, DefaultAPIURL is used if empty.
+func WithAPIURL(url string) OptionFunc {
+	return func(c *Client) {
+		if url != "" {
+			c.apiURL = strings.TrimSuffix(url, "/")
+		}
+	}
+}
+
+// WithToken authenticates the requests using the given personal, group or project access token.
+func WithToken(token string) OptionFunc {
+	return func(c *Client) {
+		c.header = "PRIVATE-TOKEN"
+		c.token = token
+	}
+}
+
+// WithJobToken authenticates the requests using the given CI/CD job token (i.e., CI_JOB_TOKEN), which can only create
+// releases.
+func WithJobToken(token string) OptionFunc {
+	return func(c *Client) {
+		c.header = "JOB-TOKEN"
+		c.token = token
+	}
+}
+
+// WithHTTPClient sends the requests using the given HTTP client instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) OptionFunc {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func NewClient(options ...OptionFunc) *Client {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		apiURL:     DefaultAPIURL,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// Project identifies a GitLab project, either by its numeric ID or by its path with namespace (e.g.,
+// "group/subgroup/name").
+type Project string
+
+// ParseProject parses a project given either as its numeric ID, as its path with namespace or as its URL (e.g.,
+// "https://gitlab.com/group/name.git" or "git@gitlab.com:group/name.git").
+func ParseProject(s string) (Project, error) {
+	if _, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return Project(s), nil
+	}
+
+	path := s
+
+	if strings.Contains(s, "://") || strings.Contains(s, "@") {
+		endpoint, err := transport.NewEndpoint(s)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidProject, err)
+		}
+
+		path = endpoint.Path
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	if !strings.Contains(path, "/") || strings.Contains(path, "//") {
+		return "", fmt.Errorf("%w: %q is neither an ID nor of the form namespace/name", ErrInvalidProject, s)
+	}
+
+	return Project(path), nil
+}
+
+// path returns the API path of the project, the path with namespace being URL-encoded as a single segment.
+func (p Project) path() string {
+	return "/projects/" + url.PathEscape(string(p))
+}
+
+// do sends a request with the given method to the given API path, authenticated with the token of the client. See
+// restapi.Client.Do.
+func (c *Client) do(ctx context.Context, method, path string, body, result any, expectedStatus int) error {
+	api := restapi.New(c.apiURL)
+	api.HTTPClient = c.httpClient
+
+	if c.token != "" {
+		api.Header.Set(c.header, c.token)
+	}
+
+	return api.Do(ctx, method, path, body, result, expectedStatus)
+}
@@ -0,0 +1,264 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func TestParseProject(t *testing.T) {
+	assert := assertion.New(t)
+
+	tests := map[string]Project{
+		"42":                  "42",
+		"group/name":          "group/name",
+		"group/subgroup/name": "group/subgroup/name",
+		"https://gitlab.com/group/subgroup/name.git": "group/subgroup/name",
+		"git@gitlab.example.com:group/name.git":      "group/name",
+	}
+
+	for s, want := range tests {
+		project, err := ParseProject(s)
+		checkErr(t, "parsing project", err)
+
+		assert.Equal(want, project, s)
+	}
+
+	for _, s := range []string{"name", "https://gitlab.com/name.git", "group//name"} {
+		_, err := ParseProject(s)
+		assert.ErrorIs(err, ErrInvalidProject, s)
+	}
+
+	assert.Equal("/projects/group%2Fsubgroup%2Fname", Project("group/subgroup/name").path())
+}
+
+func TestClient_CreateRelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	remote := newFakeGitLab(t)
+	client := NewClient(WithAPIURL(remote.server.URL), WithJobToken("job-token"))
+
+	release := Release{TagName: "v1.0.0", Name: "v1.0.0", Description: "## v1.0.0", Ref: "main"}
+
+	err := client.CreateRelease(context.Background(), "group/name", release)
+	checkErr(t, "creating release", err)
+
+	assert.Equal([]Release{release}, remote.releases)
+	assert.Equal("job-token", remote.jobToken, "job token should be sent in the JOB-TOKEN header")
+
+	err = client.CreateRelease(context.Background(), "group/name", release)
+	assert.ErrorIs(err, restapi.ErrUnexpectedResponse, "release should not be created twice")
+}
+
+func TestTagPusher_PushTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	remote := newFakeGitLab(t)
+	pusher := NewTagPusher(NewClient(WithAPIURL(remote.server.URL), WithToken("token")), testRepository.Repository, "group/name")
+
+	tests := []struct {
+		name    string
+		version string
+		message string
+		options []tag.OptionFunc
+	}{
+		{name: "annotated", version: "1.0.0", message: "1.0.0"},
+		{name: "lightweight", version: "1.0.1", options: []tag.OptionFunc{tag.WithLightweight(true)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, err := semver.NewFromString(test.version)
+			checkErr(t, "parsing version", err)
+
+			err = tag.NewTagger("Go Semver Release", "go-semver@release.ci", test.options...).TagRepository(testRepository.Repository, version, hash)
+			checkErr(t, "tagging repository", err)
+
+			err = pusher.PushTag(context.Background(), test.version)
+			checkErr(t, "pushing tag", err)
+
+			created, ok := remote.tags[test.version]
+			assert.True(ok, "tag should have been created")
+			assert.Equal(hash.String(), created.Ref, "tag should point to the tagged commit")
+			assert.Equal(test.message, strings.TrimSpace(created.Message))
+
+			err = pusher.PushTag(context.Background(), test.version)
+			assert.ErrorIs(err, restapi.ErrUnexpectedResponse, "existing tag should not be replaced")
+		})
+	}
+
+	assert.Equal("token", remote.privateToken, "access token should be sent in the PRIVATE-TOKEN header")
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, "generating SSH key", err)
+
+	block, err := cryptossh.MarshalPrivateKey(key, "")
+	checkErr(t, "marshalling SSH key", err)
+
+	signer, err := ssh.FromPEM(bytes.NewReader(pem.EncodeToMemory(block)))
+	checkErr(t, "loading SSH key", err)
+
+	version, err := semver.NewFromString("1.0.2")
+	checkErr(t, "parsing version", err)
+
+	err = tag.NewTagger("Go Semver Release", "go-semver@release.ci", tag.WithSSHSigner(signer)).TagRepository(testRepository.Repository, version, hash)
+	checkErr(t, "tagging repository", err)
+
+	err = pusher.PushTag(context.Background(), "1.0.2")
+	assert.ErrorIs(err, ErrSignedTag)
+}
+
+func TestTagPusher_ForcePushTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	remote := newFakeGitLab(t)
+	pusher := NewTagPusher(NewClient(WithAPIURL(remote.server.URL), WithToken("token")), testRepository.Repository, "42")
+
+	for range 2 {
+		hash, err := testRepository.AddCommit("feat")
+		checkErr(t, "adding commit", err)
+
+		_, err = testRepository.CreateTag("v1", hash, nil)
+		checkErr(t, "tagging repository", err)
+
+		err = pusher.ForcePushTag(context.Background(), "v1")
+		checkErr(t, "force pushing tag", err)
+
+		assert.Equal(hash.String(), remote.tags["v1"].Ref, "tag should have been moved")
+
+		err = testRepository.DeleteTag("v1")
+		checkErr(t, "deleting tag", err)
+	}
+}
+
+type fakeTag struct {
+	TagName string `json:"tag_name"`
+	Ref     string `json:"ref"`
+	Message string `json:"message"`
+}
+
+// fakeGitLab implements the tags and releases endpoints of the GitLab REST API, for any project.
+type fakeGitLab struct {
+	server       *httptest.Server
+	tags         map[string]fakeTag
+	releases     []Release
+	privateToken string
+	jobToken     string
+	mu           sync.Mutex
+}
+
+func newFakeGitLab(t *testing.T) *fakeGitLab {
+	t.Helper()
+
+	f := &fakeGitLab{tags: make(map[string]fakeTag)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /projects/{project}/repository/tags", f.createTag)
+	mux.HandleFunc("DELETE /projects/{project}/repository/tags/{tag}", f.deleteTag)
+	mux.HandleFunc("POST /projects/{project}/releases", f.createRelease)
+
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		f.privateToken = r.Header.Get("PRIVATE-TOKEN")
+		f.jobToken = r.Header.Get("JOB-TOKEN")
+
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeGitLab) createTag(w http.ResponseWriter, r *http.Request) {
+	var body fakeTag
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := f.tags[body.TagName]; ok {
+		http.Error(w, `{"message": "Tag `+body.TagName+` already exists"}`, http.StatusBadRequest)
+		return
+	}
+
+	f.tags[body.TagName] = body
+
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func (f *fakeGitLab) deleteTag(w http.ResponseWriter, r *http.Request) {
+	if _, ok := f.tags[r.PathValue("tag")]; !ok {
+		http.Error(w, `{"message": "404 Tag Not Found"}`, http.StatusNotFound)
+		return
+	}
+
+	delete(f.tags, r.PathValue("tag"))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeGitLab) createRelease(w http.ResponseWriter, r *http.Request) {
+	var release Release
+
+	if err := json.NewDecoder(r.Body).Decode(&release); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, existing := range f.releases {
+		if existing.TagName == release.TagName {
+			http.Error(w, `{"message": "Release already exists"}`, http.StatusConflict)
+			return
+		}
+	}
+
+	f.releases = append(f.releases, release)
+
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
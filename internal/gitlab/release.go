@@ -0,0 +1,26 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Release is a GitLab release.
+type Release struct {
+	TagName string `json:"tag_name"`
+	Name    string `json:"name,omitempty"`
+	// Description is the Markdown release notes.
+	Description string `json:"description,omitempty"`
+	// Ref is the commit the tag is created on if it does not exist yet.
+	Ref string `json:"ref,omitempty"`
+}
+
+// CreateRelease creates the given release in the given project.
+func (c *Client) CreateRelease(ctx context.Context, project Project, release Release) error {
+	if err := c.do(ctx, http.MethodPost, project.path()+"/releases", release, nil, http.StatusCreated); err != nil {
+		return fmt.Errorf("creating release %q: %w", release.TagName, err)
+	}
+
+	return nil
+}
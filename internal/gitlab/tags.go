@@ -0,0 +1,87 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+var ErrSignedTag = errors.New("signed tags cannot be created through the GitLab API")
+
+// TagPusher publishes the tags of a local repository to a GitLab project through the REST API instead of Git, which
+// is required when protected tags only allow some users to create them. GitLab creates the tag objects itself, so
+// signed tags cannot be published this way.
+type TagPusher struct {
+	client  *Client
+	local   *git.Repository
+	project Project
+}
+
+func NewTagPusher(client *Client, local *git.Repository, project Project) *TagPusher {
+	return &TagPusher{
+		client:  client,
+		local:   local,
+		project: project,
+	}
+}
+
+// PushTag creates the given local tag in the GitLab project, failing if it already exists.
+func (p *TagPusher) PushTag(ctx context.Context, tagName string) error {
+	return p.pushTag(ctx, tagName, false)
+}
+
+// ForcePushTag creates the given local tag in the GitLab project, replacing it if it already exists.
+func (p *TagPusher) ForcePushTag(ctx context.Context, tagName string) error {
+	return p.pushTag(ctx, tagName, true)
+}
+
+func (p *TagPusher) pushTag(ctx context.Context, tagName string, force bool) error {
+	reference, err := p.local.Tag(tagName)
+	if err != nil {
+		return fmt.Errorf("fetching tag %q: %w", tagName, err)
+	}
+
+	body := struct {
+		TagName string `json:"tag_name"`
+		Ref     string `json:"ref"`
+		Message string `json:"message,omitempty"`
+	}{
+		TagName: tagName,
+		Ref:     reference.Hash().String(),
+	}
+
+	tag, err := p.local.TagObject(reference.Hash())
+	switch {
+	case errors.Is(err, plumbing.ErrObjectNotFound):
+		// Lightweight tags are created without message
+	case err != nil:
+		return fmt.Errorf("fetching tag object %q: %w", tagName, err)
+	case tag.PGPSignature != "":
+		return fmt.Errorf("%w: %q", ErrSignedTag, tagName)
+	default:
+		body.Ref = tag.Target.String()
+		body.Message = tag.Message
+	}
+
+	tagPath := p.project.path() + "/repository/tags"
+
+	if force {
+		err = p.client.do(ctx, http.MethodDelete, tagPath+"/"+url.PathEscape(tagName), nil, nil, http.StatusNoContent)
+		if err != nil && !restapi.HasStatus(err, http.StatusNotFound) {
+			return fmt.Errorf("deleting tag %q: %w", tagName, err)
+		}
+	}
+
+	if err = p.client.do(ctx, http.MethodPost, tagPath, body, nil, http.StatusCreated); err != nil {
+		return fmt.Errorf("creating tag %q: %w", tagName, err)
+	}
+
+	return nil
+}
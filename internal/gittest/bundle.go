@@ -0,0 +1,277 @@
+package gittest
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/revlist"
+)
+
+// Signatures of the first line of Git bundles, Export writes v2 bundles which can also be read by "git clone".
+const (
+	bundleSignature   = "# v2 git bundle"
+	bundleV3Signature = "# v3 git bundle"
+)
+
+var (
+	ErrInvalidBundle    = errors.New("invalid Git bundle")
+	ErrIncompleteBundle = errors.New("Git bundle requires commits it does not contain")
+)
+
+// Export writes the references and the objects of the underlying Git repository to the given writer as a Git bundle.
+// The bundle only depends on the content of the repository: the same repository is always exported to the same bytes.
+func (r *TestRepository) Export(w io.Writer) error {
+	head, err := r.Head()
+	if err != nil {
+		return fmt.Errorf("fetching head: %w", err)
+	}
+
+	references, err := r.References()
+	if err != nil {
+		return fmt.Errorf("fetching references: %w", err)
+	}
+
+	var refs []*plumbing.Reference
+
+	err = references.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference && strings.HasPrefix(ref.Name().String(), "refs/") {
+			refs = append(refs, ref)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing references: %w", err)
+	}
+
+	slices.SortFunc(refs, func(a, b *plumbing.Reference) int {
+		return strings.Compare(a.Name().String(), b.Name().String())
+	})
+
+	tips := []plumbing.Hash{head.Hash()}
+
+	buf := bufio.NewWriter(w)
+
+	_, _ = fmt.Fprintf(buf, "%s\n%s %s\n", bundleSignature, head.Hash(), plumbing.HEAD)
+
+	for _, ref := range refs {
+		tips = append(tips, ref.Hash())
+		_, _ = fmt.Fprintf(buf, "%s %s\n", ref.Hash(), ref.Name())
+	}
+
+	_, _ = buf.WriteString("\n")
+
+	objects, err := revlist.Objects(r.Storer, tips, nil)
+	if err != nil {
+		return fmt.Errorf("listing objects: %w", err)
+	}
+
+	slices.SortFunc(objects, func(a, b plumbing.Hash) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	// Objects are neither deltified nor reordered so that the packfile only depends on them
+	_, err = packfile.NewEncoder(buf, r.Storer, false).Encode(objects, 0)
+	if err != nil {
+		return fmt.Errorf("encoding packfile: %w", err)
+	}
+
+	return buf.Flush()
+}
+
+// ExportFile exports the underlying Git repository to a Git bundle at the given path.
+func (r *TestRepository) ExportFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating bundle file: %w", err)
+	}
+
+	if err = r.Export(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Import creates a new TestRepository from a Git bundle, such as the ones written by Export or by
+// "git bundle create <file> --all". The branch of the bundle pointing to its HEAD is checked out, and When carries on
+// from the latest commit so that new commits are always more recent than the imported ones.
+func Import(rd io.Reader) (*TestRepository, error) {
+	bundle := bufio.NewReader(rd)
+
+	signature, err := bundle.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading signature: %w", ErrInvalidBundle, err)
+	}
+
+	if signature = strings.TrimSuffix(signature, "\n"); signature != bundleSignature && signature != bundleV3Signature {
+		return nil, fmt.Errorf("%w: unknown signature %q", ErrInvalidBundle, signature)
+	}
+
+	var (
+		head plumbing.Hash
+		refs []*plumbing.Reference
+	)
+
+	for {
+		line, err := bundle.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading references: %w", ErrInvalidBundle, err)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+
+		if line == "" {
+			break
+		}
+
+		switch line[0] {
+		case '@':
+			// Capabilities of v3 bundles, only SHA-1 object names are supported
+			if strings.HasPrefix(line, "@object-format=") && line != "@object-format=sha1" {
+				return nil, fmt.Errorf("%w: unsupported capability %q", ErrInvalidBundle, line)
+			}
+
+			continue
+		case '-':
+			return nil, fmt.Errorf("%w: %s", ErrIncompleteBundle, strings.TrimPrefix(line, "-"))
+		}
+
+		hash, name, ok := strings.Cut(line, " ")
+		if !ok || !plumbing.IsHash(hash) {
+			return nil, fmt.Errorf("%w: invalid reference %q", ErrInvalidBundle, line)
+		}
+
+		if name == plumbing.HEAD.String() {
+			head = plumbing.NewHash(hash)
+			continue
+		}
+
+		refs = append(refs, plumbing.NewHashReference(plumbing.ReferenceName(name), plumbing.NewHash(hash)))
+	}
+
+	testRepository := &TestRepository{}
+
+	testRepository.Path, err = os.MkdirTemp("", "gittest-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+
+	if err = testRepository.load(bundle, head, refs); err != nil {
+		_ = testRepository.Remove()
+		return nil, err
+	}
+
+	return testRepository, nil
+}
+
+// ImportFile imports the Git bundle at the given path.
+func ImportFile(path string) (*TestRepository, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading bundle file: %w", err)
+	}
+
+	return Import(bytes.NewReader(content))
+}
+
+// load initializes the repository with the objects of the given packfile and the given references, and checks out the
+// given HEAD.
+func (r *TestRepository) load(pack io.Reader, head plumbing.Hash, refs []*plumbing.Reference) error {
+	repository, err := git.PlainInit(r.Path, false)
+	if err != nil {
+		return fmt.Errorf("initializing repository: %w", err)
+	}
+
+	r.Repository = repository
+
+	if err = packfile.UpdateObjectStorage(repository.Storer, pack); err != nil {
+		return fmt.Errorf("%w: decoding packfile: %w", ErrInvalidBundle, err)
+	}
+
+	for _, ref := range refs {
+		if err = repository.Storer.SetReference(ref); err != nil {
+			return fmt.Errorf("setting reference %q: %w", ref.Name(), err)
+		}
+	}
+
+	if head.IsZero() {
+		return nil
+	}
+
+	if err = r.checkoutHead(head, refs); err != nil {
+		return err
+	}
+
+	return r.resumeCounter()
+}
+
+// checkoutHead checks out the branch pointing to the given commit, preferring the default branch, or the commit itself
+// if no branch points to it.
+func (r *TestRepository) checkoutHead(head plumbing.Hash, refs []*plumbing.Reference) error {
+	defaultBranch, err := r.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return fmt.Errorf("fetching head: %w", err)
+	}
+
+	options := &git.CheckoutOptions{Hash: head, Force: true}
+
+	for _, ref := range refs {
+		if !ref.Name().IsBranch() || ref.Hash() != head {
+			continue
+		}
+
+		if options.Branch == "" || ref.Name() == defaultBranch.Target() {
+			options.Branch, options.Hash = ref.Name(), plumbing.ZeroHash
+		}
+	}
+
+	worktree, err := r.Worktree()
+	if err != nil {
+		return fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	if err = worktree.Checkout(options); err != nil {
+		return fmt.Errorf("checking out head: %w", err)
+	}
+
+	return nil
+}
+
+// resumeCounter sets the counter of When so that it returns times following the latest commit of the repository.
+func (r *TestRepository) resumeCounter() error {
+	commits, err := r.CommitObjects()
+	if err != nil {
+		return fmt.Errorf("fetching commits: %w", err)
+	}
+
+	latest := referenceTime
+
+	err = commits.ForEach(func(c *object.Commit) error {
+		if c.Committer.When.After(latest) {
+			latest = c.Committer.When
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing commits: %w", err)
+	}
+
+	r.Counter = uint(latest.Sub(referenceTime) / (10 * time.Second))
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package gittest
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestBundle_ExportImport(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := generate(t, GenerateOptions{Commits: 30, Branches: 2, Merges: 1, Tags: 3, Seed: 1})
+
+	err := testRepository.Checkout("branch-1")
+	checkErr(t, "checking out branch", err)
+
+	first := new(bytes.Buffer)
+
+	err = testRepository.Export(first)
+	checkErr(t, "exporting repository", err)
+
+	second := new(bytes.Buffer)
+
+	err = testRepository.Export(second)
+	checkErr(t, "exporting repository again", err)
+
+	assert.Equal(first.Bytes(), second.Bytes(), "exports should be deterministic")
+
+	imported, err := Import(bytes.NewReader(first.Bytes()))
+	checkErr(t, "importing repository", err)
+
+	t.Cleanup(func() {
+		_ = imported.Remove()
+	})
+
+	assert.Equal(references(t, testRepository), references(t, imported), "references should have been imported")
+
+	head, err := imported.Head()
+	checkErr(t, "fetching head", err)
+
+	assert.Equal(plumbing.NewBranchReferenceName("branch-1"), head.Name(), "branch of the exported head should be checked out")
+
+	assert.True(isClean(t, imported), "worktree should be clean")
+
+	hash, err := imported.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	c, err := imported.CommitObject(hash)
+	checkErr(t, "fetching commit", err)
+
+	parent, err := c.Parent(0)
+	checkErr(t, "fetching parent", err)
+
+	// Every commit of the generated repository is older than the last time returned by its When
+	latest := referenceTime.Add(time.Duration(testRepository.Counter*10) * time.Second)
+
+	assert.True(c.Committer.When.After(latest), "new commits should be more recent than the imported ones")
+	assert.True(c.Committer.When.After(parent.Committer.When))
+}
+
+func TestBundle_ExportImportFile(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := newRepository(t)
+
+	_, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	path := filepath.Join(t.TempDir(), "fixture.bundle")
+
+	err = testRepository.ExportFile(path)
+	checkErr(t, "exporting repository", err)
+
+	imported, err := ImportFile(path)
+	checkErr(t, "importing repository", err)
+
+	t.Cleanup(func() {
+		_ = imported.Remove()
+	})
+
+	assert.Equal(references(t, testRepository), references(t, imported), "references should have been imported")
+}
+
+func TestBundle_Import_Invalid(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := Import(strings.NewReader("not a bundle\n"))
+	assert.ErrorIs(err, ErrInvalidBundle)
+
+	_, err = Import(strings.NewReader(bundleSignature + "\nnot a hash refs/heads/master\n\n"))
+	assert.ErrorIs(err, ErrInvalidBundle)
+
+	_, err = Import(strings.NewReader(bundleSignature + "\n-" + strings.Repeat("a", 40) + " missing\n\n"))
+	assert.ErrorIs(err, ErrIncompleteBundle)
+
+	_, err = Import(strings.NewReader(bundleSignature + "\n" + strings.Repeat("a", 40) + " refs/heads/master\n\nnot a packfile"))
+	assert.ErrorIs(err, ErrInvalidBundle)
+}
+
+func TestBundle_GitCompatibility(t *testing.T) {
+	assert := assertion.New(t)
+
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not installed")
+	}
+
+	testRepository := generate(t, GenerateOptions{Commits: 10, Branches: 1, Merges: 1, Tags: 2, Seed: 1})
+
+	dir := t.TempDir()
+	exported := filepath.Join(dir, "exported.bundle")
+
+	err = testRepository.ExportFile(exported)
+	checkErr(t, "exporting repository", err)
+
+	out, err := exec.Command(gitPath, "bundle", "verify", exported).CombinedOutput()
+	checkErr(t, "verifying bundle with git: "+string(out), err)
+
+	created := filepath.Join(dir, "created.bundle")
+
+	out, err = exec.Command(gitPath, "-C", testRepository.Path, "bundle", "create", created, "--all").CombinedOutput()
+	checkErr(t, "creating bundle with git: "+string(out), err)
+
+	imported, err := ImportFile(created)
+	checkErr(t, "importing bundle created by git", err)
+
+	t.Cleanup(func() {
+		_ = imported.Remove()
+	})
+
+	assert.Equal(references(t, testRepository), references(t, imported), "references should have been imported")
+}
+
+// references returns the hashes of the branches and tags of the given repository by name.
+func references(t *testing.T, testRepository *TestRepository) map[string]plumbing.Hash {
+	t.Helper()
+
+	iter, err := testRepository.References()
+	checkErr(t, "fetching references", err)
+
+	refs := make(map[string]plumbing.Hash)
+
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Name().IsBranch() || ref.Name().IsTag() {
+			refs[ref.Name().String()] = ref.Hash()
+		}
+
+		return nil
+	})
+	checkErr(t, "listing references", err)
+
+	return refs
+}
+
+func isClean(t *testing.T, testRepository *TestRepository) bool {
+	t.Helper()
+
+	worktree, err := testRepository.Worktree()
+	checkErr(t, "fetching worktree", err)
+
+	status, err := worktree.Status()
+	checkErr(t, "fetching status", err)
+
+	return status.IsClean()
+}
@@ -0,0 +1,205 @@
+package gittest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var ErrInvalidGenerateOptions = errors.New("invalid generate options")
+
+// generatedCommitTypes are the conventional commit types of the generated commits, the last one being breaking.
+var generatedCommitTypes = []string{"fix", "feat", "chore", "docs", "refactor", "perf", "test", "feat!"}
+
+// GenerateOptions describes the size of a synthetic repository.
+type GenerateOptions struct {
+	// Commits is the number of non-merge commits, spread between the default branch and the other branches.
+	Commits int
+	// Branches is the number of branches forked from the default branch.
+	Branches int
+	// Merges is the number of branches merged back into the default branch, at most Branches.
+	Merges int
+	// Tags is the number of annotated semver tags spread along the history of the default branch.
+	Tags int
+	// Seed drives the commit types and file contents, the same options always produce the same commit hashes.
+	Seed uint64
+}
+
+// Generate creates a new TestRepository whose history is synthesized from the given options. The history of the
+// default branch is split into as many segments as there are branches plus one: each branch is forked at the end of a
+// segment, receives half of the commits of the next segment, and is merged back once the default branch received the
+// other half, if it is one of the first Merges branches. Tags are named v0.1.0, v0.2.0, etc. from the oldest to the
+// newest and never point to the last commit so that there is always something left to release.
+func Generate(opts GenerateOptions) (*TestRepository, error) {
+	segment := opts.Commits / (opts.Branches + 1)
+
+	switch {
+	case opts.Commits < 1 || opts.Branches < 0 || opts.Merges < 0 || opts.Tags < 0:
+		return nil, fmt.Errorf("%w: sizes must be positive and there must be at least one commit", ErrInvalidGenerateOptions)
+	case opts.Merges > opts.Branches:
+		return nil, fmt.Errorf("%w: cannot merge %d out of %d branches", ErrInvalidGenerateOptions, opts.Merges, opts.Branches)
+	case opts.Branches > 0 && segment < 2:
+		return nil, fmt.Errorf("%w: %d commits are not enough for %d branches", ErrInvalidGenerateOptions, opts.Commits, opts.Branches)
+	}
+
+	testRepository, err := NewRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	generator := &generator{
+		repository: testRepository,
+		random:     rand.New(rand.NewPCG(opts.Seed, opts.Seed)),
+	}
+
+	if err = generator.run(opts, segment); err != nil {
+		_ = testRepository.Remove()
+		return nil, err
+	}
+
+	return testRepository, nil
+}
+
+type generator struct {
+	repository *TestRepository
+	random     *rand.Rand
+	commits    int
+	// trunk holds the commits of the default branch that can be tagged, oldest first.
+	trunk []plumbing.Hash
+}
+
+func (g *generator) run(opts GenerateOptions, segment int) error {
+	head, err := g.repository.Head()
+	if err != nil {
+		return fmt.Errorf("fetching head: %w", err)
+	}
+
+	defaultBranch := head.Name().Short()
+	g.trunk = append(g.trunk, head.Hash())
+
+	// The default branch receives the remainder of the division on top of its first segment
+	if err = g.commitN(opts.Commits-segment*opts.Branches, true); err != nil {
+		return err
+	}
+
+	for i := range opts.Branches {
+		name := fmt.Sprintf("branch-%d", i)
+
+		if err = g.repository.CheckoutBranch(name); err != nil {
+			return fmt.Errorf("creating branch %q: %w", name, err)
+		}
+
+		if err = g.commitN(segment/2, false); err != nil {
+			return err
+		}
+
+		if err = g.repository.Checkout(defaultBranch); err != nil {
+			return fmt.Errorf("checking out branch %q: %w", defaultBranch, err)
+		}
+
+		if err = g.commitN(segment-segment/2, true); err != nil {
+			return err
+		}
+
+		if i >= opts.Merges {
+			continue
+		}
+
+		hash, err := g.repository.Merge(name)
+		if err != nil {
+			return err
+		}
+
+		g.trunk = append(g.trunk, hash)
+	}
+
+	// The last commit is left untagged
+	taggable := g.trunk[:len(g.trunk)-1]
+
+	if opts.Tags > len(taggable) {
+		return fmt.Errorf("%w: %d tags do not fit on %d commits", ErrInvalidGenerateOptions, opts.Tags, len(taggable))
+	}
+
+	for i := range opts.Tags {
+		hash := taggable[(i+1)*len(taggable)/(opts.Tags+1)]
+		name := fmt.Sprintf("v0.%d.0", i+1)
+
+		if err = g.repository.AddTag(name, hash); err != nil {
+			return fmt.Errorf("adding tag %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// commitN adds n commits to the current branch, recording them as taggable if they are on the default branch.
+func (g *generator) commitN(n int, trunk bool) error {
+	for range n {
+		g.commits++
+
+		commitType := generatedCommitTypes[g.random.IntN(len(generatedCommitTypes))]
+		message := fmt.Sprintf("%s: generated commit %d", commitType, g.commits)
+
+		hash, err := g.commit(message, strconv.FormatUint(g.random.Uint64(), 10))
+		if err != nil {
+			return err
+		}
+
+		if trunk {
+			g.trunk = append(g.trunk, hash)
+		}
+	}
+
+	return nil
+}
+
+// commit adds a commit with the given message writing the given content to the sample file, unlike
+// TestRepository.AddCommitWithMessage whose content is random.
+func (g *generator) commit(message, content string) (plumbing.Hash, error) {
+	var commitHash plumbing.Hash
+
+	r := g.repository
+
+	worktree, err := r.Worktree()
+	if err != nil {
+		return commitHash, fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(r.Path, sampleFile), []byte(content), 0o644)
+	if err != nil {
+		return commitHash, fmt.Errorf("writing commit file: %w", err)
+	}
+
+	_, err = worktree.Add(sampleFile)
+	if err != nil {
+		return commitHash, fmt.Errorf("adding commit file to worktree: %w", err)
+	}
+
+	when := r.When()
+
+	commitHash, err = worktree.Commit(message, &git.CommitOptions{
+		Committer: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  when,
+		},
+		Author: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  when,
+		},
+		AllowEmptyCommits: true,
+	})
+	if err != nil {
+		return commitHash, fmt.Errorf("creating commit: %w", err)
+	}
+
+	return commitHash, nil
+}
@@ -0,0 +1,120 @@
+package gittest
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	assert := assertion.New(t)
+
+	opts := GenerateOptions{
+		Commits:  50,
+		Branches: 4,
+		Merges:   3,
+		Tags:     5,
+		Seed:     42,
+	}
+
+	first := generate(t, opts)
+	second := generate(t, opts)
+
+	firstHead, err := first.Head()
+	checkErr(t, "fetching head", err)
+
+	secondHead, err := second.Head()
+	checkErr(t, "fetching head", err)
+
+	assert.Equal(firstHead.Hash(), secondHead.Hash(), "the same options should produce the same history")
+
+	commits, merges := 0, 0
+
+	iter, err := first.CommitObjects()
+	checkErr(t, "fetching commits", err)
+
+	err = iter.ForEach(func(c *object.Commit) error {
+		commits++
+		if c.NumParents() > 1 {
+			merges++
+		}
+
+		return nil
+	})
+	checkErr(t, "counting commits", err)
+
+	// The first commit of the repository is not generated
+	assert.Equal(opts.Commits+opts.Merges+1, commits, "commits count should match the options")
+	assert.Equal(opts.Merges, merges, "merges count should match the options")
+
+	tags, err := first.Tags()
+	checkErr(t, "fetching tags", err)
+
+	var names []string
+
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		names = append(names, ref.Name().Short())
+		return nil
+	})
+	checkErr(t, "listing tags", err)
+
+	assert.ElementsMatch([]string{"v0.1.0", "v0.2.0", "v0.3.0", "v0.4.0", "v0.5.0"}, names)
+
+	branches, err := first.Branches()
+	checkErr(t, "fetching branches", err)
+
+	count := 0
+
+	err = branches.ForEach(func(*plumbing.Reference) error {
+		count++
+		return nil
+	})
+	checkErr(t, "counting branches", err)
+
+	assert.Equal(opts.Branches+1, count, "branches count should match the options")
+
+	other := generate(t, GenerateOptions{Commits: 50, Branches: 4, Merges: 3, Tags: 5, Seed: 7})
+
+	otherHead, err := other.Head()
+	checkErr(t, "fetching head", err)
+
+	assert.NotEqual(firstHead.Hash(), otherHead.Hash(), "another seed should produce another history")
+}
+
+func TestGenerate_InvalidOptions(t *testing.T) {
+	assert := assertion.New(t)
+
+	tests := []GenerateOptions{
+		{Commits: 0},
+		{Commits: 10, Branches: 1, Merges: 2},
+		{Commits: 10, Branches: 5},
+		{Commits: 3, Tags: 4},
+	}
+
+	for _, opts := range tests {
+		_, err := Generate(opts)
+		assert.ErrorIs(err, ErrInvalidGenerateOptions, "%+v", opts)
+	}
+}
+
+func generate(t *testing.T, opts GenerateOptions) *TestRepository {
+	t.Helper()
+
+	testRepository, err := Generate(opts)
+	checkErr(t, "generating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	return testRepository
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -2,9 +2,11 @@
 package gittest
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"math/rand/v2"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -14,6 +16,7 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"golang.org/x/crypto/ssh"
 )
 
 const sampleFile = "sample.txt"
@@ -23,9 +26,12 @@ var referenceTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
 type TestRepository struct {
 	*git.Repository
 	RemoteServer *http.Server
-	RemoteURL    string
-	Path         string
-	Counter      uint
+	// SSHHostKey is the host key of the server started by StartSSHServer.
+	SSHHostKey  ssh.PublicKey
+	RemoteURL   string
+	Path        string
+	Counter     uint
+	sshListener net.Listener
 }
 
 // NewRepository creates a new TestRepository.
@@ -110,6 +116,11 @@ func (r *TestRepository) Clone() (*TestRepository, error) {
 
 // AddCommit adds a new commit with a given conventional commit type to the underlying Git repository.
 func (r *TestRepository) AddCommit(commitType string) (plumbing.Hash, error) {
+	return r.AddCommitWithMessage(fmt.Sprintf("%s: this a test commit", commitType))
+}
+
+// AddCommitWithMessage adds a new commit with a given message to the underlying Git repository.
+func (r *TestRepository) AddCommitWithMessage(commitMessage string) (plumbing.Hash, error) {
 	var commitHash plumbing.Hash
 
 	worktree, err := r.Worktree()
@@ -129,8 +140,6 @@ func (r *TestRepository) AddCommit(commitType string) (plumbing.Hash, error) {
 		return commitHash, fmt.Errorf("adding commit file to worktree: %w", err)
 	}
 
-	commitMessage := fmt.Sprintf("%s: this a test commit", commitType)
-
 	when := r.When()
 
 	commitOpts := &git.CommitOptions{
@@ -144,6 +153,7 @@ func (r *TestRepository) AddCommit(commitType string) (plumbing.Hash, error) {
 			Email: "go-semver@release.ci",
 			When:  when,
 		},
+		AllowEmptyCommits: true,
 	}
 
 	commitHash, err = worktree.Commit(commitMessage, commitOpts)
@@ -205,6 +215,86 @@ func (r *TestRepository) AddCommitWithSpecificFile(commitType, filePath string)
 	return commitHash, nil
 }
 
+// CherryPick adds a copy of the given commit, with the same author and message, on top of the current HEAD of the
+// underlying Git repository.
+func (r *TestRepository) CherryPick(hash plumbing.Hash) (plumbing.Hash, error) {
+	var commitHash plumbing.Hash
+
+	c, err := r.CommitObject(hash)
+	if err != nil {
+		return commitHash, fmt.Errorf("fetching commit %q: %w", hash, err)
+	}
+
+	worktree, err := r.Worktree()
+	if err != nil {
+		return commitHash, fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	author := c.Author
+
+	commitOpts := &git.CommitOptions{
+		Committer: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  r.When(),
+		},
+		Author:            &author,
+		AllowEmptyCommits: true,
+	}
+
+	commitHash, err = worktree.Commit(c.Message, commitOpts)
+	if err != nil {
+		return commitHash, fmt.Errorf("creating commit: %w", err)
+	}
+
+	return commitHash, nil
+}
+
+// Merge adds a new merge commit, whose parents are the current HEAD and the given branch, to the underlying Git
+// repository.
+func (r *TestRepository) Merge(branchName string) (plumbing.Hash, error) {
+	var commitHash plumbing.Hash
+
+	head, err := r.Head()
+	if err != nil {
+		return commitHash, fmt.Errorf("fetching head: %w", err)
+	}
+
+	branchRef, err := r.Reference(plumbing.NewBranchReferenceName(branchName), true)
+	if err != nil {
+		return commitHash, fmt.Errorf("fetching branch %q: %w", branchName, err)
+	}
+
+	worktree, err := r.Worktree()
+	if err != nil {
+		return commitHash, fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	when := r.When()
+
+	commitOpts := &git.CommitOptions{
+		Parents: []plumbing.Hash{head.Hash(), branchRef.Hash()},
+		Committer: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  when,
+		},
+		Author: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  when,
+		},
+		AllowEmptyCommits: true,
+	}
+
+	commitHash, err = worktree.Commit(fmt.Sprintf("Merge branch '%s'", branchName), commitOpts)
+	if err != nil {
+		return commitHash, fmt.Errorf("creating merge commit: %w", err)
+	}
+
+	return commitHash, nil
+}
+
 // AddTag adds a new tag to the underlying Git repository with a given name and pointing to a given hash.
 func (r *TestRepository) AddTag(tagName string, hash plumbing.Hash) error {
 	commit, err := r.CommitObject(hash)
@@ -226,9 +316,117 @@ func (r *TestRepository) AddTag(tagName string, hash plumbing.Hash) error {
 	return err
 }
 
-// Remove removes the underlying Git repository.
+// AddTagAt adds a new tag to the underlying Git repository with a given name and pointing to the commit the given
+// revision (e.g., "HEAD~2", a branch name or a hash) resolves to, and returns the hash of that commit.
+func (r *TestRepository) AddTagAt(tagName, revision string) (plumbing.Hash, error) {
+	hash, err := r.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("resolving revision %q: %w", revision, err)
+	}
+
+	return *hash, r.AddTag(tagName, *hash)
+}
+
+// Reset moves the current branch of the underlying Git repository to the given commit, discarding the changes of the
+// worktree like "git reset --hard" would.
+func (r *TestRepository) Reset(hash plumbing.Hash) error {
+	worktree, err := r.Worktree()
+	if err != nil {
+		return fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	err = worktree.Reset(&git.ResetOptions{
+		Commit: hash,
+		Mode:   git.HardReset,
+	})
+	if err != nil {
+		return fmt.Errorf("resetting to %q: %w", hash, err)
+	}
+
+	return nil
+}
+
+// Rebase replays the commits of the current branch that are not reachable from the given branch on top of it, oldest
+// first, and moves the current branch to the last replayed commit whose hash is returned. Like CherryPick, only the
+// messages and authors of the commits are replayed, and like "git rebase", merge commits are dropped.
+func (r *TestRepository) Rebase(onto string) (plumbing.Hash, error) {
+	head, err := r.Head()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching head: %w", err)
+	}
+
+	ontoRef, err := r.Reference(plumbing.NewBranchReferenceName(onto), true)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching branch %q: %w", onto, err)
+	}
+
+	ontoCommit, err := r.CommitObject(ontoRef.Hash())
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching commit %q: %w", ontoRef.Hash(), err)
+	}
+
+	c, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("fetching commit %q: %w", head.Hash(), err)
+	}
+
+	// The current branch is already up-to-date
+	upToDate, err := ontoCommit.IsAncestor(c)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("checking ancestry of %q: %w", ontoCommit.Hash, err)
+	}
+
+	if upToDate || ontoCommit.Hash == c.Hash {
+		return c.Hash, nil
+	}
+
+	var replayed []*object.Commit
+
+	for {
+		reachable, err := c.IsAncestor(ontoCommit)
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("checking ancestry of %q: %w", c.Hash, err)
+		}
+
+		if reachable {
+			break
+		}
+
+		if c.NumParents() < 2 {
+			replayed = append(replayed, c)
+		}
+
+		if c.NumParents() == 0 {
+			break
+		}
+
+		parent, err := c.Parent(0)
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("fetching parent of %q: %w", c.Hash, err)
+		}
+
+		c = parent
+	}
+
+	if err = r.Reset(ontoCommit.Hash); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	hash := ontoCommit.Hash
+
+	for i := len(replayed) - 1; i >= 0; i-- {
+		hash, err = r.CherryPick(replayed[i].Hash)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	return hash, nil
+}
+
+// Remove stops the servers of the underlying Git repository, if any, and removes it.
 func (r *TestRepository) Remove() error {
-	return os.RemoveAll(r.Path)
+	return errors.Join(r.stopServers(), os.RemoveAll(r.Path))
 }
 
 // CheckoutBranch creates a new branch with the given name and checkout to it.
@@ -264,6 +462,19 @@ func (r *TestRepository) CheckoutBranch(name string) error {
 	return nil
 }
 
+// Checkout checkouts to an existing branch with the given name.
+func (r *TestRepository) Checkout(name string) error {
+	worktree, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	return worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(name),
+		Force:  true,
+	})
+}
+
 // When returns a time.Time starting at 2000/01/01 00:00:00 and increasing of 10 second every new call.
 func (r *TestRepository) When() time.Time {
 	r.Counter++
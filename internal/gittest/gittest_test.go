@@ -0,0 +1,125 @@
+package gittest
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestTestRepository_AddTagAt(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := newRepository(t)
+
+	older, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	hash, err := testRepository.AddTagAt("v1.0.0", "HEAD~1")
+	checkErr(t, "adding tag", err)
+
+	assert.Equal(older, hash, "tag should point to the older commit")
+
+	tag, err := testRepository.Tag("v1.0.0")
+	checkErr(t, "fetching tag", err)
+
+	tagObject, err := testRepository.TagObject(tag.Hash())
+	checkErr(t, "fetching tag object", err)
+
+	assert.Equal(older, tagObject.Target, "annotated tag should target the older commit")
+
+	_, err = testRepository.AddTagAt("v2.0.0", "does-not-exist")
+	assert.Error(err, "tagging an unknown revision should fail")
+}
+
+func TestTestRepository_Reset(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := newRepository(t)
+
+	first, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.Reset(first)
+	checkErr(t, "resetting", err)
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	assert.Equal(first, head.Hash(), "head should have been reset")
+	assert.Equal("master", head.Name().Short(), "branch should have been moved, not detached")
+
+	next, err := testRepository.AddCommit("chore")
+	checkErr(t, "adding commit", err)
+
+	c, err := testRepository.CommitObject(next)
+	checkErr(t, "fetching commit", err)
+
+	assert.Equal(first, c.ParentHashes[0], "history should continue from the reset commit")
+}
+
+func TestTestRepository_Rebase(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := newRepository(t)
+
+	err := testRepository.CheckoutBranch("feature")
+	checkErr(t, "creating branch", err)
+
+	_, err = testRepository.AddCommitWithMessage("feat: first")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommitWithMessage("fix: second")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.Checkout("master")
+	checkErr(t, "checking out master", err)
+
+	masterHead, err := testRepository.AddCommit("chore")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.Checkout("feature")
+	checkErr(t, "checking out feature", err)
+
+	hash, err := testRepository.Rebase("master")
+	checkErr(t, "rebasing", err)
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	assert.Equal(hash, head.Hash(), "branch should point to the last replayed commit")
+
+	last, err := testRepository.CommitObject(hash)
+	checkErr(t, "fetching commit", err)
+
+	first, err := last.Parent(0)
+	checkErr(t, "fetching parent", err)
+
+	assert.Equal("fix: second", last.Message)
+	assert.Equal("feat: first", first.Message)
+	assert.Equal(masterHead, first.ParentHashes[0], "replayed commits should be on top of master")
+
+	// Rebasing again is a no-op since every commit is already on top of master
+	again, err := testRepository.Rebase("master")
+	checkErr(t, "rebasing again", err)
+
+	assert.Equal(hash, again, "rebasing an up-to-date branch should not replay anything")
+}
+
+func newRepository(t *testing.T) *TestRepository {
+	t.Helper()
+
+	testRepository, err := NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	return testRepository
+}
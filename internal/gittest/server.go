@@ -0,0 +1,483 @@
+package gittest
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/format/pktline"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"golang.org/x/crypto/ssh"
+)
+
+// RemotePath is the path of the repository on the servers started by StartHTTPServer and StartSSHServer.
+const RemotePath = "/repository.git"
+
+var ErrServerStarted = errors.New("server already started")
+
+type serverOptions struct {
+	username      string
+	password      string
+	token         string
+	authorizedKey ssh.PublicKey
+}
+
+type ServerOptionFunc func(o *serverOptions)
+
+// WithBasicAuth requires the clients of the servers to authenticate with the given username and password, using HTTP
+// basic authentication or SSH password authentication.
+func WithBasicAuth(username, password string) ServerOptionFunc {
+	return func(o *serverOptions) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithToken requires the clients of the HTTP server to authenticate with the given bearer token. The token is also
+// accepted as the password of HTTP basic authentication, with any username, as most Git forges do.
+func WithToken(token string) ServerOptionFunc {
+	return func(o *serverOptions) {
+		o.token = token
+	}
+}
+
+// WithAuthorizedKey requires the clients of the SSH server to authenticate with the private key of the given public key.
+func WithAuthorizedKey(key ssh.PublicKey) ServerOptionFunc {
+	return func(o *serverOptions) {
+		o.authorizedKey = key
+	}
+}
+
+// NewSSHSigner returns a new, in-memory, ed25519 SSH key.
+func NewSSHSigner() (ssh.Signer, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating key: %w", err)
+	}
+
+	return ssh.NewSignerFromKey(key)
+}
+
+// StartHTTPServer serves the underlying Git repository over the smart HTTP protocol on a random local port, clients
+// can then fetch from and push to RemoteURL. Anonymous clients are accepted unless an authentication is required by
+// the given options. The server is stopped by Remove.
+func (r *TestRepository) StartHTTPServer(options ...ServerOptionFunc) error {
+	if r.RemoteServer != nil {
+		return ErrServerStarted
+	}
+
+	opts := newServerOptions(options)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("listening: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+RemotePath+"/info/refs", r.handleInfoRefs)
+	mux.HandleFunc("POST "+RemotePath+"/"+transport.UploadPackServiceName, r.handleUploadPack)
+	mux.HandleFunc("POST "+RemotePath+"/"+transport.ReceivePackServiceName, r.handleReceivePack)
+
+	r.RemoteServer = &http.Server{Handler: opts.httpAuth(mux)}
+	r.RemoteURL = "http://" + listener.Addr().String() + RemotePath
+
+	go func() {
+		_ = r.RemoteServer.Serve(listener)
+	}()
+
+	return nil
+}
+
+// StartSSHServer serves the underlying Git repository over SSH on a random local port, with an in-memory host key
+// whose public key is set as SSHHostKey, clients can then fetch from and push to RemoteURL. Anonymous clients are
+// accepted unless an authentication is required by the given options. The server is stopped by Remove.
+func (r *TestRepository) StartSSHServer(options ...ServerOptionFunc) error {
+	if r.sshListener != nil {
+		return ErrServerStarted
+	}
+
+	opts := newServerOptions(options)
+
+	hostKey, err := NewSSHSigner()
+	if err != nil {
+		return fmt.Errorf("generating host key: %w", err)
+	}
+
+	config := opts.sshConfig()
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("listening: %w", err)
+	}
+
+	r.sshListener = listener
+	r.SSHHostKey = hostKey.PublicKey()
+	r.RemoteURL = "ssh://git@" + listener.Addr().String() + RemotePath
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go r.serveSSH(conn, config)
+		}
+	}()
+
+	return nil
+}
+
+// stopServers stops the servers started by StartHTTPServer and StartSSHServer.
+func (r *TestRepository) stopServers() error {
+	var errs []error
+
+	if r.RemoteServer != nil {
+		errs = append(errs, r.RemoteServer.Close())
+		r.RemoteServer = nil
+	}
+
+	if r.sshListener != nil {
+		errs = append(errs, r.sshListener.Close())
+		r.sshListener = nil
+	}
+
+	return errors.Join(errs...)
+}
+
+func newServerOptions(options []ServerOptionFunc) *serverOptions {
+	opts := &serverOptions{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
+
+// httpAuth rejects the requests that do not carry the credentials required by the options.
+func (o *serverOptions) httpAuth(next http.Handler) http.Handler {
+	if o.username == "" && o.token == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		username, password, basic := req.BasicAuth()
+
+		switch {
+		case basic && o.username != "" && equal(username, o.username) && equal(password, o.password):
+		case basic && o.token != "" && equal(password, o.token):
+		case o.token != "" && equal(req.Header.Get("Authorization"), "Bearer "+o.token):
+		case req.Header.Get("Authorization") == "":
+			w.Header().Set("WWW-Authenticate", `Basic realm="gittest"`)
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		default:
+			http.Error(w, "invalid credentials", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// sshConfig returns the configuration of an SSH server requiring the credentials required by the options.
+func (o *serverOptions) sshConfig() *ssh.ServerConfig {
+	config := &ssh.ServerConfig{
+		NoClientAuth: o.username == "" && o.authorizedKey == nil,
+	}
+
+	if o.username != "" {
+		config.PasswordCallback = func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if equal(conn.User(), o.username) && equal(string(password), o.password) {
+				return nil, nil
+			}
+
+			return nil, errors.New("invalid password")
+		}
+	}
+
+	if o.authorizedKey != nil {
+		authorized := o.authorizedKey.Marshal()
+
+		config.PublicKeyCallback = func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if subtle.ConstantTimeCompare(key.Marshal(), authorized) == 1 {
+				return nil, nil
+			}
+
+			return nil, errors.New("unauthorized key")
+		}
+	}
+
+	return config
+}
+
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// gitServer returns the transport serving the underlying Git repository whatever the requested path.
+func (r *TestRepository) gitServer() transport.Transport {
+	return server.NewServer(storerLoader{storer: r.Storer})
+}
+
+type storerLoader struct {
+	storer storer.Storer
+}
+
+func (l storerLoader) Load(*transport.Endpoint) (storer.Storer, error) {
+	return l.storer, nil
+}
+
+func (r *TestRepository) handleInfoRefs(w http.ResponseWriter, req *http.Request) {
+	service := req.URL.Query().Get("service")
+
+	var session transport.Session
+
+	var err error
+
+	switch service {
+	case transport.UploadPackServiceName:
+		session, err = r.gitServer().NewUploadPackSession(nil, nil)
+	case transport.ReceivePackServiceName:
+		session, err = r.gitServer().NewReceivePackSession(nil, nil)
+	default:
+		http.Error(w, "only the smart HTTP protocol is supported", http.StatusForbidden)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	advRefs, err := session.AdvertisedReferences()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	advRefs.Prefix = [][]byte{[]byte("# service=" + service + "\n"), pktline.Flush}
+
+	w.Header().Set("Content-Type", "application/x-"+service+"-advertisement")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	_ = advRefs.Encode(w)
+}
+
+func (r *TestRepository) handleUploadPack(w http.ResponseWriter, req *http.Request) {
+	session, err := r.gitServer().NewUploadPackSession(nil, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	request := packp.NewUploadPackRequest()
+	if err = request.Decode(req.Body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := session.UploadPack(req.Context(), request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-git-upload-pack-result")
+
+	_ = response.Encode(w)
+}
+
+func (r *TestRepository) handleReceivePack(w http.ResponseWriter, req *http.Request) {
+	session, err := r.gitServer().NewReceivePackSession(nil, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	request := packp.NewReferenceUpdateRequest()
+	if err = request.Decode(req.Body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status, err := session.ReceivePack(req.Context(), request)
+	if status == nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-git-receive-pack-result")
+
+	_ = status.Encode(w)
+}
+
+// serveSSH serves the Git commands executed by the clients of the given SSH connection.
+func (r *TestRepository) serveSSH(conn net.Conn, config *ssh.ServerConfig) {
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	defer func() {
+		_ = serverConn.Close()
+	}()
+
+	go ssh.DiscardRequests(requests)
+
+	var wg sync.WaitGroup
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "only session channels are supported")
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			r.serveSSHSession(channel, channelRequests)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// serveSSHSession executes the git-upload-pack or git-receive-pack command requested on the given channel.
+func (r *TestRepository) serveSSHSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer func() {
+		_ = channel.Close()
+	}()
+
+	for req := range requests {
+		switch req.Type {
+		case "env":
+			_ = req.Reply(true, nil)
+		case "exec":
+			var payload struct{ Command string }
+
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+
+			_ = req.Reply(true, nil)
+
+			exitStatus := uint32(0)
+
+			// The channel is hidden behind a reader and a writer since the packfile of a push is closed once read
+			stdio := struct {
+				io.Reader
+				io.Writer
+			}{channel, channel}
+
+			if err := r.execGitCommand(context.Background(), payload.Command, stdio); err != nil {
+				_, _ = fmt.Fprintln(channel.Stderr(), err)
+				exitStatus = 1
+			}
+
+			status := make([]byte, 4)
+			binary.BigEndian.PutUint32(status, exitStatus)
+
+			_, _ = channel.SendRequest("exit-status", false, status)
+
+			return
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+// execGitCommand serves the given Git command, such as "git-upload-pack '/repository.git'", over the given channel.
+func (r *TestRepository) execGitCommand(ctx context.Context, command string, channel io.ReadWriter) error {
+	service, _, _ := strings.Cut(command, " ")
+
+	switch service {
+	case transport.UploadPackServiceName:
+		session, err := r.gitServer().NewUploadPackSession(nil, nil)
+		if err != nil {
+			return err
+		}
+
+		advRefs, err := session.AdvertisedReferences()
+		if err != nil {
+			return err
+		}
+
+		if err = advRefs.Encode(channel); err != nil {
+			return err
+		}
+
+		request := packp.NewUploadPackRequest()
+		if err = request.Decode(channel); err != nil {
+			// The client closes the connection without request when it is already up-to-date
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		response, err := session.UploadPack(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		return response.Encode(channel)
+	case transport.ReceivePackServiceName:
+		session, err := r.gitServer().NewReceivePackSession(nil, nil)
+		if err != nil {
+			return err
+		}
+
+		advRefs, err := session.AdvertisedReferences()
+		if err != nil {
+			return err
+		}
+
+		if err = advRefs.Encode(channel); err != nil {
+			return err
+		}
+
+		request := packp.NewReferenceUpdateRequest()
+		if err = request.Decode(channel); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		status, err := session.ReceivePack(ctx, request)
+		if status != nil {
+			if encodeErr := status.Encode(channel); encodeErr != nil {
+				return encodeErr
+			}
+		}
+
+		return err
+	default:
+		return fmt.Errorf("unsupported command %q", command)
+	}
+}
@@ -0,0 +1,125 @@
+package gittest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+	assertion "github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestServer_HTTP(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := newRepository(t)
+
+	err := testRepository.StartHTTPServer(WithBasicAuth("user", "password"), WithToken("token"))
+	checkErr(t, "starting server", err)
+
+	err = testRepository.StartHTTPServer()
+	assert.ErrorIs(err, ErrServerStarted)
+
+	_, err = clone(testRepository.RemoteURL, nil)
+	assert.ErrorIs(err, transport.ErrAuthenticationRequired, "anonymous clone should have been rejected")
+
+	_, err = clone(testRepository.RemoteURL, &http.BasicAuth{Username: "user", Password: "wrong"})
+	assert.ErrorIs(err, transport.ErrAuthorizationFailed, "clone with invalid credentials should have been rejected")
+
+	_, err = clone(testRepository.RemoteURL, &http.TokenAuth{Token: "token"})
+	checkErr(t, "cloning with token", err)
+
+	_, err = clone(testRepository.RemoteURL, &http.BasicAuth{Username: "x-access-token", Password: "token"})
+	checkErr(t, "cloning with token as password", err)
+
+	auth := &http.BasicAuth{Username: "user", Password: "password"}
+
+	cloned, err := clone(testRepository.RemoteURL, auth)
+	checkErr(t, "cloning with basic auth", err)
+
+	pushed := push(t, cloned, auth)
+
+	tag, err := testRepository.Tag("v1.0.0")
+	checkErr(t, "fetching pushed tag", err)
+
+	assert.Equal(pushed, tag.Hash(), "tag should have been pushed to the served repository")
+}
+
+func TestServer_SSH(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository := newRepository(t)
+
+	clientKey, err := NewSSHSigner()
+	checkErr(t, "generating client key", err)
+
+	otherKey, err := NewSSHSigner()
+	checkErr(t, "generating other key", err)
+
+	err = testRepository.StartSSHServer(WithAuthorizedKey(clientKey.PublicKey()), WithBasicAuth("git", "password"))
+	checkErr(t, "starting server", err)
+
+	publicKeys := func(signer ssh.Signer) *gitssh.PublicKeys {
+		auth := &gitssh.PublicKeys{User: "git", Signer: signer}
+		auth.HostKeyCallback = ssh.FixedHostKey(testRepository.SSHHostKey)
+
+		return auth
+	}
+
+	_, err = clone(testRepository.RemoteURL, publicKeys(otherKey))
+	assert.Error(err, "clone with an unauthorized key should have been rejected")
+
+	password := &gitssh.Password{User: "git", Password: "password"}
+	password.HostKeyCallback = ssh.FixedHostKey(testRepository.SSHHostKey)
+
+	_, err = clone(testRepository.RemoteURL, password)
+	checkErr(t, "cloning with password", err)
+
+	cloned, err := clone(testRepository.RemoteURL, publicKeys(clientKey))
+	checkErr(t, "cloning with key", err)
+
+	pushed := push(t, cloned, publicKeys(clientKey))
+
+	tag, err := testRepository.Tag("v1.0.0")
+	checkErr(t, "fetching pushed tag", err)
+
+	assert.Equal(pushed, tag.Hash(), "tag should have been pushed to the served repository")
+
+	err = testRepository.Remove()
+	checkErr(t, "removing repository", err)
+
+	_, err = clone(testRepository.RemoteURL, publicKeys(clientKey))
+	assert.Error(err, "server should have been stopped")
+}
+
+func clone(url string, auth transport.AuthMethod) (*git.Repository, error) {
+	return git.CloneContext(context.Background(), memory.NewStorage(), nil, &git.CloneOptions{
+		URL:  url,
+		Auth: auth,
+	})
+}
+
+// push pushes a new tag pointing to the HEAD of the given repository and returns the hash of the tag reference.
+func push(t *testing.T, repository *git.Repository, auth transport.AuthMethod) plumbing.Hash {
+	t.Helper()
+
+	head, err := repository.Head()
+	checkErr(t, "fetching head", err)
+
+	tag, err := repository.CreateTag("v1.0.0", head.Hash(), nil)
+	checkErr(t, "creating tag", err)
+
+	err = repository.Push(&git.PushOptions{
+		RefSpecs: []config.RefSpec{"refs/tags/v1.0.0:refs/tags/v1.0.0"},
+		Auth:     auth,
+	})
+	checkErr(t, "pushing tag", err)
+
+	return tag.Hash()
+}
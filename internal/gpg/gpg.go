@@ -2,17 +2,258 @@
 package gpg
 
 import (
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 )
 
-// FromArmored reads an armored keyring buffer and returns the first key pair.
-func FromArmored(reader io.Reader) (*openpgp.Entity, error) {
+var (
+	ErrPassphraseRequired = errors.New("private key is encrypted, a passphrase is required")
+	ErrKeyNotFound        = errors.New("no matching key found in keyring")
+	ErrInvalidKeyID       = errors.New("invalid key ID")
+	ErrKeyExpired         = errors.New("key is expired")
+	ErrKeyRevoked         = errors.New("key is revoked")
+	ErrCannotSign         = errors.New("key has no signing capability")
+)
+
+const minKeyIDLength = 8
+
+type OptionFunc func(s *selector)
+
+// WithKeyID selects the key, or subkey, whose fingerprint ends with the given hexadecimal key ID (e.g., a short or long
+// key ID or a full fingerprint).
+func WithKeyID(id string) OptionFunc {
+	return func(s *selector) {
+		s.keyID = id
+	}
+}
+
+// WithEmail selects the entity having an identity with the given email.
+func WithEmail(email string) OptionFunc {
+	return func(s *selector) {
+		s.email = email
+	}
+}
+
+// WithTime sets the date at which the selected key must be valid, the current date by default.
+func WithTime(now time.Time) OptionFunc {
+	return func(s *selector) {
+		s.now = now
+	}
+}
+
+type selector struct {
+	keyID string
+	email string
+	now   time.Time
+}
+
+// FromArmored reads an armored keyring buffer and returns the first key pair matching the given options, or the first
+// key pair if no option is given. The selected key must be able to sign: unless a subkey is selected, the newest valid
+// signing subkey is used, or else the primary key.
+func FromArmored(reader io.Reader, options ...OptionFunc) (*openpgp.Entity, error) {
 	entities, err := openpgp.ReadArmoredKeyRing(reader)
 	if err != nil {
 		return nil, err
 	}
 
-	return entities[0], nil
+	s := &selector{now: time.Now()}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	entity, subkey, err := s.selectEntity(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkSigningKey(entity, subkey, s.now); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// selectEntity returns the entity matching the selector, along with its subkey designated by the key ID, if any.
+func (s *selector) selectEntity(entities openpgp.EntityList) (*openpgp.Entity, *openpgp.Subkey, error) {
+	if s.keyID == "" && s.email == "" {
+		return entities[0], nil, nil
+	}
+
+	keyID := strings.ToUpper(strings.TrimPrefix(strings.ReplaceAll(s.keyID, " ", ""), "0x"))
+
+	if keyID != "" {
+		if _, err := hex.DecodeString(keyID); err != nil || len(keyID) < minKeyIDLength {
+			return nil, nil, fmt.Errorf("%w: %q", ErrInvalidKeyID, s.keyID)
+		}
+	}
+
+	for _, entity := range entities {
+		if s.email != "" && !hasEmail(entity, s.email) {
+			continue
+		}
+
+		if keyID == "" {
+			return entity, nil, nil
+		}
+
+		if matchesKeyID(entity.PrimaryKey, keyID) {
+			return withSigningSubkey(entity, nil), nil, nil
+		}
+
+		for i := range entity.Subkeys {
+			if matchesKeyID(entity.Subkeys[i].PublicKey, keyID) {
+				return withSigningSubkey(entity, &entity.Subkeys[i]), &entity.Subkeys[i], nil
+			}
+		}
+	}
+
+	return nil, nil, ErrKeyNotFound
+}
+
+// checkSigningKey returns a descriptive error if the given entity cannot sign at the given date, because its primary
+// key is expired or revoked, because the given selected subkey, if any, cannot sign, or because none of its keys can.
+func checkSigningKey(entity *openpgp.Entity, selected *openpgp.Subkey, now time.Time) error {
+	fingerprint := keyFingerprint(entity.PrimaryKey)
+
+	selfSignature, identity := entity.PrimarySelfSignature()
+
+	switch {
+	case selfSignature == nil:
+		return fmt.Errorf("%w: primary key %s has no self-signature", ErrCannotSign, fingerprint)
+	case entity.Revoked(now) || (identity != nil && identity.Revoked(now)):
+		return fmt.Errorf("%w: primary key %s", ErrKeyRevoked, fingerprint)
+	case entity.PrimaryKey.KeyExpired(selfSignature, now) || selfSignature.SigExpired(now):
+		return fmt.Errorf("%w: primary key %s expired on %s", ErrKeyExpired, fingerprint, expiry(entity.PrimaryKey, selfSignature))
+	}
+
+	if selected != nil {
+		if err := checkSubkey(selected, now); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := entity.SigningKey(now); ok {
+		return nil
+	}
+
+	// The primary key cannot sign either, the subkeys explain why no key can
+	var errs []error
+
+	for i := range entity.Subkeys {
+		if err := checkSubkey(&entity.Subkeys[i], now); err != nil && !errors.Is(err, ErrCannotSign) {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return fmt.Errorf("%w: neither primary key %s nor its subkeys", ErrCannotSign, fingerprint)
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkSubkey returns a descriptive error if the given subkey cannot sign at the given date.
+func checkSubkey(subkey *openpgp.Subkey, now time.Time) error {
+	fingerprint := keyFingerprint(subkey.PublicKey)
+
+	switch {
+	case subkey.Sig == nil || !subkey.Sig.FlagsValid || !subkey.Sig.FlagSign || !subkey.PublicKey.PubKeyAlgo.CanSign():
+		return fmt.Errorf("%w: subkey %s", ErrCannotSign, fingerprint)
+	case subkey.Revoked(now):
+		return fmt.Errorf("%w: subkey %s", ErrKeyRevoked, fingerprint)
+	case subkey.PublicKey.KeyExpired(subkey.Sig, now) || subkey.Sig.SigExpired(now):
+		return fmt.Errorf("%w: subkey %s expired on %s", ErrKeyExpired, fingerprint, expiry(subkey.PublicKey, subkey.Sig))
+	}
+
+	return nil
+}
+
+// expiry returns the expiration date of the given key according to its self-signature, or of the self-signature
+// itself if the key does not expire.
+func expiry(key *packet.PublicKey, selfSignature *packet.Signature) string {
+	if selfSignature.KeyLifetimeSecs != nil && *selfSignature.KeyLifetimeSecs != 0 {
+		return key.CreationTime.Add(time.Duration(*selfSignature.KeyLifetimeSecs) * time.Second).Format(time.DateOnly)
+	}
+
+	if selfSignature.SigLifetimeSecs != nil && *selfSignature.SigLifetimeSecs != 0 {
+		return selfSignature.CreationTime.Add(time.Duration(*selfSignature.SigLifetimeSecs) * time.Second).Format(time.DateOnly)
+	}
+
+	return "an unknown date"
+}
+
+// keyFingerprint returns the uppercase hexadecimal fingerprint of the given key.
+func keyFingerprint(key *packet.PublicKey) string {
+	return strings.ToUpper(hex.EncodeToString(key.Fingerprint))
+}
+
+func hasEmail(entity *openpgp.Entity, email string) bool {
+	for _, identity := range entity.Identities {
+		if identity.UserId != nil && strings.EqualFold(identity.UserId.Email, email) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesKeyID(key *packet.PublicKey, keyID string) bool {
+	return strings.HasSuffix(keyFingerprint(key), keyID)
+}
+
+// withSigningSubkey returns a copy of the given entity whose only signing-capable subkey is the given one so that it is
+// the key actually used to sign. If the subkey is nil, signing-capable subkeys are all removed so that the primary key
+// is used.
+func withSigningSubkey(entity *openpgp.Entity, selected *openpgp.Subkey) *openpgp.Entity {
+	selection := *entity
+	selection.Subkeys = nil
+
+	for i, subkey := range entity.Subkeys {
+		if &entity.Subkeys[i] == selected || subkey.Sig == nil || !subkey.Sig.FlagSign {
+			selection.Subkeys = append(selection.Subkeys, subkey)
+		}
+	}
+
+	return &selection
+}
+
+// IsEncrypted returns true if the private key, or any private subkey, of a given entity is protected by a passphrase.
+func IsEncrypted(entity *openpgp.Entity) bool {
+	if entity.PrivateKey != nil && entity.PrivateKey.Encrypted {
+		return true
+	}
+
+	for _, subkey := range entity.Subkeys {
+		if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Decrypt decrypts the private key and private subkeys of a given entity using a passphrase so that the entity can be
+// used for signing.
+func Decrypt(entity *openpgp.Entity, passphrase []byte) error {
+	if !IsEncrypted(entity) {
+		return nil
+	}
+
+	if len(passphrase) == 0 {
+		return ErrPassphraseRequired
+	}
+
+	if err := entity.DecryptPrivateKeys(passphrase); err != nil {
+		return fmt.Errorf("decrypting private keys: %w", err)
+	}
+
+	return nil
 }
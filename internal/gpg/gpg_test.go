@@ -2,10 +2,13 @@ package gpg
 
 import (
 	"bytes"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/ProtonMail/go-crypto/openpgp/armor"
@@ -85,3 +88,229 @@ func TestGPG_FromArmored_EmptyReader(t *testing.T) {
 
 	assert.Error(err, "should have failed trying to read empty reader")
 }
+
+func TestGPG_Decrypt(t *testing.T) {
+	assert := assertion.New(t)
+
+	passphrase := []byte("passphrase")
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	if err != nil {
+		t.Fatalf("entity creation failed: %s", err)
+	}
+
+	assert.False(IsEncrypted(entity), "new entity should not be encrypted")
+	assert.NoError(Decrypt(entity, nil), "decrypting an unencrypted entity should be a no-op")
+
+	if err = entity.EncryptPrivateKeys(passphrase, nil); err != nil {
+		t.Fatalf("encrypting private keys failed: %s", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	armorWriter, err := armor.Encode(buf, openpgp.PrivateKeyType, map[string]string{})
+	if err != nil {
+		t.Fatalf("armor encoding failed: %s", err)
+	}
+
+	if err = entity.SerializePrivateWithoutSigning(armorWriter, nil); err != nil {
+		t.Fatalf("serialization failed: %s", err)
+	}
+
+	if err = armorWriter.Close(); err != nil {
+		t.Fatalf("failed to close armor writer: %s", err)
+	}
+
+	actualEntity, err := FromArmored(buf)
+	if err != nil {
+		t.Fatalf("failed to read from armored: %s", err)
+	}
+
+	assert.True(IsEncrypted(actualEntity), "entity should be encrypted")
+	assert.ErrorIs(Decrypt(actualEntity, nil), ErrPassphraseRequired)
+	assert.Error(Decrypt(actualEntity, []byte("wrong")), "should have failed decrypting with a wrong passphrase")
+	assert.NoError(Decrypt(actualEntity, passphrase))
+	assert.False(IsEncrypted(actualEntity), "entity should have been decrypted")
+}
+
+func TestGPG_FromArmored_Selection(t *testing.T) {
+	assert := assertion.New(t)
+
+	config := &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA}
+
+	john, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", config)
+	if err != nil {
+		t.Fatalf("entity creation failed: %s", err)
+	}
+
+	jane, err := openpgp.NewEntity("Jane Doe", "", "jane.doe@example.com", config)
+	if err != nil {
+		t.Fatalf("entity creation failed: %s", err)
+	}
+
+	if err = jane.AddSigningSubkey(config); err != nil {
+		t.Fatalf("subkey creation failed: %s", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	armorWriter, err := armor.Encode(buf, openpgp.PrivateKeyType, map[string]string{})
+	if err != nil {
+		t.Fatalf("armor encoding failed: %s", err)
+	}
+
+	for _, entity := range []*openpgp.Entity{john, jane} {
+		if err = entity.SerializePrivateWithoutSigning(armorWriter, nil); err != nil {
+			t.Fatalf("serialization failed: %s", err)
+		}
+	}
+
+	if err = armorWriter.Close(); err != nil {
+		t.Fatalf("failed to close armor writer: %s", err)
+	}
+
+	keyring := buf.String()
+
+	var signingSubkey *packet.PublicKey
+	for _, subkey := range jane.Subkeys {
+		if subkey.Sig.FlagSign {
+			signingSubkey = subkey.PublicKey
+		}
+	}
+
+	janeFingerprint := hex.EncodeToString(jane.PrimaryKey.Fingerprint)
+
+	type test struct {
+		options  []OptionFunc
+		wantKey  *packet.PublicKey
+		wantErr  error
+		scenario string
+	}
+
+	matrix := []test{
+		{options: nil, wantKey: john.PrimaryKey, scenario: "first entity by default"},
+		{options: []OptionFunc{WithEmail("JANE.doe@example.com")}, wantKey: signingSubkey, scenario: "entity by email"},
+		{options: []OptionFunc{WithKeyID(janeFingerprint)}, wantKey: jane.PrimaryKey, scenario: "primary key by fingerprint"},
+		{options: []OptionFunc{WithKeyID(fmt.Sprintf("0x%X", signingSubkey.KeyId))}, wantKey: signingSubkey, scenario: "subkey by long key ID"},
+		{options: []OptionFunc{WithEmail("john.doe@example.com"), WithKeyID(janeFingerprint)}, wantErr: ErrKeyNotFound, scenario: "email and key ID mismatch"},
+		{options: []OptionFunc{WithEmail("unknown@example.com")}, wantErr: ErrKeyNotFound, scenario: "unknown email"},
+		{options: []OptionFunc{WithKeyID("ABC")}, wantErr: ErrInvalidKeyID, scenario: "too short key ID"},
+		{options: []OptionFunc{WithKeyID("not-hexadecimal")}, wantErr: ErrInvalidKeyID, scenario: "non hexadecimal key ID"},
+	}
+
+	for _, tc := range matrix {
+		entity, err := FromArmored(strings.NewReader(keyring), tc.options...)
+		if tc.wantErr != nil {
+			assert.ErrorIs(err, tc.wantErr, tc.scenario)
+			continue
+		}
+
+		if !assert.NoError(err, tc.scenario) {
+			continue
+		}
+
+		key, ok := entity.SigningKey(time.Now())
+		assert.True(ok, "%s: signing key should have been found", tc.scenario)
+		assert.Equal(tc.wantKey.Fingerprint, key.PublicKey.Fingerprint, "%s: signing key should be equal", tc.scenario)
+	}
+}
+
+func TestGPG_FromArmored_SigningKeyValidity(t *testing.T) {
+	assert := assertion.New(t)
+
+	config := &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA}
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", config)
+	if err != nil {
+		t.Fatalf("entity creation failed: %s", err)
+	}
+
+	encryptionSubkey := entity.Subkeys[0].PublicKey
+
+	if err = entity.AddSigningSubkey(&packet.Config{Algorithm: packet.PubKeyAlgoEdDSA, KeyLifetimeSecs: 3600}); err != nil {
+		t.Fatalf("subkey creation failed: %s", err)
+	}
+
+	expiringSubkey := entity.Subkeys[1].PublicKey
+
+	if err = entity.AddSigningSubkey(config); err != nil {
+		t.Fatalf("subkey creation failed: %s", err)
+	}
+
+	revokedSubkey := entity.Subkeys[2].PublicKey
+
+	if err = entity.RevokeSubkey(&entity.Subkeys[2], packet.KeyRetired, "", nil); err != nil {
+		t.Fatalf("subkey revocation failed: %s", err)
+	}
+
+	expiring, err := openpgp.NewEntity("Jane Doe", "", "jane.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA, KeyLifetimeSecs: 3600})
+	if err != nil {
+		t.Fatalf("entity creation failed: %s", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	armorWriter, err := armor.Encode(buf, openpgp.PrivateKeyType, map[string]string{})
+	if err != nil {
+		t.Fatalf("armor encoding failed: %s", err)
+	}
+
+	for _, e := range []*openpgp.Entity{entity, expiring} {
+		if err = e.SerializePrivateWithoutSigning(armorWriter, nil); err != nil {
+			t.Fatalf("serialization failed: %s", err)
+		}
+	}
+
+	if err = armorWriter.Close(); err != nil {
+		t.Fatalf("failed to close armor writer: %s", err)
+	}
+
+	keyring := buf.String()
+	later := WithTime(time.Now().Add(2 * time.Hour))
+
+	keyID := func(key *packet.PublicKey) OptionFunc {
+		return WithKeyID(fmt.Sprintf("%016X", key.KeyId))
+	}
+
+	type test struct {
+		options  []OptionFunc
+		wantKey  *packet.PublicKey
+		wantErr  error
+		scenario string
+	}
+
+	matrix := []test{
+		{options: nil, wantKey: expiringSubkey, scenario: "valid signing subkey by default"},
+		{options: []OptionFunc{later}, wantKey: entity.PrimaryKey, scenario: "primary key once the signing subkeys are invalid"},
+		{options: []OptionFunc{keyID(expiringSubkey)}, wantKey: expiringSubkey, scenario: "valid subkey by key ID"},
+		{options: []OptionFunc{keyID(expiringSubkey), later}, wantErr: ErrKeyExpired, scenario: "expired subkey by key ID"},
+		{options: []OptionFunc{keyID(revokedSubkey)}, wantErr: ErrKeyRevoked, scenario: "revoked subkey by key ID"},
+		{options: []OptionFunc{keyID(encryptionSubkey)}, wantErr: ErrCannotSign, scenario: "encryption subkey by key ID"},
+		{options: []OptionFunc{WithEmail("jane.doe@example.com")}, wantKey: expiring.PrimaryKey, scenario: "valid primary key"},
+		{options: []OptionFunc{WithEmail("jane.doe@example.com"), later}, wantErr: ErrKeyExpired, scenario: "expired primary key"},
+	}
+
+	for _, tc := range matrix {
+		actualEntity, err := FromArmored(strings.NewReader(keyring), tc.options...)
+		if tc.wantErr != nil {
+			assert.ErrorIs(err, tc.wantErr, tc.scenario)
+			continue
+		}
+
+		if !assert.NoError(err, tc.scenario) {
+			continue
+		}
+
+		s := &selector{now: time.Now()}
+		for _, option := range tc.options {
+			option(s)
+		}
+
+		key, ok := actualEntity.SigningKey(s.now)
+		assert.True(ok, "%s: signing key should have been found", tc.scenario)
+		assert.Equal(tc.wantKey.Fingerprint, key.PublicKey.Fingerprint, "%s: signing key should be equal", tc.scenario)
+	}
+
+	_, err = FromArmored(strings.NewReader(keyring), keyID(expiringSubkey), later)
+	assert.ErrorContains(err, "expired on "+expiringSubkey.CreationTime.Add(time.Hour).Format(time.DateOnly), "error should give the expiration date")
+}
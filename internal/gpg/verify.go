@@ -0,0 +1,34 @@
+package gpg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+var ErrInvalidSignature = errors.New("invalid GPG signature")
+
+// ReadKeyRing reads an armored public keyring whose keys are trusted to verify signatures.
+func ReadKeyRing(reader io.Reader) (openpgp.EntityList, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading armored keyring: %w", err)
+	}
+
+	return keyring, nil
+}
+
+// Verify checks that an armored detached signature of a given message has been produced by one of the keys of the
+// given keyring and returns the entity owning that key.
+func Verify(keyring openpgp.EntityList, message []byte, armoredSignature string) (*openpgp.Entity, error) {
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(message), strings.NewReader(armoredSignature), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return signer, nil
+}
@@ -0,0 +1,70 @@
+package gpg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestGPG_Verify(t *testing.T) {
+	assert := assertion.New(t)
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	if err != nil {
+		t.Fatalf("creating entity: %s", err)
+	}
+
+	other, err := openpgp.NewEntity("Jane Doe", "", "jane.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	if err != nil {
+		t.Fatalf("creating entity: %s", err)
+	}
+
+	publicKey := new(bytes.Buffer)
+
+	armorWriter, err := armor.Encode(publicKey, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("encoding armor: %s", err)
+	}
+
+	if err = entity.Serialize(armorWriter); err != nil {
+		t.Fatalf("serializing public key: %s", err)
+	}
+
+	if err = armorWriter.Close(); err != nil {
+		t.Fatalf("closing armor writer: %s", err)
+	}
+
+	keyring, err := ReadKeyRing(publicKey)
+	if err != nil {
+		t.Fatalf("reading keyring: %s", err)
+	}
+
+	message := []byte("message")
+
+	sign := func(signer *openpgp.Entity) string {
+		signature := new(bytes.Buffer)
+		if err := openpgp.ArmoredDetachSign(signature, signer, bytes.NewReader(message), nil); err != nil {
+			t.Fatalf("signing message: %s", err)
+		}
+
+		return signature.String()
+	}
+
+	signer, err := Verify(keyring, message, sign(entity))
+	if assert.NoError(err) {
+		assert.Equal(entity.PrimaryKey.Fingerprint, signer.PrimaryKey.Fingerprint)
+	}
+
+	_, err = Verify(keyring, []byte("tampered"), sign(entity))
+	assert.ErrorIs(err, ErrInvalidSignature)
+
+	_, err = Verify(keyring, message, sign(other))
+	assert.ErrorIs(err, ErrInvalidSignature)
+
+	_, err = ReadKeyRing(bytes.NewBufferString("not a keyring"))
+	assert.Error(err)
+}
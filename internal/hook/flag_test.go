@@ -0,0 +1,46 @@
+package hook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHooksFlag_String(t *testing.T) {
+	assert := assert.New(t)
+
+	hooksConfiguration := map[string][]string{"post-tag": {"make deploy"}, "pre-tag": {"make check"}}
+	hooksFlag := Flag(hooksConfiguration)
+
+	var emptyFlag Flag
+
+	type test struct {
+		got  *Flag
+		want string
+	}
+
+	tests := []test{
+		{got: &hooksFlag, want: "{\"post-tag\":[\"make deploy\"],\"pre-tag\":[\"make check\"]}"},
+		{got: &emptyFlag, want: "{}"},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(tc.want, tc.got.String())
+	}
+}
+
+func TestHooksFlag_Set(t *testing.T) {
+	var flag Flag
+
+	err := flag.Set("[\"make check\"]")
+	assert.Error(t, err, "should have errored, invalid JSON string")
+
+	err = flag.Set("{\"pre-tag\": [\"make check\"]}")
+	assert.NoError(t, err, "should not have errored")
+}
+
+func TestHooksFlag_Type(t *testing.T) {
+	var f Flag
+
+	assert.Equal(t, FlagType, f.Type())
+}
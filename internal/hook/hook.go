@@ -0,0 +1,131 @@
+// Package hook runs the external commands configured to be executed at given steps of a release.
+package hook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Steps of a release at which hooks are run.
+const (
+	// PreTag hooks are run before the release is tagged, the release is aborted if one of them fails.
+	PreTag = "pre-tag"
+	// PostTag hooks are run once the release tag is pushed.
+	PostTag = "post-tag"
+	// PostRelease hooks are run once the release is published to the forges and notified.
+	PostRelease = "post-release"
+)
+
+var (
+	ErrInvalidStep = errors.New("invalid hook step")
+	ErrHookFailed  = errors.New("hook failed")
+)
+
+var validSteps = map[string]struct{}{
+	PreTag:      {},
+	PostTag:     {},
+	PostRelease: {},
+}
+
+// Hooks maps the steps of a release to the shell commands run, in order, at this step.
+type Hooks map[string][]string
+
+func Unmarshall(input map[string][]string) (Hooks, error) {
+	hooks := make(Hooks, len(input))
+
+	for step, commands := range input {
+		if _, ok := validSteps[step]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidStep, step)
+		}
+
+		hooks[step] = commands
+	}
+
+	return hooks, nil
+}
+
+// Release describes the release for which hooks are run. It is exported to the hooks as environment variables.
+type Release struct {
+	Version         string
+	PreviousVersion string
+	Tag             string
+	Branch          string
+	Project         string
+}
+
+func (r Release) environment() []string {
+	return []string{
+		"SEMVER_NEW_VERSION=" + r.Version,
+		"SEMVER_PREVIOUS_VERSION=" + r.PreviousVersion,
+		"SEMVER_TAG=" + r.Tag,
+		"SEMVER_BRANCH=" + r.Branch,
+		"SEMVER_PROJECT=" + r.Project,
+	}
+}
+
+type Runner struct {
+	hooks  Hooks
+	output io.Writer
+	dir    string
+}
+
+type OptionFunc func(r *Runner)
+
+// WithOutput writes the standard and error outputs of the hooks to the given writer instead of discarding them.
+func WithOutput(output io.Writer) OptionFunc {
+	return func(r *Runner) {
+		r.output = output
+	}
+}
+
+// WithDir runs the hooks in the given directory instead of the current one.
+func WithDir(dir string) OptionFunc {
+	return func(r *Runner) {
+		r.dir = dir
+	}
+}
+
+func NewRunner(hooks Hooks, options ...OptionFunc) *Runner {
+	r := &Runner{
+		hooks:  hooks,
+		output: io.Discard,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// Run runs, in order, the hooks of the given step, stopping at the first one that fails. The hooks inherit the
+// environment of the process, to which the variables describing the given release are added.
+func (r *Runner) Run(ctx context.Context, step string, release Release) error {
+	for _, command := range r.hooks[step] {
+		cmd := shellCommand(ctx, command)
+		cmd.Dir = r.dir
+		cmd.Env = append(os.Environ(), release.environment()...)
+		cmd.Stdout = r.output
+		cmd.Stderr = r.output
+
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%w: %s hook %q: %w", ErrHookFailed, step, command, err)
+		}
+	}
+
+	return nil
+}
+
+// shellCommand returns the command running the given command line through the shell of the system.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
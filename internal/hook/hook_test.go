@@ -0,0 +1,64 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshall(t *testing.T) {
+	assert := assertion.New(t)
+
+	hooks, err := Unmarshall(map[string][]string{PreTag: {"make check"}, PostRelease: {"make deploy"}})
+	checkErr(t, "unmarshalling hooks", err)
+
+	assert.Equal(Hooks{PreTag: {"make check"}, PostRelease: {"make deploy"}}, hooks)
+
+	_, err = Unmarshall(map[string][]string{"pre-commit": {"make check"}})
+	assert.ErrorIs(err, ErrInvalidStep)
+}
+
+func TestRunner_Run(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := t.TempDir()
+	output := new(bytes.Buffer)
+
+	hooks := Hooks{
+		PreTag: {
+			`echo "$SEMVER_PREVIOUS_VERSION -> $SEMVER_NEW_VERSION ($SEMVER_TAG on $SEMVER_BRANCH)"`,
+			"touch pre-tag",
+		},
+		PostTag: {"exit 3", "touch post-tag"},
+	}
+
+	runner := NewRunner(hooks, WithOutput(output), WithDir(dir))
+	release := Release{Version: "1.1.0", PreviousVersion: "1.0.0", Tag: "v1.1.0", Branch: "main"}
+
+	err := runner.Run(context.Background(), PreTag, release)
+	checkErr(t, "running pre-tag hooks", err)
+
+	assert.Equal("1.0.0 -> 1.1.0 (v1.1.0 on main)\n", output.String())
+	assert.FileExists(filepath.Join(dir, "pre-tag"))
+
+	err = runner.Run(context.Background(), PostTag, release)
+	assert.ErrorIs(err, ErrHookFailed)
+	assert.ErrorContains(err, "exit status 3")
+
+	_, err = os.Stat(filepath.Join(dir, "post-tag"))
+	assert.True(os.IsNotExist(err), "hooks following a failed hook should not have been run")
+
+	err = runner.Run(context.Background(), PostRelease, release)
+	checkErr(t, "running post-release hooks", err)
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,210 @@
+// Package ledger provides the release ledger, an append-only record of every release decision stored as a JSON lines
+// file on an orphan branch of the released repository, so that releases can be audited beyond their tags.
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// FileName is the name of the file of the ledger branch holding the entries, one JSON object per line.
+const FileName = "releases.jsonl"
+
+// Entry is the record of a release decision.
+type Entry struct {
+	Time            time.Time `json:"time"`
+	Actor           string    `json:"actor"`
+	Command         string    `json:"command"`
+	Project         string    `json:"project,omitempty"`
+	Branch          string    `json:"branch,omitempty"`
+	PreviousVersion string    `json:"previous-version,omitempty"`
+	Version         string    `json:"version"`
+	NewRelease      bool      `json:"new-release"`
+	Commit          string    `json:"commit,omitempty"`
+	RulesDigest     string    `json:"rules-digest,omitempty"`
+	DryRun          bool      `json:"dry-run"`
+}
+
+// actorVariables are the environment variables naming the user who triggered a run, set by CI systems (GitHub Actions,
+// GitLab CI/CD, Azure Pipelines, Bitbucket Pipelines) or else by the shell.
+var actorVariables = []string{"GITHUB_ACTOR", "GITLAB_USER_LOGIN", "BUILD_REQUESTEDFOR", "BITBUCKET_STEP_TRIGGERER_UUID", "USER", "USERNAME"}
+
+// Actor returns the user who triggered the current run, empty if unknown.
+func Actor() string {
+	for _, variable := range actorVariables {
+		if actor := os.Getenv(variable); actor != "" {
+			return actor
+		}
+	}
+
+	return ""
+}
+
+// Ledger is the ledger stored on a given branch of a cloned repository.
+type Ledger struct {
+	repository *git.Repository
+	branch     string
+	remoteName string
+}
+
+// New returns the ledger stored on the given branch of the remote of the given name, as fetched by the clone of the
+// repository.
+func New(repository *git.Repository, branch, remoteName string) *Ledger {
+	return &Ledger{
+		repository: repository,
+		branch:     branch,
+		remoteName: remoteName,
+	}
+}
+
+// Entries returns the entries of the ledger, oldest first, none if the ledger branch does not exist yet.
+func (l *Ledger) Entries() ([]Entry, error) {
+	_, content, err := l.head()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	for {
+		var entry Entry
+
+		err = decoder.Decode(&entry)
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding ledger entry %d: %w", len(entries)+1, err)
+		}
+
+		entries = append(entries, entry)
+	}
+}
+
+// Append adds a commit made by the given signature, appending the given entries to the ledger, on top of the remote
+// ledger branch and sets the local branch to it. The local branch must then be pushed, which is rejected if the remote
+// branch was updated in the meantime: the remote branch must then be fetched again before the entries are appended
+// again.
+func (l *Ledger) Append(signature object.Signature, entries ...Entry) error {
+	parent, content, err := l.head()
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(content)
+
+	encoder := json.NewEncoder(buf)
+
+	for _, entry := range entries {
+		if err = encoder.Encode(entry); err != nil {
+			return fmt.Errorf("encoding ledger entry: %w", err)
+		}
+	}
+
+	blob := l.repository.Storer.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+
+	writer, err := blob.Writer()
+	if err != nil {
+		return fmt.Errorf("writing ledger blob: %w", err)
+	}
+
+	if _, err = writer.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("writing ledger blob: %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("writing ledger blob: %w", err)
+	}
+
+	blobHash, err := l.repository.Storer.SetEncodedObject(blob)
+	if err != nil {
+		return fmt.Errorf("storing ledger blob: %w", err)
+	}
+
+	treeHash, err := l.store(&object.Tree{
+		Entries: []object.TreeEntry{{Name: FileName, Mode: filemode.Regular, Hash: blobHash}},
+	})
+	if err != nil {
+		return fmt.Errorf("storing ledger tree: %w", err)
+	}
+
+	c := &object.Commit{
+		Author:    signature,
+		Committer: signature,
+		Message:   "Record release decisions\n",
+		TreeHash:  treeHash,
+	}
+
+	if !parent.IsZero() {
+		c.ParentHashes = []plumbing.Hash{parent}
+	}
+
+	commitHash, err := l.store(c)
+	if err != nil {
+		return fmt.Errorf("storing ledger commit: %w", err)
+	}
+
+	err = l.repository.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(l.branch), commitHash))
+	if err != nil {
+		return fmt.Errorf("updating ledger branch: %w", err)
+	}
+
+	return nil
+}
+
+// head returns the commit the remote ledger branch points to and the content of its ledger file, a zero hash and no
+// content if the branch does not exist.
+func (l *Ledger) head() (plumbing.Hash, []byte, error) {
+	ref, err := l.repository.Reference(plumbing.NewRemoteReferenceName(l.remoteName, l.branch), true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return plumbing.ZeroHash, nil, nil
+	}
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("fetching ledger branch: %w", err)
+	}
+
+	c, err := l.repository.CommitObject(ref.Hash())
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("fetching ledger commit: %w", err)
+	}
+
+	file, err := c.File(FileName)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return ref.Hash(), nil, nil
+	}
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("fetching ledger file: %w", err)
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("reading ledger file: %w", err)
+	}
+
+	return ref.Hash(), []byte(content), nil
+}
+
+func (l *Ledger) store(o interface {
+	Encode(plumbing.EncodedObject) error
+}) (plumbing.Hash, error) {
+	encoded := l.repository.Storer.NewEncodedObject()
+
+	if err := o.Encode(encoded); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return l.repository.Storer.SetEncodedObject(encoded)
+}
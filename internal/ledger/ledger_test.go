@@ -0,0 +1,88 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+)
+
+var signature = object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: time.Now()}
+
+func TestLedger_AppendAndEntries(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	l := New(testRepository.Repository, "releases", "origin")
+
+	entries, err := l.Entries()
+	checkErr(t, "reading entries", err)
+	assert.Empty(entries, "ledger should be empty until its branch exists")
+
+	first := Entry{Time: signature.When.UTC(), Actor: "alice", Command: "release", Version: "1.0.0", NewRelease: true, Commit: "abc"}
+	second := Entry{Time: signature.When.UTC(), Actor: "bob", Command: "release", PreviousVersion: "1.0.0", Version: "1.0.0", DryRun: true}
+
+	err = l.Append(signature, first)
+	checkErr(t, "appending entry", err)
+
+	branch, err := testRepository.Reference(plumbing.NewBranchReferenceName("releases"), true)
+	checkErr(t, "fetching ledger branch", err)
+
+	c, err := testRepository.CommitObject(branch.Hash())
+	checkErr(t, "fetching ledger commit", err)
+
+	assert.Equal(0, c.NumParents(), "first ledger commit should be an orphan")
+
+	// The local branch is pushed, and fetched back as the remote branch
+	remoteBranch := plumbing.NewRemoteReferenceName("origin", "releases")
+
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference(remoteBranch, branch.Hash()))
+	checkErr(t, "setting remote branch", err)
+
+	err = l.Append(signature, second)
+	checkErr(t, "appending entry", err)
+
+	branch, err = testRepository.Reference(plumbing.NewBranchReferenceName("releases"), true)
+	checkErr(t, "fetching ledger branch", err)
+
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference(remoteBranch, branch.Hash()))
+	checkErr(t, "setting remote branch", err)
+
+	entries, err = l.Entries()
+	checkErr(t, "reading entries", err)
+
+	assert.Equal([]Entry{first, second}, entries, "entries should be appended")
+}
+
+func TestActor(t *testing.T) {
+	assert := assertion.New(t)
+
+	for _, variable := range actorVariables {
+		t.Setenv(variable, "")
+	}
+
+	assert.Empty(Actor())
+
+	t.Setenv("USER", "alice")
+	assert.Equal("alice", Actor())
+
+	t.Setenv("GITHUB_ACTOR", "octocat")
+	assert.Equal("octocat", Actor(), "CI actor should take precedence over the shell user")
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,66 @@
+// Package logging provides the loggers of the diagnostic output, either configured from a level and a format or
+// forwarding to a log/slog handler.
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+var (
+	ErrInvalidLevel  = errors.New("invalid log level, expected debug, info, warn or error")
+	ErrInvalidFormat = errors.New("invalid log format, expected json or console")
+)
+
+// New returns a logger writing to the given writer the events of at least the given level, either as JSON objects or
+// as human-readable lines. The writes of the logger are serialized so that it can be used concurrently.
+func New(w io.Writer, level, format string) (zerolog.Logger, error) {
+	logLevel, err := ParseLevel(level)
+	if err != nil {
+		return zerolog.Nop(), err
+	}
+
+	w = zerolog.SyncWriter(w)
+
+	switch format {
+	case FormatJSON:
+	case FormatConsole:
+		w = zerolog.ConsoleWriter{Out: w, NoColor: true, TimeFormat: time.RFC3339}
+	default:
+		return zerolog.Nop(), fmt.Errorf("%w: %q", ErrInvalidFormat, format)
+	}
+
+	return zerolog.New(w).Level(logLevel).With().Timestamp().Logger(), nil
+}
+
+// ParseLevel returns the zerolog level of the given level name.
+func ParseLevel(level string) (zerolog.Level, error) {
+	switch level {
+	case LevelDebug:
+		return zerolog.DebugLevel, nil
+	case LevelInfo:
+		return zerolog.InfoLevel, nil
+	case LevelWarn:
+		return zerolog.WarnLevel, nil
+	case LevelError:
+		return zerolog.ErrorLevel, nil
+	default:
+		return zerolog.NoLevel, fmt.Errorf("%w: %q", ErrInvalidLevel, level)
+	}
+}
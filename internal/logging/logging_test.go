@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestLogging_New(t *testing.T) {
+	assert := assertion.New(t)
+
+	buf := new(bytes.Buffer)
+
+	logger, err := New(buf, LevelWarn, FormatJSON)
+	checkErr(t, "creating logger", err)
+
+	logger.Info().Msg("hidden")
+	logger.Warn().Str("tag", "v1.0.0").Msg("shown")
+
+	var event map[string]any
+
+	err = json.Unmarshal(buf.Bytes(), &event)
+	checkErr(t, "decoding event", err)
+
+	assert.Equal("warn", event["level"])
+	assert.Equal("shown", event["message"])
+	assert.Equal("v1.0.0", event["tag"])
+	assert.Contains(event, "time")
+
+	buf.Reset()
+
+	logger, err = New(buf, LevelDebug, FormatConsole)
+	checkErr(t, "creating logger", err)
+
+	logger.Debug().Str("tag", "v1.0.0").Msg("shown")
+
+	assert.Contains(buf.String(), "DBG shown tag=v1.0.0")
+	assert.False(json.Valid(buf.Bytes()), "console format should not be JSON")
+
+	_, err = New(buf, "verbose", FormatJSON)
+	assert.ErrorIs(err, ErrInvalidLevel)
+
+	_, err = New(buf, LevelInfo, "xml")
+	assert.ErrorIs(err, ErrInvalidFormat)
+}
+
+func TestLogging_FromSlog(t *testing.T) {
+	assert := assertion.New(t)
+
+	buf := new(bytes.Buffer)
+
+	handler := slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+
+	logger := FromSlog(handler).With().Str("repository", "foo").Logger()
+
+	logger.Debug().Msg("hidden")
+	logger.Warn().Int("commits", 3).Str("tag", "v1.0.0").Msg("tag pushed")
+	logger.Log().Msg("no level")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	assert.Equal([]string{
+		`level=WARN msg="tag pushed" commits=3 repository=foo tag=v1.0.0`,
+		`level=INFO msg="no level" repository=foo`,
+	}, lines)
+}
+
+func TestLogging_SlogLevel(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Equal(slog.LevelDebug, slogLevel(zerolog.DebugLevel))
+	assert.Equal(slog.LevelInfo, slogLevel(zerolog.InfoLevel))
+	assert.Equal(slog.LevelWarn, slogLevel(zerolog.WarnLevel))
+	assert.Equal(slog.LevelError, slogLevel(zerolog.ErrorLevel))
+	assert.Equal(slog.LevelError, slogLevel(zerolog.FatalLevel))
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
+
+func TestLogging_Redact(t *testing.T) {
+	assert := assertion.New(t)
+
+	buf := new(bytes.Buffer)
+
+	logger, err := New(Redact(buf, "s3cr3t", `qu"ote`, ""), LevelDebug, FormatJSON)
+	checkErr(t, "creating logger", err)
+
+	logger.Debug().Str("token", "s3cr3t").Str("secret", `qu"ote`).Msg("pushing with s3cr3t")
+
+	assert.NotContains(buf.String(), "s3cr3t")
+	assert.NotContains(buf.String(), `qu\"ote`)
+	assert.Contains(buf.String(), `"token":"<redacted>"`)
+	assert.Contains(buf.String(), `"message":"pushing with <redacted>"`)
+	assert.True(json.Valid(buf.Bytes()), "redacted event should be JSON")
+
+	assert.Equal(buf, Redact(buf, ""), "writer without secret should not be wrapped")
+}
@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// Redacted replaces the secrets written to a redacting writer.
+const Redacted = "<redacted>"
+
+// Redact returns a writer replacing the given secrets, as is or JSON-escaped, by Redacted before writing to the given
+// writer. Empty secrets are ignored, the writer is returned as is if there is no secret to redact.
+func Redact(w io.Writer, secrets ...string) io.Writer {
+	var oldnew []string
+
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+
+		oldnew = append(oldnew, secret, Redacted)
+
+		escaped, err := json.Marshal(secret)
+		if err == nil && string(escaped[1:len(escaped)-1]) != secret {
+			oldnew = append(oldnew, string(escaped[1:len(escaped)-1]), Redacted)
+		}
+	}
+
+	if len(oldnew) == 0 {
+		return w
+	}
+
+	return redactWriter{w: w, replacer: strings.NewReplacer(oldnew...)}
+}
+
+// redactWriter replaces secrets in every write. Loggers write an event at once so that a secret is never split across
+// two writes.
+type redactWriter struct {
+	w        io.Writer
+	replacer *strings.Replacer
+}
+
+func (r redactWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.w, r.replacer.Replace(string(p))); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// FromSlog returns a logger forwarding its events to the given slog handler, which decides which levels are enabled.
+// The fields of the events become the attributes of the records.
+func FromSlog(handler slog.Handler) zerolog.Logger {
+	return zerolog.New(slogWriter{handler: handler}).Level(zerolog.TraceLevel)
+}
+
+// slogWriter converts the JSON events written by a zerolog logger to slog records.
+type slogWriter struct {
+	handler slog.Handler
+}
+
+func (s slogWriter) Write(p []byte) (int, error) {
+	return s.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (s slogWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	ctx := context.Background()
+
+	slogLevel := slogLevel(level)
+	if !s.handler.Enabled(ctx, slogLevel) {
+		return len(p), nil
+	}
+
+	var fields map[string]any
+
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&fields); err != nil {
+		return 0, err
+	}
+
+	recordTime := time.Now()
+
+	if value, ok := fields[zerolog.TimestampFieldName].(string); ok {
+		if parsed, err := time.Parse(zerolog.TimeFieldFormat, value); err == nil {
+			recordTime = parsed
+		}
+	}
+
+	message, _ := fields[zerolog.MessageFieldName].(string)
+
+	record := slog.NewRecord(recordTime, slogLevel, message, 0)
+
+	delete(fields, zerolog.LevelFieldName)
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, zerolog.TimestampFieldName)
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		record.AddAttrs(slog.Any(key, fields[key]))
+	}
+
+	if err := s.handler.Handle(ctx, record); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func slogLevel(level zerolog.Level) slog.Level {
+	switch level {
+	case zerolog.TraceLevel:
+		return slog.LevelDebug - 4
+	case zerolog.DebugLevel:
+		return slog.LevelDebug
+	case zerolog.WarnLevel:
+		return slog.LevelWarn
+	case zerolog.ErrorLevel, zerolog.FatalLevel, zerolog.PanicLevel:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
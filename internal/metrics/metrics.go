@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+)
+
+const namespace = "go_semver_release_"
+
+// DurationBuckets are the upper bounds, in seconds, of the buckets of the analysis durations.
+var DurationBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+// Error types of the errors counter.
+const (
+	ErrorInvalidSignature  = "invalid_signature"
+	ErrorInvalidPayload    = "invalid_payload"
+	ErrorQueueFull         = "queue_full"
+	ErrorUnknownRepository = "unknown_repository"
+	ErrorUnauthorized      = "unauthorized"
+	ErrorInvalidRequest    = "invalid_request"
+	ErrorAnalysis          = "analysis"
+	ErrorRelease           = "release"
+)
+
+// Metrics are the metrics of the server mode. The methods of a nil Metrics record nothing, so that the commands can
+// record their metrics whether they run in server mode or not.
+type Metrics struct {
+	registry         *Registry
+	analyses         *Counter
+	analysisDuration *Histogram
+	commits          *Counter
+	releases         *Counter
+	errors           *Counter
+}
+
+func New() *Metrics {
+	registry := NewRegistry()
+
+	return &Metrics{
+		registry:         registry,
+		analyses:         registry.Counter(namespace+"analyses_total", "Number of commit history analyses performed."),
+		analysisDuration: registry.Histogram(namespace+"analysis_duration_seconds", "Duration of the commit history analyses.", DurationBuckets),
+		commits:          registry.Counter(namespace+"commits_walked_total", "Number of commits walked by the analyses."),
+		releases:         registry.Counter(namespace+"releases_total", "Number of releases created."),
+		errors:           registry.Counter(namespace+"errors_total", "Number of errors by type.", "type"),
+	}
+}
+
+// Analysis records an analysis of the given duration that walked the given number of commits.
+func (m *Metrics) Analysis(duration time.Duration, commits int) {
+	if m == nil {
+		return
+	}
+
+	m.analyses.Inc()
+	m.analysisDuration.Observe(duration.Seconds())
+	m.commits.Add(float64(commits))
+}
+
+// Release records a created release.
+func (m *Metrics) Release() {
+	if m == nil {
+		return
+	}
+
+	m.releases.Inc()
+}
+
+// Error records an error of the given type.
+func (m *Metrics) Error(errorType string) {
+	if m == nil {
+		return
+	}
+
+	m.errors.Inc(errorType)
+}
+
+// Handler returns the HTTP handler exposing the metrics in the Prometheus text exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		_ = m.registry.Write(w)
+	})
+}
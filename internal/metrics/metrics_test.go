@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	assert := assertion.New(t)
+
+	registry := NewRegistry()
+
+	counter := registry.Counter("jobs_total", "Number of jobs.", "type")
+	histogram := registry.Histogram("job_duration_seconds", "Duration of the jobs.", []float64{1, 5})
+	total := registry.Counter("runs_total", "Number of runs.")
+
+	counter.Inc("b")
+	counter.Add(2, "a\"\n")
+	histogram.Observe(0.5)
+	histogram.Observe(3)
+	histogram.Observe(10)
+
+	buf := new(bytes.Buffer)
+
+	err := registry.Write(buf)
+	checkErr(t, "writing metrics", err)
+
+	want := `# HELP jobs_total Number of jobs.
+# TYPE jobs_total counter
+jobs_total{type="a\"\n"} 2
+jobs_total{type="b"} 1
+# HELP job_duration_seconds Duration of the jobs.
+# TYPE job_duration_seconds histogram
+job_duration_seconds_bucket{le="1"} 1
+job_duration_seconds_bucket{le="5"} 2
+job_duration_seconds_bucket{le="+Inf"} 3
+job_duration_seconds_sum 13.5
+job_duration_seconds_count 3
+# HELP runs_total Number of runs.
+# TYPE runs_total counter
+runs_total 0
+`
+
+	assert.Equal(want, buf.String())
+
+	total.Inc()
+	assert.Equal(1.0, total.values[""])
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	assert := assertion.New(t)
+
+	m := New()
+
+	m.Analysis(1500*time.Millisecond, 12)
+	m.Release()
+	m.Error(ErrorRelease)
+	m.Error(ErrorRelease)
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := recorder.Body.String()
+
+	assert.Equal(http.StatusOK, recorder.Code)
+	assert.Contains(recorder.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+	assert.Contains(body, "go_semver_release_analyses_total 1\n")
+	assert.Contains(body, "go_semver_release_analysis_duration_seconds_bucket{le=\"2.5\"} 1\n")
+	assert.Contains(body, "go_semver_release_analysis_duration_seconds_bucket{le=\"1\"} 0\n")
+	assert.Contains(body, "go_semver_release_commits_walked_total 12\n")
+	assert.Contains(body, "go_semver_release_releases_total 1\n")
+	assert.Contains(body, "go_semver_release_errors_total{type=\"release\"} 2\n")
+}
+
+func TestMetrics_Nil(t *testing.T) {
+	var m *Metrics
+
+	assertion.NotPanics(t, func() {
+		m.Analysis(time.Second, 1)
+		m.Release()
+		m.Error(ErrorAnalysis)
+	})
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
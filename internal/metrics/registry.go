@@ -0,0 +1,246 @@
+// Package metrics provides the metrics of the server mode, exposed in the Prometheus text format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// labelEscaper escapes the label values as required by the text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Registry holds metrics and writes them in the Prometheus text exposition format.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []collector
+}
+
+type collector interface {
+	write(w io.Writer) error
+}
+
+// Counter is a monotonically increasing value, partitioned by the values of its labels.
+type Counter struct {
+	family
+	values map[string]float64
+}
+
+// Histogram counts observations in cumulative buckets, partitioned by the values of its labels.
+type Histogram struct {
+	family
+	buckets []float64
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// family holds the description of a metric and the key of each of its series.
+type family struct {
+	mu     *sync.Mutex
+	name   string
+	help   string
+	labels []string
+	keys   map[string][]string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Counter registers and returns a counter with the given label names. A counter without labels starts at zero, the
+// series of the others appear once incremented.
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	c := &Counter{
+		family: r.family(name, help, labels),
+		values: make(map[string]float64),
+	}
+
+	if len(labels) == 0 {
+		c.values[c.key(nil)] = 0
+	}
+
+	r.register(c)
+
+	return c
+}
+
+// Histogram registers and returns a histogram with the given upper bounds of its buckets, sorted in increasing order,
+// and label names.
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := &Histogram{
+		family:  r.family(name, help, labels),
+		buckets: buckets,
+		series:  make(map[string]*histogramSeries),
+	}
+
+	r.register(h)
+
+	return h
+}
+
+// Write writes every metric in the Prometheus text exposition format.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, m := range r.metrics {
+		if err := m.write(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) family(name, help string, labels []string) family {
+	return family{
+		mu:     &r.mu,
+		name:   name,
+		help:   help,
+		labels: labels,
+		keys:   make(map[string][]string),
+	}
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metrics = append(r.metrics, c)
+}
+
+// Add adds the given value to the series of the given label values, given in the order of the label names.
+func (c *Counter) Add(value float64, labelValues ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[c.key(labelValues)] += value
+}
+
+// Inc increments the series of the given label values.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *Counter) write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name); err != nil {
+		return err
+	}
+
+	for _, key := range c.sortedKeys() {
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(key), formatFloat(c.values[key])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Observe adds an observation to the series of the given label values.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := h.key(labelValues)
+
+	series, ok := h.series[key]
+	if !ok {
+		series = &histogramSeries{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = series
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			series.counts[i]++
+		}
+	}
+
+	series.count++
+	series.sum += value
+}
+
+func (h *Histogram) write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name); err != nil {
+		return err
+	}
+
+	for _, key := range h.sortedKeys() {
+		series := h.series[key]
+
+		for i, bound := range h.buckets {
+			_, err := fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(key, "le", formatFloat(bound)), series.counts[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			h.name, h.labelPairs(key, "le", "+Inf"), series.count,
+			h.name, h.labelPairs(key), formatFloat(series.sum),
+			h.name, h.labelPairs(key), series.count)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// key returns the key of the series of the given label values, missing values being empty.
+func (f *family) key(labelValues []string) string {
+	values := make([]string, len(f.labels))
+	copy(values, labelValues)
+
+	key := strings.Join(values, "\xff")
+	f.keys[key] = values
+
+	return key
+}
+
+func (f *family) sortedKeys() []string {
+	keys := make([]string, 0, len(f.keys))
+	for key := range f.keys {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// labelPairs returns the label pairs of the given series followed by the given extra name and value pairs, or an empty
+// string if there is none.
+func (f *family) labelPairs(key string, extra ...string) string {
+	var pairs []string
+
+	for i, value := range f.keys[key] {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", f.labels[i], labelEscaper.Replace(value)))
+	}
+
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extra[i], labelEscaper.Replace(extra[i+1])))
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
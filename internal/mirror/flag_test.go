@@ -0,0 +1,46 @@
+package mirror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirrorsFlag_String(t *testing.T) {
+	assert := assert.New(t)
+
+	mirrorsConfiguration := []map[string]any{{"url": "https://example.com/repository.git"}, {"url": "git@example.com:repository.git", "optional": true}}
+	mirrorsFlag := Flag(mirrorsConfiguration)
+
+	var emptyFlag Flag
+
+	type test struct {
+		got  *Flag
+		want string
+	}
+
+	tests := []test{
+		{got: &mirrorsFlag, want: "[{\"url\":\"https://example.com/repository.git\"},{\"optional\":true,\"url\":\"git@example.com:repository.git\"}]"},
+		{got: &emptyFlag, want: "[]"},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(tc.want, tc.got.String())
+	}
+}
+
+func TestMirrorsFlag_Set(t *testing.T) {
+	var flag Flag
+
+	err := flag.Set("[{\"url\": \"https://example.com/repository.git\"}]")
+	assert.NoError(t, err, "should not have errored")
+
+	err = flag.Set("{\"url\": \"https://example.com/repository.git\"}")
+	assert.Error(t, err, "should have errored, invalid JSON string")
+}
+
+func TestMirrorsFlag_Type(t *testing.T) {
+	var f Flag
+
+	assert.Equal(t, FlagType, f.Type())
+}
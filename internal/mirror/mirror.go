@@ -0,0 +1,71 @@
+// Package mirror provides the configuration of the mirrors, the remotes to which the release tags are pushed in
+// addition to the released repository.
+package mirror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	ErrNoURL         = errors.New("mirror has no URL")
+	ErrDuplicateName = errors.New("duplicate mirror name")
+)
+
+// Config is the configuration of a mirror.
+type Config struct {
+	// Name is the name of the Git remote of the mirror, "mirror-<N>" for the N-th mirror if empty.
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url"`
+	// TokenEnv is the name of the environment variable holding the access token used to push to the mirror over HTTP.
+	TokenEnv string `json:"token-env,omitempty"`
+	// Username is sent along the access token, the default username of the released repository is used if empty.
+	Username string `json:"username,omitempty"`
+	// Optional mirrors failing to receive a tag do not fail the release, the failure is only logged as a warning.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// Token returns the access token of the mirror, read from its environment variable.
+func (c Config) Token() string {
+	if c.TokenEnv == "" {
+		return ""
+	}
+
+	return os.Getenv(c.TokenEnv)
+}
+
+// Unmarshall takes a raw Viper configuration and returns the configurations of the mirrors it lists.
+func Unmarshall(input []map[string]any) ([]Config, error) {
+	content, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("encoding mirrors configuration: %w", err)
+	}
+
+	var configs []Config
+
+	if err = json.Unmarshal(content, &configs); err != nil {
+		return nil, fmt.Errorf("decoding mirrors configuration: %w", err)
+	}
+
+	names := make(map[string]struct{}, len(configs))
+
+	for i := range configs {
+		if configs[i].URL == "" {
+			return nil, ErrNoURL
+		}
+
+		if configs[i].Name == "" {
+			configs[i].Name = fmt.Sprintf("mirror-%d", i+1)
+		}
+
+		if _, ok := names[configs[i].Name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateName, configs[i].Name)
+		}
+
+		names[configs[i].Name] = struct{}{}
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,49 @@
+package mirror
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshall(t *testing.T) {
+	assert := assertion.New(t)
+
+	configs, err := Unmarshall([]map[string]any{
+		{"name": "internal", "url": "https://git.example.com/repository.git", "token-env": "MIRROR_TOKEN", "username": "bot", "optional": true},
+		{"url": "git@example.com:repository.git"},
+	})
+	checkErr(t, "unmarshalling mirrors", err)
+
+	want := []Config{
+		{Name: "internal", URL: "https://git.example.com/repository.git", TokenEnv: "MIRROR_TOKEN", Username: "bot", Optional: true},
+		{Name: "mirror-2", URL: "git@example.com:repository.git"},
+	}
+
+	assert.Equal(want, configs)
+
+	_, err = Unmarshall([]map[string]any{{"name": "internal"}})
+	assert.ErrorIs(err, ErrNoURL)
+
+	_, err = Unmarshall([]map[string]any{
+		{"name": "mirror-2", "url": "https://git.example.com/repository.git"},
+		{"url": "git@example.com:repository.git"},
+	})
+	assert.ErrorIs(err, ErrDuplicateName)
+}
+
+func TestConfig_Token(t *testing.T) {
+	assert := assertion.New(t)
+
+	t.Setenv("MIRROR_TOKEN", "secret")
+
+	assert.Equal("secret", Config{TokenEnv: "MIRROR_TOKEN"}.Token())
+	assert.Empty(Config{}.Token(), "mirror without token variable should have no token")
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
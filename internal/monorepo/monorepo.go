@@ -3,23 +3,44 @@ package monorepo
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
 )
 
 var (
-	ErrNoProjects = errors.New("no projects found in configuration file despite operating in monorepo mode")
-	ErrNoName     = errors.New("project has no name")
-	ErrNoPath     = errors.New("project has no path")
+	ErrNoProjects            = errors.New("no projects found in configuration file despite operating in monorepo mode")
+	ErrNoName                = errors.New("project has no name")
+	ErrNoPath                = errors.New("project has no path")
+	ErrUnknownDependency     = errors.New("project depends on an unknown project")
+	ErrDependencyCycle       = errors.New("projects dependencies contain a cycle")
+	ErrInvalidPrereleaseID   = errors.New("invalid prerelease identifier in project configuration")
+	ErrInvalidInitialVersion = errors.New("invalid initial version in project configuration")
 )
 
 type Project struct {
 	Path string
 	Name string
+	// DependsOn lists the names of the projects this project depends on. A release of one of them triggers at least
+	// a patch release of this project.
+	DependsOn []string
+	// TagPrefix overrides the global tag prefix if not nil.
+	TagPrefix *string
+	// Rules overrides the global release rules if not nil.
+	Rules *rule.Rules
+	// PrereleaseID overrides the prerelease identifier of the prerelease branches if not empty.
+	PrereleaseID string
+	// InitialVersion is the version of the first release of the project, if not nil. Otherwise, the first release is
+	// bumped from 0.0.0.
+	InitialVersion *semver.Version
 }
 
 // Unmarshall takes a raw Viper configuration and returns a slice of Project representing various projects in a
 // monorepo.
-func Unmarshall(input []map[string]string) ([]Project, error) {
+func Unmarshall(input []map[string]any) ([]Project, error) {
 	if len(input) == 0 {
 		return nil, ErrNoProjects
 	}
@@ -33,18 +54,179 @@ func Unmarshall(input []map[string]string) ([]Project, error) {
 			return nil, ErrNoName
 		}
 
+		stringName, ok := name.(string)
+		if !ok {
+			return nil, fmt.Errorf("could not assert that the \"name\" property of the project configuration is a string")
+		}
+
 		path, ok := p["path"]
 		if !ok {
 			return nil, ErrNoPath
 		}
 
+		stringPath, ok := path.(string)
+		if !ok {
+			return nil, fmt.Errorf("could not assert that the \"path\" property of the project configuration is a string")
+		}
+
 		project := Project{
-			Name: name,
-			Path: filepath.Clean(path),
+			Name: stringName,
+			Path: filepath.Clean(stringPath),
+		}
+
+		if err := unmarshallOverrides(p, &project); err != nil {
+			return nil, fmt.Errorf("parsing project %q configuration: %w", stringName, err)
+		}
+
+		if dependsOn, ok := p["depends-on"]; ok {
+			dependencies, err := unmarshallDependencies(dependsOn)
+			if err != nil {
+				return nil, err
+			}
+
+			project.DependsOn = dependencies
 		}
 
 		projects[i] = project
 	}
 
+	if _, err := DependencyOrder(projects); err != nil {
+		return nil, err
+	}
+
 	return projects, nil
 }
+
+// DependencyOrder returns the given projects sorted so that every project comes after the projects it depends on.
+// Projects without dependencies between them keep their relative order.
+func DependencyOrder(projects []Project) ([]Project, error) {
+	byName := make(map[string]Project, len(projects))
+	for _, project := range projects {
+		byName[project.Name] = project
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	var (
+		sorted = make([]Project, 0, len(projects))
+		state  = make(map[string]int, len(projects))
+		visit  func(project Project) error
+	)
+
+	visit = func(project Project) error {
+		switch state[project.Name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %q", ErrDependencyCycle, project.Name)
+		}
+
+		state[project.Name] = visiting
+
+		for _, name := range project.DependsOn {
+			dependency, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("%w: %q depends on %q", ErrUnknownDependency, project.Name, name)
+			}
+
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+
+		state[project.Name] = visited
+		sorted = append(sorted, project)
+
+		return nil
+	}
+
+	for _, project := range projects {
+		if err := visit(project); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+// unmarshallDependencies returns the project names of a "depends-on" property, given either as a single name or as an
+// array of names.
+func unmarshallDependencies(dependsOn any) ([]string, error) {
+	switch d := dependsOn.(type) {
+	case string:
+		return []string{d}, nil
+	case []any:
+		dependencies := make([]string, len(d))
+
+		for i, name := range d {
+			stringName, ok := name.(string)
+			if !ok {
+				return nil, fmt.Errorf("could not assert that the \"depends-on\" property of the project configuration is an array of strings")
+			}
+
+			dependencies[i] = stringName
+		}
+
+		return dependencies, nil
+	default:
+		return nil, fmt.Errorf("could not assert that the \"depends-on\" property of the project configuration is a string or an array of strings")
+	}
+}
+
+// unmarshallOverrides sets the project settings overriding the global configuration.
+func unmarshallOverrides(p map[string]any, project *Project) error {
+	if tagPrefix, ok := p["tag-prefix"]; ok {
+		stringTagPrefix, ok := tagPrefix.(string)
+		if !ok {
+			return fmt.Errorf("could not assert that the \"tag-prefix\" property of the project configuration is a string")
+		}
+
+		project.TagPrefix = &stringTagPrefix
+	}
+
+	if rulesPath, ok := p["rules-path"]; ok {
+		stringRulesPath, ok := rulesPath.(string)
+		if !ok {
+			return fmt.Errorf("could not assert that the \"rules-path\" property of the project configuration is a string")
+		}
+
+		rules, err := rule.FromFile(stringRulesPath)
+		if err != nil {
+			return fmt.Errorf("loading rules file: %w", err)
+		}
+
+		project.Rules = &rules
+	}
+
+	if prerelease, ok := p["prerelease"]; ok {
+		stringPrerelease, ok := prerelease.(string)
+		if !ok {
+			return fmt.Errorf("could not assert that the \"prerelease\" property of the project configuration is a string")
+		}
+
+		if !branch.ValidPrereleaseIdentifier(stringPrerelease) {
+			return fmt.Errorf("%w: %q", ErrInvalidPrereleaseID, stringPrerelease)
+		}
+
+		project.PrereleaseID = stringPrerelease
+	}
+
+	if initialVersion, ok := p["initial-version"]; ok {
+		stringInitialVersion, ok := initialVersion.(string)
+		if !ok {
+			return fmt.Errorf("could not assert that the \"initial-version\" property of the project configuration is a string")
+		}
+
+		version, err := semver.NewFromString(stringInitialVersion)
+		if err != nil || version.String() != stringInitialVersion {
+			return fmt.Errorf("%w: %q", ErrInvalidInitialVersion, stringInitialVersion)
+		}
+
+		project.InitialVersion = version
+	}
+
+	return nil
+}
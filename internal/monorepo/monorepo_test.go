@@ -1,16 +1,20 @@
 package monorepo
 
 import (
+	"io/fs"
+	"os"
 	"path/filepath"
 	"testing"
 
 	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
 )
 
 func TestMonorepo_Unmarshall(t *testing.T) {
 	assert := assertion.New(t)
 
-	have := []map[string]string{{"name": "bar", "path": "./bar/"}, {"name": "foo", "path": "./xyz/foo/"}}
+	have := []map[string]any{{"name": "bar", "path": "./bar/"}, {"name": "foo", "path": "./xyz/foo/"}}
 
 	localizedPath, _ := filepath.Localize("xyz/foo")
 
@@ -31,15 +35,15 @@ func TestMonorepo_UnmarshallErrors(t *testing.T) {
 	assert := assertion.New(t)
 
 	type test struct {
-		have []map[string]string
+		have []map[string]any
 		want error
 	}
 
 	tests := []test{
-		{have: []map[string]string{{"path": "./foo/"}}, want: ErrNoName},
-		{have: []map[string]string{{"name": "foo"}}, want: ErrNoPath},
-		{have: []map[string]string{}, want: ErrNoProjects},
-		{have: []map[string]string{{"name": "foo", "path": "./foo/"}}, want: nil},
+		{have: []map[string]any{{"path": "./foo/"}}, want: ErrNoName},
+		{have: []map[string]any{{"name": "foo"}}, want: ErrNoPath},
+		{have: []map[string]any{}, want: ErrNoProjects},
+		{have: []map[string]any{{"name": "foo", "path": "./foo/"}}, want: nil},
 	}
 
 	for _, tc := range tests {
@@ -47,3 +51,106 @@ func TestMonorepo_UnmarshallErrors(t *testing.T) {
 		assert.Equal(tc.want, err)
 	}
 }
+
+func TestMonorepo_UnmarshallDependencies(t *testing.T) {
+	assert := assertion.New(t)
+
+	have := []map[string]any{
+		{"name": "web", "path": "web", "depends-on": []any{"shared", "api"}},
+		{"name": "api", "path": "api", "depends-on": "shared"},
+		{"name": "shared", "path": "shared"},
+	}
+
+	projects, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling projects: %s", err)
+	}
+
+	assert.Equal([]string{"shared", "api"}, projects[0].DependsOn)
+	assert.Equal([]string{"shared"}, projects[1].DependsOn)
+
+	_, err = Unmarshall([]map[string]any{{"name": "web", "path": "web", "depends-on": "shared"}})
+	assert.ErrorIs(err, ErrUnknownDependency)
+
+	_, err = Unmarshall([]map[string]any{{"name": "web", "path": "web", "depends-on": 1}})
+	assert.Error(err, "depends-on should be a string or an array of strings")
+}
+
+func TestMonorepo_DependencyOrder(t *testing.T) {
+	assert := assertion.New(t)
+
+	projects := []Project{
+		{Name: "web", DependsOn: []string{"api"}},
+		{Name: "docs"},
+		{Name: "api", DependsOn: []string{"shared"}},
+		{Name: "shared"},
+	}
+
+	sorted, err := DependencyOrder(projects)
+	if err != nil {
+		t.Fatalf("sorting projects: %s", err)
+	}
+
+	var names []string
+	for _, project := range sorted {
+		names = append(names, project.Name)
+	}
+
+	assert.Equal([]string{"shared", "api", "web", "docs"}, names)
+
+	_, err = DependencyOrder([]Project{
+		{Name: "web", DependsOn: []string{"api"}},
+		{Name: "api", DependsOn: []string{"web"}},
+	})
+	assert.ErrorIs(err, ErrDependencyCycle)
+}
+
+func TestMonorepo_UnmarshallOverrides(t *testing.T) {
+	assert := assertion.New(t)
+
+	rulesPath := filepath.Join(t.TempDir(), "rules.json")
+
+	err := os.WriteFile(rulesPath, []byte(`{"minor": ["feat", "perf"], "patch": ["fix"]}`), 0o644)
+	if err != nil {
+		t.Fatalf("writing rules file: %s", err)
+	}
+
+	have := []map[string]any{
+		{"name": "lib", "path": "lib", "tag-prefix": "", "rules-path": rulesPath, "prerelease": "beta", "initial-version": "1.0.0"},
+		{"name": "svc", "path": "svc"},
+	}
+
+	projects, err := Unmarshall(have)
+	if err != nil {
+		t.Fatalf("unmarshalling projects: %s", err)
+	}
+
+	if assert.NotNil(projects[0].TagPrefix) {
+		assert.Equal("", *projects[0].TagPrefix)
+	}
+	if assert.NotNil(projects[0].Rules) {
+		assert.Equal("minor", projects[0].Rules.Map["perf"])
+	}
+	assert.Equal("beta", projects[0].PrereleaseID)
+	assert.Equal(&semver.Version{Major: 1}, projects[0].InitialVersion)
+
+	assert.Nil(projects[1].TagPrefix)
+	assert.Nil(projects[1].Rules)
+	assert.Nil(projects[1].InitialVersion)
+
+	type test struct {
+		have map[string]any
+		want error
+	}
+
+	tests := []test{
+		{have: map[string]any{"name": "lib", "path": "lib", "prerelease": "beta!"}, want: ErrInvalidPrereleaseID},
+		{have: map[string]any{"name": "lib", "path": "lib", "initial-version": "v1"}, want: ErrInvalidInitialVersion},
+		{have: map[string]any{"name": "lib", "path": "lib", "rules-path": filepath.Join(t.TempDir(), "missing.json")}, want: fs.ErrNotExist},
+	}
+
+	for _, tc := range tests {
+		_, err = Unmarshall([]map[string]any{tc.have})
+		assert.ErrorIs(err, tc.want)
+	}
+}
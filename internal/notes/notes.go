@@ -0,0 +1,197 @@
+// Package notes provides functions to read and write Git notes, which go-git does not support.
+//
+// Notes are stored under a reference pointing to a commit whose tree contains one blob per annotated object, named
+// after the hash of that object, possibly split in fan-out directories (e.g., "ab/cdef...").
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Notes are the notes stored under a given reference of a Git repository.
+type Notes struct {
+	repository *git.Repository
+	ref        plumbing.ReferenceName
+	// parent is the commit the reference points to, zero if it does not exist yet
+	parent plumbing.Hash
+	// blobs maps the annotated objects to the blobs of their note
+	blobs   map[plumbing.Hash]plumbing.Hash
+	changed bool
+}
+
+// Open loads the notes stored under the given reference, there is none if the reference does not exist.
+func Open(repository *git.Repository, ref plumbing.ReferenceName) (*Notes, error) {
+	n := &Notes{
+		repository: repository,
+		ref:        ref,
+		blobs:      make(map[plumbing.Hash]plumbing.Hash),
+	}
+
+	reference, err := repository.Reference(ref, true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return n, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching notes reference: %w", err)
+	}
+
+	n.parent = reference.Hash()
+
+	c, err := repository.CommitObject(n.parent)
+	if err != nil {
+		return nil, fmt.Errorf("fetching notes commit: %w", err)
+	}
+
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("fetching notes tree: %w", err)
+	}
+
+	err = tree.Files().ForEach(func(f *object.File) error {
+		// Fan-out directories are part of the annotated object hash
+		name := strings.ReplaceAll(f.Name, "/", "")
+		if !plumbing.IsHash(name) {
+			return nil
+		}
+
+		n.blobs[plumbing.NewHash(name)] = f.Hash
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading notes tree: %w", err)
+	}
+
+	return n, nil
+}
+
+// Get returns the note of the given object, if any.
+func (n *Notes) Get(hash plumbing.Hash) ([]byte, bool, error) {
+	blobHash, ok := n.blobs[hash]
+	if !ok {
+		return nil, false, nil
+	}
+
+	blob, err := n.repository.BlobObject(blobHash)
+	if err != nil {
+		return nil, false, fmt.Errorf("fetching note blob: %w", err)
+	}
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, false, fmt.Errorf("reading note blob: %w", err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, false, fmt.Errorf("reading note blob: %w", err)
+	}
+
+	return content, true, nil
+}
+
+// Has returns true if the given object has a note.
+func (n *Notes) Has(hash plumbing.Hash) bool {
+	_, ok := n.blobs[hash]
+	return ok
+}
+
+// Set adds, or replaces, the note of the given object. Notes are only stored under the reference once committed.
+func (n *Notes) Set(hash plumbing.Hash, content []byte) error {
+	blob := n.repository.Storer.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+
+	writer, err := blob.Writer()
+	if err != nil {
+		return fmt.Errorf("writing note blob: %w", err)
+	}
+
+	if _, err = writer.Write(content); err != nil {
+		return fmt.Errorf("writing note blob: %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("writing note blob: %w", err)
+	}
+
+	blobHash, err := n.repository.Storer.SetEncodedObject(blob)
+	if err != nil {
+		return fmt.Errorf("storing note blob: %w", err)
+	}
+
+	n.blobs[hash] = blobHash
+	n.changed = true
+
+	return nil
+}
+
+// Commit stores the notes set so far under the reference, in a new commit made by the given signature. Nothing is
+// done if no note was set.
+func (n *Notes) Commit(signature object.Signature, message string) error {
+	if !n.changed {
+		return nil
+	}
+
+	tree := &object.Tree{}
+
+	for hash, blobHash := range n.blobs {
+		tree.Entries = append(tree.Entries, object.TreeEntry{Name: hash.String(), Mode: filemode.Regular, Hash: blobHash})
+	}
+
+	sort.Slice(tree.Entries, func(i, j int) bool {
+		return tree.Entries[i].Name < tree.Entries[j].Name
+	})
+
+	treeHash, err := n.store(tree)
+	if err != nil {
+		return fmt.Errorf("storing notes tree: %w", err)
+	}
+
+	c := &object.Commit{
+		Author:    signature,
+		Committer: signature,
+		Message:   message,
+		TreeHash:  treeHash,
+	}
+
+	if !n.parent.IsZero() {
+		c.ParentHashes = []plumbing.Hash{n.parent}
+	}
+
+	commitHash, err := n.store(c)
+	if err != nil {
+		return fmt.Errorf("storing notes commit: %w", err)
+	}
+
+	err = n.repository.Storer.SetReference(plumbing.NewHashReference(n.ref, commitHash))
+	if err != nil {
+		return fmt.Errorf("updating notes reference: %w", err)
+	}
+
+	n.parent = commitHash
+	n.changed = false
+
+	return nil
+}
+
+func (n *Notes) store(o interface {
+	Encode(plumbing.EncodedObject) error
+}) (plumbing.Hash, error) {
+	encoded := n.repository.Storer.NewEncodedObject()
+
+	if err := o.Encode(encoded); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return n.repository.Storer.SetEncodedObject(encoded)
+}
@@ -0,0 +1,103 @@
+package notes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+)
+
+const ref = plumbing.ReferenceName("refs/notes/test")
+
+var signature = object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: time.Now()}
+
+func TestNotes_SetAndGet(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	first, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	second, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	n, err := Open(testRepository.Repository, ref)
+	checkErr(t, "opening notes", err)
+
+	assert.False(n.Has(first), "no note should exist yet")
+
+	err = n.Set(first, []byte("first note"))
+	checkErr(t, "setting note", err)
+
+	err = n.Commit(signature, "Notes added by test")
+	checkErr(t, "committing notes", err)
+
+	n, err = Open(testRepository.Repository, ref)
+	checkErr(t, "opening notes", err)
+
+	err = n.Set(second, []byte("second note"))
+	checkErr(t, "setting note", err)
+
+	err = n.Commit(signature, "Notes added by test")
+	checkErr(t, "committing notes", err)
+
+	n, err = Open(testRepository.Repository, ref)
+	checkErr(t, "opening notes", err)
+
+	content, ok, err := n.Get(first)
+	checkErr(t, "getting note", err)
+
+	assert.True(ok)
+	assert.Equal("first note", string(content), "notes of previous commits should be kept")
+
+	content, ok, err = n.Get(second)
+	checkErr(t, "getting note", err)
+
+	assert.True(ok)
+	assert.Equal("second note", string(content))
+
+	reference, err := testRepository.Reference(ref, true)
+	checkErr(t, "fetching notes reference", err)
+
+	c, err := testRepository.CommitObject(reference.Hash())
+	checkErr(t, "fetching notes commit", err)
+
+	assert.Equal(1, c.NumParents(), "notes commits should be chained")
+}
+
+func TestNotes_CommitUnchanged(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	n, err := Open(testRepository.Repository, ref)
+	checkErr(t, "opening notes", err)
+
+	err = n.Commit(signature, "Notes added by test")
+	checkErr(t, "committing notes", err)
+
+	_, err = testRepository.Reference(ref, true)
+	assert.ErrorIs(err, plumbing.ErrReferenceNotFound, "no notes commit should have been created")
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
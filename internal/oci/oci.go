@@ -0,0 +1,96 @@
+// Package oci provides the Docker tags of releases and a minimal client of the OCI distribution API to retag images.
+package oci
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+// dockerHubRegistry is the host of the registry API of Docker Hub, the registry of images named without one.
+const dockerHubRegistry = "registry-1.docker.io"
+
+// LatestTag is the tag of the latest stable release.
+const LatestTag = "latest"
+
+var ErrInvalidImage = errors.New("invalid image name")
+
+// repositoryRegex matches the repository component of image names, as defined by the OCI distribution specification.
+var repositoryRegex = regexp.MustCompile(`^[a-z0-9]+(?:(?:\.|_|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:\.|_|__|-+)[a-z0-9]+)*)*$`)
+
+// Image is an image of a container registry.
+type Image struct {
+	// Name is the name of the image as given (e.g., "ghcr.io/owner/app" or "owner/app").
+	Name string
+	// Registry is the host of the registry API (e.g., "ghcr.io").
+	Registry string
+	// Repository is the repository of the image in the registry (e.g., "owner/app").
+	Repository string
+}
+
+// ParseImage parses the name of an image, without tag nor digest, such as "ghcr.io/owner/app". Images named without a
+// registry (e.g., "owner/app" or "app") are hosted on Docker Hub.
+func ParseImage(name string) (Image, error) {
+	image := Image{Name: name, Registry: dockerHubRegistry, Repository: name}
+
+	if first, rest, found := strings.Cut(name, "/"); found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		image.Registry = first
+		image.Repository = rest
+	}
+
+	if strings.Contains(image.Repository, "@") || strings.Contains(image.Repository, ":") {
+		return Image{}, fmt.Errorf("%w: %q must not have a tag nor a digest", ErrInvalidImage, name)
+	}
+
+	if !repositoryRegex.MatchString(image.Repository) {
+		return Image{}, fmt.Errorf("%w: %q", ErrInvalidImage, name)
+	}
+
+	// Official images of Docker Hub are stored under the "library" namespace
+	if image.Registry == dockerHubRegistry && !strings.Contains(image.Repository, "/") {
+		image.Repository = "library/" + image.Repository
+	}
+
+	return image, nil
+}
+
+// Reference returns the reference of the image with the given tag (e.g., "ghcr.io/owner/app:1.2.3").
+func (i Image) Reference(tag string) string {
+	return i.Name + ":" + tag
+}
+
+// Tags returns the Docker tags of the given version: the version itself, and for versions that are not prereleases,
+// its major and minor aliases (e.g., "1" and "1.2" for "1.2.3") if it is the highest of the given released versions in
+// their line, followed by "latest" if the version is the latest release. Tags cannot contain a "+", the one preceding
+// the build metadata is replaced by an underscore.
+func Tags(version *semver.Version, released []*semver.Version, latest bool) []string {
+	tag := strings.Replace(version.String(), "+", "_", 1)
+
+	if version.Prerelease != "" {
+		return []string{tag}
+	}
+
+	var tags []string
+
+	major, minor := semver.HighestInLines(version, released)
+
+	if major {
+		tags = append(tags, strconv.Itoa(version.Major))
+	}
+
+	if minor {
+		tags = append(tags, fmt.Sprintf("%d.%d", version.Major, version.Minor))
+	}
+
+	tags = append(tags, tag)
+
+	if latest {
+		tags = append(tags, LatestTag)
+	}
+
+	return tags
+}
@@ -0,0 +1,72 @@
+package oci
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+func TestParseImage(t *testing.T) {
+	assert := assertion.New(t)
+
+	tests := map[string]Image{
+		"ghcr.io/owner/app":     {Name: "ghcr.io/owner/app", Registry: "ghcr.io", Repository: "owner/app"},
+		"localhost:5000/app":    {Name: "localhost:5000/app", Registry: "localhost:5000", Repository: "app"},
+		"localhost/group/app":   {Name: "localhost/group/app", Registry: "localhost", Repository: "group/app"},
+		"owner/app":             {Name: "owner/app", Registry: dockerHubRegistry, Repository: "owner/app"},
+		"app":                   {Name: "app", Registry: dockerHubRegistry, Repository: "library/app"},
+		"registry.io/a/b/c-d_e": {Name: "registry.io/a/b/c-d_e", Registry: "registry.io", Repository: "a/b/c-d_e"},
+	}
+
+	for name, want := range tests {
+		image, err := ParseImage(name)
+		checkErr(t, "parsing image", err)
+
+		assert.Equal(want, image, name)
+	}
+
+	for _, name := range []string{"", "ghcr.io/owner/app:1.0.0", "owner/app@sha256:abc", "Owner/App", "ghcr.io/"} {
+		_, err := ParseImage(name)
+		assert.ErrorIs(err, ErrInvalidImage, name)
+	}
+}
+
+func TestTags(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		version  semver.Version
+		released []*semver.Version
+		latest   bool
+		want     []string
+	}
+
+	maintained := []*semver.Version{{Major: 1, Minor: 2, Patch: 3}, {Major: 1, Minor: 5, Patch: 0}}
+
+	matrix := []test{
+		{version: semver.Version{Major: 1, Minor: 2, Patch: 3}, latest: true, want: []string{"1", "1.2", "1.2.3", "latest"}},
+		{version: semver.Version{Major: 1, Minor: 2, Patch: 3}, latest: false, want: []string{"1", "1.2", "1.2.3"}},
+		{version: semver.Version{Major: 1, Minor: 2, Patch: 3, Metadata: "build.1"}, latest: true, want: []string{"1", "1.2", "1.2.3_build.1", "latest"}},
+		{version: semver.Version{Major: 2, Prerelease: "rc.1"}, latest: true, want: []string{"2.0.0-rc.1"}},
+		{version: semver.Version{Major: 1, Minor: 2, Patch: 4}, released: maintained, latest: false, want: []string{"1.2", "1.2.4"}},
+		{version: semver.Version{Major: 1, Minor: 5, Patch: 1}, released: maintained, latest: true, want: []string{"1", "1.5", "1.5.1", "latest"}},
+		{version: semver.Version{Major: 1, Minor: 1, Patch: 9}, released: maintained, latest: false, want: []string{"1.1", "1.1.9"}},
+		{version: semver.Version{Major: 1, Minor: 2, Patch: 2}, released: maintained, latest: false, want: []string{"1.2.2"}},
+	}
+
+	for _, tc := range matrix {
+		assert.Equal(tc.want, Tags(&tc.version, tc.released, tc.latest), tc.version.String())
+	}
+
+	image, _ := ParseImage("ghcr.io/owner/app")
+	assert.Equal("ghcr.io/owner/app:1.2.3", image.Reference("1.2.3"))
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
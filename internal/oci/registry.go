@@ -0,0 +1,269 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+// maxManifestSize is the maximum size of the manifests fetched from registries.
+const maxManifestSize = 4 << 20
+
+// manifestMediaTypes are the media types of the manifests a registry may return for an image, single or
+// multi-platform.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var ErrUnauthorized = errors.New("registry authentication failed")
+
+// Client is a minimal client of the OCI distribution API of container registries. Registries requiring a bearer token
+// are authenticated through the token endpoint given in their challenge, using the credentials of the client, if any.
+type Client struct {
+	httpClient *http.Client
+	username   string
+	password   string
+	// token is the bearer token obtained from the registry, if any
+	token string
+}
+
+type OptionFunc func(c *Client)
+
+// WithCredentials authenticates the requests using the given username and password (or access token).
+func WithCredentials(username, password string) OptionFunc {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// WithHTTPClient sends the requests using the given HTTP client instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) OptionFunc {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func NewClient(options ...OptionFunc) *Client {
+	c := &Client{
+		httpClient: http.DefaultClient,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// Retag adds the given tags to the manifest of the given image tagged with the source tag, without pulling nor pushing
+// its layers, so that the tags point to the exact same image.
+func (c *Client) Retag(ctx context.Context, image Image, source string, tags []string) error {
+	manifest, mediaType, err := c.manifest(ctx, image, source)
+	if err != nil {
+		return fmt.Errorf("fetching manifest of %q: %w", image.Reference(source), err)
+	}
+
+	for _, tag := range tags {
+		header := http.Header{"Content-Type": {mediaType}}
+
+		response, err := c.send(ctx, image, http.MethodPut, "/manifests/"+url.PathEscape(tag), header, manifest)
+		if err != nil {
+			return fmt.Errorf("tagging %q: %w", image.Reference(tag), err)
+		}
+
+		_ = response.Body.Close()
+	}
+
+	return nil
+}
+
+// manifest returns the manifest of the given image tagged with the given tag, and its media type.
+func (c *Client) manifest(ctx context.Context, image Image, tag string) ([]byte, string, error) {
+	header := http.Header{"Accept": {strings.Join(manifestMediaTypes, ", ")}}
+
+	response, err := c.send(ctx, image, http.MethodGet, "/manifests/"+url.PathEscape(tag), header, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	manifest, err := io.ReadAll(io.LimitReader(response.Body, maxManifestSize))
+	if err != nil {
+		return nil, "", fmt.Errorf("reading manifest: %w", err)
+	}
+
+	return manifest, response.Header.Get("Content-Type"), nil
+}
+
+// send sends a request to the given path of the API of the repository of the given image, authenticating it if the
+// registry requires it. The response is returned if its status is successful, its body must be closed by the caller.
+func (c *Client) send(ctx context.Context, image Image, method, path string, header http.Header, body []byte) (*http.Response, error) {
+	endpoint := registryURL(image.Registry) + "/v2/" + image.Repository + path
+
+	response, err := c.do(ctx, method, endpoint, header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusUnauthorized {
+		challenge := response.Header.Get("WWW-Authenticate")
+		_ = response.Body.Close()
+
+		if err = c.authenticate(ctx, image, challenge); err != nil {
+			return nil, err
+		}
+
+		response, err = c.do(ctx, method, endpoint, header, body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+
+		return nil, &restapi.ResponseError{
+			Method:     method,
+			Path:       path,
+			Status:     response.Status,
+			Message:    strings.TrimSpace(string(message)),
+			StatusCode: response.StatusCode,
+		}
+	}
+
+	return response, nil
+}
+
+func (c *Client) do(ctx context.Context, method, endpoint string, header http.Header, body []byte) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	for key, values := range header {
+		request.Header[key] = values
+	}
+
+	request.Header.Set("User-Agent", "go-semver-release")
+
+	switch {
+	case c.token != "":
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	case c.username != "" || c.password != "":
+		request.SetBasicAuth(c.username, c.password)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+
+	return response, nil
+}
+
+// authenticate obtains a bearer token allowed to pull and push the repository of the given image from the token
+// endpoint of the given challenge.
+func (c *Client) authenticate(ctx context.Context, image Image, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") || c.token != "" {
+		return ErrUnauthorized
+	}
+
+	parameters := parseChallenge(params)
+
+	realm, err := url.Parse(parameters["realm"])
+	if err != nil || parameters["realm"] == "" {
+		return fmt.Errorf("%w: invalid token realm %q", ErrUnauthorized, parameters["realm"])
+	}
+
+	query := realm.Query()
+	query.Set("scope", fmt.Sprintf("repository:%s:pull,push", image.Repository))
+
+	if service := parameters["service"]; service != "" {
+		query.Set("service", service)
+	}
+
+	realm.RawQuery = query.Encode()
+
+	response, err := c.do(ctx, http.MethodGet, realm.String(), nil, nil)
+	if err != nil {
+		return fmt.Errorf("requesting token: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: token endpoint responded %s", ErrUnauthorized, response.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err = json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return fmt.Errorf("decoding token: %w", err)
+	}
+
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+
+	if c.token == "" {
+		return fmt.Errorf("%w: no token returned", ErrUnauthorized)
+	}
+
+	return nil
+}
+
+// parseChallenge parses the comma-separated key="value" parameters of an authentication challenge.
+func parseChallenge(params string) map[string]string {
+	parameters := make(map[string]string)
+
+	for params != "" {
+		var key, value string
+
+		key, params, _ = strings.Cut(params, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if strings.HasPrefix(params, `"`) {
+			value, params, _ = strings.Cut(params[1:], `"`)
+			params = strings.TrimPrefix(strings.TrimSpace(params), ",")
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+
+		parameters[key] = strings.TrimSpace(value)
+	}
+
+	return parameters
+}
+
+// registryURL returns the base URL of the API of the given registry. As with Docker, registries running on the local
+// host are reached over plain HTTP.
+func registryURL(registry string) string {
+	host := registry
+	if h, _, found := strings.Cut(registry, ":"); found {
+		host = h
+	}
+
+	if host == "localhost" || host == "127.0.0.1" {
+		return "http://" + registry
+	}
+
+	return "https://" + registry
+}
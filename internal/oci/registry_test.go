@@ -0,0 +1,107 @@
+package oci
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+func TestClient_Retag(t *testing.T) {
+	assert := assertion.New(t)
+
+	const (
+		manifest  = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}` + "\n"
+		mediaType = "application/vnd.oci.image.index.v1+json"
+	)
+
+	manifests := map[string]string{"sha-abc1234": manifest}
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			username, password, _ := r.BasicAuth()
+			if username != "user" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			assert.Equal("repository:owner/app:pull,push", r.URL.Query().Get("scope"))
+			assert.Equal("registry", r.URL.Query().Get("service"))
+
+			_, _ = w.Write([]byte(`{"token": "bearer-token"}`))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer bearer-token" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="registry",scope="repository:owner/app:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		tag, found := strings.CutPrefix(r.URL.Path, "/v2/owner/app/manifests/")
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			content, ok := manifests[tag]
+			if !ok {
+				http.Error(w, `{"errors":[{"code":"MANIFEST_UNKNOWN"}]}`, http.StatusNotFound)
+				return
+			}
+
+			assert.Contains(r.Header.Get("Accept"), mediaType)
+
+			w.Header().Set("Content-Type", mediaType)
+			_, _ = w.Write([]byte(content))
+		case http.MethodPut:
+			assert.Equal(mediaType, r.Header.Get("Content-Type"))
+
+			content, _ := io.ReadAll(r.Body)
+			manifests[tag] = string(content)
+
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	image, err := ParseImage(strings.TrimPrefix(server.URL, "http://") + "/owner/app")
+	checkErr(t, "parsing image", err)
+
+	client := NewClient(WithCredentials("user", "secret"))
+
+	err = client.Retag(context.Background(), image, "sha-abc1234", []string{"1", "1.2", "1.2.3", "latest"})
+	checkErr(t, "retagging image", err)
+
+	for _, tag := range []string{"1", "1.2", "1.2.3", "latest"} {
+		assert.Equal(manifest, manifests[tag], "manifest should be copied as is")
+	}
+
+	err = client.Retag(context.Background(), image, "unknown", []string{"1"})
+	assert.True(restapi.HasStatus(err, http.StatusNotFound), "retagging an unknown tag should fail")
+
+	err = NewClient(WithCredentials("user", "wrong")).Retag(context.Background(), image, "sha-abc1234", []string{"1"})
+	assert.ErrorIs(err, ErrUnauthorized)
+}
+
+func TestParseChallenge(t *testing.T) {
+	assert := assertion.New(t)
+
+	parameters := parseChallenge(`realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:owner/app:pull,push"`)
+
+	assert.Equal(map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:owner/app:pull,push",
+	}, parameters)
+}
@@ -0,0 +1,169 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+const (
+	BumpMajor      = "major"
+	BumpMinor      = "minor"
+	BumpPatch      = "patch"
+	BumpPrerelease = "prerelease"
+	BumpNone       = "none"
+)
+
+// Manifest lists the releases of every branch and project in a single document.
+type Manifest struct {
+	Releases []ManifestEntry `json:"releases"`
+	// Released lists the names of the projects, or branches if not in monorepo mode, that have a new release.
+	Released []string `json:"released"`
+}
+
+type ManifestEntry struct {
+	Project         string   `json:"project,omitempty"`
+	Branch          string   `json:"branch"`
+	Channel         string   `json:"channel"`
+	PreviousVersion string   `json:"previous-version,omitempty"`
+	NextVersion     string   `json:"next-version"`
+	Bump            string   `json:"bump"`
+	NewRelease      bool     `json:"new-release"`
+	Forced          bool     `json:"forced,omitempty"`
+	Issues          []string `json:"issues,omitempty"`
+	DockerTags      []string `json:"docker-tags,omitempty"`
+}
+
+// Matrix is a GitHub Actions matrix whose jobs are the releases that were found, see
+// https://docs.github.com/en/actions/using-jobs/using-a-matrix-for-your-jobs.
+type Matrix struct {
+	Include []MatrixEntry `json:"include"`
+}
+
+type MatrixEntry struct {
+	Project string `json:"project,omitempty"`
+	Branch  string `json:"branch"`
+	Channel string `json:"channel"`
+	Version string `json:"version"`
+}
+
+// NewManifest returns the manifest of the given releases.
+func NewManifest(releases []Release) (Manifest, error) {
+	manifest := Manifest{
+		Releases: make([]ManifestEntry, 0, len(releases)),
+		Released: []string{},
+	}
+
+	for _, release := range releases {
+		bump, err := Bump(release)
+		if err != nil {
+			return manifest, err
+		}
+
+		manifest.Releases = append(manifest.Releases, ManifestEntry{
+			Project:         release.Project,
+			Branch:          release.Branch,
+			Channel:         release.Channel,
+			PreviousVersion: release.PreviousVersion,
+			NextVersion:     release.Version,
+			Bump:            bump,
+			NewRelease:      release.NewRelease,
+			Forced:          release.Forced,
+			Issues:          release.Issues,
+			DockerTags:      release.DockerTags,
+		})
+
+		if !release.NewRelease {
+			continue
+		}
+
+		name := release.Project
+		if name == "" {
+			name = release.Branch
+		}
+
+		manifest.Released = append(manifest.Released, name)
+	}
+
+	return manifest, nil
+}
+
+// NewMatrix returns the GitHub Actions matrix of the given releases, only those that are new releases are included.
+func NewMatrix(releases []Release) Matrix {
+	matrix := Matrix{Include: []MatrixEntry{}}
+
+	for _, release := range releases {
+		if !release.NewRelease {
+			continue
+		}
+
+		matrix.Include = append(matrix.Include, MatrixEntry{
+			Project: release.Project,
+			Branch:  release.Branch,
+			Channel: release.Channel,
+			Version: release.Version,
+		})
+	}
+
+	return matrix
+}
+
+// Bump returns the highest component of the version that was incremented by a release, "prerelease" if only the
+// prerelease component changed, or "none" if there is no new release.
+func Bump(release Release) (string, error) {
+	if !release.NewRelease {
+		return BumpNone, nil
+	}
+
+	previous := &semver.Version{}
+
+	if release.PreviousVersion != "" {
+		var err error
+
+		previous, err = semver.NewFromString(release.PreviousVersion)
+		if err != nil {
+			return "", fmt.Errorf("parsing previous version: %w", err)
+		}
+	}
+
+	next, err := semver.NewFromString(release.Version)
+	if err != nil {
+		return "", fmt.Errorf("parsing version: %w", err)
+	}
+
+	switch {
+	case next.Major != previous.Major:
+		return BumpMajor, nil
+	case next.Minor != previous.Minor:
+		return BumpMinor, nil
+	case next.Patch != previous.Patch:
+		return BumpPatch, nil
+	default:
+		return BumpPrerelease, nil
+	}
+}
+
+func (w *Writer) writeManifest() error {
+	var document any
+
+	switch w.format {
+	case FormatManifest:
+		manifest, err := NewManifest(w.releases)
+		if err != nil {
+			return fmt.Errorf("building manifest: %w", err)
+		}
+
+		document = manifest
+	default:
+		document = NewMatrix(w.releases)
+	}
+
+	content, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+
+	_, err = fmt.Fprintln(w.out, string(content))
+	return err
+}
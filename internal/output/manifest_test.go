@@ -0,0 +1,85 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+var releases = []Release{
+	{NewRelease: true, Version: "1.3.0", PreviousVersion: "1.2.3", Branch: "main", Channel: "stable", Project: "foo"},
+	{NewRelease: false, Version: "0.4.2", PreviousVersion: "0.4.2", Branch: "main", Channel: "stable", Project: "bar"},
+	{NewRelease: true, Version: "0.0.1", Branch: "main", Channel: "stable", Project: "baz"},
+}
+
+func TestOutput_Manifest(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		format string
+		want   string
+	}
+
+	matrix := []test{
+		{
+			format: FormatManifest,
+			want: `{"releases":[` +
+				`{"project":"foo","branch":"main","channel":"stable","previous-version":"1.2.3","next-version":"1.3.0","bump":"minor","new-release":true},` +
+				`{"project":"bar","branch":"main","channel":"stable","previous-version":"0.4.2","next-version":"0.4.2","bump":"none","new-release":false},` +
+				`{"project":"baz","branch":"main","channel":"stable","next-version":"0.0.1","bump":"patch","new-release":true}` +
+				`],"released":["foo","baz"]}` + "\n",
+		},
+		{
+			format: FormatMatrix,
+			want: `{"include":[` +
+				`{"project":"foo","branch":"main","channel":"stable","version":"1.3.0"},` +
+				`{"project":"baz","branch":"main","channel":"stable","version":"0.0.1"}` +
+				`]}` + "\n",
+		},
+	}
+
+	for _, tc := range matrix {
+		buf := new(bytes.Buffer)
+
+		w, err := NewWriter(tc.format, buf)
+		checkErr(t, "creating writer", err)
+
+		for _, release := range releases {
+			err = w.Write(release)
+			checkErr(t, "writing release", err)
+		}
+
+		assert.Empty(buf.String(), "nothing should be written before flushing in %q format", tc.format)
+
+		err = w.Flush()
+		checkErr(t, "flushing writer", err)
+
+		assert.Equal(tc.want, buf.String(), "output in %q format should be equal", tc.format)
+	}
+}
+
+func TestOutput_Bump(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		release Release
+		want    string
+	}
+
+	matrix := []test{
+		{Release{NewRelease: true, PreviousVersion: "1.2.3", Version: "2.0.0"}, BumpMajor},
+		{Release{NewRelease: true, PreviousVersion: "1.2.3", Version: "1.3.0"}, BumpMinor},
+		{Release{NewRelease: true, PreviousVersion: "1.2.3", Version: "1.2.4"}, BumpPatch},
+		{Release{NewRelease: true, PreviousVersion: "1.3.0-rc.1", Version: "1.3.0-rc.2"}, BumpPrerelease},
+		{Release{NewRelease: true, Version: "0.1.0"}, BumpMinor},
+		{Release{NewRelease: false, PreviousVersion: "1.2.3", Version: "1.2.3"}, BumpNone},
+	}
+
+	for _, tc := range matrix {
+		got, err := Bump(tc.release)
+		checkErr(t, "computing bump", err)
+
+		assert.Equal(tc.want, got, "bump of %q to %q should be equal", tc.release.PreviousVersion, tc.release.Version)
+	}
+}
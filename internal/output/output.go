@@ -0,0 +1,224 @@
+// Package output provides functions to print out the result of a release in various formats.
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatText     = "text"
+	FormatManifest = "manifest"
+	FormatMatrix   = "matrix"
+	templatePrefix = "go-template="
+)
+
+var ErrInvalidFormat = errors.New("invalid output format")
+
+// Release is the result of a release computed for a branch, and a project if in monorepo mode.
+type Release struct {
+	Message    string `yaml:"message"`
+	NewRelease bool   `yaml:"new-release"`
+	Version    string `yaml:"version"`
+	// NewVersion is the version of the new release, empty if no new release was found.
+	NewVersion string `yaml:"-"`
+	// PreviousVersion is the version of the latest release, empty if there is none.
+	PreviousVersion string `yaml:"-"`
+	Branch          string `yaml:"branch"`
+	Channel         string `yaml:"channel"`
+	Project         string `yaml:"project,omitempty"`
+	ReleaseAs       string `yaml:"release-as,omitempty"`
+	// Forced is true if the version was set or bumped by the release manager instead of being computed.
+	Forced bool `yaml:"forced,omitempty"`
+	// BumpedBy lists the projects whose release triggered the release of the project, which depends on them.
+	BumpedBy []string `yaml:"bumped-by,omitempty"`
+	// From and To are the bounds of the analyzed commit range, only set when the range is explicitly given.
+	From string `yaml:"from,omitempty"`
+	To   string `yaml:"to,omitempty"`
+	// Issues lists the issues and tickets referenced by the commits of the release (e.g., "#12", "JIRA-456").
+	Issues []string `yaml:"issues,omitempty"`
+	// DockerTags lists the references of the Docker image tagged with the new release (e.g., "ghcr.io/owner/app:1.2").
+	DockerTags []string `yaml:"docker-tags,omitempty"`
+}
+
+// Writer prints out releases in a given format. The JSON format is produced by a logger writing to the output of the
+// Writer, separate from the diagnostic logs of the program, so that releases are printed out as JSON log events.
+type Writer struct {
+	out      io.Writer
+	logger   zerolog.Logger
+	format   string
+	template *template.Template
+	// documents is the number of YAML documents written so far
+	documents int
+	// releases are the releases written so far, printed out at once by Flush in the manifest and matrix formats
+	releases []Release
+}
+
+// NewWriter returns a Writer for the given format, either "json", "yaml", "text", "manifest", "matrix" or
+// "go-template=<TEMPLATE>".
+func NewWriter(format string, out io.Writer) (*Writer, error) {
+	w := &Writer{
+		out:    out,
+		logger: zerolog.New(out),
+		format: format,
+	}
+
+	switch {
+	case format == FormatJSON, format == FormatYAML, format == FormatText, format == FormatManifest, format == FormatMatrix:
+	case strings.HasPrefix(format, templatePrefix):
+		tmpl, err := template.New("output").Option("missingkey=error").Parse(strings.TrimPrefix(format, templatePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("%w: parsing template: %w", ErrInvalidFormat, err)
+		}
+
+		w.template = tmpl
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidFormat, format)
+	}
+
+	return w, nil
+}
+
+// Write prints out a given release.
+func (w *Writer) Write(release Release) error {
+	if release.NewRelease {
+		release.NewVersion = release.Version
+	}
+
+	switch {
+	case w.template != nil:
+		if err := w.template.Execute(w.out, release); err != nil {
+			return fmt.Errorf("executing template: %w", err)
+		}
+
+		_, err := fmt.Fprintln(w.out)
+		return err
+	case w.format == FormatYAML:
+		return w.writeYAML(release)
+	case w.format == FormatText:
+		return w.writeText(release)
+	case w.format == FormatManifest, w.format == FormatMatrix:
+		w.releases = append(w.releases, release)
+		return nil
+	default:
+		w.writeJSON(release)
+		return nil
+	}
+}
+
+// Flush prints out the releases written so far if the format aggregates them in a single document, i.e., "manifest"
+// and "matrix". It must be called once every release has been written.
+func (w *Writer) Flush() error {
+	if w.format != FormatManifest && w.format != FormatMatrix {
+		return nil
+	}
+
+	return w.writeManifest()
+}
+
+func (w *Writer) writeJSON(release Release) {
+	logEvent := w.logger.Info()
+	logEvent.Bool("new-release", release.NewRelease)
+	logEvent.Str("version", release.Version)
+	logEvent.Str("branch", release.Branch)
+	logEvent.Str("channel", release.Channel)
+
+	if release.ReleaseAs != "" {
+		logEvent.Str("release-as", release.ReleaseAs)
+	}
+
+	if release.Forced {
+		logEvent.Bool("forced", true)
+	}
+
+	if release.Project != "" {
+		logEvent.Str("project", release.Project)
+	}
+
+	if len(release.BumpedBy) > 0 {
+		logEvent.Strs("bumped-by", release.BumpedBy)
+	}
+
+	if release.From != "" {
+		logEvent.Str("from", release.From)
+	}
+
+	if release.To != "" {
+		logEvent.Str("to", release.To)
+	}
+
+	if len(release.Issues) > 0 {
+		logEvent.Strs("issues", release.Issues)
+	}
+
+	if len(release.DockerTags) > 0 {
+		logEvent.Strs("docker-tags", release.DockerTags)
+	}
+
+	logEvent.Msg(release.Message)
+}
+
+func (w *Writer) writeYAML(release Release) error {
+	if w.documents > 0 {
+		if _, err := fmt.Fprintln(w.out, "---"); err != nil {
+			return err
+		}
+	}
+
+	w.documents++
+
+	content, err := yaml.Marshal(release)
+	if err != nil {
+		return fmt.Errorf("encoding release: %w", err)
+	}
+
+	_, err = w.out.Write(content)
+	return err
+}
+
+func (w *Writer) writeText(release Release) error {
+	line := fmt.Sprintf("%s: version=%s branch=%s channel=%s new-release=%t", release.Message, release.Version, release.Branch, release.Channel, release.NewRelease)
+
+	if release.Project != "" {
+		line += " project=" + release.Project
+	}
+
+	if release.ReleaseAs != "" {
+		line += " release-as=" + release.ReleaseAs
+	}
+
+	if release.Forced {
+		line += " forced=true"
+	}
+
+	if len(release.BumpedBy) > 0 {
+		line += " bumped-by=" + strings.Join(release.BumpedBy, ",")
+	}
+
+	if release.From != "" {
+		line += " from=" + release.From
+	}
+
+	if release.To != "" {
+		line += " to=" + release.To
+	}
+
+	if len(release.Issues) > 0 {
+		line += " issues=" + strings.Join(release.Issues, ",")
+	}
+
+	if len(release.DockerTags) > 0 {
+		line += " docker-tags=" + strings.Join(release.DockerTags, ",")
+	}
+
+	_, err := fmt.Fprintln(w.out, line)
+	return err
+}
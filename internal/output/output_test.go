@@ -0,0 +1,164 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+var (
+	newRelease = Release{
+		Message:    "new release found",
+		NewRelease: true,
+		Version:    "1.2.3",
+		Branch:     "master",
+		Channel:    "stable",
+		Project:    "foo",
+		BumpedBy:   []string{"bar"},
+		Issues:     []string{"#12", "JIRA-456"},
+	}
+	noRelease = Release{
+		Message: "no new release",
+		Version: "1.0.0",
+		Branch:  "rc",
+		Channel: "rc",
+	}
+)
+
+func TestOutput_Write(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		format string
+		want   string
+	}
+
+	matrix := []test{
+		{
+			format: FormatJSON,
+			want: `{"level":"info","new-release":true,"version":"1.2.3","branch":"master","channel":"stable","project":"foo","bumped-by":["bar"],"issues":["#12","JIRA-456"],"message":"new release found"}
+{"level":"info","new-release":false,"version":"1.0.0","branch":"rc","channel":"rc","message":"no new release"}
+`,
+		},
+		{
+			format: FormatYAML,
+			want: `message: new release found
+new-release: true
+version: 1.2.3
+branch: master
+channel: stable
+project: foo
+bumped-by:
+    - bar
+issues:
+    - '#12'
+    - JIRA-456
+---
+message: no new release
+new-release: false
+version: 1.0.0
+branch: rc
+channel: rc
+`,
+		},
+		{
+			format: FormatText,
+			want: `new release found: version=1.2.3 branch=master channel=stable new-release=true project=foo bumped-by=bar issues=#12,JIRA-456
+no new release: version=1.0.0 branch=rc channel=rc new-release=false
+`,
+		},
+		{
+			format: "go-template={{ .NewVersion }}",
+			want:   "1.2.3\n\n",
+		},
+		{
+			format: "go-template={{ .Branch }}={{ .Version }}",
+			want:   "master=1.2.3\nrc=1.0.0\n",
+		},
+	}
+
+	for _, tc := range matrix {
+		buf := new(bytes.Buffer)
+
+		w, err := NewWriter(tc.format, buf)
+		checkErr(t, "creating writer", err)
+
+		err = w.Write(newRelease)
+		checkErr(t, "writing release", err)
+
+		err = w.Write(noRelease)
+		checkErr(t, "writing release", err)
+
+		assert.Equal(tc.want, buf.String(), "output in %q format should be equal", tc.format)
+	}
+}
+
+func TestOutput_WriteForced(t *testing.T) {
+	assert := assertion.New(t)
+
+	release := Release{Message: "new release forced", NewRelease: true, Version: "2.0.0", Branch: "master", Forced: true}
+
+	want := map[string]string{
+		FormatJSON: `{"level":"info","new-release":true,"version":"2.0.0","branch":"master","channel":"","forced":true,"message":"new release forced"}` + "\n",
+		FormatText: "new release forced: version=2.0.0 branch=master channel= new-release=true forced=true\n",
+	}
+
+	for format, output := range want {
+		buf := new(bytes.Buffer)
+
+		w, err := NewWriter(format, buf)
+		checkErr(t, "creating writer", err)
+
+		err = w.Write(release)
+		checkErr(t, "writing release", err)
+
+		assert.Equal(output, buf.String(), "output in %q format should be equal", format)
+	}
+}
+
+func TestOutput_WriteDockerTags(t *testing.T) {
+	assert := assertion.New(t)
+
+	release := Release{Message: "new release found", NewRelease: true, Version: "1.2.0", Branch: "master", DockerTags: []string{"app:1", "app:1.2", "app:1.2.0"}}
+
+	want := map[string]string{
+		FormatJSON: `{"level":"info","new-release":true,"version":"1.2.0","branch":"master","channel":"","docker-tags":["app:1","app:1.2","app:1.2.0"],"message":"new release found"}` + "\n",
+		FormatText: "new release found: version=1.2.0 branch=master channel= new-release=true docker-tags=app:1,app:1.2,app:1.2.0\n",
+	}
+
+	for format, output := range want {
+		buf := new(bytes.Buffer)
+
+		w, err := NewWriter(format, buf)
+		checkErr(t, "creating writer", err)
+
+		err = w.Write(release)
+		checkErr(t, "writing release", err)
+
+		assert.Equal(output, buf.String(), "output in %q format should be equal", format)
+	}
+}
+
+func TestOutput_InvalidFormat(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := NewWriter("xml", new(bytes.Buffer))
+	assert.ErrorIs(err, ErrInvalidFormat)
+
+	_, err = NewWriter("go-template={{ .Version", new(bytes.Buffer))
+	assert.ErrorIs(err, ErrInvalidFormat)
+
+	w, err := NewWriter("go-template={{ .Unknown }}", new(bytes.Buffer))
+	checkErr(t, "creating writer", err)
+
+	err = w.Write(newRelease)
+	assert.ErrorContains(err, "executing template")
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
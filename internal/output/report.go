@@ -0,0 +1,147 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const shortHashLength = 7
+
+// CommitReport is the classification of a commit considered when computing a release.
+type CommitReport struct {
+	Hash     string `yaml:"hash" json:"hash"`
+	Type     string `yaml:"type,omitempty" json:"type,omitempty"`
+	Scope    string `yaml:"scope,omitempty" json:"scope,omitempty"`
+	Breaking bool   `yaml:"breaking" json:"breaking"`
+	// Rule is the release type of the rule matching the commit type, empty if there is none.
+	Rule string `yaml:"rule,omitempty" json:"rule,omitempty"`
+	// Bump is the version component bumped by the commit, empty if the commit is ignored.
+	Bump    string `yaml:"bump,omitempty" json:"bump,omitempty"`
+	Ignored bool   `yaml:"ignored" json:"ignored"`
+	// Reason explains why the commit is ignored.
+	Reason string `yaml:"reason,omitempty" json:"reason,omitempty"`
+}
+
+type report struct {
+	Branch  string         `yaml:"branch"`
+	Project string         `yaml:"project,omitempty"`
+	Commits []CommitReport `yaml:"commits"`
+}
+
+// WriteReport prints out the classification of the commits considered when computing the given release. Only the
+// "json", "yaml" and "text" formats print out reports.
+func (w *Writer) WriteReport(release Release, commits []CommitReport) error {
+	switch w.format {
+	case FormatJSON:
+		w.writeJSONReport(release, commits)
+		return nil
+	case FormatYAML:
+		return w.writeYAMLReport(release, commits)
+	case FormatText:
+		return w.writeTextReport(release, commits)
+	default:
+		return nil
+	}
+}
+
+func (w *Writer) writeJSONReport(release Release, commits []CommitReport) {
+	for _, commit := range commits {
+		logEvent := w.logger.Info()
+		logEvent.Str("commit", commit.Hash)
+		logEvent.Str("branch", release.Branch)
+
+		if release.Project != "" {
+			logEvent.Str("project", release.Project)
+		}
+
+		if commit.Type != "" {
+			logEvent.Str("type", commit.Type)
+		}
+
+		if commit.Scope != "" {
+			logEvent.Str("scope", commit.Scope)
+		}
+
+		logEvent.Bool("breaking", commit.Breaking)
+
+		if commit.Rule != "" {
+			logEvent.Str("rule", commit.Rule)
+		}
+
+		if commit.Ignored {
+			logEvent.Str("reason", commit.Reason).Msg("commit ignored")
+			continue
+		}
+
+		logEvent.Str("bump", commit.Bump).Msg("commit triggers a release")
+	}
+}
+
+func (w *Writer) writeYAMLReport(release Release, commits []CommitReport) error {
+	if w.documents > 0 {
+		if _, err := fmt.Fprintln(w.out, "---"); err != nil {
+			return err
+		}
+	}
+
+	w.documents++
+
+	content, err := yaml.Marshal(report{Branch: release.Branch, Project: release.Project, Commits: commits})
+	if err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+
+	_, err = w.out.Write(content)
+	return err
+}
+
+func (w *Writer) writeTextReport(release Release, commits []CommitReport) error {
+	buf := new(strings.Builder)
+
+	buf.WriteString("commits considered on branch " + release.Branch)
+
+	if release.Project != "" {
+		buf.WriteString(" for project " + release.Project)
+	}
+
+	buf.WriteString(":\n")
+
+	for _, commit := range commits {
+		buf.WriteString("  " + commit.String() + "\n")
+	}
+
+	_, err := fmt.Fprint(w.out, buf.String())
+	return err
+}
+
+// String returns the human-readable classification of the commit (e.g., "0a4e3b5 feat(api) rule=minor bump=minor").
+func (c CommitReport) String() string {
+	hash := c.Hash
+	if len(hash) > shortHashLength {
+		hash = hash[:shortHashLength]
+	}
+
+	commitType := c.Type
+	if c.Scope != "" {
+		commitType += "(" + c.Scope + ")"
+	}
+	if c.Breaking {
+		commitType += "!"
+	}
+
+	switch {
+	case c.Ignored && commitType == "":
+		return fmt.Sprintf("%s ignored: %s", hash, c.Reason)
+	case c.Ignored:
+		return fmt.Sprintf("%s %s ignored: %s", hash, commitType, c.Reason)
+	default:
+		rule := c.Rule
+		if rule == "" {
+			rule = "-"
+		}
+
+		return fmt.Sprintf("%s %s rule=%s bump=%s", hash, commitType, rule, c.Bump)
+	}
+}
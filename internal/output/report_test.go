@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+var commits = []CommitReport{
+	{Hash: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Type: "feat", Scope: "api", Rule: "minor", Bump: "minor"},
+	{Hash: "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", Type: "chore", Rule: "none", Ignored: true, Reason: "commit type skipped by release rule"},
+	{Hash: "cccccccccccccccccccccccccccccccccccccccc", Ignored: true, Reason: "not a conventional commit"},
+}
+
+func TestOutput_WriteReport(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		format string
+		want   string
+	}
+
+	matrix := []test{
+		{
+			format: FormatJSON,
+			want: `{"level":"info","commit":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","branch":"master","project":"foo","type":"feat","scope":"api","breaking":false,"rule":"minor","bump":"minor","message":"commit triggers a release"}
+{"level":"info","commit":"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb","branch":"master","project":"foo","type":"chore","breaking":false,"rule":"none","reason":"commit type skipped by release rule","message":"commit ignored"}
+{"level":"info","commit":"cccccccccccccccccccccccccccccccccccccccc","branch":"master","project":"foo","breaking":false,"reason":"not a conventional commit","message":"commit ignored"}
+`,
+		},
+		{
+			format: FormatYAML,
+			want: `branch: master
+project: foo
+commits:
+    - hash: aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
+      type: feat
+      scope: api
+      breaking: false
+      rule: minor
+      bump: minor
+      ignored: false
+    - hash: bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb
+      type: chore
+      breaking: false
+      rule: none
+      ignored: true
+      reason: commit type skipped by release rule
+    - hash: cccccccccccccccccccccccccccccccccccccccc
+      breaking: false
+      ignored: true
+      reason: not a conventional commit
+`,
+		},
+		{
+			format: FormatText,
+			want: `commits considered on branch master for project foo:
+  aaaaaaa feat(api) rule=minor bump=minor
+  bbbbbbb chore ignored: commit type skipped by release rule
+  ccccccc ignored: not a conventional commit
+`,
+		},
+		{
+			format: "go-template={{ .Version }}",
+			want:   "",
+		},
+	}
+
+	for _, tc := range matrix {
+		buf := new(bytes.Buffer)
+
+		w, err := NewWriter(tc.format, buf)
+		checkErr(t, "creating writer", err)
+
+		err = w.WriteReport(newRelease, commits)
+		checkErr(t, "writing report", err)
+
+		assert.Equal(tc.want, buf.String(), "report in %q format should be equal", tc.format)
+	}
+}
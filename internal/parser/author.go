@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// excludesAuthor returns true if the author of the given commit is ignored, or is not allowed when an allowlist of
+// authors is configured, so that the commit cannot trigger a release.
+func (p *Parser) excludesAuthor(commit *object.Commit) bool {
+	for _, pattern := range p.ctx.IgnoreAuthorsFlag {
+		if matchesAuthor(pattern, commit.Author) {
+			return true
+		}
+	}
+
+	if len(p.ctx.OnlyAuthorsFlag) == 0 {
+		return false
+	}
+
+	for _, pattern := range p.ctx.OnlyAuthorsFlag {
+		if matchesAuthor(pattern, commit.Author) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesAuthor returns true if the given pattern matches either the name or the email of the given author. Patterns
+// are case-insensitive and "*" matches any sequence of characters, other characters being literal (e.g.,
+// "dependabot[bot]" or "*@example.com").
+func matchesAuthor(pattern string, author object.Signature) bool {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return false
+	}
+
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	regex := regexp.MustCompile(`(?i)^` + strings.Join(parts, ".*") + `$`)
+
+	return regex.MatchString(author.Name) || regex.MatchString(author.Email)
+}
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+)
+
+// breakingClassifier detects the breaking changes of commit messages: the marker of the header (i.e., "!" after the
+// commit type, or the "breaking" group of a custom commit pattern), the BREAKING CHANGE footers and the lines of the
+// body starting with an additional keyword.
+type breakingClassifier struct {
+	// keywords are the additional markers of breaking changes (e.g., "MAJOR:" or "BACKWARDS INCOMPATIBLE").
+	keywords []string
+	// ignoreMarker is true if the marker of the header is not considered a breaking change.
+	ignoreMarker bool
+}
+
+func newBreakingClassifier(ctx *appcontext.AppContext) breakingClassifier {
+	return breakingClassifier{
+		keywords:     ctx.BreakingKeywordsFlag,
+		ignoreMarker: ctx.IgnoreExclamationFlag,
+	}
+}
+
+// classify sets whether the given commit, parsed from the given message, is a breaking change and the descriptions of
+// its breaking changes. The commit is expected to only hold the marker of its header.
+func (c breakingClassifier) classify(commit *Commit, message string) {
+	if c.ignoreMarker {
+		commit.Breaking = false
+	}
+
+	for _, f := range parseFooters(message) {
+		if f.isBreakingChange() {
+			commit.Breaking = true
+			commit.BreakingChanges = append(commit.BreakingChanges, f.value)
+		}
+	}
+
+	_, body, _ := strings.Cut(message, "\n")
+
+	for _, line := range strings.Split(body, "\n") {
+		description, ok := c.keywordDescription(line)
+		if !ok {
+			continue
+		}
+
+		commit.Breaking = true
+
+		if description != "" {
+			commit.BreakingChanges = append(commit.BreakingChanges, description)
+		}
+	}
+}
+
+// keywordDescription returns the description following the breaking change keyword starting the given line, if any. A
+// keyword must be followed by the end of the line, a colon or a whitespace, so that "MAJOR" does not match "MAJORITY".
+func (c breakingClassifier) keywordDescription(line string) (string, bool) {
+	for _, keyword := range c.keywords {
+		rest, ok := strings.CutPrefix(line, keyword)
+		if !ok || keyword == "" {
+			continue
+		}
+
+		if !strings.HasSuffix(keyword, ":") && rest != "" && rest[0] != ':' && rest[0] != ' ' && rest[0] != '\t' {
+			continue
+		}
+
+		return strings.TrimSpace(strings.TrimPrefix(rest, ":")), true
+	}
+
+	return "", false
+}
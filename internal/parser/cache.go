@@ -0,0 +1,303 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/commit"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/notes"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+// NotesRef is the reference of the Git notes caching the analysis of the commit history, so that subsequent runs only
+// analyze the commits added since.
+const NotesRef = plumbing.ReferenceName("refs/notes/go-semver-release")
+
+// cacheVersion must be incremented whenever the cached state, or the way it is computed, changes so that previous
+// caches are ignored.
+const cacheVersion = 2
+
+// cacheState is the state of the analysis of the commit history up to a given commit. Every state is stored along a
+// key identifying the project, the configuration and the commit at which the history analysis stops, so that a state
+// is only reused by an identical analysis.
+type cacheState struct {
+	Version    string         `json:"version"`
+	NewRelease bool           `json:"new-release"`
+	CommitHash string         `json:"commit,omitempty"`
+	ReleaseAs  string         `json:"release-as,omitempty"`
+	Commits    []cachedCommit `json:"commits,omitempty"`
+}
+
+type cachedCommit struct {
+	Hash            string   `json:"hash"`
+	Type            string   `json:"type"`
+	Scope           string   `json:"scope,omitempty"`
+	Description     string   `json:"description"`
+	Breaking        bool     `json:"breaking,omitempty"`
+	BreakingChanges []string `json:"breaking-changes,omitempty"`
+	References      []string `json:"references,omitempty"`
+}
+
+func newCacheState(version *semver.Version, newRelease bool, commitHash plumbing.Hash, releaseAs *semver.Version, commits []Commit) cacheState {
+	state := cacheState{
+		Version:    version.String(),
+		NewRelease: newRelease,
+	}
+
+	if !commitHash.IsZero() {
+		state.CommitHash = commitHash.String()
+	}
+
+	if releaseAs != nil {
+		state.ReleaseAs = releaseAs.String()
+	}
+
+	for _, c := range commits {
+		state.Commits = append(state.Commits, cachedCommit{
+			Hash:            c.Hash.String(),
+			Type:            c.Type,
+			Scope:           c.Scope,
+			Description:     c.Description,
+			Breaking:        c.Breaking,
+			BreakingChanges: c.BreakingChanges,
+			References:      c.References,
+		})
+	}
+
+	return state
+}
+
+// commits returns the release commits of the state.
+func (s cacheState) commits() []Commit {
+	var commits []Commit
+
+	for _, c := range s.Commits {
+		commits = append(commits, Commit{
+			Hash:            plumbing.NewHash(c.Hash),
+			Type:            c.Type,
+			Scope:           c.Scope,
+			Description:     c.Description,
+			Breaking:        c.Breaking,
+			BreakingChanges: c.BreakingChanges,
+			References:      c.References,
+		})
+	}
+
+	return commits
+}
+
+// cacheKey returns the key identifying the analysis of the history of the given project, with the current
+// configuration, starting from the given version and stopping at the given commit.
+func (p *Parser) cacheKey(project monorepo.Project, versionRange *branch.Range, latestSemver *semver.Version, stopAt plumbing.Hash) (string, error) {
+	key := struct {
+		CacheVersion         int
+		LatestSemver         string
+		StopAt               string
+		Project              string
+		ProjectPath          string
+		Range                string
+		Rules                map[string]string
+		Paths                []string
+		ExcludePaths         []string
+		SkipReleaseMarkers   []string
+		IssuePattern         string
+		CommitPattern        string
+		BreakingKeywords     []string
+		IgnoreAuthors        []string
+		OnlyAuthors          []string
+		SignaturePolicy      string
+		SignatureKeyring     string
+		SignatureSigners     string
+		VersionScheme        string
+		CalVerFormat         string
+		FirstParent          bool
+		SquashedCommits      bool
+		Strict               bool
+		IgnoreExclamation    bool
+		MajorOnBreakingInDev bool
+	}{
+		CacheVersion:         cacheVersion,
+		LatestSemver:         latestSemver.String(),
+		StopAt:               stopAt.String(),
+		Project:              project.Name,
+		ProjectPath:          project.Path,
+		Rules:                p.rules(project).Map,
+		Paths:                p.ctx.PathsFlag,
+		ExcludePaths:         p.ctx.ExcludePathsFlag,
+		SkipReleaseMarkers:   p.ctx.SkipReleaseMarkersFlag,
+		IssuePattern:         p.ctx.IssuePatternFlag,
+		CommitPattern:        p.ctx.CommitPatternFlag,
+		BreakingKeywords:     p.ctx.BreakingKeywordsFlag,
+		IgnoreAuthors:        p.ctx.IgnoreAuthorsFlag,
+		OnlyAuthors:          p.ctx.OnlyAuthorsFlag,
+		SignaturePolicy:      p.ctx.SignaturePolicyFlag,
+		SignatureKeyring:     p.ctx.SignatureKeyringFlag,
+		SignatureSigners:     p.ctx.SignatureSignersFlag,
+		VersionScheme:        p.ctx.VersionSchemeFlag,
+		CalVerFormat:         p.ctx.CalVerFormatFlag,
+		FirstParent:          p.ctx.FirstParentFlag,
+		SquashedCommits:      p.ctx.SquashedCommitsFlag,
+		Strict:               p.ctx.StrictFlag,
+		IgnoreExclamation:    p.ctx.IgnoreExclamationFlag,
+		MajorOnBreakingInDev: p.ctx.MajorOnBreakingInDevFlag,
+	}
+
+	if versionRange != nil {
+		key.Range = versionRange.String()
+	}
+
+	content, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("encoding cache key: %w", err)
+	}
+
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// resume returns a walk option stopping the walk at the most recent commit whose analysis state is cached under the
+// given key, so that only the commits added since are read. The cached state is then stored in the given pointer. The
+// parser mutex must be held by the caller.
+func (p *Parser) resume(repository *git.Repository, key string, state **cacheState) (commit.OptionFunc, error) {
+	if err := p.loadNotes(repository); err != nil {
+		return nil, err
+	}
+
+	return commit.WithStopWhen(func(hash plumbing.Hash) (bool, error) {
+		// Commits are walked from the most recent, only the first cached state is used
+		if *state != nil {
+			return false, nil
+		}
+
+		cached, err := p.cachedState(hash, key)
+		if err != nil || cached == nil {
+			return false, err
+		}
+
+		p.ctx.Logger.Debug().Str("commit", hash.String()).Msg("resuming analysis from cached state")
+
+		*state = cached
+
+		return true, nil
+	}), nil
+}
+
+// cachedState returns the analysis state cached on the given commit under the given key, if any.
+func (p *Parser) cachedState(hash plumbing.Hash, key string) (*cacheState, error) {
+	states, err := p.cachedStates(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	state, ok := states[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &state, nil
+}
+
+// cachedStates returns every analysis state cached on the given commit.
+func (p *Parser) cachedStates(hash plumbing.Hash) (map[string]cacheState, error) {
+	content, ok, err := p.notes.Get(hash)
+	if err != nil {
+		return nil, fmt.Errorf("reading cache note of commit %q: %w", hash, err)
+	}
+
+	states := make(map[string]cacheState)
+
+	if !ok {
+		return states, nil
+	}
+
+	// Notes that cannot be decoded, such as notes written by a newer version, are ignored
+	if err = json.Unmarshal(content, &states); err != nil {
+		p.ctx.Logger.Debug().Str("commit", hash.String()).Err(err).Msg("ignoring invalid cache note")
+		return make(map[string]cacheState), nil
+	}
+
+	return states, nil
+}
+
+// cacheAnalysis records the analysis state of the history up to the given commit, stored by SaveCache. The parser
+// mutex must be held by the caller.
+func (p *Parser) cacheAnalysis(hash plumbing.Hash, key string, state cacheState) {
+	if p.pendingStates == nil {
+		p.pendingStates = make(map[plumbing.Hash]map[string]cacheState)
+	}
+
+	if p.pendingStates[hash] == nil {
+		p.pendingStates[hash] = make(map[string]cacheState)
+	}
+
+	p.pendingStates[hash][key] = state
+}
+
+// SaveCache stores the analysis states recorded by the previous computations as Git notes, under NotesRef, made by
+// the given signature. The notes reference must then be pushed to the remote for subsequent runs to use them.
+func (p *Parser) SaveCache(repository *git.Repository, signature object.Signature) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.pendingStates) == 0 {
+		return nil
+	}
+
+	if err := p.loadNotes(repository); err != nil {
+		return err
+	}
+
+	for hash, pending := range p.pendingStates {
+		// States of other projects and configurations cached on the same commit are kept
+		states, err := p.cachedStates(hash)
+		if err != nil {
+			return err
+		}
+
+		for key, state := range pending {
+			states[key] = state
+		}
+
+		content, err := json.Marshal(states)
+		if err != nil {
+			return fmt.Errorf("encoding cache note: %w", err)
+		}
+
+		if err = p.notes.Set(hash, content); err != nil {
+			return fmt.Errorf("setting cache note of commit %q: %w", hash, err)
+		}
+	}
+
+	if err := p.notes.Commit(signature, "Cache commit history analysis"); err != nil {
+		return fmt.Errorf("committing cache notes: %w", err)
+	}
+
+	p.pendingStates = nil
+
+	return nil
+}
+
+// loadNotes loads the cache notes of the repository, once. The parser mutex must be held by the caller.
+func (p *Parser) loadNotes(repository *git.Repository) error {
+	if p.notes != nil {
+		return nil
+	}
+
+	n, err := notes.Open(repository, NotesRef)
+	if err != nil {
+		return fmt.Errorf("loading cache notes: %w", err)
+	}
+
+	p.notes = n
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/ianaindex"
+)
+
+// commitMessage returns the message of the given commit transcoded to UTF-8 from the encoding declared by the commit,
+// with LF line endings. Messages that are not valid UTF-8 although they do not declare another encoding, as written
+// by tools ignoring i18n.commitEncoding, are assumed to be Windows-1252, a superset of the printable Latin-1.
+func commitMessage(c *object.Commit) string {
+	message := c.Message
+
+	if enc := messageEncoding(string(c.Encoding)); enc != nil {
+		if decoded, err := enc.NewDecoder().String(message); err == nil {
+			message = decoded
+		}
+	}
+
+	if !utf8.ValidString(message) {
+		decoded, err := charmap.Windows1252.NewDecoder().String(message)
+		if err != nil {
+			decoded = strings.ToValidUTF8(message, string(utf8.RuneError))
+		}
+
+		message = decoded
+	}
+
+	return normalizeLineEndings(message)
+}
+
+// messageEncoding returns the encoding of the given name, as declared in the encoding header of a commit, nil if the
+// message is UTF-8 or the encoding is unknown.
+func messageEncoding(name string) encoding.Encoding {
+	name = strings.TrimSpace(name)
+
+	if name == "" || strings.EqualFold(name, "UTF-8") || strings.EqualFold(name, "UTF8") {
+		return nil
+	}
+
+	// Git accepts the names known to iconv, the WHATWG labels cover most of them (e.g., latin1 or cp1252)
+	enc, err := htmlindex.Get(name)
+	if err != nil {
+		enc, err = ianaindex.IANA.Encoding(name)
+	}
+
+	if err != nil || enc == nil {
+		return nil
+	}
+
+	return enc
+}
+
+// normalizeLineEndings replaces the CRLF and CR line endings of the given message with LF.
+func normalizeLineEndings(message string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(message, "\r\n", "\n"), "\r", "\n")
+}
+
+// subject returns the subject of the given message, its first paragraph, whose lines are joined by a space as Git does
+// for subjects spanning several lines.
+func subject(message string) string {
+	paragraph, _, _ := strings.Cut(strings.Trim(message, "\n"), "\n\n")
+
+	lines := strings.Split(paragraph, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	return strings.Join(lines, " ")
+}
@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// footerRegex matches the first line of a footer, whose token is either "BREAKING CHANGE" or a word using "-" in
+// place of whitespaces, followed by either ": " or " #" (e.g., "Refs #123").
+var footerRegex = regexp.MustCompile(`^(BREAKING CHANGE|[\w-]+)(?:: | #)(.*)$`)
+
+// footer is a token and value pair of the footer section of a commit message.
+type footer struct {
+	token string
+	value string
+}
+
+// isBreakingChange returns true if the footer describes a breaking change.
+func (f footer) isBreakingChange() bool {
+	return f.token == "BREAKING CHANGE" || f.token == "BREAKING-CHANGE"
+}
+
+// parseFooters returns the footers of a commit message. The footer section starts with the first paragraph, after the
+// subject, beginning with a footer token. The value of a footer spans over multiple lines until the next footer token.
+func parseFooters(message string) []footer {
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+
+	start := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i-1]) == "" && footerRegex.MatchString(lines[i]) {
+			start = i
+			break
+		}
+	}
+
+	if start == -1 {
+		return nil
+	}
+
+	var (
+		footers []footer
+		value   []string
+	)
+
+	for _, line := range lines[start:] {
+		match := footerRegex.FindStringSubmatch(line)
+		if match == nil {
+			value = append(value, line)
+			continue
+		}
+
+		if len(footers) > 0 {
+			footers[len(footers)-1].value = strings.TrimSpace(strings.Join(value, "\n"))
+		}
+
+		footers = append(footers, footer{token: match[1]})
+		value = []string{match[2]}
+	}
+
+	footers[len(footers)-1].value = strings.TrimSpace(strings.Join(value, "\n"))
+
+	return footers
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidCommitPattern = errors.New("invalid commit pattern")
+
+// commitPatternGroups are the named groups a commit pattern can capture, only the commit type is mandatory.
+var commitPatternGroups = []string{"type", "scope", "breaking", "description"}
+
+// CompileCommitPattern compiles a custom commit grammar replacing the Conventional Commits one. The pattern is matched
+// against the header of commit messages and captures the commit type in a group named "type". It may also capture
+// the scope, a breaking change marker and the description in groups named "scope", "breaking" and "description".
+func CompileCommitPattern(pattern string) (*regexp.Regexp, error) {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCommitPattern, err)
+	}
+
+	names := compiled.SubexpNames()
+
+	if !slices.Contains(names, "type") {
+		return nil, fmt.Errorf("%w: missing the \"type\" named group", ErrInvalidCommitPattern)
+	}
+
+	for _, name := range names {
+		if name != "" && !slices.Contains(commitPatternGroups, name) {
+			return nil, fmt.Errorf("%w: unknown named group %q, expected one of %s", ErrInvalidCommitPattern, name, strings.Join(commitPatternGroups, ", "))
+		}
+	}
+
+	return compiled, nil
+}
+
+// parseMessage parses a commit message using the custom commit grammar if configured, the Conventional Commits
+// specification otherwise, and classifies its breaking changes.
+func (p *Parser) parseMessage(message string) (Commit, bool) {
+	var (
+		parsedCommit Commit
+		ok           bool
+	)
+
+	if p.ctx.CommitPattern != nil {
+		parsedCommit, ok = parseCustomMessage(message, p.ctx.CommitPattern)
+	} else {
+		parsedCommit, ok = parseMessage(message)
+	}
+
+	if !ok {
+		return parsedCommit, false
+	}
+
+	newBreakingClassifier(p.ctx).classify(&parsedCommit, message)
+
+	return parsedCommit, true
+}
+
+// parseCustomMessage parses the header of a message matching the given commit pattern. The captured commit type is
+// lowercased to be matched against the release rules, and the description defaults to the rest of the header. A
+// header spanning several lines is joined into one.
+func parseCustomMessage(message string, pattern *regexp.Regexp) (Commit, bool) {
+	header := subject(message)
+
+	match := pattern.FindStringSubmatchIndex(header)
+	if match == nil {
+		return Commit{}, false
+	}
+
+	group := func(name string) string {
+		i := pattern.SubexpIndex(name)
+		if i == -1 || match[2*i] == -1 {
+			return ""
+		}
+
+		return header[match[2*i]:match[2*i+1]]
+	}
+
+	parsedCommit := Commit{
+		Type:        strings.ToLower(strings.TrimSpace(group("type"))),
+		Scope:       strings.Trim(group("scope"), "() "),
+		Description: strings.TrimSpace(group("description")),
+		Breaking:    group("breaking") != "",
+	}
+
+	if parsedCommit.Type == "" {
+		return Commit{}, false
+	}
+
+	if pattern.SubexpIndex("description") == -1 {
+		parsedCommit.Description = strings.TrimSpace(header[match[1]:])
+	}
+
+	return parsedCommit, true
+}
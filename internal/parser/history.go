@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/s0ders/go-semver-release/v6/internal/commit"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+// SemverTags returns every semver tag of the given project, using its tag prefix, sorted from the lowest to the
+// highest version.
+func (p *Parser) SemverTags(repository *git.Repository, project monorepo.Project) ([]SemverTag, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tagPrefix, err := p.tagPrefix(repository, project)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []SemverTag
+
+	err = p.forEachSemverTag(repository, project, tagPrefix, func(reference *plumbing.Reference, version *semver.Version) error {
+		semverTag, err := newSemverTag(repository, reference, version)
+		if err != nil {
+			return err
+		}
+
+		tags = append(tags, *semverTag)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return semver.Compare(tags[i].Version, tags[j].Version) == -1
+	})
+
+	return tags, nil
+}
+
+// Comparison is the analysis of the commits between two revisions.
+type Comparison struct {
+	// From is the commit at which the analysis stops, excluded, and To the one from which it starts.
+	From plumbing.Hash
+	To   plumbing.Hash
+	// Version is the highest version among the semver tags pointing to From, 0.0.0 if there is none.
+	Version *semver.Version
+	// NextVersion is the version bumped by the commits between the revisions.
+	NextVersion *semver.Version
+	Commits     []Commit
+	// Report lists the classification of every commit between the revisions, from the oldest to the most recent.
+	Report []CommitReport
+}
+
+// Compare analyzes the commits of the given project between the given revisions, such as two tags or a tag and HEAD,
+// and returns the version they would release, without tagging anything. The commits reachable from the first revision
+// are excluded.
+func (p *Parser) Compare(ctx context.Context, repository *git.Repository, project monorepo.Project, from, to string) (Comparison, error) {
+	var comparison Comparison
+
+	tags, err := p.SemverTags(repository, project)
+	if err != nil {
+		return comparison, fmt.Errorf("fetching semver tags: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	comparison.From, err = p.resolveRevision(repository, from)
+	if err != nil {
+		return comparison, err
+	}
+
+	comparison.To, err = p.resolveRevision(repository, to)
+	if err != nil {
+		return comparison, err
+	}
+
+	comparison.Version = &semver.Version{}
+
+	for _, semverTag := range tags {
+		if semverTag.Hash == comparison.From {
+			comparison.Version = semverTag.Version
+		}
+	}
+
+	version := *comparison.Version
+	version.Metadata = ""
+
+	comparison.Commits, comparison.Report, err = p.analyze(ctx, repository, project, &version, comparison.From, comparison.To)
+	if err != nil {
+		return comparison, err
+	}
+
+	comparison.NextVersion = &version
+
+	return comparison, nil
+}
+
+// Commits returns the commits of the given project that trigger a release, from the oldest to the most recent, among
+// the history of the given commit stopping at the given commit, excluded, or at the root of the history if zero.
+func (p *Parser) Commits(ctx context.Context, repository *git.Repository, project monorepo.Project, from, to plumbing.Hash) ([]Commit, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The version is only bumped to classify the commits, it is discarded
+	commits, _, err := p.analyze(ctx, repository, project, &semver.Version{}, from, to)
+
+	return commits, err
+}
+
+// analyze bumps the given version according to the commits of the given project among the history of the given
+// commit, stopping at the given commit, excluded, if not zero. It returns the commits that trigger a release and the
+// classification of every commit. The parser mutex must be held by the caller.
+func (p *Parser) analyze(ctx context.Context, repository *git.Repository, project monorepo.Project, version *semver.Version, from, to plumbing.Hash) ([]Commit, []CommitReport, error) {
+	var walkOptions []commit.OptionFunc
+
+	if !from.IsZero() {
+		walkOptions = append(walkOptions, commit.WithStopAt(from))
+	}
+
+	if p.ctx.FirstParentFlag {
+		walkOptions = append(walkOptions, commit.WithFirstParentOnly())
+	}
+
+	history, err := p.history(ctx, repository, to, walkOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		commits []Commit
+		report  []CommitReport
+	)
+
+	for _, c := range history {
+		if hasSkipMarker(commitMessage(c), p.ctx.SkipReleaseMarkersFlag) {
+			report = append(report, CommitReport{Hash: c.Hash, Ignored: true, Reason: ReasonSkipMarker})
+			continue
+		}
+
+		if p.excludesAuthor(c) {
+			report = append(report, CommitReport{Hash: c.Hash, Ignored: true, Reason: ReasonAuthorFilter})
+			continue
+		}
+
+		releaseCommits, commitReport, err := p.ProcessCommit(c, version, project, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing commit history: %w", err)
+		}
+
+		commits = append(commits, releaseCommits...)
+		report = append(report, commitReport...)
+	}
+
+	return commits, report, nil
+}
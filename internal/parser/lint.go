@@ -0,0 +1,229 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/s0ders/go-semver-release/v6/internal/commit"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+var (
+	lintTypeRegex        = regexp.MustCompile(`^[A-Za-z]+`)
+	lintScopeRegex       = regexp.MustCompile(`^[\w\-.\\/]+$`)
+	lintDescriptionRegex = regexp.MustCompile(`^[\p{L}\p{N}_ ]`)
+)
+
+// Violation is a part of a commit message that does not follow the Conventional Commits specification or the release
+// configuration. Lines and columns start at 1.
+type Violation struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%d:%d: %s", v.Line, v.Column, v.Message)
+}
+
+// LintResult lists the violations of a commit.
+type LintResult struct {
+	Hash       plumbing.Hash
+	Subject    string
+	Violations []Violation
+}
+
+// Lint returns the violations of the given commit message, none if the message would be parsed as a Conventional
+// Commit when computing a release.
+func (p *Parser) Lint(message string) []Violation {
+	message = normalizeLineEndings(message)
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+
+	var violations []Violation
+
+	if p.ctx.CommitPattern != nil {
+		// A custom grammar is only known through its pattern, the header either matches it or not
+		if _, ok := p.parseMessage(lines[0]); !ok {
+			violations = append(violations, Violation{Line: 1, Column: 1, Message: fmt.Sprintf("header does not match the commit pattern %q", p.ctx.CommitPattern)})
+		}
+	} else {
+		violations = lintHeader(lines[0])
+	}
+
+	if parsedCommit, ok := p.parseMessage(lines[0]); ok && len(violations) == 0 {
+		if _, ok := p.rules(monorepo.Project{}).Map[parsedCommit.Type]; !ok && p.ctx.StrictFlag {
+			violations = append(violations, Violation{Line: 1, Column: 1, Message: fmt.Sprintf("commit type %q has no release rule", parsedCommit.Type)})
+		}
+	}
+
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		violations = append(violations, Violation{Line: 2, Column: 1, Message: "expected a blank line between the header and the body"})
+	}
+
+	for i, line := range lines[1:] {
+		match := releaseAsRegex.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+
+		value := line[match[2]:match[3]]
+
+		if version, err := semver.NewFromString(value); err != nil || version.String() != value {
+			violations = append(violations, Violation{Line: i + 2, Column: match[2] + 1, Message: fmt.Sprintf("invalid Release-As version %q", value)})
+		}
+	}
+
+	// The detailed checks above are expected to cover the grammar, the parser remains the reference
+	if _, ok := p.parseMessage(message); !ok && len(violations) == 0 {
+		violations = append(violations, Violation{Line: 1, Column: 1, Message: ErrNonConventionalCommit.Error()})
+	}
+
+	return violations
+}
+
+// lintHeader returns the violations of the header of a commit message, which must be formatted as
+// "<type>[(<scope>)][!]: <description>". Checks stop at the first violation making the rest of the header ambiguous.
+func lintHeader(header string) []Violation {
+	var violations []Violation
+
+	violation := func(column int, format string, args ...any) {
+		violations = append(violations, Violation{Line: 1, Column: column, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(header) == "" {
+		violation(1, "missing header")
+		return violations
+	}
+
+	commitType := lintTypeRegex.FindString(header)
+	if commitType == "" {
+		violation(1, "missing commit type")
+		return violations
+	}
+
+	if commitTypes := rule.CommitTypes(); !slices.Contains(commitTypes, commitType) {
+		violation(1, "unknown commit type %q, expected one of %s", commitType, strings.Join(commitTypes, ", "))
+	}
+
+	pos := len(commitType)
+
+	if pos < len(header) && header[pos] == '(' {
+		end := strings.IndexByte(header[pos:], ')')
+		if end == -1 {
+			violation(pos+1, "unclosed scope")
+			return violations
+		}
+
+		if scope := header[pos+1 : pos+end]; !lintScopeRegex.MatchString(scope) {
+			violation(pos+2, "invalid scope %q", scope)
+		}
+
+		pos += end + 1
+	}
+
+	if pos < len(header) && header[pos] == '!' {
+		pos++
+	}
+
+	if pos >= len(header) || header[pos] != ':' {
+		violation(pos+1, `expected ":" after the commit type`)
+		return violations
+	}
+
+	pos++
+
+	if pos >= len(header) || header[pos] != ' ' {
+		violation(pos+1, `expected a space after ":"`)
+		return violations
+	}
+
+	pos++
+
+	description := header[pos:]
+
+	switch {
+	case strings.TrimSpace(description) == "":
+		violation(pos+1, "missing description")
+	case !lintDescriptionRegex.MatchString(description):
+		violation(pos+1, "description must start with a letter, a digit or an underscore")
+	}
+
+	return violations
+}
+
+// LintHistory lints the commits reachable from the given revision, HEAD if empty, stopping at the given revision,
+// excluded, or at the latest semver tag if empty. Merge commits, whose messages are generated by Git, are skipped.
+// Only the commits having violations are returned, from the oldest to the most recent.
+func (p *Parser) LintHistory(ctx context.Context, repository *git.Repository, from, to string) ([]LintResult, error) {
+	var stopAt plumbing.Hash
+
+	if from == "" {
+		latestTag, err := p.FetchLatestSemverTag(repository, monorepo.Project{})
+		if err != nil {
+			return nil, fmt.Errorf("fetching latest semver tag: %w", err)
+		}
+
+		if latestTag != nil {
+			stopAt = latestTag.Hash
+		}
+	}
+
+	if to == "" {
+		to = "HEAD"
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if from != "" {
+		var err error
+
+		stopAt, err = p.resolveRevision(repository, from)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hash, err := p.resolveRevision(repository, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var walkOptions []commit.OptionFunc
+
+	if !stopAt.IsZero() {
+		walkOptions = append(walkOptions, commit.WithStopAt(stopAt))
+	}
+
+	history, err := p.history(ctx, repository, hash, walkOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []LintResult
+
+	for _, c := range history {
+		if c.NumParents() > 1 {
+			continue
+		}
+
+		message := commitMessage(c)
+
+		violations := p.Lint(message)
+		if len(violations) == 0 {
+			continue
+		}
+
+		results = append(results, LintResult{Hash: c.Hash, Subject: shortenMessage(subject(message)), Violations: violations})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+)
+
+var ErrInvalidBuildMetadata = errors.New("invalid build metadata template")
+
+// invalidMetadataRegex matches the characters that build metadata identifiers cannot contain.
+var invalidMetadataRegex = regexp.MustCompile(`[^0-9A-Za-z.-]+`)
+
+// runNumberVariables are the environment variables holding the number of the current CI run, by order of preference:
+// GitHub Actions, GitLab CI, Jenkins or TeamCity, and Azure Pipelines.
+var runNumberVariables = []string{"GITHUB_RUN_NUMBER", "CI_PIPELINE_IID", "BUILD_NUMBER", "BUILD_BUILDNUMBER"}
+
+// BuildMetadata is the data given to the build metadata template.
+type BuildMetadata struct {
+	// SHA is the hash of the release commit, ShortSHA its first seven characters.
+	SHA      string
+	ShortSHA string
+	// CommitDate is the UTC committer date of the release commit, formatted as "20060102150405".
+	CommitDate string
+	Branch     string
+	Project    string
+	// RunNumber is the number of the current CI run, read from the environment, empty outside of CI.
+	RunNumber string
+}
+
+var buildMetadataFuncs = template.FuncMap{
+	"env": os.Getenv,
+}
+
+// ParseBuildMetadata parses a build metadata template, such as "{{ .ShortSHA }}.{{ .RunNumber }}". A string without
+// placeholders is a valid template rendering itself. Templates are executed once with empty data to report unknown
+// fields early.
+func ParseBuildMetadata(text string) (*template.Template, error) {
+	tmpl, err := template.New("build-metadata").Funcs(buildMetadataFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBuildMetadata, err)
+	}
+
+	if err = tmpl.Execute(new(strings.Builder), BuildMetadata{}); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBuildMetadata, err)
+	}
+
+	return tmpl, nil
+}
+
+// buildMetadata renders the build metadata template of the release of the given commit. Characters that are not
+// allowed in build metadata are replaced by hyphens and empty identifiers are removed.
+func (p *Parser) buildMetadata(repository *git.Repository, hash plumbing.Hash, branchName string, project monorepo.Project) (string, error) {
+	if p.ctx.BuildMetadataFlag == "" {
+		return "", nil
+	}
+
+	tmpl, err := ParseBuildMetadata(p.ctx.BuildMetadataFlag)
+	if err != nil {
+		return "", err
+	}
+
+	data := BuildMetadata{
+		Branch:  branchName,
+		Project: project.Name,
+	}
+
+	for _, variable := range runNumberVariables {
+		if value := os.Getenv(variable); value != "" {
+			data.RunNumber = value
+			break
+		}
+	}
+
+	if !hash.IsZero() {
+		c, err := repository.CommitObject(hash)
+		if err != nil {
+			return "", fmt.Errorf("fetching release commit: %w", err)
+		}
+
+		data.SHA = c.Hash.String()
+		data.ShortSHA = data.SHA[:7]
+		data.CommitDate = c.Committer.When.UTC().Format("20060102150405")
+	}
+
+	rendered := new(strings.Builder)
+
+	if err = tmpl.Execute(rendered, data); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidBuildMetadata, err)
+	}
+
+	var identifiers []string
+
+	for _, identifier := range strings.Split(invalidMetadataRegex.ReplaceAllString(rendered.String(), "-"), ".") {
+		if identifier != "" {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	return strings.Join(identifiers, "."), nil
+}
@@ -22,35 +22,146 @@ import (
 
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/commit"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/notes"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/scheme"
 	"github.com/s0ders/go-semver-release/v6/internal/semver"
 )
 
-var conventionalCommitRegex = regexp.MustCompile(`^(build|chore|ci|docs|feat|fix|perf|refactor|revert|style|test)(\([\w\-.\\\/]+\))?(!)?: ([\w ]+[\s\S]*)`)
+var (
+	ErrInvalidReleaseAs      = errors.New("invalid Release-As version")
+	ErrNonConventionalCommit = errors.New("commit does not follow the Conventional Commits specification")
+	ErrUnknownCommitType     = errors.New("commit type has no release rule")
+	ErrNoReleaseInRange      = errors.New("no release found in the version range of the maintenance branch")
+	ErrInvalidRevision       = errors.New("revision not found")
+	ErrCommitNotOnBranch     = errors.New("commit is not reachable from the release branch")
+)
+
+var (
+	releaseAsRegex          = regexp.MustCompile(`(?m)^Release-As:[ \t]*(\S+)[ \t]*$`)
+	conventionalCommitRegex = regexp.MustCompile(`^(build|chore|ci|docs|feat|fix|perf|refactor|revert|style|test)(\([\w\-.\\\/]+\))?(!)?: ([\p{L}\p{N}_ ]+[\s\S]*)`)
+	squashedCommitRegex     = regexp.MustCompile(`^\s*[*-]\s+(.+)$`)
+)
 
 type Parser struct {
-	ctx *appcontext.AppContext
-	mu  sync.Mutex
+	ctx        *appcontext.AppContext
+	pathFilter *pathFilter
+	// notes are the cache notes, loaded on first use
+	notes *notes.Notes
+	// pendingStates are the analysis states to cache, by commit and cache key
+	pendingStates map[plumbing.Hash]map[string]cacheState
+	// index holds the commits of the indexed repository, walked once per branch and project
+	index   *commit.Index
+	indexed *git.Repository
+	mu      sync.Mutex
 }
 
 func New(ctx *appcontext.AppContext) *Parser {
-	parser := &Parser{ctx: ctx}
+	parser := &Parser{
+		ctx:        ctx,
+		pathFilter: newPathFilter(ctx.PathsFlag, ctx.ExcludePathsFlag),
+	}
 
 	return parser
 }
 
 type ComputeNewSemverOutput struct {
-	Semver     *semver.Version
-	Project    monorepo.Project
-	Branch     string
+	Semver *semver.Version
+	// PreviousSemver is the version of the latest semver tag, nil if there is none.
+	PreviousSemver *semver.Version
+	// BumpedBy lists the projects whose release triggered the release of the project, which depends on them.
+	BumpedBy []string
+	// Report lists the classification of every commit considered, from the oldest to the most recent.
+	Report []CommitReport
+	// Skipped is true if the release is suppressed by a SkipReleaseFile found at the root of the repository.
+	Skipped bool
+	// TagPrefix is the prefix of the version in the release tags of the project.
+	TagPrefix string
+	// From is the commit at which the analysis of the history stops, excluded, zero if the whole history is analyzed.
+	From plumbing.Hash
+	// To is the commit from which the history is analyzed.
+	To        plumbing.Hash
+	Project   monorepo.Project
+	Branch    string
+	Channel   string
+	ReleaseAs string
+	// Forced is true if the version is set or bumped by the release manager instead of being computed.
+	Forced     bool
+	Commits    []Commit
 	CommitHash plumbing.Hash
 	NewRelease bool
 }
 
+// SemverTag is a tag, annotated or lightweight, whose name contains a semantic version.
+type SemverTag struct {
+	Name    string
+	Version *semver.Version
+	// Hash is the hash of the commit the tag points to.
+	Hash plumbing.Hash
+	// Date is the date of the tag, the one of the commit it points to if it is a lightweight tag.
+	Date time.Time
+}
+
+// Commit represents a commit, formatted according to the Conventional Commits specification, that triggered a
+// release.
+type Commit struct {
+	Hash        plumbing.Hash
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+	// BreakingChanges lists the descriptions of the BREAKING CHANGE footers of the commit message, if any.
+	BreakingChanges []string
+	// References lists the issues and tickets referenced in the body of the commit message (e.g., "#12", "JIRA-456").
+	References []string
+}
+
+// Reasons for which a commit did not trigger a release.
+const (
+	ReasonNonConventional = "not a conventional commit"
+	ReasonOutsideProject  = "no change in project path"
+	ReasonFilteredPaths   = "no change in filtered paths"
+	ReasonNoRule          = "no release rule for commit type"
+	ReasonSkippedByRule   = "commit type skipped by release rule"
+	ReasonSkipMarker      = "skip release marker in commit message"
+	ReasonAuthorFilter    = "author excluded by filters"
+	ReasonUnverified      = "commit signature cannot be verified"
+)
+
+// CommitReport is the classification of a commit considered when computing a new release.
+type CommitReport struct {
+	Hash     plumbing.Hash
+	Type     string
+	Scope    string
+	Breaking bool
+	// Rule is the release type of the rule matching the commit type, empty if there is none.
+	Rule string
+	// Bump is the version component bumped by the commit, empty if the commit is ignored.
+	Bump    string
+	Ignored bool
+	// Reason explains why the commit is ignored.
+	Reason string
+}
+
 // Run execute a parser on a repository and analyze the given branches and projects contained inside the given
 // AppContext.
 func (p *Parser) Run(ctx context.Context, repository *git.Repository) ([]ComputeNewSemverOutput, error) {
-	var output []ComputeNewSemverOutput
+	var (
+		output     []ComputeNewSemverOutput
+		commitHash plumbing.Hash
+	)
+
+	// The commit is resolved before checking out any branch so that HEAD refers to the checked out commit
+	if p.ctx.CommitFlag != "" {
+		var err error
+
+		commitHash, err = p.resolveRevision(repository, p.ctx.CommitFlag)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	for _, gitBranch := range p.ctx.Branches {
 		err := p.checkoutBranch(repository, gitBranch.Name)
@@ -58,8 +169,15 @@ func (p *Parser) Run(ctx context.Context, repository *git.Repository) ([]Compute
 			return output, fmt.Errorf("checking out to gitBranch %q: %w", gitBranch.Name, err)
 		}
 
+		if !commitHash.IsZero() {
+			err = p.checkoutCommit(repository, gitBranch.Name, commitHash)
+			if err != nil {
+				return output, fmt.Errorf("checking out to commit %q: %w", commitHash, err)
+			}
+		}
+
 		if len(p.ctx.Projects) == 0 {
-			computerNewSemverOutput, err := p.ComputeNewSemver(repository, monorepo.Project{}, gitBranch)
+			computerNewSemverOutput, err := p.ComputeNewSemver(ctx, repository, monorepo.Project{}, gitBranch)
 			if err != nil {
 				return nil, fmt.Errorf("computing new semver: %w", err)
 			}
@@ -69,11 +187,11 @@ func (p *Parser) Run(ctx context.Context, repository *git.Repository) ([]Compute
 
 		outputBuf := make([]ComputeNewSemverOutput, len(p.ctx.Projects))
 
-		g, _ := errgroup.WithContext(ctx)
+		g, groupCtx := errgroup.WithContext(ctx)
 
 		for i, project := range p.ctx.Projects {
 			g.Go(func() error {
-				result, err := p.ComputeNewSemver(repository, project, gitBranch)
+				result, err := p.ComputeNewSemver(groupCtx, repository, project, gitBranch)
 				if err != nil {
 					return fmt.Errorf("computing project %q new semver: %w", project.Name, err)
 				}
@@ -87,6 +205,10 @@ func (p *Parser) Run(ctx context.Context, repository *git.Repository) ([]Compute
 			return nil, fmt.Errorf("parsing monorepository projects: %w", err)
 		}
 
+		if err := p.bumpDependents(repository, gitBranch, outputBuf); err != nil {
+			return nil, fmt.Errorf("releasing monorepository projects dependents: %w", err)
+		}
+
 		output = append(output, outputBuf...)
 	}
 
@@ -95,22 +217,35 @@ func (p *Parser) Run(ctx context.Context, repository *git.Repository) ([]Compute
 
 // ComputeNewSemver returns the next, if any, semantic version number from a given Git repository by parsing its commit
 // history.
-func (p *Parser) ComputeNewSemver(repository *git.Repository, project monorepo.Project, branch branch.Branch) (ComputeNewSemverOutput, error) {
+func (p *Parser) ComputeNewSemver(ctx context.Context, repository *git.Repository, project monorepo.Project, branch branch.Branch) (ComputeNewSemverOutput, error) {
 	output := ComputeNewSemverOutput{}
 
 	if project.Name != "" {
 		output.Project = project
 	}
 
-	latestSemverTag, err := p.FetchLatestSemverTag(repository, project)
+	tagPrefix, err := p.TagPrefix(repository, project)
+	if err != nil {
+		return output, fmt.Errorf("fetching tag prefix: %w", err)
+	}
+
+	output.TagPrefix = tagPrefix
+
+	latestSemverTag, err := p.fetchLatestSemverTag(repository, project, branch.Range)
 	if err != nil {
 		return output, fmt.Errorf("fetching latest semver tag: %w", err)
 	}
 
+	// Maintenance branches release versions following an existing release of their range
+	if latestSemverTag == nil && branch.Range != nil {
+		return output, fmt.Errorf("%w: branch %q, range %s", ErrNoReleaseInRange, branch.Name, branch.Range)
+	}
+
 	var (
 		latestSemver *semver.Version
 		history      []*object.Commit
-		logOptions   git.LogOptions
+		walkOptions  []commit.OptionFunc
+		stopAt       plumbing.Hash
 	)
 
 	if latestSemverTag == nil {
@@ -120,159 +255,904 @@ func (p *Parser) ComputeNewSemver(repository *git.Repository, project monorepo.P
 	} else {
 		p.ctx.Logger.Debug().Str("tag", latestSemverTag.Name).Msg("latest semver tag found")
 
-		latestSemver, err = semver.NewFromString(latestSemverTag.Name)
+		version := *latestSemverTag.Version
+		latestSemver = &version
+
+		previousSemver := *latestSemver
+		output.PreviousSemver = &previousSemver
+
+		// Stop walking the history once reaching the commit pointed by the latest SemVer tag
+		stopAt = latestSemverTag.Hash
+	}
+
+	if p.ctx.FirstParentFlag {
+		walkOptions = append(walkOptions, commit.WithFirstParentOnly())
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	head, err := repository.Head()
+	if err != nil {
+		return output, fmt.Errorf("fetching head: %w", err)
+	}
+
+	to := head.Hash()
+
+	if p.ctx.ToFlag != "" {
+		to, err = p.resolveRevision(repository, p.ctx.ToFlag)
 		if err != nil {
-			return output, fmt.Errorf("building semver from git tag: %w", err)
+			return output, err
 		}
+	}
 
-		p.mu.Lock()
-		latestSemverTagCommit, err := latestSemverTag.Commit()
+	if p.ctx.FromFlag != "" {
+		stopAt, err = p.resolveRevision(repository, p.ctx.FromFlag)
 		if err != nil {
-			return output, fmt.Errorf("fetching latest semver tag commit: %w", err)
+			return output, err
 		}
-		p.mu.Unlock()
+	}
 
-		// Show all commits that are at least one second older than the latest one pointed by SemVer tag
-		since := latestSemverTagCommit.Committer.When.Add(time.Second)
-		logOptions.Since = &since
+	if !stopAt.IsZero() {
+		walkOptions = append(walkOptions, commit.WithStopAt(stopAt))
 	}
 
-	p.mu.Lock()
-	defer p.mu.Unlock()
+	output.From = stopAt
+	output.To = to
 
-	repositoryLogs, err := repository.Log(&logOptions)
+	toCommit, err := repository.CommitObject(to)
 	if err != nil {
-		return output, fmt.Errorf("fetching commit history: %w", err)
+		return output, fmt.Errorf("fetching commit %q: %w", to, err)
 	}
 
-	// Create commit history
-	_ = repositoryLogs.ForEach(func(c *object.Commit) error {
-		history = append(history, c)
-		return nil
-	})
+	output.Skipped, err = hasSkipReleaseFile(toCommit)
+	if err != nil {
+		return output, err
+	}
 
-	// Sort commit history from oldest to most recent
-	sort.Slice(history, func(i, j int) bool {
-		return history[i].Committer.When.Before(history[j].Committer.When)
-	})
+	var (
+		newRelease    bool
+		commitHash    plumbing.Hash
+		releaseAs     *semver.Version
+		currentSemver = *latestSemver
+		cacheKey      string
+		state         *cacheState
+	)
+
+	if output.Skipped {
+		p.ctx.Logger.Debug().Str("file", SkipReleaseFile).Msg("release skipped by marker file")
+	} else {
+		if p.ctx.CacheFlag {
+			cacheKey, err = p.cacheKey(project, branch.Range, &currentSemver, stopAt)
+			if err != nil {
+				return output, err
+			}
 
-	var newRelease bool
-	var commitHash plumbing.Hash
+			resume, err := p.resume(repository, cacheKey, &state)
+			if err != nil {
+				return output, err
+			}
 
-	for _, commit := range history {
-		newReleaseFound, hash, err := p.ProcessCommit(commit, latestSemver, project)
+			walkOptions = append(walkOptions, resume)
+		}
+
+		history, err = p.history(ctx, repository, to, walkOptions)
+		if err != nil {
+			return output, err
+		}
+
+		if state != nil {
+			latestSemver, err = semver.NewFromString(state.Version)
+			if err != nil {
+				return output, fmt.Errorf("parsing cached version: %w", err)
+			}
+
+			if state.ReleaseAs != "" {
+				releaseAs, err = semver.NewFromString(state.ReleaseAs)
+				if err != nil {
+					return output, fmt.Errorf("parsing cached Release-As version: %w", err)
+				}
+			}
+
+			newRelease = state.NewRelease
+			commitHash = plumbing.NewHash(state.CommitHash)
+			output.Commits = state.commits()
+		}
+	}
+
+	for _, c := range history {
+		if hasSkipMarker(commitMessage(c), p.ctx.SkipReleaseMarkersFlag) {
+			p.ctx.Logger.Debug().Str("commit", c.Hash.String()).Msg("commit skipped by release marker")
+			output.Report = append(output.Report, CommitReport{Hash: c.Hash, Ignored: true, Reason: ReasonSkipMarker})
+			continue
+		}
+
+		if p.excludesAuthor(c) {
+			p.ctx.Logger.Debug().Str("commit", c.Hash.String()).Str("author", c.Author.Email).Msg("commit skipped by author filters")
+			output.Report = append(output.Report, CommitReport{Hash: c.Hash, Ignored: true, Reason: ReasonAuthorFilter})
+			continue
+		}
+
+		releaseCommits, report, err := p.ProcessCommit(c, latestSemver, project, branch.Range)
 		if err != nil {
 			return output, fmt.Errorf("parsing commit history: %w", err)
 		}
 
-		if newReleaseFound {
+		output.Report = append(output.Report, report...)
+
+		if len(releaseCommits) > 0 {
 			newRelease = true
-			commitHash = hash
+			commitHash = c.Hash
+			output.Commits = append(output.Commits, releaseCommits...)
+		}
+
+		commitReleaseAs, err := p.releaseAs(c, &currentSemver, project, branch.Range)
+		if err != nil {
+			return output, fmt.Errorf("parsing commit %q Release-As footer: %w", c.Hash, err)
+		}
+
+		if commitReleaseAs != nil {
+			p.ctx.Logger.Debug().Str("commit", c.Hash.String()).Str("version", commitReleaseAs.String()).Msg("Release-As footer found")
+
+			newRelease = true
+			commitHash = c.Hash
+			releaseAs = commitReleaseAs
+		}
+	}
+
+	if cacheKey != "" {
+		p.cacheAnalysis(to, cacheKey, newCacheState(latestSemver, newRelease, commitHash, releaseAs, output.Commits))
+	}
+
+	// The first release starts from the initial version, if any
+	if initialVersion := p.initialVersion(project); latestSemverTag == nil && newRelease && initialVersion != nil {
+		latestSemver = initialVersion
+	}
+
+	// A version set using a Release-As footer overrides the version computed from the commit history
+	if releaseAs != nil {
+		latestSemver = releaseAs
+		output.ReleaseAs = releaseAs.String()
+	}
+
+	// A version forced by the release manager overrides both the commit history and the Release-As footers
+	forced, err := p.forcedVersion(output.PreviousSemver, project, toCommit.Committer.When)
+	if err != nil {
+		return output, err
+	}
+
+	if forced != nil {
+		p.ctx.Logger.Debug().Str("version", forced.String()).Msg("version forced")
+
+		latestSemver = forced
+		newRelease = true
+		output.Forced = true
+
+		if commitHash.IsZero() {
+			commitHash = to
 		}
 	}
 
 	if branch.Prerelease {
-		latestSemver.Prerelease = branch.Name
+		err = p.setPrerelease(repository, project, branch, latestSemver, newRelease)
+		if err != nil {
+			return output, err
+		}
 	}
 
-	latestSemver.Metadata = p.ctx.BuildMetadataFlag
+	metadataHash := commitHash
+	if metadataHash.IsZero() {
+		metadataHash = to
+	}
+
+	latestSemver.Metadata, err = p.buildMetadata(repository, metadataHash, branch.Name, project)
+	if err != nil {
+		return output, err
+	}
 
 	output.Semver = latestSemver
 	output.Branch = branch.Name
+	output.Channel = branch.ReleaseChannel()
 	output.CommitHash = commitHash
 	output.NewRelease = newRelease
 
 	return output, nil
 }
 
-// ProcessCommit parse a commit message and bump the latest semantic version accordingly.
-func (p *Parser) ProcessCommit(commit *object.Commit, latestSemver *semver.Version, project monorepo.Project) (bool, plumbing.Hash, error) {
-	if !conventionalCommitRegex.MatchString(commit.Message) {
-		return false, plumbing.ZeroHash, nil
+// history returns the commits reachable from the given commit, sorted from the oldest to the most recent. The parser
+// mutex must be held by the caller.
+func (p *Parser) history(ctx context.Context, repository *git.Repository, from plumbing.Hash, walkOptions []commit.OptionFunc) ([]*object.Commit, error) {
+	var history []*object.Commit
+
+	walkOptions = append([]commit.OptionFunc{commit.WithIndex(p.commitIndex(repository))}, walkOptions...)
+
+	walker, err := commit.NewWalker(repository, from, walkOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("walking commit history: %w", err)
+	}
+
+	err = walker.ForEach(ctx, func(c *object.Commit) error {
+		history = append(history, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit history: %w", err)
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Committer.When.Before(history[j].Committer.When)
+	})
+
+	return history, nil
+}
+
+// Close releases the commit index of the last analyzed repository. The parser can still be used afterward, the index
+// being reopened on the next walk.
+func (p *Parser) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.index == nil {
+		return nil
+	}
+
+	err := p.index.Close()
+	p.index, p.indexed = nil, nil
+
+	return err
+}
+
+// commitIndex returns the index of the commits of the given repository, shared by the walks of every branch and
+// project. The parser mutex must be held by the caller.
+func (p *Parser) commitIndex(repository *git.Repository) *commit.Index {
+	if p.indexed != repository {
+		if p.index != nil {
+			_ = p.index.Close()
+		}
+
+		p.index = commit.NewIndex(repository)
+		p.indexed = repository
+
+		if p.index.HasCommitGraph() {
+			p.ctx.Logger.Debug().Msg("reading commits from commit-graph")
+		}
+	}
+
+	return p.index
+}
+
+// setPrerelease sets the prerelease component of a version computed on a prerelease branch, using the prerelease
+// identifier of the project if it overrides the one of the branch. A new release is numbered after the previous
+// prereleases of the same version.
+func (p *Parser) setPrerelease(repository *git.Repository, project monorepo.Project, branch branch.Branch, version *semver.Version, newRelease bool) error {
+	identifier := branch.PrereleaseIdentifier()
+	if project.PrereleaseID != "" {
+		identifier = project.PrereleaseID
+	}
+
+	switch {
+	case newRelease:
+		number, err := p.nextPrereleaseNumber(repository, project, version, identifier)
+		if err != nil {
+			return fmt.Errorf("computing prerelease number: %w", err)
+		}
+
+		version.Prerelease = fmt.Sprintf("%s.%d", identifier, number)
+	case version.Prerelease == "":
+		version.Prerelease = identifier
+	}
+
+	return nil
+}
+
+// bumpDependents releases the projects, without a release of their own, depending on a project that has a new
+// release. Their version is bumped to the next patch and their release tag points to the most recent release commit of
+// their dependencies. Dependencies are resolved in order so that releases cascade through the dependency graph.
+func (p *Parser) bumpDependents(repository *git.Repository, branch branch.Branch, outputs []ComputeNewSemverOutput) error {
+	sorted, err := monorepo.DependencyOrder(p.ctx.Projects)
+	if err != nil {
+		return fmt.Errorf("sorting projects dependencies: %w", err)
+	}
+
+	byName := make(map[string]*ComputeNewSemverOutput, len(outputs))
+	for i := range outputs {
+		byName[outputs[i].Project.Name] = &outputs[i]
+	}
+
+	for _, project := range sorted {
+		output := byName[project.Name]
+		if output.NewRelease || output.Skipped {
+			continue
+		}
+
+		var (
+			bumpedBy   []string
+			commitHash plumbing.Hash
+			commitDate time.Time
+		)
+
+		for _, name := range project.DependsOn {
+			dependency := byName[name]
+			if !dependency.NewRelease {
+				continue
+			}
+
+			bumpedBy = append(bumpedBy, name)
+
+			c, err := repository.CommitObject(dependency.CommitHash)
+			if err != nil {
+				return fmt.Errorf("fetching project %q release commit: %w", name, err)
+			}
+
+			if c.Committer.When.After(commitDate) {
+				commitHash = c.Hash
+				commitDate = c.Committer.When
+			}
+		}
+
+		if len(bumpedBy) == 0 {
+			continue
+		}
+
+		version := &semver.Version{}
+
+		switch {
+		case output.PreviousSemver != nil:
+			previousSemver := *output.PreviousSemver
+			version = &previousSemver
+			err = p.scheme().Bump(version, "patch", commitDate)
+		case p.initialVersion(project) != nil:
+			version = p.initialVersion(project)
+		default:
+			err = p.scheme().Bump(version, "patch", commitDate)
+		}
+
+		if err != nil {
+			return fmt.Errorf("bumping project %q version: %w", project.Name, err)
+		}
+
+		if branch.Prerelease {
+			err = p.setPrerelease(repository, project, branch, version, true)
+			if err != nil {
+				return err
+			}
+		}
+
+		version.Metadata, err = p.buildMetadata(repository, commitHash, branch.Name, project)
+		if err != nil {
+			return err
+		}
+
+		output.Semver = version
+		output.CommitHash = commitHash
+		output.NewRelease = true
+		output.BumpedBy = bumpedBy
+
+		p.ctx.Logger.Debug().Str("project", project.Name).Strs("dependencies", bumpedBy).Msg("project released along its dependencies")
+	}
+
+	return nil
+}
+
+// ProcessCommit parse a commit message and bump the latest semantic version accordingly. The parsed commits that
+// triggered a release are returned, there can be more than one if the commit is a squashed commit, along with the
+// classification of every parsed commit.
+func (p *Parser) ProcessCommit(commit *object.Commit, latestSemver *semver.Version, project monorepo.Project, versionRange *branch.Range) ([]Commit, []CommitReport, error) {
+	parsedCommits := p.parseCommit(commit)
+	if len(parsedCommits) == 0 {
+		// Merge commits messages are generated by Git and are not expected to follow the specification
+		if p.ctx.StrictFlag && commit.NumParents() < 2 {
+			return nil, nil, fmt.Errorf("%w: %s %q", ErrNonConventionalCommit, commit.Hash, shortenMessage(subject(commitMessage(commit))))
+		}
+
+		return nil, []CommitReport{{Hash: commit.Hash, Ignored: true, Reason: ReasonNonConventional}}, nil
+	}
+
+	if project.Name != "" {
+		containsProjectFiles, err := commitContainsProjectFiles(commit, project.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("checking if commit contains project files: %w", err)
+		}
+		if !containsProjectFiles {
+			return nil, []CommitReport{{Hash: commit.Hash, Ignored: true, Reason: ReasonOutsideProject}}, nil
+		}
+	}
+
+	if p.pathFilter != nil {
+		matchesPaths, err := p.pathFilter.matchCommit(commit)
+		if err != nil {
+			return nil, nil, fmt.Errorf("checking if commit changes filtered paths: %w", err)
+		}
+		if !matchesPaths {
+			p.ctx.Logger.Debug().Str("commit", commit.Hash.String()).Msg("commit skipped by path filters")
+			return nil, []CommitReport{{Hash: commit.Hash, Ignored: true, Reason: ReasonFilteredPaths}}, nil
+		}
+	}
+
+	excluded, err := p.verifySignature(commit, parsedCommits, project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if excluded {
+		return nil, []CommitReport{{Hash: commit.Hash, Ignored: true, Reason: ReasonUnverified}}, nil
+	}
+
+	var (
+		releaseCommits []Commit
+		report         []CommitReport
+	)
+
+	for _, parsedCommit := range parsedCommits {
+		releaseType, err := p.bump(parsedCommit, latestSemver, project, versionRange, commit.Committer.When)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entry := CommitReport{
+			Hash:     parsedCommit.Hash,
+			Type:     parsedCommit.Type,
+			Scope:    parsedCommit.Scope,
+			Breaking: parsedCommit.Breaking,
+			Rule:     p.rules(project).Map[parsedCommit.Type],
+		}
+
+		switch {
+		case releaseType != "":
+			entry.Bump = releaseType
+		case entry.Rule == "":
+			entry.Ignored = true
+			entry.Reason = ReasonNoRule
+		default:
+			entry.Ignored = true
+			entry.Reason = ReasonSkippedByRule
+		}
+
+		report = append(report, entry)
+
+		if releaseType != "" {
+			releaseCommits = append(releaseCommits, parsedCommit)
+		}
+	}
+
+	return releaseCommits, report, nil
+}
+
+// releaseAs returns the version set by the Release-As footer of a commit message, if any. The version must be a valid
+// semantic version greater than the current one.
+func (p *Parser) releaseAs(commit *object.Commit, currentSemver *semver.Version, project monorepo.Project, versionRange *branch.Range) (*semver.Version, error) {
+	match := releaseAsRegex.FindStringSubmatch(commitMessage(commit))
+	if match == nil {
+		return nil, nil
 	}
 
 	if project.Name != "" {
 		containsProjectFiles, err := commitContainsProjectFiles(commit, project.Path)
 		if err != nil {
-			return false, plumbing.ZeroHash, fmt.Errorf("checking if commit contains project files: %w", err)
+			return nil, fmt.Errorf("checking if commit contains project files: %w", err)
 		}
 		if !containsProjectFiles {
-			return false, plumbing.ZeroHash, nil
+			return nil, nil
+		}
+	}
+
+	version, err := semver.NewFromString(match[1])
+	if err != nil || version.String() != match[1] {
+		return nil, fmt.Errorf("%w: %q is not a valid semantic version", ErrInvalidReleaseAs, match[1])
+	}
+
+	if semver.Compare(version, currentSemver) != 1 {
+		return nil, fmt.Errorf("%w: %q is not greater than the current version %q", ErrInvalidReleaseAs, version, currentSemver)
+	}
+
+	if versionRange != nil && !versionRange.Contains(version) {
+		return nil, fmt.Errorf("%w: %q is outside of the branch range %s", ErrInvalidReleaseAs, version, versionRange)
+	}
+
+	return version, nil
+}
+
+// parseCommit returns the Conventional Commits found in a commit message. If enabled, the Conventional Commits listed
+// in the body of squashed commits are also returned.
+func (p *Parser) parseCommit(commit *object.Commit) []Commit {
+	var parsedCommits []Commit
+
+	message := commitMessage(commit)
+
+	if parsedCommit, ok := p.parseMessage(message); ok {
+		parsedCommit.Hash = commit.Hash
+		parsedCommits = append(parsedCommits, parsedCommit)
+	}
+
+	if p.ctx.SquashedCommitsFlag {
+		_, body, _ := strings.Cut(message, "\n")
+
+		for _, line := range strings.Split(body, "\n") {
+			match := squashedCommitRegex.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+
+			if parsedCommit, ok := p.parseMessage(match[1]); ok {
+				parsedCommit.Hash = commit.Hash
+				parsedCommits = append(parsedCommits, parsedCommit)
+			}
 		}
 	}
 
-	match := conventionalCommitRegex.FindStringSubmatch(commit.Message)
-	breakingChange := match[3] == "!" || strings.HasPrefix(commit.Message, "BREAKING CHANGE")
-	commitType := match[1]
+	// References are found in the body of the whole message, they are given to the first commit only so that a
+	// squashed commit does not list them more than once
+	if len(parsedCommits) > 0 {
+		parsedCommits[0].References = parseReferences(message, p.ctx.IssuePattern)
+	}
+
+	return parsedCommits
+}
+
+// parseMessage parses the header of a message formatted according to the Conventional Commits specification. Only the
+// "!" marker of the header is considered to tell whether the commit is a breaking change.
+func parseMessage(message string) (Commit, bool) {
+	match := conventionalCommitRegex.FindStringSubmatch(message)
+	if match == nil {
+		return Commit{}, false
+	}
 
-	if breakingChange {
-		latestSemver.BumpMajor()
-		return true, commit.Hash, nil
+	parsedCommit := Commit{
+		Type:        match[1],
+		Scope:       strings.Trim(match[2], "()"),
+		Description: subject(match[4]),
+		Breaking:    match[3] == "!",
 	}
 
-	releaseType, ok := p.ctx.Rules.Map[commitType]
+	return parsedCommit, true
+}
+
+// bump increments the given semantic version according to the commit, made at the given date, and the release rules,
+// those of the project if it overrides them. The release type is lowered if needed so that the version stays in the
+// given range, if any. It returns the release type applied, empty if the commit did not trigger a release.
+func (p *Parser) bump(commit Commit, latestSemver *semver.Version, project monorepo.Project, versionRange *branch.Range, date time.Time) (string, error) {
+	if commit.Breaking {
+		return p.applyBump(latestSemver, versionRange.Clamp(p.breakingReleaseType(latestSemver)), date)
+	}
+
+	releaseType, ok := p.rules(project).Map[commit.Type]
 	if !ok {
-		return false, plumbing.ZeroHash, nil
+		if p.ctx.StrictFlag {
+			return "", fmt.Errorf("%w: %s %q", ErrUnknownCommitType, commit.Hash, commit.Type)
+		}
+
+		return "", nil
 	}
 
-	switch releaseType {
-	case "patch":
-		latestSemver.BumpPatch()
-	case "minor":
-		latestSemver.BumpMinor()
-	default:
-		return false, plumbing.ZeroHash, fmt.Errorf("unknown release type %q", releaseType)
+	if releaseType == rule.None {
+		p.ctx.Logger.Debug().Str("commit", commit.Hash.String()).Str("type", commit.Type).Msg("commit skipped by rule")
+		return "", nil
 	}
 
-	return true, commit.Hash, nil
+	return p.applyBump(latestSemver, versionRange.Clamp(releaseType), date)
+}
+
+// applyBump increments the given semantic version according to the given release type, using the versioning scheme,
+// and returns it.
+func (p *Parser) applyBump(latestSemver *semver.Version, releaseType string, date time.Time) (string, error) {
+	if err := p.scheme().Bump(latestSemver, releaseType, date); err != nil {
+		return "", err
+	}
+
+	return releaseType, nil
+}
+
+// scheme returns the versioning scheme of the released versions, the Semantic Versioning if none is configured.
+func (p *Parser) scheme() scheme.Scheme {
+	if p.ctx.Scheme == nil {
+		return scheme.SemVer{}
+	}
+
+	return p.ctx.Scheme
+}
+
+// forcedVersion returns the version forced by the release manager, if any: either the version set explicitly or the
+// given previous version, the initial version if none, bumped as requested for a release made at the given date.
+func (p *Parser) forcedVersion(previousSemver *semver.Version, project monorepo.Project, date time.Time) (*semver.Version, error) {
+	if p.ctx.SetVersion != nil {
+		version := *p.ctx.SetVersion
+		return &version, nil
+	}
+
+	if p.ctx.ForceBumpFlag == "" {
+		return nil, nil
+	}
+
+	if previousSemver == nil {
+		if initialVersion := p.initialVersion(project); initialVersion != nil {
+			return initialVersion, nil
+		}
+
+		previousSemver = &semver.Version{}
+	}
+
+	version := *previousSemver
+
+	if _, err := p.applyBump(&version, p.ctx.ForceBumpFlag, date); err != nil {
+		return nil, fmt.Errorf("forcing bump: %w", err)
+	}
+
+	return &version, nil
+}
+
+// breakingReleaseType returns the release type of a breaking change made on the given version. Breaking changes
+// always bump the major version, unless disabled for versions in initial development (i.e., 0.y.z) where they only
+// bump the minor version.
+func (p *Parser) breakingReleaseType(version *semver.Version) string {
+	if version.Major == 0 && !p.ctx.MajorOnBreakingInDevFlag {
+		return "minor"
+	}
+
+	return "major"
+}
+
+// initialVersion returns a copy of the version of the first release, the one of the project if it overrides it, or nil
+// if the first release is computed from the commit history.
+func (p *Parser) initialVersion(project monorepo.Project) *semver.Version {
+	initialVersion := p.ctx.InitialVersion
+	if project.InitialVersion != nil {
+		initialVersion = project.InitialVersion
+	}
+
+	if initialVersion == nil {
+		return nil
+	}
+
+	version := *initialVersion
+
+	return &version
+}
+
+// rules returns the release rules applying to the commits of the given project.
+func (p *Parser) rules(project monorepo.Project) rule.Rules {
+	if project.Rules != nil {
+		return *project.Rules
+	}
+
+	return p.ctx.Rules
 }
 
 // FetchLatestSemverTag parses a Git repository to fetch the tag corresponding to the highest semantic version number
-// among all tags.
-func (p *Parser) FetchLatestSemverTag(repository *git.Repository, project monorepo.Project) (*object.Tag, error) {
+// among all tags, annotated or lightweight, using the tag prefix of the given project.
+func (p *Parser) FetchLatestSemverTag(repository *git.Repository, project monorepo.Project) (*SemverTag, error) {
+	return p.fetchLatestSemverTag(repository, project, nil)
+}
+
+// fetchLatestSemverTag returns the tag corresponding to the highest semantic version number among all tags whose
+// version belongs to the given range, among all tags if the range is nil.
+func (p *Parser) fetchLatestSemverTag(repository *git.Repository, project monorepo.Project, versionRange *branch.Range) (*SemverTag, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	tags, err := repository.TagObjects()
+	tagPrefix, err := p.tagPrefix(repository, project)
 	if err != nil {
-		return nil, fmt.Errorf("fetching tag objects: %w", err)
+		return nil, err
 	}
 
 	var (
-		latestSemver *semver.Version
-		latestTag    *object.Tag
+		latestSemver    *semver.Version
+		latestReference *plumbing.Reference
 	)
 
-	err = tags.ForEach(func(tag *object.Tag) error {
-		if !semver.Regex.MatchString(tag.Name) {
+	err = p.forEachSemverTag(repository, project, tagPrefix, func(reference *plumbing.Reference, version *semver.Version) error {
+		if versionRange != nil && !versionRange.Contains(version) {
 			return nil
 		}
 
-		if project.Name != "" && !strings.HasPrefix(tag.Name, project.Name+"-") {
+		if latestSemver == nil || semver.Compare(latestSemver, version) == -1 {
+			latestSemver = version
+			latestReference = reference
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if latestReference == nil {
+		return nil, nil
+	}
+
+	return newSemverTag(repository, latestReference, latestSemver)
+}
+
+// nextPrereleaseNumber returns the number of the next prerelease of the given version using the given identifier by
+// looking for the highest prerelease number among existing tags sharing the same major, minor and patch components.
+// The parser mutex must be held by the caller.
+func (p *Parser) nextPrereleaseNumber(repository *git.Repository, project monorepo.Project, version *semver.Version, identifier string) (int, error) {
+	tagPrefix, err := p.tagPrefix(repository, project)
+	if err != nil {
+		return 0, err
+	}
+
+	highest := 0
+
+	err = p.forEachSemverTag(repository, project, tagPrefix, func(_ *plumbing.Reference, tagSemver *semver.Version) error {
+		if tagSemver.Major != version.Major || tagSemver.Minor != version.Minor || tagSemver.Patch != version.Patch {
 			return nil
 		}
 
-		currentSemver, err := semver.NewFromString(tag.Name)
-		if err != nil {
-			return fmt.Errorf("converting tag to semver: %w", err)
+		if number, ok := tagSemver.PrereleaseNumber(identifier); ok && number > highest {
+			highest = number
 		}
 
-		if latestSemver == nil || semver.Compare(latestSemver, currentSemver) == -1 {
-			latestSemver = currentSemver
-			latestTag = tag
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return highest + 1, nil
+}
+
+// TagPrefix returns the prefix of the version in the tag names of the given project: the one of the project if it
+// overrides the global one or, if tag prefix detection is enabled, the one of the tag of the highest version found.
+func (p *Parser) TagPrefix(repository *git.Repository, project monorepo.Project) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.tagPrefix(repository, project)
+}
+
+// tagPrefix returns the tag prefix of the given project. The parser mutex must be held by the caller.
+func (p *Parser) tagPrefix(repository *git.Repository, project monorepo.Project) (string, error) {
+	if project.TagPrefix != nil {
+		return *project.TagPrefix, nil
+	}
+
+	tagPrefix := p.ctx.TagPrefixFlag
+
+	if !p.ctx.DetectTagPrefixFlag {
+		return tagPrefix, nil
+	}
+
+	var latestSemver *semver.Version
+
+	err := p.forEachTag(repository, project, func(_ *plumbing.Reference, prefix string, version *semver.Version) error {
+		if latestSemver == nil || semver.Compare(latestSemver, version) == -1 {
+			latestSemver = version
+			tagPrefix = prefix
 		}
+
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("looping over tags: %w", err)
+		return "", err
+	}
+
+	p.ctx.Logger.Debug().Str("prefix", tagPrefix).Str("project", project.Name).Msg("tag prefix detected")
+
+	return tagPrefix, nil
+}
+
+// forEachSemverTag calls the given function for every tag, annotated or lightweight, named after a semantic version
+// preceded by the given tag prefix and, in monorepo mode, by the name of the project.
+func (p *Parser) forEachSemverTag(repository *git.Repository, project monorepo.Project, tagPrefix string, cb func(*plumbing.Reference, *semver.Version) error) error {
+	return p.forEachTag(repository, project, func(reference *plumbing.Reference, prefix string, version *semver.Version) error {
+		if prefix != tagPrefix {
+			return nil
+		}
+
+		return cb(reference, version)
+	})
+}
+
+// forEachTag calls the given function for every tag, annotated or lightweight, whose name ends with a semantic version
+// and, in monorepo mode, starts with the name of the project, along with the prefix preceding the version. Tags
+// matching an ignore pattern are skipped.
+func (p *Parser) forEachTag(repository *git.Repository, project monorepo.Project, cb func(*plumbing.Reference, string, *semver.Version) error) error {
+	tags, err := repository.Tags()
+	if err != nil {
+		return fmt.Errorf("fetching tags: %w", err)
+	}
+
+	err = tags.ForEach(func(reference *plumbing.Reference) error {
+		name := reference.Name().Short()
+
+		if p.ignoreTag(name) {
+			return nil
+		}
+
+		if project.Name != "" {
+			var ok bool
+			if name, ok = strings.CutPrefix(name, project.Name+"-"); !ok {
+				return nil
+			}
+		}
+
+		loc := semver.Regex.FindStringIndex(name)
+		if loc == nil {
+			return nil
+		}
+
+		version, err := semver.NewFromString(name[loc[0]:])
+		if err != nil {
+			return fmt.Errorf("converting tag to semver: %w", err)
+		}
+
+		return cb(reference, name[:loc[0]], version)
+	})
+	if err != nil {
+		return fmt.Errorf("looping over tags: %w", err)
+	}
+
+	return nil
+}
+
+// ignoreTag returns true if the given tag name matches one of the tag ignore patterns.
+func (p *Parser) ignoreTag(name string) bool {
+	for _, pattern := range p.ctx.TagIgnorePatterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveRevision returns the hash of the commit a revision, such as a branch, a tag or a commit hash, points to.
+// Branches that only exist on the remote are resolved as well, since the repository is expected to be a clone.
+func (p *Parser) resolveRevision(repository *git.Repository, revision string) (plumbing.Hash, error) {
+	hash, err := repository.ResolveRevision(plumbing.Revision(revision))
+	if err == nil {
+		return *hash, nil
+	}
+
+	remoteBranchRef := plumbing.NewRemoteReferenceName(p.ctx.RemoteNameFlag, revision)
+
+	reference, refErr := repository.Reference(remoteBranchRef, true)
+	if refErr != nil {
+		return plumbing.ZeroHash, fmt.Errorf("%w: %q: %w", ErrInvalidRevision, revision, err)
+	}
+
+	return reference.Hash(), nil
+}
+
+// newSemverTag returns the semver tag of the given tag reference and version.
+func newSemverTag(repository *git.Repository, reference *plumbing.Reference, version *semver.Version) (*SemverTag, error) {
+	commitHash, date, err := tagTarget(repository, reference)
+	if err != nil {
+		return nil, fmt.Errorf("fetching tag %q commit: %w", reference.Name().Short(), err)
 	}
 
-	return latestTag, nil
+	return &SemverTag{Name: reference.Name().Short(), Version: version, Hash: commitHash, Date: date}, nil
+}
+
+// tagTarget returns the hash of the commit a tag reference points to along with the date of the tag. Lightweight tags
+// directly point to the commit, and are dated by it, while annotated tags point to a tag object that targets the
+// commit.
+func tagTarget(repository *git.Repository, reference *plumbing.Reference) (plumbing.Hash, time.Time, error) {
+	tagObject, err := repository.TagObject(reference.Hash())
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		// The tagged commit may be missing from shallow clones, in which case the tag is left undated
+		var date time.Time
+		if c, err := repository.CommitObject(reference.Hash()); err == nil {
+			date = c.Committer.When
+		}
+
+		return reference.Hash(), date, nil
+	}
+	if err != nil {
+		return plumbing.ZeroHash, time.Time{}, fmt.Errorf("fetching tag object: %w", err)
+	}
+
+	c, err := tagObject.Commit()
+	if err != nil {
+		return plumbing.ZeroHash, time.Time{}, fmt.Errorf("fetching tag target: %w", err)
+	}
+
+	return c.Hash, tagObject.Tagger.When, nil
 }
 
 // checkoutBranch moves the HEAD pointer of the given repository to the given branch. This function expects the
 // repository to be a clone and have a remote to which it will set the branch being checkout to a remote reference to
-// the corresponding remote branch.
+// the corresponding remote branch. Only HEAD is moved in bare repositories, which have no worktree to update.
 func (p *Parser) checkoutBranch(repository *git.Repository, branchName string) error {
 	remoteBranchRef := plumbing.NewRemoteReferenceName(p.ctx.RemoteNameFlag, branchName)
 	_, err := repository.Reference(remoteBranchRef, true)
@@ -289,6 +1169,9 @@ func (p *Parser) checkoutBranch(repository *git.Repository, branchName string) e
 
 	// Checkout the new local branch
 	w, err := repository.Worktree()
+	if errors.Is(err, git.ErrIsBareRepository) {
+		return repository.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, localBranchRef))
+	}
 	if err != nil {
 		return fmt.Errorf("error getting worktree: %w", err)
 	}
@@ -307,6 +1190,54 @@ func (p *Parser) checkoutBranch(repository *git.Repository, branchName string) e
 	return nil
 }
 
+// checkoutCommit moves the HEAD pointer of the given repository, expected to be on the given branch, to the given
+// commit. The commit must be reachable from the branch. Only HEAD is moved in bare repositories.
+func (p *Parser) checkoutCommit(repository *git.Repository, branchName string, hash plumbing.Hash) error {
+	head, err := repository.Head()
+	if err != nil {
+		return fmt.Errorf("fetching head: %w", err)
+	}
+
+	headCommit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return fmt.Errorf("fetching head commit: %w", err)
+	}
+
+	c, err := repository.CommitObject(hash)
+	if err != nil {
+		return fmt.Errorf("fetching commit: %w", err)
+	}
+
+	if c.Hash != headCommit.Hash {
+		reachable, err := c.IsAncestor(headCommit)
+		if err != nil {
+			return fmt.Errorf("checking if commit is reachable: %w", err)
+		}
+
+		if !reachable {
+			return fmt.Errorf("%w: branch %q", ErrCommitNotOnBranch, branchName)
+		}
+	}
+
+	w, err := repository.Worktree()
+	if errors.Is(err, git.ErrIsBareRepository) {
+		return repository.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, hash))
+	}
+	if err != nil {
+		return fmt.Errorf("error getting worktree: %w", err)
+	}
+
+	err = w.Checkout(&git.CheckoutOptions{
+		Hash:  hash,
+		Force: true,
+	})
+	if err != nil {
+		return fmt.Errorf("checking out to commit: %w", err)
+	}
+
+	return nil
+}
+
 // commitContainsProjectFiles checks if a given commit changes contain at least one file whose path belongs to the
 // given project's path.
 func commitContainsProjectFiles(commit *object.Commit, projectPath string) (bool, error) {
@@ -5,19 +5,28 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage"
 	"github.com/rs/zerolog"
 	assertion "github.com/stretchr/testify/assert"
 
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
 	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/commit"
 	"github.com/s0ders/go-semver-release/v6/internal/gittest"
 	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
 	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/scheme"
 	"github.com/s0ders/go-semver-release/v6/internal/semver"
 )
 
@@ -66,6 +75,116 @@ func TestParser_BreakingChangeRegex(t *testing.T) {
 	}
 }
 
+func TestParser_ParseFooters(t *testing.T) {
+	assert := assertion.New(t)
+
+	message := `feat(api): remove the v1 endpoints
+
+Some body explaining the change.
+
+Reviewed-by: Z
+BREAKING CHANGE: the v1 endpoints are removed,
+use the v2 endpoints instead
+Refs #123
+BREAKING-CHANGE: the foo parameter is renamed
+`
+
+	want := []footer{
+		{token: "Reviewed-by", value: "Z"},
+		{token: "BREAKING CHANGE", value: "the v1 endpoints are removed,\nuse the v2 endpoints instead"},
+		{token: "Refs", value: "123"},
+		{token: "BREAKING-CHANGE", value: "the foo parameter is renamed"},
+	}
+
+	assert.Equal(want, parseFooters(message), "footers should be equal")
+	assert.Empty(parseFooters("fix: fixed foo\n\nBody only."), "message should not have any footer")
+	assert.Empty(parseFooters("fix: fixed foo\nRefs: #123"), "subject should not be parsed as a footer")
+}
+
+func TestParser_BreakingChangeFooter(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		message         string
+		breaking        bool
+		breakingChanges []string
+	}
+
+	matrix := []test{
+		{"feat: implemented foo", false, nil},
+		{"feat!: implemented foo", true, nil},
+		{"fix: fixed foo\n\nBREAKING CHANGE: foo is removed", true, []string{"foo is removed"}},
+		{"fix: fixed foo\n\nBREAKING-CHANGE: foo is removed\nBREAKING-CHANGE: bar is removed", true, []string{"foo is removed", "bar is removed"}},
+		{"fix: fixed foo\n\nbreaking-change: lowercase tokens are not breaking changes", false, nil},
+	}
+
+	parser := New(NewTestHelper(t).Ctx)
+
+	for _, item := range matrix {
+		commit, ok := parser.parseMessage(item.message)
+
+		assert.True(ok, "message should be parsed")
+		assert.Equal(item.breaking, commit.Breaking, "breaking change should be equal")
+		assert.Equal(item.breakingChanges, commit.BreakingChanges, "breaking changes descriptions should be equal")
+	}
+}
+
+func TestParser_BreakingClassifier(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		message         string
+		breaking        bool
+		breakingChanges []string
+	}
+
+	matrix := []test{
+		{"feat!: implemented foo", false, nil},
+		{"feat: implemented foo\n\nMAJOR: foo is removed", true, []string{"foo is removed"}},
+		{"feat: implemented foo\n\nSome body.\nBACKWARDS INCOMPATIBLE bar is renamed", true, []string{"bar is renamed"}},
+		{"feat: implemented foo\n\nBACKWARDS INCOMPATIBLE", true, nil},
+		{"feat: implemented foo\n\nBACKWARDS INCOMPATIBLEs are listed here", false, nil},
+		{"fix: fixed foo\n\nBREAKING CHANGE: foo is removed", true, []string{"foo is removed"}},
+		{"MAJOR: not a conventional commit", false, nil},
+	}
+
+	th := NewTestHelper(t)
+	th.Ctx.BreakingKeywordsFlag = []string{"MAJOR:", "BACKWARDS INCOMPATIBLE"}
+	th.Ctx.IgnoreExclamationFlag = true
+	parser := New(th.Ctx)
+
+	for _, item := range matrix {
+		commit, _ := parser.parseMessage(item.message)
+
+		assert.Equal(item.breaking, commit.Breaking, "breaking change should be equal: %q", item.message)
+		assert.Equal(item.breakingChanges, commit.BreakingChanges, "breaking changes descriptions should be equal: %q", item.message)
+	}
+}
+
+func TestParser_ParseReferences(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		message string
+		pattern *regexp.Regexp
+		want    []string
+	}
+
+	matrix := []test{
+		{message: "fix: fixed foo\n\nCloses #123", want: []string{"#123"}},
+		{message: "fix: fixed foo\n\nThis fixes JIRA-456 and resolves #7, #8.\n\nRefs: #7", want: []string{"JIRA-456", "#7", "#8"}},
+		{message: "fix: fixed foo\n\nfixed #1\nCLOSED #2", want: []string{"#1", "#2"}},
+		{message: "fix: closes #123", want: nil},
+		{message: "fix: fixed foo\n\nMentions #123 and JIRA-456 without keyword.", want: nil},
+		{message: "fix: fixed foo\n\nTicket: [ABC-1], [ABC-2]", pattern: regexp.MustCompile(`\[(ABC-\d+)\]`), want: []string{"ABC-1", "ABC-2"}},
+		{message: "fix: fixed foo\n\nSee ABC-1", pattern: regexp.MustCompile(`ABC-\d+`), want: []string{"ABC-1"}},
+	}
+
+	for _, tc := range matrix {
+		assert.Equal(tc.want, parseReferences(tc.message, tc.pattern), "message: %q", tc.message)
+	}
+}
+
 func TestParser_FetchLatestSemverTag_NoTag(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -145,6 +264,208 @@ func TestParser_FetchLatestSemverTag_MultipleTags(t *testing.T) {
 	assert.Equal(want, latest.Name, "latest semver tag should be equal")
 }
 
+func TestParser_FetchLatestSemverTag_Precedence(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		// tags are created in order, on commits of increasing dates
+		tags []string
+		want string
+	}
+
+	matrix := []test{
+		{tags: []string{"v1.10.0", "v1.9.0"}, want: "v1.10.0"},
+		{tags: []string{"v1.2.0", "v1.2.0-rc.1"}, want: "v1.2.0"},
+		{tags: []string{"v1.2.0-rc.10", "v1.2.0-rc.9"}, want: "v1.2.0-rc.10"},
+		{tags: []string{"v1.2.0-rc.1", "v1.2.0-beta.3", "v1.2.0-alpha"}, want: "v1.2.0-rc.1"},
+	}
+
+	for _, tc := range matrix {
+		testRepository, err := gittest.NewRepository()
+		checkErr(t, "creating repository", err)
+
+		for _, tagName := range tc.tags {
+			hash, err := testRepository.AddCommit("fix")
+			checkErr(t, "adding commit", err)
+
+			err = testRepository.AddTag(tagName, hash)
+			checkErr(t, "creating tag", err)
+		}
+
+		th := NewTestHelper(t)
+		th.Ctx.TagPrefixFlag = "v"
+		parser := New(th.Ctx)
+
+		latest, err := parser.FetchLatestSemverTag(testRepository.Repository, monorepo.Project{})
+		checkErr(t, "fetching latest semver tag", err)
+
+		assert.Equal(tc.want, latest.Name, "latest semver tag should be equal")
+
+		_ = testRepository.Remove()
+	}
+}
+
+func TestParser_FetchLatestSemverTag_LightweightTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	annotatedHash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v1.0.0", annotatedHash)
+	checkErr(t, "creating annotated tag", err)
+
+	lightweightHash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.CreateTag("v1.0.1", lightweightHash, nil)
+	checkErr(t, "creating lightweight tag", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.TagPrefixFlag = "v"
+	parser := New(th.Ctx)
+
+	latest, err := parser.FetchLatestSemverTag(testRepository.Repository, monorepo.Project{})
+	checkErr(t, "fetching latest semver tag", err)
+
+	assert.Equal("v1.0.1", latest.Name, "latest semver tag should be the lightweight tag")
+	assert.Equal(lightweightHash, latest.Hash, "latest semver tag should point to the tagged commit")
+
+	err = testRepository.DeleteTag("v1.0.1")
+	checkErr(t, "deleting lightweight tag", err)
+
+	latest, err = parser.FetchLatestSemverTag(testRepository.Repository, monorepo.Project{})
+	checkErr(t, "fetching latest semver tag", err)
+
+	assert.Equal("v1.0.0", latest.Name, "latest semver tag should be the annotated tag")
+	assert.Equal(annotatedHash, latest.Hash, "annotated tag should be resolved to the tagged commit")
+}
+
+func TestParser_FetchLatestSemverTag_IgnorePatterns(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	for _, tagName := range []string{"v1.0.0", "nightly-2.0.0", "docker-3.0.0"} {
+		err = testRepository.AddTag(tagName, head.Hash())
+		checkErr(t, "creating tag", err)
+	}
+
+	th := NewTestHelper(t)
+	th.Ctx.TagPrefixFlag = "v"
+	th.Ctx.TagIgnorePatterns = []*regexp.Regexp{regexp.MustCompile(`^nightly-`), regexp.MustCompile(`^docker-`)}
+	parser := New(th.Ctx)
+
+	latest, err := parser.FetchLatestSemverTag(testRepository.Repository, monorepo.Project{})
+	checkErr(t, "fetching latest semver tag", err)
+
+	assert.Equal("v1.0.0", latest.Name, "ignored tags should not be considered")
+}
+
+func TestParser_FetchLatestSemverTag_TagPrefix(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	for _, tagName := range []string{"1.5.0", "v1.2.0", "release-2.0.0", "foo-v3.0.0", "foo-bar-v4.0.0"} {
+		err = testRepository.AddTag(tagName, head.Hash())
+		checkErr(t, "creating tag", err)
+	}
+
+	type test struct {
+		prefix  string
+		project monorepo.Project
+		want    string
+	}
+
+	matrix := []test{
+		{prefix: "v", want: "v1.2.0"},
+		{prefix: "", want: "1.5.0"},
+		{prefix: "release-", want: "release-2.0.0"},
+		{prefix: "v", project: monorepo.Project{Name: "foo"}, want: "foo-v3.0.0"},
+		{prefix: "v", project: monorepo.Project{Name: "foo-bar"}, want: "foo-bar-v4.0.0"},
+	}
+
+	for _, tc := range matrix {
+		th := NewTestHelper(t)
+		th.Ctx.TagPrefixFlag = tc.prefix
+		parser := New(th.Ctx)
+
+		latest, err := parser.FetchLatestSemverTag(testRepository.Repository, tc.project)
+		checkErr(t, "fetching latest semver tag", err)
+
+		assert.Equal(tc.want, latest.Name, "only tags with the prefix should be considered")
+	}
+}
+
+func TestParser_TagPrefix_Detect(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.TagPrefixFlag = "v"
+	th.Ctx.DetectTagPrefixFlag = true
+	parser := New(th.Ctx)
+
+	prefix, err := parser.TagPrefix(testRepository.Repository, monorepo.Project{})
+	checkErr(t, "detecting tag prefix", err)
+
+	assert.Equal("v", prefix, "tag prefix flag should be used without tags")
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	for _, tagName := range []string{"v0.9.0", "1.0.0", "1.1.0"} {
+		err = testRepository.AddTag(tagName, head.Hash())
+		checkErr(t, "creating tag", err)
+	}
+
+	prefix, err = parser.TagPrefix(testRepository.Repository, monorepo.Project{})
+	checkErr(t, "detecting tag prefix", err)
+
+	assert.Equal("", prefix, "prefix of the highest version should be detected")
+
+	projectPrefix := "release-"
+
+	prefix, err = parser.TagPrefix(testRepository.Repository, monorepo.Project{Name: "foo", TagPrefix: &projectPrefix})
+	checkErr(t, "detecting tag prefix", err)
+
+	assert.Equal(projectPrefix, prefix, "project tag prefix should override detection")
+
+	latest, err := parser.FetchLatestSemverTag(testRepository.Repository, monorepo.Project{})
+	checkErr(t, "fetching latest semver tag", err)
+
+	assert.Equal("1.1.0", latest.Name)
+}
+
 func TestParser_ComputeNewSemver_UntaggedRepository_NoRelease(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -158,7 +479,7 @@ func TestParser_ComputeNewSemver_UntaggedRepository_NoRelease(t *testing.T) {
 	th := NewTestHelper(t)
 	parser := New(th.Ctx)
 
-	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
 	checkErr(t, "computing new semver", err)
 
 	want := "0.0.0"
@@ -182,13 +503,47 @@ func TestParser_ComputeNewSemver_UntaggedRepository_PatchRelease(t *testing.T) {
 	th := NewTestHelper(t)
 	parser := New(th.Ctx)
 
-	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
 	checkErr(t, "computing new semver", err)
 
 	want := "0.0.1"
 	assert.Equal(want, output.Semver.String(), "version should be equal")
 }
 
+func TestParser_Close(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	parser := New(th.Ctx)
+
+	err = parser.Close()
+	assert.NoError(err, "closing an unused parser should not fail")
+
+	_, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.NotNil(parser.index, "commit index should have been opened")
+
+	err = parser.Close()
+	assert.NoError(err, "closing parser should not fail")
+	assert.Nil(parser.index, "commit index should have been released")
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver after closing", err)
+
+	assert.Equal("0.0.1", output.Semver.String(), "version should be equal")
+}
+
 func TestParser_ComputeNewSemver_UnknownReleaseType(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -213,7 +568,7 @@ func TestParser_ComputeNewSemver_UnknownReleaseType(t *testing.T) {
 	th.Ctx.Rules = invalidRules
 	parser := New(th.Ctx)
 
-	_, err = parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	_, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
 	assert.ErrorContains(err, "unknown release type")
 }
 
@@ -233,7 +588,7 @@ func TestParser_ComputeNewSemver_UntaggedRepository_MinorRelease(t *testing.T) {
 	th := NewTestHelper(t)
 	parser := New(th.Ctx)
 
-	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
 	checkErr(t, "computing new semver", err)
 
 	want := "0.1.0"
@@ -256,7 +611,7 @@ func TestParser_ComputeNewSemver_UntaggedRepository_MajorRelease(t *testing.T) {
 	th := NewTestHelper(t)
 	parser := New(th.Ctx)
 
-	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
 	checkErr(t, "computing new semver ", err)
 
 	want := "1.0.0"
@@ -289,12 +644,13 @@ func TestParser_ComputeNewSemver_TaggedRepository(t *testing.T) {
 	th := NewTestHelper(t)
 	parser := New(th.Ctx)
 
-	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
 	checkErr(t, "computing new semver ", err)
 
 	want := "1.1.1"
 
 	assert.Equal(want, output.Semver.String(), "version should be equal")
+	assert.Equal("1.0.0", output.PreviousSemver.String(), "previous version should be equal")
 	assert.Equal(true, output.NewRelease, "boolean should be equal")
 }
 
@@ -314,7 +670,7 @@ func TestParser_ComputeNewSemver_UninitializedRepository(t *testing.T) {
 	th := NewTestHelper(t)
 	parser := New(th.Ctx)
 
-	_, err = parser.ComputeNewSemver(repository, monorepo.Project{}, th.Ctx.Branches[0])
+	_, err = parser.ComputeNewSemver(context.Background(), repository, monorepo.Project{}, th.Ctx.Branches[0])
 	assert.ErrorIs(err, plumbing.ErrReferenceNotFound)
 }
 
@@ -335,7 +691,7 @@ func TestParser_ComputeNewSemver_BuildMetadata(t *testing.T) {
 	th.Ctx.BuildMetadataFlag = "metadata"
 	parser := New(th.Ctx)
 
-	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
 	checkErr(t, "computing new semver", err)
 
 	want := semver.Version{
@@ -349,6 +705,59 @@ func TestParser_ComputeNewSemver_BuildMetadata(t *testing.T) {
 	assert.Equal(true, output.NewRelease, "boolean should be equal")
 }
 
+func TestParser_ComputeNewSemver_BuildMetadataTemplate(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	c, err := testRepository.CommitObject(hash)
+	checkErr(t, "fetching commit", err)
+
+	t.Setenv("GITHUB_RUN_NUMBER", "42")
+	t.Setenv("BUILD_ENV", "ci/staging")
+
+	th := NewTestHelper(t)
+	th.Ctx.BuildMetadataFlag = `{{ .Branch }}.{{ .ShortSHA }}.{{ .CommitDate }}.{{ .RunNumber }}.{{ env "BUILD_ENV" }}.{{ .Project }}`
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	want := fmt.Sprintf("master.%s.%s.42.ci-staging", hash.String()[:7], c.Committer.When.UTC().Format("20060102150405"))
+
+	assert.Equal(want, output.Semver.Metadata, "invalid characters and empty identifiers should have been removed")
+}
+
+func TestParser_ParseBuildMetadata(t *testing.T) {
+	assert := assertion.New(t)
+
+	tests := map[string]error{
+		"build.1":             nil,
+		"{{ .ShortSHA }}":     nil,
+		"{{ .ShortSHA ":       ErrInvalidBuildMetadata,
+		"{{ .Unknown }}":      ErrInvalidBuildMetadata,
+		`{{ env "FOO" }}`:     nil,
+		`{{ unknown "FOO" }}`: ErrInvalidBuildMetadata,
+	}
+
+	for text, want := range tests {
+		_, err := ParseBuildMetadata(text)
+		if want == nil {
+			assert.NoError(err, text)
+		} else {
+			assert.ErrorIs(err, want, text)
+		}
+	}
+}
+
 func TestParser_ComputeNewSemver_Prerelease(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -362,13 +771,13 @@ func TestParser_ComputeNewSemver_Prerelease(t *testing.T) {
 	_, err = testRepository.AddCommit("feat")
 	checkErr(t, "adding commit", err)
 
-	prereleaseID := "master"
+	prereleaseID := "master.1"
 
 	th := NewTestHelper(t)
 	th.Ctx.Branches[0].Prerelease = true
 	parser := New(th.Ctx)
 
-	output, err := parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
 	checkErr(t, "computing new semver", err)
 
 	want := semver.Version{
@@ -382,8 +791,7 @@ func TestParser_ComputeNewSemver_Prerelease(t *testing.T) {
 	assert.Equal(true, output.NewRelease, "boolean should be equal")
 }
 
-// FIXME: the "origin" name is not set when calling parser.checkoutBranch leaving remoteRef like "ref/remote/<empty>/<branch>
-func TestParser_Run_NoMonorepoOutputLength(t *testing.T) {
+func TestParser_ComputeNewSemver_Commits(t *testing.T) {
 	assert := assertion.New(t)
 
 	testRepository, err := gittest.NewRepository()
@@ -393,40 +801,28 @@ func TestParser_Run_NoMonorepoOutputLength(t *testing.T) {
 		_ = testRepository.Remove()
 	})
 
-	_, err = testRepository.AddCommit("feat")
+	featHash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommit("chore")
+	checkErr(t, "adding commit", err)
+	breakingHash, err := testRepository.AddCommit("fix!")
 	checkErr(t, "adding commit", err)
-
-	clonedTestRepository, err := testRepository.Clone()
-	checkErr(t, "cloning test repository", err)
 
 	th := NewTestHelper(t)
 	parser := New(th.Ctx)
 
-	output, err := parser.Run(context.Background(), clonedTestRepository.Repository)
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
 	checkErr(t, "computing new semver", err)
 
-	want := semver.Version{
-		Major: 0,
-		Minor: 1,
-		Patch: 0,
+	want := []Commit{
+		{Hash: featHash, Type: "feat", Description: "this a test commit"},
+		{Hash: breakingHash, Type: "fix", Description: "this a test commit", Breaking: true},
 	}
 
-	assert.Len(output, 1, "parser run output should contain one element")
-	assert.Equal(want.String(), output[0].Semver.String(), "version should be equal")
-}
-
-func TestParser_ShortMessage(t *testing.T) {
-	assert := assertion.New(t)
-
-	msg := "This is a very long commit message that is over fifty character"
-	short := shortenMessage(msg)
-
-	expected := "This is a very long commit message that is over..."
-
-	assert.Equal(expected, short, "short message should be equal")
+	assert.Equal(want, output.Commits, "release commits should be equal")
 }
 
-func TestMonorepoParser_FetchLatestSemverTagPerProjects(t *testing.T) {
+func TestParser_ComputeNewSemver_Range(t *testing.T) {
 	assert := assertion.New(t)
 
 	testRepository, err := gittest.NewRepository()
@@ -439,25 +835,1391 @@ func TestMonorepoParser_FetchLatestSemverTagPerProjects(t *testing.T) {
 	head, err := testRepository.Head()
 	checkErr(t, "fetching head", err)
 
-	wantTag := "foo-1.0.0"
+	err = testRepository.AddTag("1.0.0", head.Hash())
+	checkErr(t, "creating tag", err)
+
+	featHash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+	fixHash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommit("feat!")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.FromFlag = featHash.String()
+	th.Ctx.ToFlag = fixHash.String()
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.1", output.Semver.String(), "only the commits of the range should be analyzed")
+	assert.Equal(featHash, output.From)
+	assert.Equal(fixHash, output.To)
+	assert.Equal(fixHash, output.CommitHash)
+
+	th.Ctx.FromFlag = ""
+	th.Ctx.ToFlag = "does-not-exist"
+
+	_, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	assert.ErrorIs(err, ErrInvalidRevision)
+}
+
+func TestParser_ComputeNewSemver_Cache(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.AddTag("1.0.0", head.Hash())
+	checkErr(t, "creating tag", err)
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+	cachedHash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.CacheFlag = true
+	signature := object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: time.Now()}
+
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.1.1", output.Semver.String())
+
+	err = parser.SaveCache(testRepository.Repository, signature)
+	checkErr(t, "saving cache", err)
+
+	_, err = testRepository.Reference(NotesRef, true)
+	checkErr(t, "fetching notes reference", err)
+
+	breakingHash, err := testRepository.AddCommit("feat!")
+	checkErr(t, "adding commit", err)
+	fixHash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	parser = New(th.Ctx)
+
+	output, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("2.0.1", output.Semver.String())
+	assert.Equal(fixHash, output.CommitHash)
+	assert.Len(output.Commits, 4, "cached release commits should be kept")
+
+	var analyzed []plumbing.Hash
+	for _, report := range output.Report {
+		analyzed = append(analyzed, report.Hash)
+	}
+
+	assert.Equal([]plumbing.Hash{breakingHash, fixHash}, analyzed, "only the commits added since the cached analysis should be analyzed")
+	assert.NotContains(analyzed, cachedHash)
+
+	// Any change of configuration invalidates the cache
+	th.Ctx.MajorOnBreakingInDevFlag = !th.Ctx.MajorOnBreakingInDevFlag
+	parser = New(th.Ctx)
+
+	output, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Len(output.Report, 4, "the whole history should be analyzed")
+}
+
+func TestParser_ComputeNewSemver_CacheStopsWalk(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.AddTag("1.0.0", head.Hash())
+	checkErr(t, "creating tag", err)
+
+	var older []plumbing.Hash
+
+	for range 3 {
+		hash, err := testRepository.AddCommit("fix")
+		checkErr(t, "adding commit", err)
+
+		older = append(older, hash)
+	}
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.CacheFlag = true
+	signature := object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: time.Now()}
+
+	parser := New(th.Ctx)
+
+	_, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	err = parser.SaveCache(testRepository.Repository, signature)
+	checkErr(t, "saving cache", err)
+
+	newHash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	storer := &countingStorer{Storer: testRepository.Storer, reads: make(map[plumbing.Hash]int)}
+
+	repository, err := git.Open(storer, nil)
+	checkErr(t, "opening repository", err)
+
+	output, err := New(th.Ctx).ComputeNewSemver(context.Background(), repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.1.1", output.Semver.String())
+	assert.Equal(newHash, output.CommitHash)
+
+	for _, hash := range older {
+		assert.Zero(storer.reads[hash], "commit %s older than the cached commit should not be read", hash)
+	}
+}
+
+// countingStorer counts the reads of every object of a storage.
+type countingStorer struct {
+	storage.Storer
+	reads map[plumbing.Hash]int
+}
+
+func (s *countingStorer) EncodedObject(objectType plumbing.ObjectType, hash plumbing.Hash) (plumbing.EncodedObject, error) {
+	s.reads[hash]++
+	return s.Storer.EncodedObject(objectType, hash)
+}
+
+func TestParser_ComputeNewSemver_Report(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	featHash, err := testRepository.AddCommitWithMessage("feat(api): add foo")
+	checkErr(t, "adding commit", err)
+	choreHash, err := testRepository.AddCommit("chore")
+	checkErr(t, "adding commit", err)
+	breakingHash, err := testRepository.AddCommit("fix!")
+	checkErr(t, "adding commit", err)
+	otherHash, err := testRepository.AddCommitWithMessage("update readme")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.Rules = rule.Rules{Map: map[string]string{"feat": "minor", "fix": "patch"}}
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	firstHash := output.Report[0].Hash
+
+	want := []CommitReport{
+		{Hash: firstHash, Ignored: true, Reason: ReasonNonConventional},
+		{Hash: featHash, Type: "feat", Scope: "api", Rule: "minor", Bump: "minor"},
+		{Hash: choreHash, Type: "chore", Ignored: true, Reason: ReasonNoRule},
+		{Hash: breakingHash, Type: "fix", Breaking: true, Rule: "patch", Bump: "major"},
+		{Hash: otherHash, Ignored: true, Reason: ReasonNonConventional},
+	}
+
+	assert.Equal(want, output.Report, "every commit should have been classified")
+}
+
+func TestParser_ComputeNewSemver_PrereleaseChannel(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	parser := New(th.Ctx)
+
+	gitBranch := branch.Branch{Name: "master", Prerelease: true, PrereleaseID: "beta"}
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, gitBranch)
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.1.0-beta.1", output.Semver.String(), "version should be equal")
+	assert.Equal("beta", output.Channel, "channel should be equal")
+}
+
+func TestParser_ComputeNewSemver_PrereleaseNumber(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	releaseHash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("1.1.0", releaseHash)
+	checkErr(t, "adding tag", err)
+
+	rcHash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("1.1.1-rc.1", rcHash)
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	parser := New(th.Ctx)
+
+	gitBranch := branch.Branch{Name: "master", Prerelease: true, PrereleaseID: "rc"}
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, gitBranch)
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.1.1-rc.2", output.Semver.String(), "prerelease number should have been incremented")
+	assert.Equal(true, output.NewRelease, "boolean should be equal")
+}
+
+func TestParser_ComputeNewSemver_FirstParent(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	err = testRepository.CheckoutBranch("feature")
+	checkErr(t, "creating feature branch", err)
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.Checkout("master")
+	checkErr(t, "checking out master", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.Merge("feature")
+	checkErr(t, "merging feature branch", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.FirstParentFlag = true
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.0.1", output.Semver.String(), "merged branch commits should have been ignored")
+}
+
+func TestParser_ComputeNewSemver_SquashedCommits(t *testing.T) {
+	assert := assertion.New(t)
+
+	message := `fix: squashed pull request (#12)
+
+* feat(api): add foo endpoint
+* fix: correct bar
+- docs: document foo
+this line is not a change`
+
+	type test struct {
+		squashedCommits bool
+		want            string
+	}
+
+	matrix := []test{
+		{squashedCommits: false, want: "0.0.1"},
+		{squashedCommits: true, want: "0.1.1"},
+	}
+
+	for _, tc := range matrix {
+		testRepository, err := gittest.NewRepository()
+		checkErr(t, "creating repository", err)
+
+		t.Cleanup(func() {
+			_ = testRepository.Remove()
+		})
+
+		_, err = testRepository.AddCommitWithMessage(message)
+		checkErr(t, "adding commit", err)
+
+		th := NewTestHelper(t)
+		th.Ctx.SquashedCommitsFlag = tc.squashedCommits
+		parser := New(th.Ctx)
+
+		output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+		checkErr(t, "computing new semver", err)
+
+		assert.Equal(tc.want, output.Semver.String(), "version should be equal")
+	}
+}
+
+func TestParser_ComputeNewSemver_References(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	messages := []string{
+		"feat: add foo\n\nCloses #12",
+		"fix: fix bar\n\nFixes JIRA-456, #12",
+		"chore: not a release commit\n\nCloses #99",
+	}
+
+	for _, message := range messages {
+		_, err = testRepository.AddCommitWithMessage(message)
+		checkErr(t, "adding commit", err)
+	}
+
+	th := NewTestHelper(t)
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	if assert.Len(output.Commits, 2) {
+		assert.Equal([]string{"#12"}, output.Commits[0].References)
+		assert.Equal([]string{"JIRA-456", "#12"}, output.Commits[1].References)
+	}
+
+	assert.Equal([]string{"#12", "JIRA-456"}, output.References(), "references should be deduplicated")
+}
+
+func TestParser_ComputeNewSemver_ReleaseAs(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	releaseAsHash, err := testRepository.AddCommitWithMessage("chore: prepare major release\n\nRelease-As: 2.0.0")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("2.0.0", output.Semver.String(), "version should be equal")
+	assert.Equal("2.0.0", output.ReleaseAs, "Release-As version should be equal")
+	assert.Equal(releaseAsHash, output.CommitHash, "release commit should be the one with the Release-As footer")
+	assert.Equal(true, output.NewRelease, "boolean should be equal")
+}
+
+func TestParser_ComputeNewSemver_Forced(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("1.2.3", hash)
+	checkErr(t, "adding tag", err)
+
+	head, err := testRepository.AddCommit("chore")
+	checkErr(t, "adding commit", err)
+
+	type test struct {
+		forceBump  string
+		setVersion *semver.Version
+		want       string
+		forced     bool
+	}
+
+	tests := []test{
+		{want: "1.2.3", forced: false},
+		{forceBump: "major", want: "2.0.0", forced: true},
+		{forceBump: "patch", want: "1.2.4", forced: true},
+		{setVersion: &semver.Version{Major: 3}, want: "3.0.0", forced: true},
+	}
+
+	for _, tc := range tests {
+		th := NewTestHelper(t)
+		th.Ctx.ForceBumpFlag = tc.forceBump
+		th.Ctx.SetVersion = tc.setVersion
+		parser := New(th.Ctx)
+
+		output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+		checkErr(t, "computing new semver", err)
+
+		assert.Equal(tc.want, output.Semver.String(), "version should be equal")
+		assert.Equal(tc.forced, output.Forced, "version should be forced")
+		assert.Equal(tc.forced, output.NewRelease, "forced version should be released")
+
+		if tc.forced {
+			assert.Equal(head, output.CommitHash, "forced release should point to the head of the branch")
+		}
+	}
+}
+
+func TestParser_ComputeNewSemver_CalVer(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("1999.12.3", hash)
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	head, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommit("chore")
+	checkErr(t, "adding commit", err)
+
+	type test struct {
+		format string
+		want   string
+	}
+
+	// Commits of the test repository are made on January 1st, 2000
+	tests := []test{
+		{format: "YYYY.MM.MICRO", want: "2000.1.1"},
+		{format: "YYYY.MINOR.MICRO", want: "2000.1.0"},
+	}
+
+	for _, tc := range tests {
+		calver, err := scheme.NewCalVer(tc.format)
+		checkErr(t, "creating CalVer scheme", err)
+
+		th := NewTestHelper(t)
+		th.Ctx.Scheme = calver
+		parser := New(th.Ctx)
+
+		output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+		checkErr(t, "computing new semver", err)
+
+		assert.Equal(tc.want, output.Semver.String(), "version should be equal")
+		assert.Equal(true, output.NewRelease, "commits should trigger a release")
+		assert.Equal(head, output.CommitHash, "release should point to the latest release commit")
+	}
+}
+
+func TestParser_ComputeNewSemver_InvalidReleaseAs(t *testing.T) {
+	assert := assertion.New(t)
+
+	matrix := []string{"1.0.0", "v2.0.0", "foo"}
+
+	for _, releaseAs := range matrix {
+		testRepository, err := gittest.NewRepository()
+		checkErr(t, "creating repository", err)
+
+		t.Cleanup(func() {
+			_ = testRepository.Remove()
+		})
+
+		hash, err := testRepository.AddCommit("feat")
+		checkErr(t, "adding commit", err)
+
+		err = testRepository.AddTag("1.0.0", hash)
+		checkErr(t, "adding tag", err)
+
+		_, err = testRepository.AddCommitWithMessage("chore: release\n\nRelease-As: " + releaseAs)
+		checkErr(t, "adding commit", err)
+
+		th := NewTestHelper(t)
+		parser := New(th.Ctx)
+
+		_, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+		assert.ErrorIs(err, ErrInvalidReleaseAs, "Release-As %q should have been rejected", releaseAs)
+	}
+}
+
+func TestParser_CompileCommitPattern(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := CompileCommitPattern(`^\[(?P<type>[A-Z]+)\](?P<breaking>!)? (?P<description>.+)$`)
+	assert.NoError(err)
+
+	tests := []string{`^(?P<type>[a-z]+`, `^\[[A-Z]+\] .+$`, `^(?P<type>[a-z]+) (?P<ticket>[A-Z]+-\d+)`}
+
+	for _, pattern := range tests {
+		_, err = CompileCommitPattern(pattern)
+		assert.ErrorIs(err, ErrInvalidCommitPattern, pattern)
+	}
+}
+
+func TestParser_ParseCustomMessage(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		pattern string
+		message string
+		want    Commit
+		ok      bool
+	}
+
+	tests := []test{
+		{pattern: `^\[(?P<type>[A-Z]+)\](?P<breaking>!)? (?P<description>.+)$`, message: "[FIX] fix foo", want: Commit{Type: "fix", Description: "fix foo"}, ok: true},
+		{pattern: `^\[(?P<type>[A-Z]+)\](?P<breaking>!)? (?P<description>.+)$`, message: "[FEAT]! drop bar\n\nbody", want: Commit{Type: "feat", Description: "drop bar", Breaking: true}, ok: true},
+		{pattern: `^[A-Z]+-\d+ (?P<type>[a-z]+)(?:\((?P<scope>\w+)\))?`, message: "JIRA-123 feat(api) add foo", want: Commit{Type: "feat", Scope: "api", Description: "add foo"}, ok: true},
+		{pattern: `^(?P<type>[a-z]+):`, message: "feat: add foo\n\nBREAKING CHANGE: foo removed", want: Commit{Type: "feat", Description: "add foo"}, ok: true},
+		{pattern: `^\[(?P<type>[A-Z]+)\]`, message: "feat: add foo", ok: false},
+	}
+
+	for _, tc := range tests {
+		got, ok := parseCustomMessage(tc.message, regexp.MustCompile(tc.pattern))
+
+		assert.Equal(tc.ok, ok, tc.message)
+		assert.Equal(tc.want, got, tc.message)
+	}
+}
+
+func TestParser_ComputeNewSemver_CommitPattern(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommitWithMessage("[FEAT] add foo")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommitWithMessage("[FIX] fix foo")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommitWithMessage("feat!: conventional commits are no longer parsed")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.CommitPattern = regexp.MustCompile(`^\[(?P<type>[A-Z]+)\] (?P<description>.+)$`)
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.1.1", output.Semver.String(), "version should be equal")
+
+	violations := parser.Lint("feat: add foo")
+	if assert.Len(violations, 1) {
+		assert.Contains(violations[0].Message, "header does not match the commit pattern")
+	}
+}
+
+func TestParser_ComputeNewSemver_Strict(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.AddTag("1.0.0", head.Hash())
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.StrictFlag = true
+	parser := New(th.Ctx)
+
+	_, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	assert.NoError(err, "conventional commits should have been accepted")
+
+	hash, err := testRepository.AddCommitWithMessage("updated readme")
+	checkErr(t, "adding commit", err)
+
+	_, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	assert.ErrorIs(err, ErrNonConventionalCommit, "non conventional commit should have been rejected")
+	assert.ErrorContains(err, hash.String(), "error should contain the commit hash")
+	assert.ErrorContains(err, "updated readme", "error should contain the commit message")
+}
+
+func TestParser_NoneReleaseRule(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.AddTag("1.0.0", head.Hash())
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommit("docs")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.StrictFlag = true
+	th.Ctx.Rules = rule.Rules{Map: map[string]string{"fix": "patch", "docs": rule.None}}
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.1", output.Semver.String(), "commit type ignored by rule should not trigger a release")
+	assert.Len(output.Commits, 1, "commit type ignored by rule should not be part of the release commits")
+
+	hash, err := testRepository.AddCommit("ci")
+	checkErr(t, "adding commit", err)
+
+	_, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	assert.ErrorIs(err, ErrUnknownCommitType, "commit type without rule should have been rejected")
+	assert.ErrorContains(err, hash.String(), "error should contain the commit hash")
+}
+
+// FIXME: the "origin" name is not set when calling parser.checkoutBranch leaving remoteRef like "ref/remote/<empty>/<branch>
+func TestParser_Run_NoMonorepoOutputLength(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	th := NewTestHelper(t)
+	parser := New(th.Ctx)
+
+	output, err := parser.Run(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	want := semver.Version{
+		Major: 0,
+		Minor: 1,
+		Patch: 0,
+	}
+
+	assert.Len(output, 1, "parser run output should contain one element")
+	assert.Equal(want.String(), output[0].Semver.String(), "version should be equal")
+}
+
+func TestParser_Run_TagOnOlderCommit(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	for _, commitType := range []string{"feat", "feat", "chore"} {
+		_, err = testRepository.AddCommit(commitType)
+		checkErr(t, "adding commit", err)
+	}
+
+	// The release is tagged after the fact, on the commit preceding the chore
+	_, err = testRepository.AddTagAt("1.0.0", "HEAD~1")
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	th := NewTestHelper(t)
+	parser := New(th.Ctx)
+
+	output, err := parser.Run(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	assert.Len(output, 1, "parser run output should contain one element")
+	assert.Equal("1.0.1", output[0].Semver.String(), "only the commits following the tagged one should be parsed")
+}
+
+func TestParser_Run_Commit(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+	fixHash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommit("feat!")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.CheckoutBranch("other")
+	checkErr(t, "creating branch", err)
+
+	otherHash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	t.Cleanup(func() {
+		_ = clonedTestRepository.Remove()
+	})
+
+	th := NewTestHelper(t)
+	th.Ctx.CommitFlag = fixHash.String()
+	parser := New(th.Ctx)
+
+	output, err := parser.Run(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "computing new semver", err)
+
+	assert.Len(output, 1, "parser run output should contain one element")
+	assert.Equal("0.1.1", output[0].Semver.String(), "only the history of the commit should be analyzed")
+	assert.Equal(fixHash, output[0].CommitHash)
+
+	th.Ctx.CommitFlag = otherHash.String()
+
+	_, err = parser.Run(context.Background(), clonedTestRepository.Repository)
+	assert.ErrorIs(err, ErrCommitNotOnBranch, "commit of another branch should have been rejected")
+}
+
+func TestParser_Commits(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	first, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v1.1.0", first)
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("chore")
+	checkErr(t, "adding commit", err)
+
+	second, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v1.0.0", second)
+	checkErr(t, "adding tag", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.TagPrefixFlag = "v"
+	parser := New(th.Ctx)
+
+	tags, err := parser.SemverTags(testRepository.Repository, monorepo.Project{})
+	checkErr(t, "fetching semver tags", err)
+
+	if assert.Len(tags, 2) {
+		assert.Equal("v1.0.0", tags[0].Name, "tags should be sorted by version")
+		assert.Equal(second, tags[0].Hash)
+		assert.False(tags[0].Date.IsZero(), "tag should be dated")
+	}
+
+	commits, err := parser.Commits(context.Background(), testRepository.Repository, monorepo.Project{}, first, second)
+	checkErr(t, "fetching commits", err)
+
+	if assert.Len(commits, 1, "only release commits should be returned") {
+		assert.Equal(second, commits[0].Hash)
+	}
+
+	commits, err = parser.Commits(context.Background(), testRepository.Repository, monorepo.Project{}, plumbing.ZeroHash, second)
+	checkErr(t, "fetching commits", err)
+
+	assert.Len(commits, 2, "whole history should be walked without a commit to stop at")
+}
+
+func TestParser_Lint(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		message string
+		want    []string
+	}
+
+	matrix := []test{
+		{message: "feat(api)!: add foo\n\nSome body.\n\nRelease-As: 2.0.0\n", want: nil},
+		{message: "", want: []string{"1:1: missing header"}},
+		{message: "Feat add foo", want: []string{`1:1: unknown commit type "Feat", expected one of build, chore, ci, docs, feat, fix, perf, refactor, revert, style, test`, `1:5: expected ":" after the commit type`}},
+		{message: ": add foo", want: []string{"1:1: missing commit type"}},
+		{message: "feature: add foo", want: []string{`1:1: unknown commit type "feature", expected one of build, chore, ci, docs, feat, fix, perf, refactor, revert, style, test`}},
+		{message: "feat(api: add foo", want: []string{"1:5: unclosed scope"}},
+		{message: "feat(a b): add foo", want: []string{`1:6: invalid scope "a b"`}},
+		{message: "feat:add foo", want: []string{`1:6: expected a space after ":"`}},
+		{message: "feat: ", want: []string{"1:7: missing description"}},
+		{message: "feat: (foo)", want: []string{"1:7: description must start with a letter, a digit or an underscore"}},
+		{message: "feat: add foo\nbody", want: []string{"2:1: expected a blank line between the header and the body"}},
+		{message: "feat: add foo\n\nRelease-As: v2", want: []string{`3:13: invalid Release-As version "v2"`}},
+		{message: "feat: add foo\r\n\r\nRelease-As: 2.0.0\r\n", want: nil},
+		{message: "docs: Élaborer la documentation", want: nil},
+	}
+
+	parser := New(NewTestHelper(t).Ctx)
+
+	for _, tc := range matrix {
+		var got []string
+		for _, violation := range parser.Lint(tc.message) {
+			got = append(got, violation.String())
+		}
+
+		assert.Equal(tc.want, got, "message: %q", tc.message)
+	}
+
+	th := NewTestHelper(t)
+	th.Ctx.StrictFlag = true
+	th.Ctx.Rules = rule.Rules{Map: map[string]string{"feat": "minor"}}
+
+	violations := New(th.Ctx).Lint("fix: fix foo")
+
+	if assert.Len(violations, 1) {
+		assert.Equal(`1:1: commit type "fix" has no release rule`, violations[0].String())
+	}
+}
+
+func TestParser_LintHistory(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommitWithMessage("not conventional")
+	checkErr(t, "adding commit", err)
+
+	tagged, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v1.0.0", tagged)
+	checkErr(t, "adding tag", err)
+
+	invalid, err := testRepository.AddCommitWithMessage("fix:missing space")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.TagPrefixFlag = "v"
+	parser := New(th.Ctx)
+
+	results, err := parser.LintHistory(context.Background(), testRepository.Repository, "", "")
+	checkErr(t, "linting history", err)
+
+	if assert.Len(results, 1, "commits before the latest tag should not be linted") {
+		assert.Equal(invalid, results[0].Hash)
+		assert.Equal("fix:missing space", results[0].Subject)
+	}
+
+	results, err = parser.LintHistory(context.Background(), testRepository.Repository, "", tagged.String())
+	checkErr(t, "linting history", err)
+
+	assert.Empty(results)
+}
+
+func TestParser_ShortMessage(t *testing.T) {
+	assert := assertion.New(t)
+
+	msg := "This is a very long commit message that is over fifty character"
+	short := shortenMessage(msg)
+
+	expected := "This is a very long commit message that is over..."
+
+	assert.Equal(expected, short, "short message should be equal")
+}
+
+func TestMonorepoParser_FetchLatestSemverTagPerProjects(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	wantTag := "foo-1.0.0"
+
+	err = testRepository.AddTag(wantTag, head.Hash())
+	checkErr(t, fmt.Sprintf("creating tag %q", wantTag), err)
+
+	th := NewTestHelper(t)
+	th.Ctx.Projects = []monorepo.Project{
+		{Name: "foo", Path: "foo"},
+		{Name: "bar", Path: "bar"},
+	}
+	parser := New(th.Ctx)
+
+	gotTag, err := parser.FetchLatestSemverTag(testRepository.Repository, th.Ctx.Projects[0])
+	checkErr(t, "fetching latest semver tag", err)
+
+	assert.Equal(gotTag.Name, wantTag, "should have found tag")
+}
+
+func TestMonorepoParser_CommitContainsProjectFiles_True(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommitWithSpecificFile("fix", "./foo/foo.txt")
+	checkErr(t, "adding commit", err)
+
+	commit, err := testRepository.CommitObject(hash)
+	checkErr(t, "getting commit", err)
+
+	contains, err := commitContainsProjectFiles(commit, "foo")
+	checkErr(t, "checking project files", err)
+
+	assert.True(contains, "commit contains project files")
+}
+
+func TestMonorepoParser_CommitContainsProjectFiles_False(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	hash, err := testRepository.AddCommitWithSpecificFile("fix", "./foo/foo.txt")
+	checkErr(t, "adding commit", err)
+
+	commit, err := testRepository.CommitObject(hash)
+	checkErr(t, "getting commit", err)
+
+	contains, err := commitContainsProjectFiles(commit, "bar")
+	checkErr(t, "checking project files", err)
+
+	assert.False(contains, "commit does not contain project files")
+}
+
+func TestParser_PathFilter_Match(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		include []string
+		exclude []string
+		name    string
+		want    bool
+	}
+
+	matrix := []test{
+		{exclude: []string{"docs/"}, name: "docs/index.md", want: false},
+		{exclude: []string{"docs/"}, name: "src/docs/index.md", want: true},
+		{exclude: []string{"docs/**"}, name: "docs/usage/index.md", want: false},
+		{exclude: []string{".github/"}, name: ".github/workflows/ci.yml", want: false},
+		{exclude: []string{"*.md"}, name: "README.md", want: false},
+		{exclude: []string{"*.md"}, name: "internal/README.md", want: false},
+		{exclude: []string{"*.md"}, name: "main.go", want: true},
+		{exclude: []string{"cmd/*.go"}, name: "cmd/sub/main.go", want: true},
+		{exclude: []string{"**/testdata/**"}, name: "internal/parser/testdata/a.json", want: false},
+		{exclude: []string{"file?.txt"}, name: "file1.txt", want: false},
+		{include: []string{"cmd/", "internal/"}, name: "cmd/root.go", want: true},
+		{include: []string{"cmd/", "internal/"}, name: "docs/index.md", want: false},
+		{include: []string{"internal/"}, exclude: []string{"*_test.go"}, name: "internal/parser/parser_test.go", want: false},
+		{include: []string{"internal/"}, exclude: []string{"*_test.go"}, name: "internal/parser/parser.go", want: true},
+	}
+
+	for _, tc := range matrix {
+		filter := newPathFilter(tc.include, tc.exclude)
+		assert.Equal(tc.want, filter.match(tc.name), "include: %v, exclude: %v, path: %s", tc.include, tc.exclude, tc.name)
+	}
+}
+
+func TestParser_PathFilter_Empty(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Nil(newPathFilter(nil, nil), "filter should be nil")
+}
+
+func TestParser_ComputeNewSemver_ExcludedPaths(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.AddTag("v1.0.0", head.Hash())
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./docs/index.md")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommitWithSpecificFile("feat", "./.github/workflows/ci.yml")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.TagPrefixFlag = "v"
+	th.Ctx.ExcludePathsFlag = []string{"docs/", ".github/"}
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.False(output.NewRelease, "commits only changing excluded paths should not trigger a release")
+	assert.Equal("1.0.0", output.Semver.String(), "version should be equal")
+
+	assert.Len(output.Report, 2, "both commits should be reported")
+	for _, entry := range output.Report {
+		assert.Equal(ReasonFilteredPaths, entry.Reason, "commit should be filtered")
+	}
+
+	_, err = testRepository.AddCommitWithSpecificFile("fix", "./main.go")
+	checkErr(t, "adding commit", err)
+
+	output, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.True(output.NewRelease, "commit changing other paths should trigger a release")
+	assert.Equal("1.0.1", output.Semver.String(), "version should be equal")
+}
+
+func TestParser_ParseCommit_Corpus(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		file        string
+		encoding    string
+		parsed      bool
+		commitType  string
+		scope       string
+		description string
+		breaking    bool
+		releaseAs   string
+	}
+
+	matrix := []test{
+		{file: "latin1.txt", encoding: "ISO-8859-1", parsed: true, commitType: "feat", scope: "api", description: "ajout de la génération des clés"},
+		{file: "cp1252.txt", encoding: "windows-1252", parsed: true, commitType: "fix", description: "handle “smart quotes” – and dashes"},
+		{file: "undeclared-latin1.txt", parsed: true, commitType: "fix", scope: "cache", description: "réparer l’invalidation du cache"},
+		{file: "crlf.txt", parsed: true, commitType: "feat", description: "drop the legacy API", breaking: true, releaseAs: "2.0.0"},
+		{file: "cr.txt", parsed: true, commitType: "fix", description: "support old line endings", releaseAs: "1.2.4"},
+		{file: "multiline-subject.txt", parsed: true, commitType: "feat", scope: "ui", description: "add a subject long enough to be wrapped on a second line"},
+		{file: "crlf-multiline-subject.txt", parsed: true, commitType: "perf", scope: "db", description: "speed up the queries of the report page"},
+		{file: "utf8-non-ascii.txt", encoding: "x-unknown", parsed: true, commitType: "docs", description: "Élaborer la documentation de l’API"},
+		{file: "non-conventional-latin1.txt", encoding: "latin1", parsed: false},
+	}
+
+	parser := New(NewTestHelper(t).Ctx)
+	current := &semver.Version{Major: 1, Minor: 2, Patch: 3}
+
+	for _, tc := range matrix {
+		raw, err := os.ReadFile(filepath.Join("testdata", "messages", tc.file))
+		if !assert.NoError(err, "reading %s", tc.file) {
+			continue
+		}
+
+		c := &object.Commit{Message: string(raw), Encoding: object.MessageEncoding(tc.encoding)}
+
+		parsedCommits := parser.parseCommit(c)
+		if !tc.parsed {
+			assert.Empty(parsedCommits, "file: %s", tc.file)
+			continue
+		}
+
+		if !assert.Len(parsedCommits, 1, "file: %s", tc.file) {
+			continue
+		}
+
+		assert.Equal(tc.commitType, parsedCommits[0].Type, "file: %s", tc.file)
+		assert.Equal(tc.scope, parsedCommits[0].Scope, "file: %s", tc.file)
+		assert.Equal(tc.description, parsedCommits[0].Description, "file: %s", tc.file)
+		assert.Equal(tc.breaking, parsedCommits[0].Breaking, "file: %s", tc.file)
+		assert.Equal(string(raw), c.Message, "the commit message should not be modified, file: %s", tc.file)
+
+		version, err := parser.releaseAs(c, current, monorepo.Project{}, nil)
+		assert.NoError(err, "file: %s", tc.file)
+
+		if tc.releaseAs == "" {
+			assert.Nil(version, "file: %s", tc.file)
+		} else if assert.NotNil(version, "file: %s", tc.file) {
+			assert.Equal(tc.releaseAs, version.String(), "file: %s", tc.file)
+		}
+	}
+}
+
+func TestParser_CommitMessage(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		message  string
+		encoding string
+		want     string
+	}
+
+	matrix := []test{
+		{message: "fix: foo\r\n\r\nbar\r\n", want: "fix: foo\n\nbar\n"},
+		{message: "fix: foo\rbar", want: "fix: foo\nbar"},
+		{message: "fix: caf\xe9", encoding: "ISO-8859-1", want: "fix: café"},
+		{message: "fix: caf\xe9", want: "fix: café"},
+		{message: "fix: café", encoding: "UTF-8", want: "fix: café"},
+		{message: "fix: café", encoding: "x-unknown", want: "fix: café"},
+		{message: "fix: \x93quoted\x94", encoding: "cp1252", want: "fix: “quoted”"},
+		{message: "fix: caf\xe9", encoding: "x-unknown", want: "fix: café"},
+	}
+
+	for _, tc := range matrix {
+		got := commitMessage(&object.Commit{Message: tc.message, Encoding: object.MessageEncoding(tc.encoding)})
+		assert.Equal(tc.want, got, "message: %q, encoding: %q", tc.message, tc.encoding)
+	}
+
+	assert.Equal("feat: foo bar", subject("\nfeat: foo\n  bar\n\nbody"), "subject lines should be joined")
+}
+
+func TestParser_HasSkipMarker(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		message string
+		want    bool
+	}
+
+	matrix := []test{
+		{message: "fix: typo [skip release]", want: true},
+		{message: "fix: typo [No Release]", want: true},
+		{message: "fix: typo\n\nSome body.\n\nReviewed-by: Z\n[skip release]", want: true},
+		{message: "fix: typo\n\n[skip release] is mentioned in the body.\n\nReviewed-by: Z", want: false},
+		{message: "fix: typo", want: false},
+		{message: "fix: typo [skip ci]", want: false},
+	}
+
+	for _, tc := range matrix {
+		assert.Equal(tc.want, hasSkipMarker(tc.message, DefaultSkipReleaseMarkers), "message: %q", tc.message)
+	}
+
+	assert.False(hasSkipMarker("fix: typo [skip release]", nil), "no marker should match without markers")
+}
+
+func TestParser_MatchesAuthor(t *testing.T) {
+	assert := assertion.New(t)
+
+	author := object.Signature{Name: "dependabot[bot]", Email: "49699333+dependabot[bot]@users.noreply.github.com"}
+
+	assert.True(matchesAuthor("dependabot[bot]", author))
+	assert.True(matchesAuthor("Dependabot[Bot]", author), "patterns should be case-insensitive")
+	assert.True(matchesAuthor("*@users.noreply.github.com", author))
+	assert.False(matchesAuthor("dependabot", author), "patterns should match the whole name or email")
+	assert.False(matchesAuthor("d[a-z]*", author), "brackets should be literal")
+	assert.False(matchesAuthor("", author))
+}
+
+func TestParser_ComputeNewSemver_AuthorFilters(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
 
-	err = testRepository.AddTag(wantTag, head.Hash())
-	checkErr(t, fmt.Sprintf("creating tag %q", wantTag), err)
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
 
-	th := NewTestHelper(t)
-	th.Ctx.Projects = []monorepo.Project{
-		{Name: "foo", Path: "foo"},
-		{Name: "bar", Path: "bar"},
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	type test struct {
+		ignore     []string
+		only       []string
+		newRelease bool
+	}
+
+	// Test commits are authored by "Go Semver Release <go-semver@release.ci>"
+	tests := []test{
+		{ignore: []string{"renovate[bot]"}, newRelease: true},
+		{ignore: []string{"*@release.ci"}, newRelease: false},
+		{only: []string{"go semver release"}, newRelease: true},
+		{only: []string{"*@example.com"}, newRelease: false},
+		{ignore: []string{"Go Semver Release"}, only: []string{"go-semver@release.ci"}, newRelease: false},
+	}
+
+	for _, tc := range tests {
+		th := NewTestHelper(t)
+		th.Ctx.IgnoreAuthorsFlag = tc.ignore
+		th.Ctx.OnlyAuthorsFlag = tc.only
+		parser := New(th.Ctx)
+
+		output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+		checkErr(t, "computing new semver", err)
+
+		assert.Equal(tc.newRelease, output.NewRelease, "ignore: %v, only: %v", tc.ignore, tc.only)
+
+		if !tc.newRelease {
+			assert.Equal(ReasonAuthorFilter, output.Report[len(output.Report)-1].Reason)
+		}
+	}
+}
+
+func TestParser_ComputeNewSemver_SignaturePolicy(t *testing.T) {
+	assert := assertion.New(t)
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, "creating openpgp entity", err)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	worktree, err := testRepository.Worktree()
+	checkErr(t, "fetching worktree", err)
+
+	signature := &object.Signature{Name: "John Doe", Email: "john.doe@example.com", When: testRepository.When()}
+
+	_, err = worktree.Commit("feat: add foo", &git.CommitOptions{Author: signature, Committer: signature, SignKey: entity, AllowEmptyCommits: true})
+	checkErr(t, "adding signed commit", err)
+
+	// Commits that do not trigger a release are not verified
+	_, err = testRepository.AddCommit("chore")
+	checkErr(t, "adding commit", err)
+
+	newParser := func(policy string) *Parser {
+		th := NewTestHelper(t)
+		th.Ctx.SignaturePolicyFlag = policy
+		th.Ctx.CommitVerifier = &commit.Verifier{KeyRing: openpgp.EntityList{entity}}
+
+		return New(th.Ctx)
 	}
+
+	output, err := newParser(SignaturePolicyFail).ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, branch.Branch{Name: "master"})
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.1.0", output.Semver.String(), "signed commit should have been counted")
+
+	unsignedHash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	_, err = newParser(SignaturePolicyFail).ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, branch.Branch{Name: "master"})
+	assert.ErrorIs(err, ErrUnverifiedCommit)
+	assert.ErrorIs(err, commit.ErrUnsignedCommit)
+	assert.ErrorContains(err, unsignedHash.String())
+
+	output, err = newParser(SignaturePolicyExclude).ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, branch.Branch{Name: "master"})
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("0.1.0", output.Semver.String(), "unsigned commit should have been excluded")
+	assert.Equal(CommitReport{Hash: unsignedHash, Ignored: true, Reason: ReasonUnverified}, output.Report[len(output.Report)-1])
+}
+
+func TestParser_ComputeNewSemver_SkipReleaseMarker(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommitWithMessage("feat!: breaking change [skip release]")
+	checkErr(t, "adding commit", err)
+
+	_, err = testRepository.AddCommitWithMessage("feat: new feature\n\nRelease-As: 5.0.0\n[no release]")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.SkipReleaseMarkersFlag = DefaultSkipReleaseMarkers
 	parser := New(th.Ctx)
 
-	gotTag, err := parser.FetchLatestSemverTag(testRepository.Repository, th.Ctx.Projects[0])
-	checkErr(t, "fetching latest semver tag", err)
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
 
-	assert.Equal(gotTag.Name, wantTag, "should have found tag")
+	assert.Equal("0.0.1", output.Semver.String(), "version should be equal")
+	assert.Len(output.Report, 4, "every commit should be reported")
+	assert.Equal(ReasonSkipMarker, output.Report[2].Reason, "commit should be skipped")
+	assert.Equal(ReasonSkipMarker, output.Report[3].Reason, "commit should be skipped")
 }
 
-func TestMonorepoParser_CommitContainsProjectFiles_True(t *testing.T) {
+func TestParser_ComputeNewSemver_SkipReleaseFile(t *testing.T) {
 	assert := assertion.New(t)
 
 	testRepository, err := gittest.NewRepository()
@@ -467,19 +2229,76 @@ func TestMonorepoParser_CommitContainsProjectFiles_True(t *testing.T) {
 		_ = testRepository.Remove()
 	})
 
-	hash, err := testRepository.AddCommitWithSpecificFile("fix", "./foo/foo.txt")
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.AddTag("v1.0.0", head.Hash())
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommitWithSpecificFile("feat", SkipReleaseFile)
 	checkErr(t, "adding commit", err)
 
-	commit, err := testRepository.CommitObject(hash)
-	checkErr(t, "getting commit", err)
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
 
-	contains, err := commitContainsProjectFiles(commit, "foo")
-	checkErr(t, "checking project files", err)
+	th := NewTestHelper(t)
+	th.Ctx.TagPrefixFlag = "v"
+	parser := New(th.Ctx)
 
-	assert.True(contains, "commit contains project files")
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.True(output.Skipped, "release should be skipped")
+	assert.False(output.NewRelease, "release should be skipped")
+	assert.Equal("1.0.0", output.Semver.String(), "version should be equal")
 }
 
-func TestMonorepoParser_CommitContainsProjectFiles_False(t *testing.T) {
+func TestParser_ComputeNewSemver_MinorOnBreakingInDev(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		tag  string
+		want string
+		bump string
+	}
+
+	matrix := []test{
+		{tag: "", want: "0.1.0", bump: "minor"},
+		{tag: "v0.4.2", want: "0.5.0", bump: "minor"},
+		{tag: "v1.4.2", want: "2.0.0", bump: "major"},
+	}
+
+	for _, tc := range matrix {
+		testRepository, err := gittest.NewRepository()
+		checkErr(t, "creating repository", err)
+
+		if tc.tag != "" {
+			head, err := testRepository.Head()
+			checkErr(t, "fetching head", err)
+
+			err = testRepository.AddTag(tc.tag, head.Hash())
+			checkErr(t, "adding tag", err)
+		}
+
+		_, err = testRepository.AddCommit("feat!")
+		checkErr(t, "adding commit", err)
+
+		th := NewTestHelper(t)
+		th.Ctx.TagPrefixFlag = "v"
+		th.Ctx.MajorOnBreakingInDevFlag = false
+		parser := New(th.Ctx)
+
+		output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+		checkErr(t, "computing new semver", err)
+
+		assert.Equal(tc.want, output.Semver.String(), "version should be equal")
+		assert.Equal(tc.bump, output.Report[len(output.Report)-1].Bump, "breaking change bump should be reported")
+
+		_ = testRepository.Remove()
+	}
+}
+
+func TestParser_ComputeNewSemver_InitialVersion(t *testing.T) {
 	assert := assertion.New(t)
 
 	testRepository, err := gittest.NewRepository()
@@ -489,16 +2308,103 @@ func TestMonorepoParser_CommitContainsProjectFiles_False(t *testing.T) {
 		_ = testRepository.Remove()
 	})
 
-	hash, err := testRepository.AddCommitWithSpecificFile("fix", "./foo/foo.txt")
+	th := NewTestHelper(t)
+	th.Ctx.TagPrefixFlag = "v"
+	th.Ctx.InitialVersion = &semver.Version{Major: 1}
+	parser := New(th.Ctx)
+
+	output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.False(output.NewRelease, "there should be no release without release commits")
+	assert.Equal("0.0.0", output.Semver.String(), "version should be equal")
+
+	_, err = testRepository.AddCommit("fix")
 	checkErr(t, "adding commit", err)
 
-	commit, err := testRepository.CommitObject(hash)
-	checkErr(t, "getting commit", err)
+	output, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
 
-	contains, err := commitContainsProjectFiles(commit, "bar")
-	checkErr(t, "checking project files", err)
+	assert.True(output.NewRelease, "first release should be found")
+	assert.Equal("1.0.0", output.Semver.String(), "first release should start at the initial version")
+	assert.Equal("1.0.0", th.Ctx.InitialVersion.String(), "initial version should not be altered")
 
-	assert.False(contains, "commit does not contain project files")
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.AddTag("v1.0.0", head.Hash())
+	checkErr(t, "adding tag", err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	output, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, th.Ctx.Branches[0])
+	checkErr(t, "computing new semver", err)
+
+	assert.Equal("1.0.1", output.Semver.String(), "following releases should be computed from the commit history")
+}
+
+func TestParser_ComputeNewSemver_MaintenanceBranch(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.AddTag("v1.2.0", head.Hash())
+	checkErr(t, "adding tag", err)
+
+	err = testRepository.CheckoutBranch("main")
+	checkErr(t, "checking out branch", err)
+
+	hash, err := testRepository.AddCommit("feat!")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("v2.0.0", hash)
+	checkErr(t, "adding tag", err)
+
+	err = testRepository.Checkout("master")
+	checkErr(t, "checking out branch", err)
+
+	_, err = testRepository.AddCommit("feat!")
+	checkErr(t, "adding commit", err)
+
+	type test struct {
+		versionRange *branch.Range
+		want         string
+	}
+
+	matrix := []test{
+		{versionRange: &branch.Range{Major: 1, Minor: -1}, want: "1.3.0"},
+		{versionRange: &branch.Range{Major: 1, Minor: 2}, want: "1.2.1"},
+	}
+
+	th := NewTestHelper(t)
+	th.Ctx.TagPrefixFlag = "v"
+	parser := New(th.Ctx)
+
+	for _, tc := range matrix {
+		output, err := parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, branch.Branch{Name: tc.versionRange.String(), Range: tc.versionRange})
+		checkErr(t, "computing new semver", err)
+
+		assert.Equal(tc.want, output.Semver.String(), "version should stay in range %s", tc.versionRange)
+		assert.Equal("1.2.0", output.PreviousSemver.String(), "previous version should be the latest in range %s", tc.versionRange)
+	}
+
+	_, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, branch.Branch{Name: "3.x", Range: &branch.Range{Major: 3, Minor: -1}})
+	assert.ErrorIs(err, ErrNoReleaseInRange, "there is no release in range")
+
+	_, err = testRepository.AddCommitWithMessage("fix: backport\n\nRelease-As: 3.0.0")
+	checkErr(t, "adding commit", err)
+
+	_, err = parser.ComputeNewSemver(context.Background(), testRepository.Repository, monorepo.Project{}, branch.Branch{Name: "1.x", Range: &branch.Range{Major: 1, Minor: -1}})
+	assert.ErrorIs(err, ErrInvalidReleaseAs, "Release-As version is out of range")
 }
 
 func TestParser_Run_Monorepo(t *testing.T) {
@@ -617,6 +2523,113 @@ func TestParser_Run_MonorepoWithPreexistingTags(t *testing.T) {
 	assert.Contains(gotSemver, "1.1.2")
 }
 
+func TestMonorepoParser_Run_Dependencies(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	webCommit, err := testRepository.AddCommitWithSpecificFile("feat", "./web/index.html")
+	checkErr(t, "adding commit", err)
+
+	err = testRepository.AddTag("web-1.0.0", webCommit)
+	checkErr(t, "adding web tag", err)
+
+	sharedCommit, err := testRepository.AddCommitWithSpecificFile("feat", "./shared/lib.go")
+	checkErr(t, "adding commit", err)
+
+	th := NewTestHelper(t)
+	th.Ctx.Projects = []monorepo.Project{
+		{Name: "app", Path: "app", DependsOn: []string{"web"}},
+		{Name: "web", Path: "web", DependsOn: []string{"shared"}},
+		{Name: "shared", Path: "shared"},
+		{Name: "docs", Path: "docs"},
+	}
+	parser := New(th.Ctx)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	output, err := parser.Run(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Len(output, 4, "parser run output should contain four elements")
+
+	type result struct {
+		version    string
+		newRelease bool
+		bumpedBy   []string
+	}
+
+	got := make(map[string]result)
+	for _, o := range output {
+		got[o.Project.Name] = result{version: o.Semver.String(), newRelease: o.NewRelease, bumpedBy: o.BumpedBy}
+
+		if o.NewRelease {
+			assert.Equal(sharedCommit, o.CommitHash, "project %q release should point to the shared release commit", o.Project.Name)
+		}
+	}
+
+	want := map[string]result{
+		"shared": {version: "0.1.0", newRelease: true},
+		"web":    {version: "1.0.1", newRelease: true, bumpedBy: []string{"shared"}},
+		"app":    {version: "0.0.1", newRelease: true, bumpedBy: []string{"web"}},
+		"docs":   {version: "0.0.0", newRelease: false},
+	}
+
+	assert.Equal(want, got, "dependents should have been released along their dependencies")
+}
+
+func TestMonorepoParser_Run_ProjectOverrides(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommitWithSpecificFile("perf", "./lib/index.js")
+	checkErr(t, "adding commit", err)
+	_, err = testRepository.AddCommitWithSpecificFile("perf", "./svc/main.go")
+	checkErr(t, "adding commit", err)
+
+	libRules := rule.Rules{Map: map[string]string{"feat": "minor", "perf": "minor", "fix": "patch"}}
+
+	th := NewTestHelper(t)
+	th.Ctx.Branches = []branch.Branch{{Name: "master", Prerelease: true, PrereleaseID: "rc"}}
+	th.Ctx.Projects = []monorepo.Project{
+		{Name: "lib", Path: "lib", Rules: &libRules, PrereleaseID: "beta", InitialVersion: &semver.Version{Major: 1}},
+		{Name: "svc", Path: "svc"},
+	}
+	parser := New(th.Ctx)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	output, err := parser.Run(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "computing projects new semver", err)
+
+	assert.Len(output, 2, "parser run output should contain two elements")
+
+	got := make(map[string]string)
+	for _, o := range output {
+		got[o.Project.Name] = o.Semver.String()
+	}
+
+	want := map[string]string{
+		"lib": "1.0.0-beta.1",
+		"svc": "0.0.1-rc.1",
+	}
+
+	assert.Equal(want, got, "projects should have been released according to their own settings")
+}
+
 func TestParser_Run_InvalidBranch(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -636,49 +2649,58 @@ func TestParser_Run_InvalidBranch(t *testing.T) {
 	assert.ErrorIs(err, plumbing.ErrReferenceNotFound, "parser run should have failed since branch does not exist")
 }
 
-func checkErr(t *testing.T, msg string, err error) {
+func checkErr(t testing.TB, msg string, err error) {
 	t.Helper()
 	if err != nil {
 		t.Fatalf("%s: %s", msg, err.Error())
 	}
 }
 
-/*
-func BenchmarkParser_ComputeNewSemver(b *testing.B) {
+func BenchmarkParser_Run(b *testing.B) {
+	sizes := []gittest.GenerateOptions{
+		{Commits: 1000, Branches: 10, Merges: 5, Tags: 10},
+		{Commits: 10000, Branches: 50, Merges: 25, Tags: 100},
+	}
 
-		parser := New(logger, rules)
-		testRepository, err := gittest.NewRepository()
-		if err != nil {
-			b.Fatalf("creating test repository: %s", err)
-		}
+	for _, opts := range sizes {
+		b.Run(fmt.Sprintf("%d-commits", opts.Commits), func(b *testing.B) {
+			testRepository, err := gittest.Generate(opts)
+			checkErr(b, "generating repository", err)
 
-		b.Cleanup(func() {
-			os.RemoveAll(testRepository.Path)
-		})
+			b.Cleanup(func() {
+				_ = testRepository.Remove()
+			})
 
-		commitTypes := []string{"feat", "fix", "chore"}
+			clonedTestRepository, err := testRepository.Clone()
+			checkErr(b, "cloning test repository", err)
 
-		for i := 1; i <= 10000; i++ {
-			commitType := commitTypes[rand.Intn(len(commitTypes))]
-			testRepository.AddCommit(commitType)
-		}
+			b.Cleanup(func() {
+				_ = clonedTestRepository.Remove()
+			})
 
-		b.ResetTimer()
-		for i := 0; i < b.N; i++ {
-			parser.ComputeNewSemver(testRepository.Repository, monorepo.Project{})
-		}
+			parser := New(NewTestHelper(b).Ctx)
+
+			b.ResetTimer()
+
+			for range b.N {
+				_, err = parser.Run(context.Background(), clonedTestRepository.Repository)
+				checkErr(b, "running parser", err)
+			}
+		})
 	}
-*/
+}
+
 type TestHelper struct {
 	Ctx *appcontext.AppContext
 }
 
-func NewTestHelper(t *testing.T) *TestHelper {
+func NewTestHelper(t testing.TB) *TestHelper {
 	ctx := &appcontext.AppContext{
-		Rules:          rule.Default,
-		RemoteNameFlag: "origin",
-		Branches:       []branch.Branch{{Name: "master"}},
-		Logger:         zerolog.New(io.Discard),
+		Rules:                    rule.Default,
+		RemoteNameFlag:           "origin",
+		MajorOnBreakingInDevFlag: true,
+		Branches:                 []branch.Branch{{Name: "master"}},
+		Logger:                   zerolog.New(io.Discard),
 	}
 
 	return &TestHelper{
@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// pathFilter tells if the files changed by a commit are taken into account when computing a release.
+type pathFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+func newPathFilter(include, exclude []string) *pathFilter {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil
+	}
+
+	f := &pathFilter{}
+
+	for _, pattern := range include {
+		f.include = append(f.include, globRegexp(pattern))
+	}
+
+	for _, pattern := range exclude {
+		f.exclude = append(f.exclude, globRegexp(pattern))
+	}
+
+	return f
+}
+
+// match returns true if the given file path is included and not excluded. If there is no include pattern, every path
+// is included.
+func (f *pathFilter) match(name string) bool {
+	included := len(f.include) == 0
+
+	for _, re := range f.include {
+		if re.MatchString(name) {
+			included = true
+			break
+		}
+	}
+
+	if !included {
+		return false
+	}
+
+	for _, re := range f.exclude {
+		if re.MatchString(name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchCommit returns true if at least one of the files changed by the given commit matches the filter.
+func (f *pathFilter) matchCommit(commit *object.Commit) (bool, error) {
+	names, err := changedFiles(commit)
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range names {
+		if f.match(name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// globRegexp converts a glob pattern to a regular expression matching slash separated file paths. "*" matches any
+// sequence of characters but "/", "?" matches any character but "/" and "**" matches any sequence of directories. A
+// pattern ending with "/" matches every file of the directory and a pattern without "/" matches file names at any
+// depth (e.g., "*.md").
+func globRegexp(pattern string) *regexp.Regexp {
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+
+	pattern = strings.TrimPrefix(path.Clean("/"+pattern), "/")
+
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String())
+}
+
+// changedFiles returns the paths of the files changed by a commit compared to its first parent, or every file of the
+// commit if it has no parent.
+func changedFiles(commit *object.Commit) ([]string, error) {
+	commitTree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("getting commit tree: %w", err)
+	}
+
+	var parentTree *object.Tree
+	if parent, err := commit.Parent(0); err == nil {
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("getting parent tree: %w", err)
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, commitTree)
+	if err != nil {
+		return nil, fmt.Errorf("getting diff tree: %w", err)
+	}
+
+	names := make([]string, 0, len(changes))
+
+	for _, change := range changes {
+		// Deleted files only have a "from" name
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// issueKeywordRegex matches the references to issues and tickets introduced by a closing keyword in a commit body,
+// either an issue number (e.g., "Closes #123") or a ticket key (e.g., "Fixes JIRA-456"). A keyword may introduce a
+// comma-separated list of references (e.g., "Fixes #1, #2").
+var issueKeywordRegex = regexp.MustCompile(`\b(?i:close[sd]?|fix(?:e[sd])?|resolve[sd]?|refs?)\b:?[ \t]+((?:#\d+|[A-Z][A-Z0-9]+-\d+)(?:[ \t]*,[ \t]*(?:#\d+|[A-Z][A-Z0-9]+-\d+))*)`)
+
+// issueReferenceRegex matches a single reference of the lists matched by issueKeywordRegex.
+var issueReferenceRegex = regexp.MustCompile(`#\d+|[A-Z][A-Z0-9]+-\d+`)
+
+// parseReferences returns the references to issues and tickets found in the body of the given commit message, in order
+// of appearance and without duplicates. If a pattern is given, it replaces the default closing keywords and every one
+// of its matches is a reference: its first group if it has one, its whole match otherwise.
+func parseReferences(message string, pattern *regexp.Regexp) []string {
+	_, body, _ := strings.Cut(message, "\n")
+
+	var references []string
+
+	if pattern != nil {
+		for _, match := range pattern.FindAllStringSubmatch(body, -1) {
+			reference := match[0]
+			if len(match) > 1 {
+				reference = match[1]
+			}
+
+			references = append(references, strings.TrimSpace(reference))
+		}
+	} else {
+		for _, match := range issueKeywordRegex.FindAllStringSubmatch(body, -1) {
+			references = append(references, issueReferenceRegex.FindAllString(match[1], -1)...)
+		}
+	}
+
+	references = slices.DeleteFunc(references, func(reference string) bool {
+		return reference == ""
+	})
+
+	return uniqueReferences(references)
+}
+
+// uniqueReferences returns the given references without duplicates, keeping the first occurrence of each.
+func uniqueReferences(references []string) []string {
+	var unique []string
+
+	for _, reference := range references {
+		if !slices.Contains(unique, reference) {
+			unique = append(unique, reference)
+		}
+	}
+
+	return unique
+}
+
+// References returns the references to issues and tickets of the release commits, in order of appearance and without
+// duplicates.
+func (o ComputeNewSemverOutput) References() []string {
+	var references []string
+
+	for _, c := range o.Commits {
+		references = append(references, c.References...)
+	}
+
+	return uniqueReferences(references)
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/s0ders/go-semver-release/v6/internal/commit"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+)
+
+// Signature policies, telling what to do with the commits triggering a release whose signature cannot be verified.
+const (
+	SignaturePolicyFail    = "fail"
+	SignaturePolicyExclude = "exclude"
+)
+
+var ErrUnverifiedCommit = errors.New("commit signature cannot be verified")
+
+// verifySignature checks, if a signature policy is configured, the signature of the given commit when one of the
+// given parsed commits would trigger a release. It returns true if the commit is excluded by the policy, or an error if
+// the policy requires to fail.
+func (p *Parser) verifySignature(c *object.Commit, parsedCommits []Commit, project monorepo.Project) (bool, error) {
+	if p.ctx.CommitVerifier == nil || !p.triggersRelease(parsedCommits, project) {
+		return false, nil
+	}
+
+	err := p.ctx.CommitVerifier.Verify(c)
+	if err == nil {
+		return false, nil
+	}
+
+	if !errors.Is(err, commit.ErrUnsignedCommit) && !errors.Is(err, commit.ErrInvalidSignature) {
+		return false, fmt.Errorf("verifying commit signature: %w", err)
+	}
+
+	if p.ctx.SignaturePolicyFlag == SignaturePolicyExclude {
+		p.ctx.Logger.Debug().Str("commit", c.Hash.String()).Str("error", err.Error()).Msg("commit skipped by signature policy")
+		return true, nil
+	}
+
+	return false, fmt.Errorf("%w: %s: %w", ErrUnverifiedCommit, c.Hash, err)
+}
+
+// triggersRelease returns true if one of the given parsed commits is a breaking change or has a release rule bumping
+// the version.
+func (p *Parser) triggersRelease(parsedCommits []Commit, project monorepo.Project) bool {
+	for _, parsedCommit := range parsedCommits {
+		if releaseType, ok := p.rules(project).Map[parsedCommit.Type]; parsedCommit.Breaking || (ok && releaseType != rule.None) {
+			return true
+		}
+	}
+
+	return false
+}
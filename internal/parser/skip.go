@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// SkipReleaseFile is the name of the file that, when committed at the root of a repository, suppresses every release
+// of the branches where it is found.
+const SkipReleaseFile = ".skip-release"
+
+// DefaultSkipReleaseMarkers are the markers excluding a commit from the version computation when found in its subject
+// or footers.
+var DefaultSkipReleaseMarkers = []string{"[skip release]", "[no release]"}
+
+// hasSkipMarker returns true if the subject or the footers, i.e. the last paragraph, of a commit message contain one
+// of the given markers. Markers are case-insensitive.
+func hasSkipMarker(message string, markers []string) bool {
+	if len(markers) == 0 {
+		return false
+	}
+
+	message = strings.ToLower(strings.TrimSpace(message))
+
+	subject, _, _ := strings.Cut(message, "\n")
+
+	var footers string
+	if i := strings.LastIndex(message, "\n\n"); i != -1 {
+		footers = message[i:]
+	}
+
+	for _, marker := range markers {
+		marker = strings.ToLower(strings.TrimSpace(marker))
+		if marker == "" {
+			continue
+		}
+
+		if strings.Contains(subject, marker) || strings.Contains(footers, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasSkipReleaseFile returns true if the tree of the given commit contains the SkipReleaseFile at its root.
+func hasSkipReleaseFile(commit *object.Commit) (bool, error) {
+	_, err := commit.File(SkipReleaseFile)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("looking for %s file: %w", SkipReleaseFile, err)
+	}
+
+	return true, nil
+}
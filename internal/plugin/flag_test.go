@@ -0,0 +1,46 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginsFlag_String(t *testing.T) {
+	assert := assert.New(t)
+
+	pluginsConfiguration := []map[string]any{{"path": "./foo"}, {"path": "bar", "phases": []string{"publish"}}}
+	pluginsFlag := Flag(pluginsConfiguration)
+
+	var emptyFlag Flag
+
+	type test struct {
+		got  *Flag
+		want string
+	}
+
+	tests := []test{
+		{got: &pluginsFlag, want: "[{\"path\":\"./foo\"},{\"path\":\"bar\",\"phases\":[\"publish\"]}]"},
+		{got: &emptyFlag, want: "[]"},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(tc.want, tc.got.String())
+	}
+}
+
+func TestPluginsFlag_Set(t *testing.T) {
+	var flag Flag
+
+	err := flag.Set("[{\"path\": \"./foo\"}]")
+	assert.NoError(t, err, "should not have errored")
+
+	err = flag.Set("{\"path\": \"./foo\"}")
+	assert.Error(t, err, "should have errored, invalid JSON string")
+}
+
+func TestPluginsFlag_Type(t *testing.T) {
+	var f Flag
+
+	assert.Equal(t, FlagType, f.Type())
+}
@@ -0,0 +1,285 @@
+// Package plugin runs the external executables extending the release process.
+//
+// A plugin is an executable run once per phase of every release. It is given a JSON Request on its standard input and
+// answers with a JSON Response on its standard output, its standard error being used for logs. A plugin fails if it
+// exits with a non-zero code or if its response has an error.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+// ProtocolVersion is the version of the protocol spoken with the plugins, sent in every request.
+const ProtocolVersion = 1
+
+// Phases of a release, in order.
+const (
+	// Analyze is run once the next version is computed, plugins may override it.
+	Analyze = "analyze"
+	// Verify is run before the release is made, even in dry-run mode, the release is aborted if a plugin fails.
+	Verify = "verify"
+	// Prepare is run before the release is tagged, for instance to update files.
+	Prepare = "prepare"
+	// Publish is run once the release tag is pushed, for instance to upload artifacts.
+	Publish = "publish"
+	// Notify is run once the release is published.
+	Notify = "notify"
+)
+
+// Phases lists the phases of a release, in order.
+var Phases = []string{Analyze, Verify, Prepare, Publish, Notify}
+
+var (
+	ErrNoPath         = errors.New("plugin has no path")
+	ErrInvalidPhase   = errors.New("invalid plugin phase")
+	ErrPluginFailed   = errors.New("plugin failed")
+	ErrInvalidVersion = errors.New("invalid plugin version")
+)
+
+// Config is the configuration of a plugin.
+type Config struct {
+	// Path is the path of the executable of the plugin, or its name if it is in the PATH.
+	Path string   `json:"path"`
+	Args []string `json:"args,omitempty"`
+	// Phases lists the phases for which the plugin is run, all of them if empty.
+	Phases []string `json:"phases,omitempty"`
+	// Config is the configuration of the plugin itself, sent as is in every request.
+	Config map[string]any `json:"config,omitempty"`
+}
+
+func (c Config) runs(phase string) bool {
+	return len(c.Phases) == 0 || slices.Contains(c.Phases, phase)
+}
+
+// Unmarshall takes a raw Viper configuration and returns the configurations of the plugins it lists.
+func Unmarshall(input []map[string]any) ([]Config, error) {
+	content, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("encoding plugins configuration: %w", err)
+	}
+
+	var configs []Config
+
+	if err = json.Unmarshal(content, &configs); err != nil {
+		return nil, fmt.Errorf("decoding plugins configuration: %w", err)
+	}
+
+	for _, config := range configs {
+		if config.Path == "" {
+			return nil, ErrNoPath
+		}
+
+		for _, phase := range config.Phases {
+			if !slices.Contains(Phases, phase) {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidPhase, phase)
+			}
+		}
+	}
+
+	return configs, nil
+}
+
+// Commit is a commit of a release.
+type Commit struct {
+	Hash        string `json:"hash"`
+	Type        string `json:"type"`
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description"`
+	Breaking    bool   `json:"breaking,omitempty"`
+	// References lists the issues and tickets referenced in the body of the commit message (e.g., "#12").
+	References []string `json:"references,omitempty"`
+}
+
+// Release describes the release being made.
+type Release struct {
+	Repository string `json:"repository"`
+	Branch     string `json:"branch"`
+	Channel    string `json:"channel,omitempty"`
+	Project    string `json:"project,omitempty"`
+	// Version is the next version, or the current version if there is no new release.
+	Version         string `json:"version"`
+	PreviousVersion string `json:"previous_version,omitempty"`
+	// Tag is the name of the release tag, known from the verify phase.
+	Tag string `json:"tag,omitempty"`
+	// Commit is the hash of the released commit, replaced by the hash of the release commit, if one is made, from
+	// the publish phase.
+	Commit     string   `json:"commit,omitempty"`
+	NewRelease bool     `json:"new_release"`
+	Commits    []Commit `json:"commits"`
+	// Changelog is the Markdown changelog of the release, known from the verify phase.
+	Changelog string `json:"changelog,omitempty"`
+}
+
+// Request is the JSON document given to a plugin on its standard input.
+type Request struct {
+	Protocol int            `json:"protocol"`
+	Phase    string         `json:"phase"`
+	DryRun   bool           `json:"dry_run"`
+	Config   map[string]any `json:"config,omitempty"`
+	Release  Release        `json:"release"`
+}
+
+// Response is the JSON document written by a plugin on its standard output. An empty output is an empty response.
+type Response struct {
+	// Error fails the plugin if not empty.
+	Error string `json:"error,omitempty"`
+	// Version overrides the next version, only read in the analyze phase.
+	Version string `json:"version,omitempty"`
+}
+
+type Runner struct {
+	output  io.Writer
+	dir     string
+	plugins []Config
+	dryRun  bool
+}
+
+type OptionFunc func(r *Runner)
+
+// WithOutput writes the standard error of the plugins to the given writer instead of discarding it.
+func WithOutput(output io.Writer) OptionFunc {
+	return func(r *Runner) {
+		r.output = output
+	}
+}
+
+// WithDir runs the plugins in the given directory instead of the current one.
+func WithDir(dir string) OptionFunc {
+	return func(r *Runner) {
+		r.dir = dir
+	}
+}
+
+// WithDryRun tells the plugins that nothing is released.
+func WithDryRun(dryRun bool) OptionFunc {
+	return func(r *Runner) {
+		r.dryRun = dryRun
+	}
+}
+
+func NewRunner(plugins []Config, options ...OptionFunc) *Runner {
+	r := &Runner{
+		plugins: plugins,
+		output:  io.Discard,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// Analyze runs the analyze phase of the given release, the plugins being run in order and each one being given the
+// version returned by the previous one. It returns the version returned by the last plugin overriding the version,
+// nil if none did or if it is the version of the given release. This version must be greater than the previous version
+// of the release.
+func (r *Runner) Analyze(ctx context.Context, release Release) (*semver.Version, error) {
+	var version *semver.Version
+
+	computed := release.Version
+
+	for _, config := range r.plugins {
+		if !config.runs(Analyze) {
+			continue
+		}
+
+		response, err := r.run(ctx, config, Analyze, release)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.Version == "" {
+			continue
+		}
+
+		version, err = semver.NewFromString(response.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidVersion, config.Path, err)
+		}
+
+		release.Version = version.String()
+	}
+
+	if version == nil || version.String() == computed {
+		return nil, nil
+	}
+
+	if release.PreviousVersion == "" {
+		return version, nil
+	}
+
+	previous, err := semver.NewFromString(release.PreviousVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing previous version: %w", err)
+	}
+
+	if semver.Compare(version, previous) <= 0 {
+		return nil, fmt.Errorf("%w: %s is not greater than the previous version %s", ErrInvalidVersion, version, previous)
+	}
+
+	return version, nil
+}
+
+// Run runs, in order, the plugins of the given phase, stopping at the first one that fails.
+func (r *Runner) Run(ctx context.Context, phase string, release Release) error {
+	for _, config := range r.plugins {
+		if !config.runs(phase) {
+			continue
+		}
+
+		if _, err := r.run(ctx, config, phase, release); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) run(ctx context.Context, config Config, phase string, release Release) (Response, error) {
+	var response Response
+
+	request, err := json.Marshal(Request{
+		Protocol: ProtocolVersion,
+		Phase:    phase,
+		DryRun:   r.dryRun,
+		Config:   config.Config,
+		Release:  release,
+	})
+	if err != nil {
+		return response, fmt.Errorf("encoding plugin request: %w", err)
+	}
+
+	stdout := new(bytes.Buffer)
+
+	cmd := exec.CommandContext(ctx, config.Path, config.Args...)
+	cmd.Dir = r.dir
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = stdout
+	cmd.Stderr = r.output
+
+	if err = cmd.Run(); err != nil {
+		return response, fmt.Errorf("%w: %s %s: %w", ErrPluginFailed, config.Path, phase, err)
+	}
+
+	if len(bytes.TrimSpace(stdout.Bytes())) > 0 {
+		if err = json.Unmarshal(stdout.Bytes(), &response); err != nil {
+			return response, fmt.Errorf("%w: %s %s: decoding response: %w", ErrPluginFailed, config.Path, phase, err)
+		}
+	}
+
+	if response.Error != "" {
+		return response, fmt.Errorf("%w: %s %s: %s", ErrPluginFailed, config.Path, phase, response.Error)
+	}
+
+	return response, nil
+}
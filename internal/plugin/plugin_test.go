@@ -0,0 +1,165 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+const testPluginEnv = "GO_SEMVER_RELEASE_TEST_PLUGIN"
+
+// TestPlugin is not a test but the plugin run by the tests, which run the test binary. It records the phases it is
+// run for in the file given by the "record" configuration and answers with the "response" configuration, or exits
+// with code 1 if the "exit" configuration is set.
+func TestPlugin(t *testing.T) {
+	if os.Getenv(testPluginEnv) != "1" {
+		return
+	}
+
+	var request Request
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		os.Exit(2)
+	}
+
+	if record, ok := request.Config["record"].(string); ok {
+		file, _ := os.OpenFile(record, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		_, _ = fmt.Fprintf(file, "%s %d %s %t\n", request.Phase, request.Protocol, request.Release.Version, request.DryRun)
+		_ = file.Close()
+	}
+
+	if _, ok := request.Config["exit"]; ok {
+		_, _ = fmt.Fprintln(os.Stderr, "exiting")
+		os.Exit(1)
+	}
+
+	if response, ok := request.Config["response"].(string); ok {
+		_, _ = fmt.Print(response)
+	}
+
+	os.Exit(0)
+}
+
+// testPlugin returns the configuration of the test plugin.
+func testPlugin(t *testing.T, config map[string]any, phases ...string) Config {
+	t.Setenv(testPluginEnv, "1")
+
+	return Config{
+		Path:   os.Args[0],
+		Args:   []string{"-test.run=^TestPlugin$"},
+		Phases: phases,
+		Config: config,
+	}
+}
+
+func TestUnmarshall(t *testing.T) {
+	assert := assertion.New(t)
+
+	configs, err := Unmarshall([]map[string]any{
+		{"path": "./plugin", "args": []any{"--foo"}, "phases": []any{"verify"}, "config": map[string]any{"bar": true}},
+		{"path": "other-plugin"},
+	})
+	checkErr(t, "unmarshalling plugins", err)
+
+	want := []Config{
+		{Path: "./plugin", Args: []string{"--foo"}, Phases: []string{Verify}, Config: map[string]any{"bar": true}},
+		{Path: "other-plugin"},
+	}
+
+	assert.Equal(want, configs)
+
+	_, err = Unmarshall([]map[string]any{{"args": []any{"--foo"}}})
+	assert.ErrorIs(err, ErrNoPath)
+
+	_, err = Unmarshall([]map[string]any{{"path": "./plugin", "phases": []any{"release"}}})
+	assert.ErrorIs(err, ErrInvalidPhase)
+}
+
+func TestRunner_Run(t *testing.T) {
+	assert := assertion.New(t)
+
+	record := t.TempDir() + "/record"
+
+	runner := NewRunner([]Config{
+		testPlugin(t, map[string]any{"record": record}),
+		testPlugin(t, map[string]any{"record": record, "response": "{}"}, Publish),
+	}, WithDryRun(true))
+
+	for _, phase := range []string{Verify, Publish} {
+		err := runner.Run(context.Background(), phase, Release{Version: "1.0.0"})
+		checkErr(t, "running plugins", err)
+	}
+
+	content, err := os.ReadFile(record)
+	checkErr(t, "reading record", err)
+
+	assert.Equal("verify 1 1.0.0 true\npublish 1 1.0.0 true\npublish 1 1.0.0 true\n", string(content), "plugins should only be run for their phases")
+
+	stderr := new(strings.Builder)
+
+	err = NewRunner([]Config{testPlugin(t, map[string]any{"exit": true})}, WithOutput(stderr)).Run(context.Background(), Verify, Release{})
+	assert.ErrorIs(err, ErrPluginFailed)
+	assert.Equal("exiting\n", stderr.String(), "standard error of the plugin should have been written")
+
+	err = NewRunner([]Config{testPlugin(t, map[string]any{"response": `{"error": "missing credentials"}`})}).Run(context.Background(), Verify, Release{})
+	assert.ErrorIs(err, ErrPluginFailed)
+	assert.ErrorContains(err, "missing credentials")
+
+	err = NewRunner([]Config{testPlugin(t, map[string]any{"response": "not JSON"})}).Run(context.Background(), Verify, Release{})
+	assert.ErrorIs(err, ErrPluginFailed)
+
+	err = NewRunner([]Config{{Path: "./does-not-exist"}}).Run(context.Background(), Verify, Release{})
+	assert.ErrorIs(err, ErrPluginFailed)
+}
+
+func TestRunner_Analyze(t *testing.T) {
+	assert := assertion.New(t)
+
+	record := t.TempDir() + "/record"
+
+	runner := NewRunner([]Config{
+		testPlugin(t, map[string]any{"response": `{"version": "2.0.0"}`}),
+		testPlugin(t, map[string]any{"record": record}),
+		testPlugin(t, map[string]any{"response": `{}`}),
+	})
+
+	version, err := runner.Analyze(context.Background(), Release{Version: "1.1.0", PreviousVersion: "1.0.0"})
+	checkErr(t, "analyzing release", err)
+
+	if assert.NotNil(version) {
+		assert.Equal("2.0.0", version.String())
+	}
+
+	content, err := os.ReadFile(record)
+	checkErr(t, "reading record", err)
+
+	assert.Equal("analyze 1 2.0.0 false\n", string(content), "plugins should be given the version of the previous plugins")
+
+	version, err = NewRunner([]Config{testPlugin(t, nil)}).Analyze(context.Background(), Release{Version: "1.1.0"})
+	checkErr(t, "analyzing release", err)
+	assert.Nil(version, "version should not have been overridden")
+
+	version, err = NewRunner([]Config{testPlugin(t, map[string]any{"response": `{"version": "1.0.0"}`})}).Analyze(context.Background(), Release{Version: "1.0.0", PreviousVersion: "1.0.0"})
+	checkErr(t, "analyzing release", err)
+	assert.Nil(version, "returning the computed version should not override it")
+
+	tests := []string{`{"version": "1.0.0"}`, `{"version": "foo"}`}
+
+	for _, response := range tests {
+		runner = NewRunner([]Config{testPlugin(t, map[string]any{"response": response})})
+
+		_, err = runner.Analyze(context.Background(), Release{Version: "1.1.0", PreviousVersion: "1.0.0"})
+		assert.ErrorIs(err, ErrInvalidVersion, response)
+	}
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
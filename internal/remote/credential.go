@@ -0,0 +1,101 @@
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// credentialTimeout is the longest time given to the Git credential helpers to return the credentials of a remote.
+const credentialTimeout = 10 * time.Second
+
+// WithCredentialHelper asks the Git credential helpers configured on the system, through "git credential fill", for
+// the credentials of remotes using HTTP URLs when no access token is set. The password returned is then used as the
+// access token of the remote.
+func WithCredentialHelper() OptionFunc {
+	return func(r *Remote) {
+		r.credentialHelper = true
+	}
+}
+
+// fillCredentials sets the username and access token of the remote to the credentials returned by the Git credential
+// helpers for the given endpoint. Nothing is done if Git is not installed, if no helper knows the endpoint, or if
+// the helpers fail, the remote is then accessed anonymously.
+func (r *Remote) fillCredentials(endpoint *transport.Endpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialTimeout)
+	defer cancel()
+
+	username, password, err := credentialFill(ctx, endpoint)
+	if err != nil || password == "" {
+		return
+	}
+
+	r.token = password
+
+	if username != "" {
+		r.username = username
+	}
+}
+
+// credentialFill runs "git credential fill" for the given endpoint and returns the username and password it outputs.
+// Terminal prompts are disabled so that Git fails instead of waiting for an input if no helper knows the endpoint.
+func credentialFill(ctx context.Context, endpoint *transport.Endpoint) (string, string, error) {
+	host := endpoint.Host
+	if endpoint.Port != 0 && endpoint.Port != defaultPort(endpoint.Protocol) {
+		host += ":" + strconv.Itoa(endpoint.Port)
+	}
+
+	input := new(bytes.Buffer)
+
+	fmt.Fprintf(input, "protocol=%s\nhost=%s\npath=%s\n", endpoint.Protocol, host, strings.TrimPrefix(endpoint.Path, "/"))
+
+	if endpoint.User != "" {
+		fmt.Fprintf(input, "username=%s\n", endpoint.User)
+	}
+
+	input.WriteString("\n")
+
+	stdout := new(bytes.Buffer)
+
+	cmd := exec.CommandContext(ctx, "git", "credential", "fill")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stdin = input
+	cmd.Stdout = stdout
+
+	if err := cmd.Run(); err != nil {
+		return "", "", fmt.Errorf("running git credential fill: %w", err)
+	}
+
+	var username, password string
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), "=")
+
+		switch key {
+		case "username":
+			username = value
+		case "password":
+			password = value
+		}
+	}
+
+	return username, password, nil
+}
+
+// defaultPort returns the port used by the given protocol when URLs do not set one.
+func defaultPort(protocol string) int {
+	if protocol == "http" {
+		return 80
+	}
+
+	return 443
+}
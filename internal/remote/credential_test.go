@@ -0,0 +1,57 @@
+package remote
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+)
+
+func TestRemote_CredentialHelper(t *testing.T) {
+	assert := assertion.New(t)
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	err = testRepository.StartHTTPServer(gittest.WithBasicAuth("user", "secret"))
+	checkErr(t, err, "starting HTTP server")
+
+	// The helper only knows the credentials of the path of the served repository
+	gitConfig := filepath.Join(t.TempDir(), "gitconfig")
+	err = os.WriteFile(gitConfig, []byte(`[credential]
+	useHttpPath = true
+	helper = "!f() { test \"$1\" = get && grep -q '^path=repository.git$' && echo username=user && echo password=secret; }; f"
+`), 0o600)
+	checkErr(t, err, "writing Git configuration")
+
+	t.Setenv("GIT_CONFIG_GLOBAL", gitConfig)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	_, err = New("origin", "").Clone(context.Background(), testRepository.RemoteURL)
+	assert.ErrorIs(err, transport.ErrAuthorizationFailed, "credential helper should not be used unless enabled")
+
+	remote := New("origin", "", WithCredentialHelper())
+
+	_, err = remote.Clone(context.Background(), testRepository.RemoteURL)
+	checkErr(t, err, "cloning repository with credential helper")
+
+	assert.Equal("secret", remote.Token(), "password of the credential helper should be used as access token")
+
+	_, err = New("origin", "", WithCredentialHelper()).Clone(context.Background(), testRepository.RemoteURL+"/../other.git")
+	assert.Error(err, "unknown path should be accessed anonymously")
+}
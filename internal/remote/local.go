@@ -0,0 +1,126 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrDirtyWorktree  = errors.New("worktree has uncommitted changes")
+	ErrUnsyncedBranch = errors.New("branch is not in sync with its upstream")
+)
+
+// CheckClean returns ErrDirtyWorktree if the worktree of the local repository at the given path has staged or unstaged
+// changes. Untracked files are ignored since they are not part of any commit, and bare repositories have no worktree
+// to check.
+func CheckClean(path string) error {
+	repository, err := git.PlainOpen(path)
+	if err != nil {
+		return fmt.Errorf("opening repository: %w", err)
+	}
+
+	worktree, err := repository.Worktree()
+	if errors.Is(err, git.ErrIsBareRepository) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("fetching worktree: %w", err)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return fmt.Errorf("fetching worktree status: %w", err)
+	}
+
+	for file, fileStatus := range status {
+		if fileStatus.Staging == git.Untracked && fileStatus.Worktree == git.Untracked {
+			continue
+		}
+
+		if fileStatus.Staging != git.Unmodified || fileStatus.Worktree != git.Unmodified {
+			return fmt.Errorf("%w: %q", ErrDirtyWorktree, file)
+		}
+	}
+
+	return nil
+}
+
+// CheckSynced returns ErrUnsyncedBranch if the given branch of the local repository at the given path points to
+// another commit than its upstream, as last fetched from the remote. The upstream is the one configured for the branch
+// if any, the branch of the same name on the given remote otherwise.
+func CheckSynced(path, branch, remoteName string) error {
+	repository, err := git.PlainOpen(path)
+	if err != nil {
+		return fmt.Errorf("opening repository: %w", err)
+	}
+
+	local, err := repository.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		return fmt.Errorf("fetching branch %q: %w", branch, err)
+	}
+
+	upstreamName, err := upstream(repository, branch, remoteName)
+	if err != nil {
+		return err
+	}
+
+	remote, err := repository.Reference(upstreamName, true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return fmt.Errorf("%w: %q has no upstream %q", ErrUnsyncedBranch, branch, upstreamName.Short())
+	}
+	if err != nil {
+		return fmt.Errorf("fetching upstream %q: %w", upstreamName.Short(), err)
+	}
+
+	if local.Hash() == remote.Hash() {
+		return nil
+	}
+
+	localCommit, err := repository.CommitObject(local.Hash())
+	if err != nil {
+		return fmt.Errorf("fetching commit: %w", err)
+	}
+
+	remoteCommit, err := repository.CommitObject(remote.Hash())
+	if err != nil {
+		return fmt.Errorf("fetching upstream commit: %w", err)
+	}
+
+	behind, err := localCommit.IsAncestor(remoteCommit)
+	if err != nil {
+		return fmt.Errorf("comparing with upstream: %w", err)
+	}
+
+	ahead, err := remoteCommit.IsAncestor(localCommit)
+	if err != nil {
+		return fmt.Errorf("comparing with upstream: %w", err)
+	}
+
+	state := "diverged from"
+
+	switch {
+	case behind:
+		state = "behind"
+	case ahead:
+		state = "ahead of"
+	}
+
+	return fmt.Errorf("%w: %q is %s %q", ErrUnsyncedBranch, branch, state, upstreamName.Short())
+}
+
+// upstream returns the name of the remote branch tracked by the given branch.
+func upstream(repository *git.Repository, branch, remoteName string) (plumbing.ReferenceName, error) {
+	cfg, err := repository.Config()
+	if err != nil {
+		return "", fmt.Errorf("fetching repository configuration: %w", err)
+	}
+
+	if tracking, ok := cfg.Branches[branch]; ok && tracking.Remote != "" && tracking.Merge.IsBranch() {
+		return plumbing.NewRemoteReferenceName(tracking.Remote, tracking.Merge.Short()), nil
+	}
+
+	return plumbing.NewRemoteReferenceName(remoteName, branch), nil
+}
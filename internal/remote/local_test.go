@@ -0,0 +1,83 @@
+package remote
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+)
+
+func TestRemote_CheckClean(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	err = os.WriteFile(filepath.Join(testRepository.Path, "untracked.txt"), []byte("untracked"), 0o600)
+	checkErr(t, err, "writing untracked file")
+
+	err = CheckClean(testRepository.Path)
+	assert.NoError(err, "untracked files should be ignored")
+
+	worktree, err := testRepository.Worktree()
+	checkErr(t, err, "fetching worktree")
+
+	_, err = worktree.Add("untracked.txt")
+	checkErr(t, err, "staging file")
+
+	err = CheckClean(testRepository.Path)
+	assert.ErrorIs(err, ErrDirtyWorktree)
+}
+
+func TestRemote_CheckSynced(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	err = CheckSynced(testRepository.Path, "master", "origin")
+	assert.ErrorIs(err, ErrUnsyncedBranch, "branch without upstream should not be in sync")
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	upstream := plumbing.NewRemoteReferenceName("origin", "master")
+
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference(upstream, head.Hash()))
+	checkErr(t, err, "setting upstream")
+
+	err = CheckSynced(testRepository.Path, "master", "origin")
+	assert.NoError(err)
+
+	_, err = testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit")
+
+	err = CheckSynced(testRepository.Path, "master", "origin")
+	assert.ErrorIs(err, ErrUnsyncedBranch)
+	assert.ErrorContains(err, "ahead of")
+
+	ahead, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	err = testRepository.Storer.SetReference(plumbing.NewHashReference(upstream, ahead.Hash()))
+	checkErr(t, err, "setting upstream")
+
+	err = testRepository.Reset(head.Hash())
+	checkErr(t, err, "resetting branch")
+
+	err = CheckSynced(testRepository.Path, "master", "origin")
+	assert.ErrorIs(err, ErrUnsyncedBranch)
+	assert.ErrorContains(err, "behind")
+}
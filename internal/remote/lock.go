@@ -0,0 +1,161 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// LockRef is the reference created on the remote by the release holding its lock.
+const LockRef = plumbing.ReferenceName("refs/go-semver-release/lock")
+
+// maxLockDelay is the longest delay between two attempts to acquire the lock.
+const maxLockDelay = 30 * time.Second
+
+var ErrLocked = errors.New("remote is locked by another release")
+
+// Lock acquires the lock of the previously cloned repository's remote by creating LockRef on it. The reference points
+// to a new commit naming the given owner, so that creating it fails if another release holds the lock. The attempts,
+// spaced by the retry delay doubled every time, go on until the given context is done, ErrLocked is then returned if
+// the lock is held. The lock must be released with Unlock.
+func (r *Remote) Lock(ctx context.Context, owner string) error {
+	hash, err := r.lockCommit(owner)
+	if err != nil {
+		return fmt.Errorf("creating lock commit: %w", err)
+	}
+
+	err = r.repository.Storer.SetReference(plumbing.NewHashReference(LockRef, hash))
+	if err != nil {
+		return fmt.Errorf("creating lock reference: %w", err)
+	}
+
+	delay := r.retryDelay
+
+	for {
+		err = r.push(ctx, fmt.Sprintf("%s:%s", LockRef, LockRef))
+		if err == nil {
+			r.locked = true
+			return nil
+		}
+
+		if !retryable(err) {
+			return fmt.Errorf("pushing lock: %w", err)
+		}
+
+		if held, heldErr := r.lockHeld(ctx); heldErr == nil && held {
+			err = ErrLocked
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("acquiring lock: %w", errors.Join(err, ctx.Err()))
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, maxLockDelay)
+	}
+}
+
+// Unlock releases the lock acquired by Lock by deleting LockRef from the remote. Nothing is done if the lock is not
+// held.
+func (r *Remote) Unlock(ctx context.Context) error {
+	if !r.locked {
+		return nil
+	}
+
+	err := r.push(ctx, ":"+LockRef.String())
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("deleting lock: %w", err)
+	}
+
+	r.locked = false
+
+	return r.repository.Storer.RemoveReference(LockRef)
+}
+
+// Refresh fetches the branches and tags of the previously cloned repository's remote again, replacing the local ones,
+// so that the changes pushed since the clone, by the release previously holding the lock for instance, are known.
+func (r *Remote) Refresh(ctx context.Context) error {
+	fo := &git.FetchOptions{
+		RemoteName: r.name,
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+refs/heads/*:refs/remotes/%s/*", r.name)),
+			"+refs/tags/*:refs/tags/*",
+		},
+		Auth:            r.auth,
+		CABundle:        r.tls.caBundle,
+		InsecureSkipTLS: r.tls.insecureSkipVerify,
+		Depth:           r.depth,
+		Tags:            git.NoTags,
+		Progress:        io.Discard,
+	}
+
+	err := r.repository.FetchContext(ctx, fo)
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("fetching remote: %w", err)
+	}
+
+	return nil
+}
+
+// lockHeld returns true if LockRef exists on the remote.
+func (r *Remote) lockHeld(ctx context.Context) (bool, error) {
+	gitRemote, err := r.repository.Remote(r.name)
+	if err != nil {
+		return false, err
+	}
+
+	refs, err := gitRemote.ListContext(ctx, &git.ListOptions{
+		Auth:            r.auth,
+		CABundle:        r.tls.caBundle,
+		InsecureSkipTLS: r.tls.insecureSkipVerify,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, ref := range refs {
+		if ref.Name() == LockRef {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// lockCommit stores a new commit, with an empty tree and no parent, whose message names the given owner and whose
+// date is the time the lock is acquired.
+func (r *Remote) lockCommit(owner string) (plumbing.Hash, error) {
+	tree := r.repository.Storer.NewEncodedObject()
+	if err := (&object.Tree{}).Encode(tree); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	treeHash, err := r.repository.Storer.SetEncodedObject(tree)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	signature := object.Signature{Name: defaultUsername, When: time.Now()}
+
+	commit := &object.Commit{
+		Author:    signature,
+		Committer: signature,
+		Message:   fmt.Sprintf("Release lock held by %s\n", owner),
+		TreeHash:  treeHash,
+	}
+
+	encoded := r.repository.Storer.NewEncodedObject()
+	if err = commit.Encode(encoded); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return r.repository.Storer.SetEncodedObject(encoded)
+}
@@ -0,0 +1,105 @@
+package remote
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func TestRemote_Lock(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	first := New("origin", "password", WithPushRetries(0, time.Millisecond))
+	second := New("origin", "password", WithPushRetries(0, time.Millisecond))
+
+	for _, remote := range []*Remote{first, second} {
+		_, err = remote.Clone(context.Background(), testRepository.Path)
+		checkErr(t, err, "cloning repository")
+	}
+
+	err = first.Lock(context.Background(), "first")
+	checkErr(t, err, "acquiring lock")
+
+	lock, err := testRepository.Reference(LockRef, true)
+	checkErr(t, err, "fetching lock reference")
+
+	c, err := testRepository.CommitObject(lock.Hash())
+	checkErr(t, err, "fetching lock commit")
+
+	assert.Contains(c.Message, "first", "lock should name its owner")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = second.Lock(ctx, "second")
+	assert.ErrorIs(err, ErrLocked, "lock held by another release should not be acquired")
+
+	err = second.Unlock(context.Background())
+	checkErr(t, err, "releasing lock not held")
+
+	_, err = testRepository.Reference(LockRef, true)
+	checkErr(t, err, "lock should still be held")
+
+	err = first.Unlock(context.Background())
+	checkErr(t, err, "releasing lock")
+
+	_, err = testRepository.Reference(LockRef, true)
+	assert.ErrorIs(err, plumbing.ErrReferenceNotFound, "lock should have been released")
+
+	err = second.Lock(context.Background(), "second")
+	checkErr(t, err, "acquiring released lock")
+
+	err = second.Unlock(context.Background())
+	checkErr(t, err, "releasing lock")
+}
+
+func TestRemote_Refresh(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	remote := New("origin", "password")
+
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	hash, err := testRepository.AddCommit("feat")
+	checkErr(t, err, "adding commit to test repository")
+
+	err = testRepository.AddTag("v1.0.0", hash)
+	checkErr(t, err, "adding tag to test repository")
+
+	err = remote.Refresh(context.Background())
+	checkErr(t, err, "refreshing remote")
+
+	exists, err := tag.Exists(clonedRepository, "v1.0.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "tag pushed since the clone should have been fetched")
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	branch, err := clonedRepository.Reference(plumbing.NewRemoteReferenceName("origin", head.Name().Short()), true)
+	checkErr(t, err, "fetching remote branch")
+	assert.Equal(hash, branch.Hash(), "remote branch should have been updated")
+}
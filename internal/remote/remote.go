@@ -2,43 +2,130 @@
 package remote
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 )
 
+var (
+	ErrCompleteHistory = errors.New("clone history is complete")
+	ErrTagConflict     = errors.New("tag already exists on the remote and points to another commit")
+)
+
+// defaultUsername is the username sent along the access token to remotes using HTTP URLs, which is ignored by most Git
+// forges.
+const defaultUsername = "go-semver-release"
+
+// defaultRetryDelay is the delay before the first retry of a failed push, doubled on every subsequent retry.
+const defaultRetryDelay = time.Second
+
 type Remote struct {
-	auth       *http.BasicAuth
+	auth       transport.AuthMethod
 	repository *git.Repository
 	name       string
+	token      string
+	username   string
+	ssh        sshOptions
+	tls        tlsOptions
+	// depth is the number of commits fetched from the tip of every branch, zero for a full clone
+	depth      int
+	retries    int
+	retryDelay time.Duration
+	// locked is true while the lock of the remote is held
+	locked bool
+	// credentialHelper is true if the Git credential helpers are asked for the credentials when no token is set
+	credentialHelper bool
+}
+
+type OptionFunc func(r *Remote)
+
+// WithDepth makes shallow clones fetching the given number of commits from the tip of every branch, the history can
+// then be deepened on demand using Deepen. A depth of zero makes full clones.
+func WithDepth(depth int) OptionFunc {
+	return func(r *Remote) {
+		r.depth = depth
+	}
+}
+
+// WithPushRetries retries a failed tag push the given number of times, waiting twice as long before every retry.
+// Pushes rejected for authentication or authorization reasons are not retried.
+func WithPushRetries(retries int, delay time.Duration) OptionFunc {
+	return func(r *Remote) {
+		r.retries = retries
+		r.retryDelay = delay
+	}
 }
 
-func New(name string, token string) *Remote {
-	return &Remote{
-		name: name,
-		auth: &http.BasicAuth{
-			Username: "go-semver-release",
-			Password: token,
-		},
+// WithUsername sets the username sent along the access token to remotes using HTTP URLs, some tokens are only
+// accepted along a given username (e.g., "x-access-token" for GitHub App installation tokens).
+func WithUsername(username string) OptionFunc {
+	return func(r *Remote) {
+		r.username = username
 	}
 }
 
-// Clone clones a given remote repository to a temporary directory.
-func (r *Remote) Clone(url string) (*git.Repository, error) {
+func New(name string, token string, options ...OptionFunc) *Remote {
+	r := &Remote{
+		name:       name,
+		token:      token,
+		username:   defaultUsername,
+		retryDelay: defaultRetryDelay,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// Token returns the access token used to authenticate to remotes using HTTP URLs.
+func (r *Remote) Token() string {
+	return r.token
+}
+
+// Clone clones a given remote repository to a temporary directory. Local repositories whose HEAD is detached, as
+// checked out by most CI systems, cannot be cloned and are copied instead.
+func (r *Remote) Clone(ctx context.Context, url string) (*git.Repository, error) {
 	tempDir, err := os.MkdirTemp("", "*")
 	if err != nil {
 		return nil, fmt.Errorf("creating temporary directory: %w", err)
 	}
 
-	r.repository, err = git.PlainClone(tempDir, false, &git.CloneOptions{
-		RemoteName: r.name,
-		Auth:       r.auth,
-		URL:        url,
-		Progress:   io.Discard,
+	if isDetachedRepository(url) {
+		r.repository, err = r.copyRepository(url, tempDir)
+		if err != nil {
+			return nil, fmt.Errorf("copying repository: %w", err)
+		}
+
+		return r.repository, nil
+	}
+
+	r.auth, err = r.authMethod(url)
+	if err != nil {
+		return nil, fmt.Errorf("configuring authentication: %w", err)
+	}
+
+	r.repository, err = git.PlainCloneContext(ctx, tempDir, false, &git.CloneOptions{
+		RemoteName:      r.name,
+		Auth:            r.auth,
+		CABundle:        r.tls.caBundle,
+		InsecureSkipTLS: r.tls.insecureSkipVerify,
+		URL:             url,
+		Depth:           r.depth,
+		Progress:        io.Discard,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("cloning repository: %w", err)
@@ -47,19 +134,382 @@ func (r *Remote) Clone(url string) (*git.Repository, error) {
 	return r.repository, nil
 }
 
-// PushTag pushes a given tag to the previously cloned repository's remote.
-func (r *Remote) PushTag(tagName string) error {
-	po := &git.PushOptions{
-		RemoteName: r.name,
-		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("refs/tags/%s:refs/tags/%s", tagName, tagName))},
-		Auth:       r.auth,
-		Progress:   io.Discard,
+// copyRepository copies the Git directory of a local repository to the given directory and sets it up as a clone would
+// be: the remote points to the local repository and its local branches are available as remote branches. The HEAD of
+// the copy is detached at the same commit as the local repository.
+func (r *Remote) copyRepository(path, dir string) (*git.Repository, error) {
+	err := os.CopyFS(filepath.Join(dir, git.GitDirName), os.DirFS(filepath.Join(path, git.GitDirName)))
+	if err != nil {
+		return nil, fmt.Errorf("copying Git directory: %w", err)
+	}
+
+	repository, err := git.PlainOpen(dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening copied repository: %w", err)
+	}
+
+	err = repository.DeleteRemote(r.name)
+	if err != nil && !errors.Is(err, git.ErrRemoteNotFound) {
+		return nil, fmt.Errorf("deleting remote %q: %w", r.name, err)
+	}
+
+	_, err = repository.CreateRemote(&config.RemoteConfig{Name: r.name, URLs: []string{path}})
+	if err != nil {
+		return nil, fmt.Errorf("creating remote %q: %w", r.name, err)
+	}
+
+	branches, err := repository.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("fetching branches: %w", err)
+	}
+
+	err = branches.ForEach(func(reference *plumbing.Reference) error {
+		remoteBranchRef := plumbing.NewRemoteReferenceName(r.name, reference.Name().Short())
+		return repository.Storer.SetReference(plumbing.NewHashReference(remoteBranchRef, reference.Hash()))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating remote branches: %w", err)
+	}
+
+	return repository, nil
+}
+
+// isDetachedRepository returns true if the given URL is the path of a local, non-bare, repository whose HEAD is
+// detached.
+func isDetachedRepository(url string) bool {
+	repository, err := git.PlainOpen(url)
+	if err != nil {
+		return false
+	}
+
+	if _, err = os.Stat(filepath.Join(url, git.GitDirName)); err != nil {
+		return false
+	}
+
+	head, err := repository.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return false
+	}
+
+	return head.Type() == plumbing.HashReference
+}
+
+// authMethod returns the authentication method used for the given repository URL: SSH keys for SSH URLs, the access
+// token otherwise, or else the credentials of the Git credential helpers if enabled.
+func (r *Remote) authMethod(url string) (transport.AuthMethod, error) {
+	endpoint, err := transport.NewEndpoint(url)
+	if err != nil {
+		return nil, fmt.Errorf("parsing repository URL: %w", err)
+	}
+
+	if endpoint.Protocol == "ssh" {
+		return r.sshAuthMethod(endpoint.User)
+	}
+
+	if r.token == "" && r.credentialHelper && (endpoint.Protocol == "http" || endpoint.Protocol == "https") {
+		r.fillCredentials(endpoint)
+	}
+
+	return &http.BasicAuth{
+		Username: r.username,
+		Password: r.token,
+	}, nil
+}
+
+// Deepen doubles the depth of a shallow clone of the previously cloned repository. ErrCompleteHistory is returned if
+// the repository is not a shallow clone or if its whole history has already been fetched.
+func (r *Remote) Deepen(ctx context.Context) error {
+	if r.depth == 0 {
+		return ErrCompleteHistory
+	}
+
+	before, err := r.repository.Storer.Shallow()
+	if err != nil {
+		return fmt.Errorf("fetching shallow commits: %w", err)
+	}
+
+	r.depth *= 2
+
+	err = r.repository.FetchContext(ctx, &git.FetchOptions{
+		RemoteName:      r.name,
+		Auth:            r.auth,
+		CABundle:        r.tls.caBundle,
+		InsecureSkipTLS: r.tls.insecureSkipVerify,
+		Depth:           r.depth,
+		Tags:            git.AllTags,
+		Progress:        io.Discard,
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		r.depth = 0
+		return ErrCompleteHistory
+	}
+	if err != nil {
+		return fmt.Errorf("deepening clone to %d commits: %w", r.depth, err)
+	}
+
+	after, err := r.repository.Storer.Shallow()
+	if err != nil {
+		return fmt.Errorf("fetching shallow commits: %w", err)
+	}
+
+	// No new shallow commit means that the root commits have been reached, the next call cannot deepen the history
+	if slices.Equal(before, after) {
+		r.depth = 0
+	}
+
+	return nil
+}
+
+// Mirror adds a remote of the given name and URL to the previously cloned repository and returns it, so that tags can be
+// pushed to it as well. The mirror authenticates with the given access token and the SSH options of r, the given
+// options applying on top of them.
+func (r *Remote) Mirror(name, url, token string, options ...OptionFunc) (*Remote, error) {
+	if r.repository == nil {
+		return nil, fmt.Errorf("adding mirror %q: repository not cloned", name)
 	}
 
-	err := r.repository.Push(po)
+	m := &Remote{
+		repository:       r.repository,
+		name:             name,
+		token:            token,
+		username:         defaultUsername,
+		ssh:              r.ssh,
+		tls:              r.tls,
+		retries:          r.retries,
+		retryDelay:       r.retryDelay,
+		credentialHelper: r.credentialHelper,
+	}
+
+	m.ssh.knownHostsPaths = slices.Clone(r.ssh.knownHostsPaths)
+
+	for _, option := range options {
+		option(m)
+	}
+
+	_, err := r.repository.CreateRemote(&config.RemoteConfig{Name: name, URLs: []string{url}})
+	if err != nil {
+		return nil, fmt.Errorf("creating remote %q: %w", name, err)
+	}
+
+	m.auth, err = m.authMethod(url)
+	if err != nil {
+		return nil, fmt.Errorf("configuring authentication of mirror %q: %w", name, err)
+	}
+
+	return m, nil
+}
+
+// PushTag pushes a given tag to the previously cloned repository's remote.
+func (r *Remote) PushTag(ctx context.Context, tagName string) error {
+	return r.pushTag(ctx, tagName, false)
+}
+
+// ForcePushTag pushes a given tag to the previously cloned repository's remote, replacing the remote tag if it already
+// exists and points to another object.
+func (r *Remote) ForcePushTag(ctx context.Context, tagName string) error {
+	return r.pushTag(ctx, tagName, true)
+}
+
+// PushBranch pushes a given local branch to the previously cloned repository's remote. The push is rejected if the
+// remote branch cannot be fast-forwarded.
+func (r *Remote) PushBranch(ctx context.Context, branchName string) error {
+	refSpec := fmt.Sprintf("refs/heads/%s:refs/heads/%s", branchName, branchName)
+
+	err := r.push(ctx, refSpec)
 	if err != nil {
-		return fmt.Errorf("pushing tag %q: %w", tagName, err)
+		return fmt.Errorf("pushing branch %q: %w", branchName, err)
 	}
 
 	return nil
 }
+
+// FetchNotes fetches the given notes reference from the previously cloned repository's remote, replacing the local
+// one. Nothing is done if the remote has no such notes.
+func (r *Remote) FetchNotes(ctx context.Context, ref plumbing.ReferenceName) error {
+	fo := &git.FetchOptions{
+		RemoteName:      r.name,
+		RefSpecs:        []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", ref, ref))},
+		Auth:            r.auth,
+		CABundle:        r.tls.caBundle,
+		InsecureSkipTLS: r.tls.insecureSkipVerify,
+		Progress:        io.Discard,
+	}
+
+	err := r.repository.FetchContext(ctx, fo)
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) && !errors.Is(err, git.NoMatchingRefSpecError{}) {
+		return fmt.Errorf("fetching notes %q: %w", ref, err)
+	}
+
+	return nil
+}
+
+// PushNotes pushes the given notes reference to the previously cloned repository's remote. The push is rejected if the
+// remote notes cannot be fast-forwarded.
+func (r *Remote) PushNotes(ctx context.Context, ref plumbing.ReferenceName) error {
+	err := r.push(ctx, fmt.Sprintf("%s:%s", ref, ref))
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("pushing notes %q: %w", ref, err)
+	}
+
+	return nil
+}
+
+// pushTag pushes the given tag, retrying failed pushes. Before every retry, the tag is fetched back from the remote in
+// case it was pushed concurrently, by another run releasing the same commit for instance: the push succeeds if the
+// remote tag points to the same commit, or is the very same tag if forced, and fails with ErrTagConflict if it points
+// to another commit.
+func (r *Remote) pushTag(ctx context.Context, tagName string, force bool) error {
+	refSpec := fmt.Sprintf("refs/tags/%s:refs/tags/%s", tagName, tagName)
+	if force {
+		refSpec = "+" + refSpec
+	}
+
+	delay := r.retryDelay
+
+	for attempt := 0; ; attempt++ {
+		err := r.push(ctx, refSpec)
+		if err == nil || errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return nil
+		}
+
+		if !retryable(err) {
+			return fmt.Errorf("pushing tag %q: %w", tagName, err)
+		}
+
+		pushed, raceErr := r.tagPushed(ctx, tagName, force)
+		switch {
+		case errors.Is(raceErr, ErrTagConflict):
+			return fmt.Errorf("pushing tag %q: %w", tagName, raceErr)
+		case raceErr == nil && pushed:
+			return nil
+		}
+
+		if attempt >= r.retries {
+			return fmt.Errorf("pushing tag %q: %w", tagName, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("pushing tag %q: %w", tagName, errors.Join(err, ctx.Err()))
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// tagPushed fetches the given tag from the remote and returns true if it is the same as the local one, or, unless
+// forced, if it points to the same commit. ErrTagConflict is returned if the remote tag points to another commit.
+func (r *Remote) tagPushed(ctx context.Context, tagName string, force bool) (bool, error) {
+	remoteRef := plumbing.NewRemoteReferenceName(r.name, "tags/"+tagName)
+
+	fo := &git.FetchOptions{
+		RemoteName:      r.name,
+		RefSpecs:        []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/tags/%s:%s", tagName, remoteRef))},
+		Auth:            r.auth,
+		CABundle:        r.tls.caBundle,
+		InsecureSkipTLS: r.tls.insecureSkipVerify,
+		Tags:            git.NoTags,
+		Progress:        io.Discard,
+	}
+
+	err := r.repository.FetchContext(ctx, fo)
+	if errors.Is(err, git.NoMatchingRefSpecError{}) {
+		return false, nil
+	}
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return false, fmt.Errorf("fetching remote tag: %w", err)
+	}
+
+	defer func() {
+		_ = r.repository.Storer.RemoveReference(remoteRef)
+	}()
+
+	remoteTag, err := r.repository.Reference(remoteRef, true)
+	if err != nil {
+		return false, fmt.Errorf("fetching remote tag: %w", err)
+	}
+
+	localTag, err := r.repository.Reference(plumbing.NewTagReferenceName(tagName), true)
+	if err != nil {
+		return false, fmt.Errorf("fetching local tag: %w", err)
+	}
+
+	if remoteTag.Hash() == localTag.Hash() {
+		return true, nil
+	}
+
+	if force {
+		return false, nil
+	}
+
+	remoteCommit, err := r.peel(remoteTag.Hash())
+	if err != nil {
+		return false, fmt.Errorf("resolving remote tag: %w", err)
+	}
+
+	localCommit, err := r.peel(localTag.Hash())
+	if err != nil {
+		return false, fmt.Errorf("resolving local tag: %w", err)
+	}
+
+	if remoteCommit != localCommit {
+		return false, fmt.Errorf("%w: %s", ErrTagConflict, remoteCommit)
+	}
+
+	return true, nil
+}
+
+// peel returns the hash of the commit pointed to by the given annotated tag, or the given hash if it is a commit.
+func (r *Remote) peel(hash plumbing.Hash) (plumbing.Hash, error) {
+	obj, err := object.GetObject(r.repository.Storer, hash)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	switch obj := obj.(type) {
+	case *object.Tag:
+		c, err := obj.Commit()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		return c.Hash, nil
+	case *object.Commit:
+		return obj.Hash, nil
+	default:
+		return plumbing.ZeroHash, fmt.Errorf("unexpected %s object", obj.Type())
+	}
+}
+
+// retryable returns true if the given push error may not happen again, that is if the push was not rejected for
+// authentication or authorization reasons, and was not canceled.
+func retryable(err error) bool {
+	for _, target := range []error{
+		transport.ErrAuthenticationRequired,
+		transport.ErrAuthorizationFailed,
+		transport.ErrRepositoryNotFound,
+		transport.ErrInvalidAuthMethod,
+		context.Canceled,
+		context.DeadlineExceeded,
+	} {
+		if errors.Is(err, target) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Remote) push(ctx context.Context, refSpec string) error {
+	po := &git.PushOptions{
+		RemoteName:      r.name,
+		RefSpecs:        []config.RefSpec{config.RefSpec(refSpec)},
+		Auth:            r.auth,
+		CABundle:        r.tls.caBundle,
+		InsecureSkipTLS: r.tls.insecureSkipVerify,
+		Progress:        io.Discard,
+	}
+
+	return r.repository.PushContext(ctx, po)
+}
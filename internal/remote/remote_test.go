@@ -1,11 +1,19 @@
 package remote
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/s0ders/go-semver-release/v6/internal/tag"
 
 	assertion "github.com/stretchr/testify/assert"
@@ -26,18 +34,136 @@ func TestRemote_Clone_HappyScenario(t *testing.T) {
 
 	remote := New("origin", "password")
 
-	clonedRepository, err := remote.Clone(testRepository.Path)
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.Path)
 	checkErr(t, err, "cloning repository")
 
 	assert.NotNil(clonedRepository)
 	assert.NoError(err)
 }
 
+func TestRemote_Clone_DetachedHead(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	worktree, err := testRepository.Worktree()
+	checkErr(t, err, "fetching worktree")
+
+	err = worktree.Checkout(&git.CheckoutOptions{Hash: head.Hash()})
+	checkErr(t, err, "detaching head")
+
+	// The checked out commit is not reachable from any branch, as the merge commits of pull requests in CI
+	commitHash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	remote := New("origin", "password")
+
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	clonedHead, err := clonedRepository.Head()
+	checkErr(t, err, "fetching cloned repository head")
+
+	assert.Equal(commitHash, clonedHead.Hash(), "cloned repository should be checked out at the same commit")
+
+	remoteBranch, err := clonedRepository.Reference(plumbing.NewRemoteReferenceName("origin", "master"), true)
+	checkErr(t, err, "fetching remote branch")
+
+	assert.Equal(head.Hash(), remoteBranch.Hash(), "local branches should be available as remote branches")
+
+	clonedRemote, err := clonedRepository.Remote("origin")
+	checkErr(t, err, "fetching remote")
+
+	assert.Equal([]string{testRepository.Path}, clonedRemote.Config().URLs, "remote should point to the local repository")
+}
+
+func TestRemote_Deepen(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	for range 4 {
+		_, err = testRepository.AddCommit("fix")
+		checkErr(t, err, "adding commit to test repository")
+	}
+
+	head, err := testRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	headCommit, err := testRepository.CommitObject(head.Hash())
+	checkErr(t, err, "fetching head commit")
+
+	remote := New("origin", "", WithDepth(1))
+
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	_, err = clonedRepository.CommitObject(headCommit.ParentHashes[0])
+	assert.ErrorIs(err, plumbing.ErrObjectNotFound, "clone should be shallow")
+
+	err = remote.Deepen(context.Background())
+	checkErr(t, err, "deepening clone")
+
+	_, err = clonedRepository.CommitObject(headCommit.ParentHashes[0])
+	assert.NoError(err, "clone should have been deepened")
+
+	for err == nil {
+		err = remote.Deepen(context.Background())
+	}
+
+	assert.ErrorIs(err, ErrCompleteHistory)
+
+	commits, err := clonedRepository.Log(&git.LogOptions{From: head.Hash()})
+	checkErr(t, err, "fetching history")
+
+	var count int
+	err = commits.ForEach(func(*object.Commit) error {
+		count++
+		return nil
+	})
+	checkErr(t, err, "walking history")
+
+	assert.Equal(5, count, "whole history should have been fetched")
+}
+
+func TestRemote_Deepen_FullClone(t *testing.T) {
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	remote := New("origin", "")
+
+	_, err = remote.Clone(context.Background(), testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	err = remote.Deepen(context.Background())
+	assertion.ErrorIs(t, err, ErrCompleteHistory)
+}
+
 func TestRemote_Clone_NonExistingPath(t *testing.T) {
 	assert := assertion.New(t)
 
 	remote := New("origin", "password")
-	clonedRepository, err := remote.Clone("https://example.com")
+	clonedRepository, err := remote.Clone(context.Background(), "https://example.com")
 
 	assert.Nil(clonedRepository)
 	assert.Error(err)
@@ -61,7 +187,7 @@ func TestRemote_PushTag(t *testing.T) {
 
 	remote := New("origin", "password")
 
-	clonedRepository, err := remote.Clone(testRepository.Path)
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.Path)
 	checkErr(t, err, "cloning repository")
 
 	_, err = clonedRepository.CreateTag(tagName, commitHash, &git.CreateTagOptions{
@@ -74,12 +200,207 @@ func TestRemote_PushTag(t *testing.T) {
 	})
 	checkErr(t, err, "creating tag on cloned repository")
 
-	err = remote.PushTag(tagName)
+	err = remote.PushTag(context.Background(), tagName)
+	checkErr(t, err, "pushing tag to remote")
+
+	assert.True(tag.Exists(testRepository.Repository, tagName))
+}
+
+func TestRemote_PushTag_HTTP(t *testing.T) {
+	assert := assertion.New(t)
+
+	tagName := "v1.0.0"
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	commitHash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	err = testRepository.StartHTTPServer(gittest.WithToken("token"))
+	checkErr(t, err, "starting HTTP server")
+
+	_, err = New("origin", "wrong").Clone(context.Background(), testRepository.RemoteURL)
+	assert.ErrorIs(err, transport.ErrAuthorizationFailed, "clone with an invalid token should have been rejected")
+
+	remote := New("origin", "token")
+
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.RemoteURL)
+	checkErr(t, err, "cloning repository")
+
+	_, err = clonedRepository.CreateTag(tagName, commitHash, nil)
+	checkErr(t, err, "creating tag on cloned repository")
+
+	err = remote.PushTag(context.Background(), tagName)
 	checkErr(t, err, "pushing tag to remote")
 
 	assert.True(tag.Exists(testRepository.Repository, tagName))
 }
 
+func TestRemote_Mirror(t *testing.T) {
+	assert := assertion.New(t)
+
+	tagName := "v1.0.0"
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	mirrorRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating mirror repository")
+
+	defer func() {
+		err = mirrorRepository.Remove()
+		checkErr(t, err, "removing mirror repository")
+	}()
+
+	err = mirrorRepository.StartHTTPServer(gittest.WithToken("token"))
+	checkErr(t, err, "starting HTTP server")
+
+	remote := New("origin", "password")
+
+	_, err = remote.Mirror("mirror", mirrorRepository.RemoteURL, "token")
+	assert.Error(err, "mirror should not be added before cloning")
+
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	head, err := clonedRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	_, err = clonedRepository.CreateTag(tagName, head.Hash(), nil)
+	checkErr(t, err, "creating tag on cloned repository")
+
+	_, err = remote.Mirror("origin", mirrorRepository.RemoteURL, "token")
+	assert.ErrorIs(err, git.ErrRemoteExists, "mirror should not replace the remote")
+
+	unauthorized, err := remote.Mirror("unauthorized", mirrorRepository.RemoteURL, "wrong")
+	checkErr(t, err, "adding unauthorized mirror")
+
+	err = unauthorized.PushTag(context.Background(), tagName)
+	assert.ErrorIs(err, transport.ErrAuthorizationFailed, "push with an invalid token should have been rejected")
+
+	mirror, err := remote.Mirror("mirror", mirrorRepository.RemoteURL, "token")
+	checkErr(t, err, "adding mirror")
+
+	err = mirror.PushTag(context.Background(), tagName)
+	checkErr(t, err, "pushing tag to mirror")
+
+	exists, err := tag.Exists(mirrorRepository.Repository, tagName)
+	checkErr(t, err, "checking if tag exists on mirror")
+	assert.True(exists, "tag should have been pushed to the mirror")
+
+	exists, err = tag.Exists(testRepository.Repository, tagName)
+	checkErr(t, err, "checking if tag exists on remote")
+	assert.False(exists, "tag should only have been pushed to the mirror")
+}
+
+func TestRemote_ForcePushTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	tagName := "v1"
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	firstHash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	err = testRepository.AddTag(tagName, firstHash)
+	checkErr(t, err, "adding tag to test repository")
+
+	secondHash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	remote := New("origin", "password")
+
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	err = clonedRepository.DeleteTag(tagName)
+	checkErr(t, err, "deleting tag on cloned repository")
+
+	_, err = clonedRepository.CreateTag(tagName, secondHash, &git.CreateTagOptions{
+		Message: tagName,
+		Tagger: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  time.Now(),
+		},
+	})
+	checkErr(t, err, "creating tag on cloned repository")
+
+	err = remote.PushTag(context.Background(), tagName)
+	assert.Error(err, "moving a remote tag should require a force push")
+
+	err = remote.ForcePushTag(context.Background(), tagName)
+	checkErr(t, err, "force pushing tag to remote")
+
+	reference, err := testRepository.Reference(plumbing.NewTagReferenceName(tagName), true)
+	checkErr(t, err, "fetching tag reference")
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.Equal(secondHash, tagObject.Target, "remote tag should have been moved")
+}
+
+func TestRemote_PushBranch(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	remote := New("origin", "password")
+
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.Path)
+	checkErr(t, err, "cloning repository")
+
+	worktree, err := clonedRepository.Worktree()
+	checkErr(t, err, "fetching worktree")
+
+	commitHash, err := worktree.Commit("chore: empty commit", &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Go Semver Release",
+			Email: "go-semver@release.ci",
+			When:  time.Now(),
+		},
+		AllowEmptyCommits: true,
+	})
+	checkErr(t, err, "creating commit on cloned repository")
+
+	// A non-bare repository refuses pushes to its checked out branch
+	err = testRepository.CheckoutBranch("other")
+	checkErr(t, err, "checking out another branch")
+
+	err = remote.PushBranch(context.Background(), "master")
+	checkErr(t, err, "pushing branch to remote")
+
+	reference, err := testRepository.Reference(plumbing.NewBranchReferenceName("master"), true)
+	checkErr(t, err, "fetching branch reference")
+
+	assert.Equal(commitHash, reference.Hash(), "remote branch should have been updated")
+}
+
 func TestRemote_PushTag_UnavailableRemote(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -93,7 +414,7 @@ func TestRemote_PushTag_UnavailableRemote(t *testing.T) {
 
 	remote := New("origin", "password")
 
-	clonedRepository, err := remote.Clone(testRepository.Path)
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.Path)
 	checkErr(t, err, "cloning repository")
 
 	_, err = clonedRepository.CreateTag(tagName, commitHash, &git.CreateTagOptions{
@@ -110,11 +431,129 @@ func TestRemote_PushTag_UnavailableRemote(t *testing.T) {
 	err = testRepository.Remove()
 	checkErr(t, err, "removing test repository")
 
-	err = remote.PushTag("v1.0.0")
+	err = remote.PushTag(context.Background(), "v1.0.0")
 
 	assert.Error(err)
 }
 
+func TestRemote_PushTag_ConcurrentPush(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	firstHash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	secondHash, err := testRepository.AddCommit("fix")
+	checkErr(t, err, "adding commit to test repository")
+
+	// Two runs releasing the same repository concurrently, each one creating its own tags
+	first, second := New("origin", "password"), New("origin", "password")
+
+	for i, remote := range []*Remote{first, second} {
+		clonedRepository, err := remote.Clone(context.Background(), testRepository.Path)
+		checkErr(t, err, "cloning repository")
+
+		tagger := &object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: time.Now().Add(time.Duration(i) * time.Minute)}
+
+		_, err = clonedRepository.CreateTag("v1.0.0", secondHash, &git.CreateTagOptions{Message: "v1.0.0", Tagger: tagger})
+		checkErr(t, err, "creating tag on cloned repository")
+
+		target := []plumbing.Hash{firstHash, secondHash}[i]
+
+		_, err = clonedRepository.CreateTag("v2.0.0", target, &git.CreateTagOptions{Message: "v2.0.0", Tagger: tagger})
+		checkErr(t, err, "creating tag on cloned repository")
+	}
+
+	err = first.PushTag(context.Background(), "v1.0.0")
+	checkErr(t, err, "pushing tag")
+
+	err = second.PushTag(context.Background(), "v1.0.0")
+	assert.NoError(err, "tag pushed concurrently on the same commit should be accepted")
+
+	err = first.PushTag(context.Background(), "v2.0.0")
+	checkErr(t, err, "pushing tag")
+
+	err = second.PushTag(context.Background(), "v2.0.0")
+	assert.ErrorIs(err, ErrTagConflict, "tag pushed concurrently on another commit should be rejected")
+
+	reference, err := testRepository.Reference(plumbing.NewTagReferenceName("v2.0.0"), true)
+	checkErr(t, err, "fetching tag reference")
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, err, "fetching tag object")
+
+	assert.Equal(firstHash, tagObject.Target, "remote tag should not have been moved")
+}
+
+func TestRemote_PushTag_Retries(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	err = testRepository.StartHTTPServer()
+	checkErr(t, err, "starting HTTP server")
+
+	target, err := url.Parse(testRepository.RemoteURL)
+	checkErr(t, err, "parsing remote URL")
+
+	// The proxy fails the given number of pushes before forwarding requests to the served repository
+	var failures atomic.Int32
+
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: target.Scheme, Host: target.Host})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("service") == "git-receive-pack" && failures.Add(-1) >= 0 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+
+		proxy.ServeHTTP(w, req)
+	}))
+	defer server.Close()
+
+	remoteURL := server.URL + target.Path
+
+	push := func(remote *Remote, tagName string) error {
+		clonedRepository, err := remote.Clone(context.Background(), remoteURL)
+		checkErr(t, err, "cloning repository")
+
+		head, err := clonedRepository.Head()
+		checkErr(t, err, "fetching head")
+
+		_, err = clonedRepository.CreateTag(tagName, head.Hash(), nil)
+		checkErr(t, err, "creating tag on cloned repository")
+
+		return remote.PushTag(context.Background(), tagName)
+	}
+
+	failures.Store(1)
+
+	err = push(New("origin", "password"), "v1.0.0")
+	assert.Error(err, "push should not have been retried by default")
+
+	failures.Store(2)
+
+	err = push(New("origin", "password", WithPushRetries(2, time.Millisecond)), "v1.0.0")
+	checkErr(t, err, "pushing tag with retries")
+
+	exists, err := tag.Exists(testRepository.Repository, "v1.0.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "tag should have been pushed once the remote is available")
+}
+
 func checkErr(t *testing.T, err error, msg string) {
 	t.Helper()
 	if err != nil {
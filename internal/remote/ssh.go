@@ -0,0 +1,87 @@
+package remote
+
+import (
+	"fmt"
+
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	cryptossh "golang.org/x/crypto/ssh"
+)
+
+// defaultSSHUser is the user of SSH URLs that do not set one, such as "ssh://github.com/owner/repository.git".
+const defaultSSHUser = "git"
+
+// sshOptions configure the authentication to remotes using SSH URLs.
+type sshOptions struct {
+	keyPath               string
+	keyPassphrase         string
+	knownHostsPaths       []string
+	insecureIgnoreHostKey bool
+}
+
+// WithSSHKey authenticates to remotes using SSH URLs with the given private key file, decrypted using the given
+// passphrase if not empty. By default, the keys of the SSH agent are used.
+func WithSSHKey(path, passphrase string) OptionFunc {
+	return func(r *Remote) {
+		r.ssh.keyPath = path
+		r.ssh.keyPassphrase = passphrase
+	}
+}
+
+// WithSSHKnownHosts checks the host keys of remotes using SSH URLs against the given known_hosts files. By default,
+// the files listed by the SSH_KNOWN_HOSTS environment variable, or else ~/.ssh/known_hosts and
+// /etc/ssh/ssh_known_hosts, are used.
+func WithSSHKnownHosts(paths ...string) OptionFunc {
+	return func(r *Remote) {
+		r.ssh.knownHostsPaths = append(r.ssh.knownHostsPaths, paths...)
+	}
+}
+
+// WithInsecureIgnoreHostKey accepts any host key from remotes using SSH URLs, which exposes to man-in-the-middle
+// attacks and should only be used on trusted networks.
+func WithInsecureIgnoreHostKey() OptionFunc {
+	return func(r *Remote) {
+		r.ssh.insecureIgnoreHostKey = true
+	}
+}
+
+// sshAuthMethod returns the authentication method of the given user to a remote using an SSH URL.
+func (r *Remote) sshAuthMethod(user string) (gitssh.AuthMethod, error) {
+	if user == "" {
+		user = defaultSSHUser
+	}
+
+	var (
+		auth   gitssh.AuthMethod
+		helper *gitssh.HostKeyCallbackHelper
+	)
+
+	if r.ssh.keyPath != "" {
+		publicKeys, err := gitssh.NewPublicKeysFromFile(user, r.ssh.keyPath, r.ssh.keyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("loading SSH key: %w", err)
+		}
+
+		auth, helper = publicKeys, &publicKeys.HostKeyCallbackHelper
+	} else {
+		agent, err := gitssh.NewSSHAgentAuth(user)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to SSH agent: %w", err)
+		}
+
+		auth, helper = agent, &agent.HostKeyCallbackHelper
+	}
+
+	switch {
+	case r.ssh.insecureIgnoreHostKey:
+		helper.HostKeyCallback = cryptossh.InsecureIgnoreHostKey()
+	case len(r.ssh.knownHostsPaths) > 0:
+		callback, err := gitssh.NewKnownHostsCallback(r.ssh.knownHostsPaths...)
+		if err != nil {
+			return nil, fmt.Errorf("loading known hosts: %w", err)
+		}
+
+		helper.HostKeyCallback = callback
+	}
+
+	return auth, nil
+}
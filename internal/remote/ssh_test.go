@@ -0,0 +1,192 @@
+package remote
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	assertion "github.com/stretchr/testify/assert"
+	cryptossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func TestRemote_AuthMethod_HTTP(t *testing.T) {
+	assert := assertion.New(t)
+
+	remote := New("origin", "token", WithSSHKey(writeSSHKey(t), ""))
+
+	auth, err := remote.authMethod("https://github.com/owner/repository.git")
+	checkErr(t, err, "configuring authentication")
+
+	basicAuth, ok := auth.(*http.BasicAuth)
+	assert.True(ok, "access token should be used for HTTP remotes")
+
+	if ok {
+		assert.Equal(defaultUsername, basicAuth.Username)
+		assert.Equal("token", basicAuth.Password)
+	}
+
+	remote = New("origin", "token", WithUsername("x-access-token"))
+
+	auth, err = remote.authMethod("https://github.com/owner/repository.git")
+	checkErr(t, err, "configuring authentication")
+
+	basicAuth, ok = auth.(*http.BasicAuth)
+	assert.True(ok, "access token should be used for HTTP remotes")
+
+	if ok {
+		assert.Equal("x-access-token", basicAuth.Username)
+	}
+}
+
+func TestRemote_AuthMethod_SSHKey(t *testing.T) {
+	assert := assertion.New(t)
+
+	remote := New("origin", "token", WithSSHKey(writeSSHKey(t), ""), WithInsecureIgnoreHostKey())
+
+	tests := []struct {
+		url  string
+		user string
+	}{
+		{url: "git@github.com:owner/repository.git", user: "git"},
+		{url: "ssh://deploy@git.example.com:2222/owner/repository.git", user: "deploy"},
+		{url: "ssh://git.example.com/owner/repository.git", user: defaultSSHUser},
+	}
+
+	for _, tc := range tests {
+		auth, err := remote.authMethod(tc.url)
+		checkErr(t, err, "configuring authentication")
+
+		publicKeys, ok := auth.(*gitssh.PublicKeys)
+		assert.True(ok, "SSH key should be used for SSH remotes")
+
+		if ok {
+			assert.Equal(tc.user, publicKeys.User)
+			assert.NotNil(publicKeys.HostKeyCallback, "host key check should be disabled")
+		}
+	}
+}
+
+func TestRemote_AuthMethod_SSHKnownHosts(t *testing.T) {
+	assert := assertion.New(t)
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, err, "generating host key")
+
+	sshPublicKey, err := cryptossh.NewPublicKey(publicKey)
+	checkErr(t, err, "converting host key")
+
+	knownHostsPath := filepath.Join(t.TempDir(), "known_hosts")
+
+	err = os.WriteFile(knownHostsPath, []byte("git.example.com "+string(cryptossh.MarshalAuthorizedKey(sshPublicKey))), 0o600)
+	checkErr(t, err, "writing known hosts")
+
+	remote := New("origin", "", WithSSHKey(writeSSHKey(t), ""), WithSSHKnownHosts(knownHostsPath))
+
+	auth, err := remote.authMethod("git@git.example.com:owner/repository.git")
+	checkErr(t, err, "configuring authentication")
+
+	publicKeys, ok := auth.(*gitssh.PublicKeys)
+	assert.True(ok)
+
+	if ok {
+		assert.NotNil(publicKeys.HostKeyCallback, "known hosts should be checked")
+	}
+
+	remote = New("origin", "", WithSSHKey(writeSSHKey(t), ""), WithSSHKnownHosts(filepath.Join(t.TempDir(), "missing")))
+
+	_, err = remote.authMethod("git@git.example.com:owner/repository.git")
+	assert.Error(err, "missing known hosts should not be ignored")
+}
+
+func TestRemote_PushTag_SSH(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	keyPath := writeSSHKey(t)
+
+	key, err := os.ReadFile(keyPath)
+	checkErr(t, err, "reading key")
+
+	signer, err := cryptossh.ParsePrivateKey(key)
+	checkErr(t, err, "parsing key")
+
+	err = testRepository.StartSSHServer(gittest.WithAuthorizedKey(signer.PublicKey()))
+	checkErr(t, err, "starting SSH server")
+
+	endpoint, err := transport.NewEndpoint(testRepository.RemoteURL)
+	checkErr(t, err, "parsing remote URL")
+
+	host := knownhosts.Normalize(fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port))
+	knownHostsPath := filepath.Join(t.TempDir(), "known_hosts")
+
+	err = os.WriteFile(knownHostsPath, []byte(knownhosts.Line([]string{host}, testRepository.SSHHostKey)+"\n"), 0o600)
+	checkErr(t, err, "writing known hosts")
+
+	_, err = New("origin", "", WithSSHKey(writeSSHKey(t), ""), WithSSHKnownHosts(knownHostsPath)).
+		Clone(context.Background(), testRepository.RemoteURL)
+	assert.Error(err, "clone with an unauthorized key should have been rejected")
+
+	remote := New("origin", "", WithSSHKey(keyPath, ""), WithSSHKnownHosts(knownHostsPath))
+
+	clonedRepository, err := remote.Clone(context.Background(), testRepository.RemoteURL)
+	checkErr(t, err, "cloning repository")
+
+	head, err := clonedRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	_, err = clonedRepository.CreateTag("v1.0.0", head.Hash(), nil)
+	checkErr(t, err, "creating tag on cloned repository")
+
+	err = remote.PushTag(context.Background(), "v1.0.0")
+	checkErr(t, err, "pushing tag to remote")
+
+	assert.True(tag.Exists(testRepository.Repository, "v1.0.0"))
+}
+
+func TestRemote_AuthMethod_InvalidSSHKey(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+
+	err := os.WriteFile(keyPath, []byte("not a key"), 0o600)
+	checkErr(t, err, "writing key")
+
+	remote := New("origin", "", WithSSHKey(keyPath, ""))
+
+	_, err = remote.authMethod("git@github.com:owner/repository.git")
+	assertion.Error(t, err)
+}
+
+// writeSSHKey writes a new unencrypted OpenSSH private key and returns its path.
+func writeSSHKey(t *testing.T) string {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, err, "generating key")
+
+	block, err := cryptossh.MarshalPrivateKey(privateKey, "")
+	checkErr(t, err, "marshalling key")
+
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+
+	err = os.WriteFile(keyPath, pem.EncodeToMemory(block), 0o600)
+	checkErr(t, err, "writing key")
+
+	return keyPath
+}
@@ -0,0 +1,23 @@
+package remote
+
+// tlsOptions are the TLS settings of the connections to remotes using HTTPS URLs.
+type tlsOptions struct {
+	caBundle           []byte
+	insecureSkipVerify bool
+}
+
+// WithCABundle trusts the certificate authorities of the given PEM bundle, in addition to the system ones, when
+// connecting to remotes using HTTPS URLs.
+func WithCABundle(pem []byte) OptionFunc {
+	return func(r *Remote) {
+		r.tls.caBundle = pem
+	}
+}
+
+// WithInsecureSkipTLSVerify accepts any certificate from remotes using HTTPS URLs, which exposes to man-in-the-middle
+// attacks and should only be used on trusted networks.
+func WithInsecureSkipTLSVerify() OptionFunc {
+	return func(r *Remote) {
+		r.tls.insecureSkipVerify = true
+	}
+}
@@ -0,0 +1,63 @@
+package remote
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+func TestRemote_TLS(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, err, "creating test repository")
+
+	defer func() {
+		err = testRepository.Remove()
+		checkErr(t, err, "removing test repository")
+	}()
+
+	err = testRepository.StartHTTPServer()
+	checkErr(t, err, "starting HTTP server")
+
+	target, err := url.Parse(testRepository.RemoteURL)
+	checkErr(t, err, "parsing remote URL")
+
+	server := httptest.NewTLSServer(httputil.NewSingleHostReverseProxy(&url.URL{Scheme: target.Scheme, Host: target.Host}))
+	defer server.Close()
+
+	remoteURL := server.URL + target.Path
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	_, err = New("origin", "password").Clone(context.Background(), remoteURL)
+	assert.Error(err, "certificate of an unknown authority should have been rejected")
+
+	_, err = New("origin", "password", WithInsecureSkipTLSVerify()).Clone(context.Background(), remoteURL)
+	assert.NoError(err, "certificate should not have been verified")
+
+	remote := New("origin", "password", WithCABundle(caBundle))
+
+	clonedRepository, err := remote.Clone(context.Background(), remoteURL)
+	checkErr(t, err, "cloning repository with CA bundle")
+
+	head, err := clonedRepository.Head()
+	checkErr(t, err, "fetching head")
+
+	_, err = clonedRepository.CreateTag("v1.0.0", head.Hash(), nil)
+	checkErr(t, err, "creating tag on cloned repository")
+
+	err = remote.PushTag(context.Background(), "v1.0.0")
+	checkErr(t, err, "pushing tag with CA bundle")
+
+	exists, err := tag.Exists(testRepository.Repository, "v1.0.0")
+	checkErr(t, err, "checking if tag exists")
+	assert.True(exists, "tag should have been pushed")
+}
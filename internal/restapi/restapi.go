@@ -0,0 +1,143 @@
+// Package restapi provides a minimal JSON REST client, shared by the clients of the APIs of the Git forges.
+package restapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// maxErrorLength is the maximum length of the response body reported in a ResponseError.
+const maxErrorLength = 512
+
+var ErrUnexpectedResponse = errors.New("unexpected API response")
+
+// ResponseError is returned when an API responds with an unexpected status.
+type ResponseError struct {
+	Method     string
+	Path       string
+	Status     string
+	Message    string
+	StatusCode int
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%s: %s %s: %s: %s", ErrUnexpectedResponse, e.Method, e.Path, e.Status, e.Message)
+}
+
+func (e *ResponseError) Unwrap() error {
+	return ErrUnexpectedResponse
+}
+
+// HasStatus returns true if the given error is a ResponseError of the given status code.
+func HasStatus(err error, statusCode int) bool {
+	var responseError *ResponseError
+	return errors.As(err, &responseError) && responseError.StatusCode == statusCode
+}
+
+type Client struct {
+	HTTPClient *http.Client
+	// Header is added to every request (e.g., the authentication header).
+	Header  http.Header
+	BaseURL string
+}
+
+func New(baseURL string) *Client {
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		Header:     make(http.Header),
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Do sends a request with the given method to the given path, relative to the base URL, encoding the given body, if
+// not nil, as JSON. The response is decoded into the given result, if not nil, when its status is one of the expected
+// ones.
+func (c *Client) Do(ctx context.Context, method, path string, body, result any, expectedStatus ...int) error {
+	if body == nil {
+		return c.Send(ctx, method, path, "", nil, result, expectedStatus...)
+	}
+
+	content, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encoding request body: %w", err)
+	}
+
+	return c.Send(ctx, method, path, "application/json", bytes.NewReader(content), result, expectedStatus...)
+}
+
+// Send sends a request with the given method to the given path, relative to the base URL, whose body, if not nil, is
+// of the given content type. The response is decoded into the given result, if not nil, when its status is one of the
+// expected ones.
+func (c *Client) Send(ctx context.Context, method, path, contentType string, body io.Reader, result any, expectedStatus ...int) error {
+	request, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	for key, values := range c.Header {
+		request.Header[key] = values
+	}
+
+	request.Header.Set("User-Agent", "go-semver-release")
+
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if !slices.Contains(expectedStatus, response.StatusCode) {
+		return &ResponseError{
+			Method:     method,
+			Path:       path,
+			Status:     response.Status,
+			Message:    errorMessage(response.Body),
+			StatusCode: response.StatusCode,
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err = json.NewDecoder(response.Body).Decode(result); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
+
+// errorMessage returns the message of an error response, read from its "message" field, or the "message" field of its
+// "error" object, if it is a JSON object having one, or else the beginning of its body.
+func errorMessage(body io.Reader) string {
+	content, _ := io.ReadAll(io.LimitReader(body, maxErrorLength))
+
+	var apiError struct {
+		Message string `json:"message"`
+		Error   struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if json.Unmarshal(content, &apiError) == nil {
+		switch {
+		case apiError.Message != "":
+			return apiError.Message
+		case apiError.Error.Message != "":
+			return apiError.Error.Message
+		}
+	}
+
+	return strings.TrimSpace(string(content))
+}
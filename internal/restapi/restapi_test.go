@@ -0,0 +1,62 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestClient_Do(t *testing.T) {
+	assert := assertion.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+			return
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("invalid body"))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"name": body["name"], "path": r.URL.Path})
+	}))
+	defer server.Close()
+
+	client := New(server.URL + "/")
+	client.Header.Set("Authorization", "Bearer token")
+
+	var result map[string]string
+
+	err := client.Do(context.Background(), http.MethodPost, "/tags", map[string]string{"name": "v1.0.0"}, &result, http.StatusCreated)
+	checkErr(t, "sending request", err)
+
+	assert.Equal(map[string]string{"name": "v1.0.0", "path": "/tags"}, result)
+
+	err = client.Do(context.Background(), http.MethodPost, "/tags", nil, nil, http.StatusCreated)
+	assert.ErrorIs(err, ErrUnexpectedResponse)
+	assert.True(HasStatus(err, http.StatusBadRequest))
+	assert.ErrorContains(err, "invalid body", "the body should be reported if it has no message")
+
+	client.Header.Del("Authorization")
+
+	err = client.Do(context.Background(), http.MethodPost, "/tags", nil, nil, http.StatusCreated)
+	assert.True(HasStatus(err, http.StatusUnauthorized))
+	assert.ErrorContains(err, "Bad credentials", "the message of the response should be reported")
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,86 @@
+package rule
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+)
+
+var ErrUnknownFormat = errors.New("unknown rules file format")
+
+var tomlKeyValueRegex = regexp.MustCompile(`(?m)^\s*"?[\w-]+"?\s*=`)
+
+// FromFile reads a rules file formatted in JSON, YAML or TOML and returns the corresponding Rules. The format is
+// deduced from the file extension or, if the extension is unknown, from the file content.
+func FromFile(path string) (Rules, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Rules{}, fmt.Errorf("reading rules file: %w", err)
+	}
+
+	format := detectFormat(path, content)
+
+	input, err := decode(format, content)
+	if err != nil {
+		return Rules{}, fmt.Errorf("decoding %s rules file: %w", format, err)
+	}
+
+	return Unmarshall(input)
+}
+
+// detectFormat returns the format of a rules file based on its extension or, as a fallback, by sniffing its content.
+func detectFormat(path string, content []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	}
+
+	trimmed := bytes.TrimSpace(content)
+
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		return FormatJSON
+	case tomlKeyValueRegex.Match(trimmed):
+		return FormatTOML
+	default:
+		return FormatYAML
+	}
+}
+
+func decode(format string, content []byte) (map[string][]string, error) {
+	var (
+		input map[string][]string
+		err   error
+	)
+
+	switch format {
+	case FormatJSON:
+		err = json.Unmarshal(content, &input)
+	case FormatYAML:
+		err = yaml.Unmarshal(content, &input)
+	case FormatTOML:
+		err = toml.Unmarshal(content, &input)
+	default:
+		err = ErrUnknownFormat
+	}
+
+	return input, err
+}
@@ -0,0 +1,76 @@
+package rule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestRule_FromFile(t *testing.T) {
+	assert := assertion.New(t)
+
+	want := Rules{Map: map[string]string{
+		"feat": "minor",
+		"fix":  "patch",
+		"perf": "patch",
+	}}
+
+	type test struct {
+		name    string
+		content string
+	}
+
+	matrix := []test{
+		{name: "rules.json", content: `{"minor": ["feat"], "patch": ["fix", "perf"]}`},
+		{name: "rules.yaml", content: "minor:\n  - feat\npatch:\n  - fix\n  - perf\n"},
+		{name: "rules.yml", content: "minor: [feat]\npatch: [fix, perf]\n"},
+		{name: "rules.toml", content: "minor = [\"feat\"]\npatch = [\"fix\", \"perf\"]\n"},
+		{name: "json-rules", content: `{"minor": ["feat"], "patch": ["fix", "perf"]}`},
+		{name: "yaml-rules", content: "minor:\n  - feat\npatch:\n  - fix\n  - perf\n"},
+		{name: "toml-rules", content: "minor = [\"feat\"]\npatch = [\"fix\", \"perf\"]\n"},
+	}
+
+	dir := t.TempDir()
+
+	for _, tc := range matrix {
+		path := filepath.Join(dir, tc.name)
+
+		err := os.WriteFile(path, []byte(tc.content), 0o644)
+		if err != nil {
+			t.Fatalf("writing rules file: %s", err)
+		}
+
+		rules, err := FromFile(path)
+		assert.NoError(err, "rules file %q should have been parsed", tc.name)
+		assert.Equal(want, rules, "rules parsed from %q should be equal", tc.name)
+	}
+}
+
+func TestRule_FromFileErrors(t *testing.T) {
+	assert := assertion.New(t)
+
+	dir := t.TempDir()
+
+	_, err := FromFile(filepath.Join(dir, "does-not-exist.yaml"))
+	assert.ErrorContains(err, "reading rules file")
+
+	path := filepath.Join(dir, "rules.json")
+
+	err = os.WriteFile(path, []byte(`{"minor": "feat"`), 0o644)
+	if err != nil {
+		t.Fatalf("writing rules file: %s", err)
+	}
+
+	_, err = FromFile(path)
+	assert.ErrorContains(err, "decoding json rules file")
+
+	err = os.WriteFile(path, []byte(`{"major": ["feat"]}`), 0o644)
+	if err != nil {
+		t.Fatalf("writing rules file: %s", err)
+	}
+
+	_, err = FromFile(path)
+	assert.ErrorIs(err, ErrInvalidReleaseType)
+}
@@ -0,0 +1,82 @@
+package rule
+
+import (
+	"errors"
+	"maps"
+	"slices"
+)
+
+var ErrUnknownPreset = errors.New("unknown rule preset")
+
+// presets are the built-in release rules selectable by name.
+var presets = map[string]Rules{
+	// conventional are the default rules.
+	"conventional": Default,
+	// angular follows the Angular commit message guidelines, where reverts do not trigger a release by themselves.
+	"angular": {
+		Map: map[string]string{
+			"feat":     "minor",
+			"fix":      "patch",
+			"perf":     "patch",
+			"build":    None,
+			"chore":    None,
+			"ci":       None,
+			"docs":     None,
+			"refactor": None,
+			"revert":   None,
+			"style":    None,
+			"test":     None,
+		},
+	},
+	// strict only releases features and fixes, every other commit type being explicitly ignored.
+	"strict": {
+		Map: map[string]string{
+			"feat":     "minor",
+			"fix":      "patch",
+			"build":    None,
+			"chore":    None,
+			"ci":       None,
+			"docs":     None,
+			"perf":     None,
+			"refactor": None,
+			"revert":   None,
+			"style":    None,
+			"test":     None,
+		},
+	},
+	// minimal only defines rules for features and fixes, other commit types have no rule.
+	"minimal": {
+		Map: map[string]string{
+			"feat": "minor",
+			"fix":  "patch",
+		},
+	},
+}
+
+// PresetNames returns the names of the built-in presets, sorted alphabetically.
+func PresetNames() []string {
+	return slices.Sorted(maps.Keys(presets))
+}
+
+// Preset returns a copy of the built-in release rules of the given name.
+func Preset(name string) (Rules, error) {
+	preset, ok := presets[name]
+	if !ok {
+		return Rules{}, ErrUnknownPreset
+	}
+
+	return Rules{Map: maps.Clone(preset.Map)}, nil
+}
+
+// Merge returns the given base rules overridden by the given rules: a commit type defined by both is given the release
+// type of the overrides, whatever the order in which rules were defined.
+func Merge(base, overrides Rules) Rules {
+	merged := Rules{Map: maps.Clone(base.Map)}
+	if merged.Map == nil {
+		merged.Map = make(map[string]string)
+	}
+
+	maps.Copy(merged.Map, overrides.Map)
+
+	return merged
+}
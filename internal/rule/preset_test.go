@@ -0,0 +1,55 @@
+package rule
+
+import (
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestRule_Preset(t *testing.T) {
+	assert := assertion.New(t)
+
+	assert.Equal([]string{"angular", "conventional", "minimal", "strict"}, PresetNames())
+
+	rules, err := Preset("conventional")
+	if err != nil {
+		t.Fatalf("fetching preset: %s", err)
+	}
+
+	assert.Equal(Default, rules)
+
+	rules.Map["feat"] = "patch"
+	assert.Equal("minor", Default.Map["feat"], "preset should be a copy")
+
+	rules, err = Preset("minimal")
+	if err != nil {
+		t.Fatalf("fetching preset: %s", err)
+	}
+
+	assert.Equal(Rules{Map: map[string]string{"feat": "minor", "fix": "patch"}}, rules)
+
+	for _, name := range PresetNames() {
+		rules, _ = Preset(name)
+
+		for commitType, releaseType := range rules.Map {
+			assert.Contains(validCommitTypes, commitType, name)
+			assert.Contains(validReleaseTypes, releaseType, name)
+		}
+	}
+
+	_, err = Preset("unknown")
+	assert.ErrorIs(err, ErrUnknownPreset)
+}
+
+func TestRule_Merge(t *testing.T) {
+	assert := assertion.New(t)
+
+	base := Rules{Map: map[string]string{"feat": "minor", "fix": "patch", "perf": None}}
+	overrides := Rules{Map: map[string]string{"perf": "patch", "refactor": "patch"}}
+
+	want := Rules{Map: map[string]string{"feat": "minor", "fix": "patch", "perf": "patch", "refactor": "patch"}}
+
+	assert.Equal(want, Merge(base, overrides))
+	assert.Equal(None, base.Map["perf"], "base rules should not be modified")
+	assert.Equal(overrides, Merge(Rules{}, overrides))
+}
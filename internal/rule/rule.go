@@ -3,18 +3,30 @@ package rule
 
 import (
 	"errors"
+	"maps"
+	"slices"
 )
 
+// None is the release type of commit types that are explicitly ignored when computing a new release.
+const None = "none"
+
 type Rules struct {
 	Map map[string]string
 }
 
 var Default = Rules{
 	Map: map[string]string{
-		"feat":   "minor",
-		"fix":    "patch",
-		"perf":   "patch",
-		"revert": "patch",
+		"feat":     "minor",
+		"fix":      "patch",
+		"perf":     "patch",
+		"revert":   "patch",
+		"build":    None,
+		"chore":    None,
+		"ci":       None,
+		"docs":     None,
+		"refactor": None,
+		"style":    None,
+		"test":     None,
 	},
 }
 
@@ -39,9 +51,15 @@ var validCommitTypes = map[string]struct{}{
 	"test":     {},
 }
 
+// CommitTypes returns the commit types that release rules can be defined for, sorted alphabetically.
+func CommitTypes() []string {
+	return slices.Sorted(maps.Keys(validCommitTypes))
+}
+
 var validReleaseTypes = map[string]struct{}{
 	"minor": {},
 	"patch": {},
+	None:    {},
 }
 
 // Unmarshall takes a raw Viper configuration and returns a Rules struct representing release rules configuration.
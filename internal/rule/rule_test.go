@@ -35,6 +35,8 @@ func TestRule_UnmarshallError(t *testing.T) {
 
 	tests := []test{
 		{have: map[string][]string{"minor": {"feat"}, "patch": {"fix", "perf"}}, want: nil},
+		{have: map[string][]string{"minor": {"feat"}, "none": {"chore", "docs"}}, want: nil},
+		{have: map[string][]string{"patch": {"fix"}, "none": {"fix"}}, want: ErrDuplicateReleaseRule},
 		{have: map[string][]string{"unknown": {"feat"}, "patch": {"perf"}}, want: ErrInvalidReleaseType},
 		{have: map[string][]string{"minor": {"unknown"}, "patch": {"perf"}}, want: ErrInvalidCommitType},
 		{have: map[string][]string{"minor": {"feat"}, "patch": {"fix", "feat"}}, want: ErrDuplicateReleaseRule},
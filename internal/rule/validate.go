@@ -0,0 +1,150 @@
+package rule
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is the JSON Schema of rules files, which editors can use to complete and check them.
+//
+//go:embed schema.json
+var Schema []byte
+
+var ErrInvalidRulesFile = errors.New("invalid rules file")
+
+// Diagnostic is a problem found in a rules file.
+type Diagnostic struct {
+	// Path locates the problem in the file, such as "minor[1]" for the second commit type of the minor release type,
+	// empty if the problem concerns the whole file.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	if d.Path == "" {
+		return d.Message
+	}
+
+	return d.Path + ": " + d.Message
+}
+
+// Validate checks the rules file at the given path, formatted in JSON, YAML or TOML, and returns every problem found
+// in it, sorted by release type, none if the file is valid. Unlike FromFile, which stops at the first problem, every
+// unknown release type, unknown commit type and duplicate commit type is reported.
+func Validate(path string) ([]Diagnostic, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules file: %w", err)
+	}
+
+	format := detectFormat(path, content)
+
+	var input map[string]any
+
+	switch format {
+	case FormatJSON:
+		err = json.Unmarshal(content, &input)
+		err = jsonPosition(content, err)
+	case FormatYAML:
+		err = yaml.Unmarshal(content, &input)
+	case FormatTOML:
+		err = toml.Unmarshal(content, &input)
+	}
+	if err != nil {
+		return []Diagnostic{{Message: fmt.Sprintf("invalid %s: %s", format, err)}}, nil
+	}
+
+	if len(input) == 0 {
+		return []Diagnostic{{Message: ErrNoRules.Error()}}, nil
+	}
+
+	var diagnostics []Diagnostic
+
+	// releaseTypes maps the commit types listed so far to their release type
+	releaseTypes := make(map[string]string)
+
+	for _, releaseType := range slices.Sorted(maps.Keys(input)) {
+		if _, ok := validReleaseTypes[releaseType]; !ok {
+			diagnostics = append(diagnostics, Diagnostic{
+				Path:    releaseType,
+				Message: fmt.Sprintf("unknown release type %q, expected one of %s", releaseType, strings.Join(slices.Sorted(maps.Keys(validReleaseTypes)), ", ")),
+			})
+
+			continue
+		}
+
+		commitTypes, ok := input[releaseType].([]any)
+		if !ok {
+			diagnostics = append(diagnostics, Diagnostic{Path: releaseType, Message: "expected a list of commit types"})
+			continue
+		}
+
+		for i, item := range commitTypes {
+			path := fmt.Sprintf("%s[%d]", releaseType, i)
+
+			commitType, ok := item.(string)
+			if !ok {
+				diagnostics = append(diagnostics, Diagnostic{Path: path, Message: fmt.Sprintf("expected a commit type, got %v", item)})
+				continue
+			}
+
+			if _, ok := validCommitTypes[commitType]; !ok {
+				diagnostics = append(diagnostics, Diagnostic{
+					Path:    path,
+					Message: fmt.Sprintf("unknown commit type %q, expected one of %s", commitType, strings.Join(CommitTypes(), ", ")),
+				})
+
+				continue
+			}
+
+			previous, ok := releaseTypes[commitType]
+
+			switch {
+			case ok && previous == releaseType:
+				diagnostics = append(diagnostics, Diagnostic{Path: path, Message: fmt.Sprintf("commit type %q is listed twice", commitType)})
+			case ok:
+				diagnostics = append(diagnostics, Diagnostic{Path: path, Message: fmt.Sprintf("commit type %q is already given the %s release type", commitType, previous)})
+			default:
+				releaseTypes[commitType] = releaseType
+			}
+		}
+	}
+
+	return diagnostics, nil
+}
+
+// jsonPosition adds the line and column of the JSON syntax and type errors, which only give their byte offset, to the
+// given error.
+func jsonPosition(content []byte, err error) error {
+	var offset int64
+
+	var (
+		syntaxError *json.SyntaxError
+		typeError   *json.UnmarshalTypeError
+	)
+
+	switch {
+	case errors.As(err, &syntaxError):
+		offset = syntaxError.Offset
+	case errors.As(err, &typeError):
+		offset = typeError.Offset
+	default:
+		return err
+	}
+
+	before := content[:min(int(offset), len(content))]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+
+	return fmt.Errorf("line %d, column %d: %w", line, column, err)
+}
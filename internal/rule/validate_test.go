@@ -0,0 +1,97 @@
+package rule
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestRule_Validate(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		name    string
+		content string
+		want    []Diagnostic
+	}
+
+	matrix := []test{
+		{name: "valid.json", content: `{"minor": ["feat"], "patch": ["fix", "perf"]}`},
+		{
+			name:    "unknown.yaml",
+			content: "minor: [feat]\nmajor: [feat]\npatch: [fix, bugfix]\n",
+			want: []Diagnostic{
+				{Path: "major", Message: `unknown release type "major", expected one of minor, none, patch`},
+				{Path: "patch[1]", Message: `unknown commit type "bugfix", expected one of build, chore, ci, docs, feat, fix, perf, refactor, revert, style, test`},
+			},
+		},
+		{
+			name:    "duplicates.toml",
+			content: "minor = [\"feat\"]\npatch = [\"fix\", \"feat\", \"fix\"]\n",
+			want: []Diagnostic{
+				{Path: "patch[1]", Message: `commit type "feat" is already given the minor release type`},
+				{Path: "patch[2]", Message: `commit type "fix" is listed twice`},
+			},
+		},
+		{
+			name:    "types.json",
+			content: `{"minor": "feat", "patch": [1]}`,
+			want: []Diagnostic{
+				{Path: "minor", Message: "expected a list of commit types"},
+				{Path: "patch[0]", Message: "expected a commit type, got 1"},
+			},
+		},
+		{name: "empty.json", content: `{}`, want: []Diagnostic{{Message: ErrNoRules.Error()}}},
+		{
+			name:    "syntax.json",
+			content: "{\n  \"minor\": [\"feat\",]\n}",
+			want:    []Diagnostic{{Message: "invalid json: line 2, column 21: invalid character ']' looking for beginning of value"}},
+		},
+	}
+
+	dir := t.TempDir()
+
+	for _, tc := range matrix {
+		path := filepath.Join(dir, tc.name)
+
+		err := os.WriteFile(path, []byte(tc.content), 0o644)
+		if err != nil {
+			t.Fatalf("writing rules file: %s", err)
+		}
+
+		diagnostics, err := Validate(path)
+		assert.NoError(err, "rules file %q should have been validated", tc.name)
+		assert.Equal(tc.want, diagnostics, "diagnostics of %q should be equal", tc.name)
+	}
+
+	_, err := Validate(filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestRule_Schema(t *testing.T) {
+	assert := assertion.New(t)
+
+	var schema struct {
+		Properties map[string]any `json:"properties"`
+		Defs       struct {
+			CommitTypes struct {
+				Items struct {
+					Enum []string `json:"enum"`
+				} `json:"items"`
+			} `json:"commitTypes"`
+		} `json:"$defs"`
+	}
+
+	err := json.Unmarshal(Schema, &schema)
+	if err != nil {
+		t.Fatalf("decoding schema: %s", err)
+	}
+
+	assert.Equal(slices.Sorted(maps.Keys(validReleaseTypes)), slices.Sorted(maps.Keys(schema.Properties)), "schema should list every release type")
+	assert.Equal(CommitTypes(), schema.Defs.CommitTypes.Items.Enum, "schema should list every commit type")
+}
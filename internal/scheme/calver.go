@@ -0,0 +1,107 @@
+package scheme
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+var ErrInvalidCalVerFormat = errors.New("invalid CalVer format")
+
+// DefaultCalVerFormat is the format of the versions of the Calendar Versioning scheme if none is given.
+const DefaultCalVerFormat = "YYYY.MM.MICRO"
+
+// dateTokens are the date components of a CalVer format and their value for a given date. Zero-padded tokens (e.g.,
+// "0M") are not supported since the components of a semantic version cannot have leading zeroes.
+var dateTokens = map[string]func(date time.Time) int{
+	"YYYY": func(date time.Time) int { return date.Year() },
+	"YY":   func(date time.Time) int { return date.Year() - 2000 },
+	"MM":   func(date time.Time) int { return int(date.Month()) },
+	"WW":   func(date time.Time) int { return (date.YearDay()-1)/7 + 1 },
+	"DD":   func(date time.Time) int { return date.Day() },
+}
+
+// CalVer is the Calendar Versioning scheme, where versions start with the date of the release (e.g., "2024.5.2").
+type CalVer struct {
+	tokens []string
+}
+
+// NewCalVer returns a Calendar Versioning scheme using the given format, such as "YYYY.MM.MICRO" or "YY.MM.MICRO". A
+// format is made of three dot-separated components: a date component (i.e., "YYYY", "YY", "MM", "WW" or "DD"), a date
+// component or "MINOR", and "MICRO" (or "PATCH").
+func NewCalVer(format string) (CalVer, error) {
+	tokens := strings.Split(format, ".")
+
+	if len(tokens) != 3 {
+		return CalVer{}, fmt.Errorf("%w %q: expected three components", ErrInvalidCalVerFormat, format)
+	}
+
+	if _, ok := dateTokens[tokens[0]]; !ok {
+		return CalVer{}, fmt.Errorf("%w %q: first component must be a date", ErrInvalidCalVerFormat, format)
+	}
+
+	if _, ok := dateTokens[tokens[1]]; !ok && tokens[1] != "MINOR" {
+		return CalVer{}, fmt.Errorf("%w %q: second component must be a date or MINOR", ErrInvalidCalVerFormat, format)
+	}
+
+	if tokens[2] != "MICRO" && tokens[2] != "PATCH" {
+		return CalVer{}, fmt.Errorf("%w %q: last component must be MICRO or PATCH", ErrInvalidCalVerFormat, format)
+	}
+
+	return CalVer{tokens: tokens}, nil
+}
+
+// Bump sets the date components of the given version to the given date, in UTC, and resets its other components if
+// the date changed. Otherwise, a major or minor release increments the "MINOR" component, if any, and any other
+// release increments the "MICRO" component. A date preceding the one of the version is ignored so that versions keep
+// increasing. As with semantic versions, a prerelease of a version is bumped to the version it precedes.
+func (c CalVer) Bump(version *semver.Version, releaseType string, date time.Time) error {
+	if !slices.Contains([]string{"major", "minor", "patch"}, releaseType) {
+		return fmt.Errorf("%w %q", ErrUnknownReleaseType, releaseType)
+	}
+
+	date = date.UTC()
+	components := []*int{&version.Major, &version.Minor, &version.Patch}
+
+	var current, next []int
+
+	for i, token := range c.tokens {
+		if value, ok := dateTokens[token]; ok {
+			current = append(current, *components[i])
+			next = append(next, value(date))
+		}
+	}
+
+	prerelease := version.Prerelease
+	version.Prerelease = ""
+	version.Metadata = ""
+
+	// A new period resets the components following the date ones
+	if slices.Compare(next, current) > 0 {
+		for i, token := range c.tokens {
+			if value, ok := dateTokens[token]; ok {
+				*components[i] = value(date)
+			} else {
+				*components[i] = 0
+			}
+		}
+
+		return nil
+	}
+
+	switch {
+	case prerelease != "":
+		// The prerelease of a version precedes it, the version is released as is
+	case releaseType != "patch" && c.tokens[1] == "MINOR":
+		version.Minor++
+		version.Patch = 0
+	default:
+		version.Patch++
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+// Package scheme provides the versioning schemes used to compute the next version of a release.
+//
+// Every scheme produces versions made of three numeric components so that they are valid semantic versions and can be
+// tagged, compared and parsed the same way. The commit analysis decides whether a new release is warranted and which
+// release type it is, the scheme decides how the version is incremented.
+package scheme
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+var (
+	ErrUnknownScheme      = errors.New("unknown versioning scheme")
+	ErrUnknownReleaseType = errors.New("unknown release type")
+)
+
+const (
+	SemVerName = "semver"
+	CalVerName = "calver"
+)
+
+// Scheme increments versions according to a versioning scheme.
+type Scheme interface {
+	// Bump increments the given version according to the given release type (i.e., "major", "minor" or "patch") for a
+	// release made at the given date.
+	Bump(version *semver.Version, releaseType string, date time.Time) error
+}
+
+// New returns the versioning scheme of the given name, the Semantic Versioning if empty. The format is only used by the
+// Calendar Versioning scheme, the DefaultCalVerFormat if empty.
+func New(name, format string) (Scheme, error) {
+	switch name {
+	case "", SemVerName:
+		return SemVer{}, nil
+	case CalVerName:
+		if format == "" {
+			format = DefaultCalVerFormat
+		}
+
+		return NewCalVer(format)
+	default:
+		return nil, fmt.Errorf("%w %q, expected %s or %s", ErrUnknownScheme, name, SemVerName, CalVerName)
+	}
+}
+
+// SemVer is the Semantic Versioning scheme, where the release type is the version component to increment.
+type SemVer struct{}
+
+func (SemVer) Bump(version *semver.Version, releaseType string, _ time.Time) error {
+	switch releaseType {
+	case "major":
+		version.BumpMajor()
+	case "minor":
+		version.BumpMinor()
+	case "patch":
+		version.BumpPatch()
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownReleaseType, releaseType)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package scheme
+
+import (
+	"testing"
+	"time"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+)
+
+func TestScheme_New(t *testing.T) {
+	assert := assertion.New(t)
+
+	s, err := New("", "")
+	assert.NoError(err)
+	assert.Equal(SemVer{}, s)
+
+	s, err = New(CalVerName, "")
+	assert.NoError(err)
+	assert.Equal(CalVer{tokens: []string{"YYYY", "MM", "MICRO"}}, s)
+
+	_, err = New("romver", "")
+	assert.ErrorIs(err, ErrUnknownScheme)
+
+	_, err = New(CalVerName, "YYYY.MM")
+	assert.ErrorIs(err, ErrInvalidCalVerFormat)
+}
+
+func TestScheme_NewCalVer(t *testing.T) {
+	assert := assertion.New(t)
+
+	valid := []string{"YYYY.MM.MICRO", "YY.MM.MICRO", "YYYY.WW.PATCH", "YY.MINOR.MICRO", "YYYY.DD.MICRO"}
+
+	for _, format := range valid {
+		_, err := NewCalVer(format)
+		assert.NoError(err, format)
+	}
+
+	invalid := []string{"", "YYYY.MM", "YYYY.MM.DD.MICRO", "MINOR.MM.MICRO", "YYYY.MICRO.MICRO", "YYYY.MM.MINOR", "YYYY.0M.MICRO"}
+
+	for _, format := range invalid {
+		_, err := NewCalVer(format)
+		assert.ErrorIs(err, ErrInvalidCalVerFormat, format)
+	}
+}
+
+func TestScheme_SemVerBump(t *testing.T) {
+	assert := assertion.New(t)
+
+	version := semver.Version{Major: 1, Minor: 2, Patch: 3}
+
+	err := SemVer{}.Bump(&version, "minor", time.Time{})
+	assert.NoError(err)
+	assert.Equal("1.3.0", version.String())
+
+	err = SemVer{}.Bump(&version, "none", time.Time{})
+	assert.ErrorIs(err, ErrUnknownReleaseType)
+}
+
+func TestScheme_CalVerBump(t *testing.T) {
+	assert := assertion.New(t)
+
+	date := time.Date(2024, time.May, 17, 12, 0, 0, 0, time.UTC)
+
+	type test struct {
+		format      string
+		have        semver.Version
+		releaseType string
+		date        time.Time
+		want        string
+	}
+
+	matrix := []test{
+		// A new period resets the counters
+		{format: "YYYY.MM.MICRO", have: semver.Version{}, releaseType: "patch", date: date, want: "2024.5.0"},
+		{format: "YYYY.MM.MICRO", have: semver.Version{Major: 2024, Minor: 4, Patch: 3}, releaseType: "major", date: date, want: "2024.5.0"},
+		{format: "YY.MM.MICRO", have: semver.Version{Major: 23, Minor: 12, Patch: 1}, releaseType: "patch", date: date, want: "24.5.0"},
+		{format: "YYYY.WW.MICRO", have: semver.Version{Major: 2024, Minor: 19, Patch: 1}, releaseType: "patch", date: date, want: "2024.20.0"},
+		{format: "YYYY.MINOR.MICRO", have: semver.Version{Major: 2023, Minor: 4, Patch: 1}, releaseType: "minor", date: date, want: "2024.0.0"},
+		// Releases made within the same period increment the counters
+		{format: "YYYY.MM.MICRO", have: semver.Version{Major: 2024, Minor: 5, Patch: 0}, releaseType: "patch", date: date, want: "2024.5.1"},
+		{format: "YYYY.MM.MICRO", have: semver.Version{Major: 2024, Minor: 5, Patch: 1}, releaseType: "major", date: date, want: "2024.5.2"},
+		{format: "YYYY.MINOR.MICRO", have: semver.Version{Major: 2024, Minor: 4, Patch: 1}, releaseType: "minor", date: date, want: "2024.5.0"},
+		{format: "YYYY.MINOR.MICRO", have: semver.Version{Major: 2024, Minor: 4, Patch: 1}, releaseType: "patch", date: date, want: "2024.4.2"},
+		// A date preceding the one of the version does not decrease it
+		{format: "YYYY.MM.MICRO", have: semver.Version{Major: 2024, Minor: 6, Patch: 0}, releaseType: "patch", date: date, want: "2024.6.1"},
+		// A prerelease is bumped to the version it precedes
+		{format: "YYYY.MM.MICRO", have: semver.Version{Major: 2024, Minor: 5, Patch: 2, Prerelease: "rc.1", Metadata: "build"}, releaseType: "patch", date: date, want: "2024.5.2"},
+	}
+
+	for _, tc := range matrix {
+		calver, err := NewCalVer(tc.format)
+		if err != nil {
+			t.Fatalf("creating CalVer scheme: %s", err)
+		}
+
+		err = calver.Bump(&tc.have, tc.releaseType, tc.date)
+		assert.NoError(err, tc.format)
+		assert.Equal(tc.want, tc.have.String(), "bumped version should be equal")
+	}
+
+	calver, _ := NewCalVer(DefaultCalVerFormat)
+	err := calver.Bump(&semver.Version{}, "none", date)
+	assert.ErrorIs(err, ErrUnknownReleaseType)
+}
@@ -18,27 +18,60 @@ type Version struct {
 	Metadata   string
 }
 
+// BumpMajor increments the major component of the version. A prerelease of a major version (e.g., 2.0.0-rc) is bumped
+// to the version it precedes (e.g., 2.0.0).
 func (v *Version) BumpMajor() {
-	v.Major++
+	if v.Prerelease == "" || v.Minor != 0 || v.Patch != 0 {
+		v.Major++
+	}
 	v.Minor = 0
 	v.Patch = 0
 	v.Prerelease = ""
 	v.Metadata = ""
 }
 
+// BumpMinor increments the minor component of the version. A prerelease of a minor version (e.g., 1.2.0-rc) is bumped
+// to the version it precedes (e.g., 1.2.0).
 func (v *Version) BumpMinor() {
-	v.Minor++
+	if v.Prerelease == "" || v.Patch != 0 {
+		v.Minor++
+	}
 	v.Patch = 0
 	v.Prerelease = ""
 	v.Metadata = ""
 }
 
+// BumpPatch increments the patch component of the version. A prerelease version (e.g., 1.2.3-rc) is bumped to the
+// version it precedes (e.g., 1.2.3).
 func (v *Version) BumpPatch() {
-	v.Patch++
+	if v.Prerelease == "" {
+		v.Patch++
+	}
 	v.Prerelease = ""
 	v.Metadata = ""
 }
 
+// PrereleaseNumber returns the number following the given identifier in the prerelease component of the version
+// (e.g., 2 for "rc.2" and the identifier "rc"). The boolean is false if the prerelease component does not match the
+// identifier, a prerelease component equal to the identifier is numbered 0.
+func (v *Version) PrereleaseNumber(identifier string) (int, bool) {
+	if v.Prerelease == identifier {
+		return 0, true
+	}
+
+	suffix, found := strings.CutPrefix(v.Prerelease, identifier+".")
+	if !found {
+		return 0, false
+	}
+
+	number, err := strconv.Atoi(suffix)
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
 // IsZero checks if all component of a semantic version number are equal to zero.
 func (v *Version) IsZero() bool {
 	isZero := v.Major == v.Minor && v.Minor == v.Patch && v.Patch == 0
@@ -108,8 +141,74 @@ func Compare(a, b *Version) int {
 		return 1
 	case a.Prerelease != "" && b.Prerelease == "":
 		return -1
-	case a.Prerelease != "" && b.Prerelease != "":
-		return strings.Compare(a.Prerelease, b.Prerelease)
+	default:
+		return comparePrerelease(a.Prerelease, b.Prerelease)
+	}
+}
+
+// HighestInLines reports whether the given version is the highest stable version of its major line and of its minor
+// line (e.g., 1 and 1.2 for 1.2.3) among the given versions, prereleases being ignored. A prerelease is the highest of
+// no line.
+func HighestInLines(version *Version, versions []*Version) (major, minor bool) {
+	if version.Prerelease != "" {
+		return false, false
+	}
+
+	major, minor = true, true
+
+	for _, v := range versions {
+		if v.Prerelease != "" || v.Major != version.Major || Compare(v, version) != 1 {
+			continue
+		}
+
+		major = false
+
+		if v.Minor == version.Minor {
+			minor = false
+		}
+	}
+
+	return major, minor
+}
+
+// comparePrerelease compares two prerelease components according to the semantic versioning specification: dot
+// separated identifiers are compared from left to right, numerically if both are numeric and in ASCII order otherwise,
+// numeric identifiers having a lower precedence than alphanumeric ones. A component with more identifiers has a higher
+// precedence if all the preceding identifiers are equal (e.g., rc.1 < rc.1.1 < rc.2 < rc.10).
+func comparePrerelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	aIdentifiers := strings.Split(a, ".")
+	bIdentifiers := strings.Split(b, ".")
+
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		aNumber, aErr := strconv.ParseUint(aIdentifiers[i], 10, 64)
+		bNumber, bErr := strconv.ParseUint(bIdentifiers[i], 10, 64)
+
+		switch {
+		case aErr == nil && bErr == nil && aNumber != bNumber:
+			if aNumber > bNumber {
+				return 1
+			}
+			return -1
+		case aErr == nil && bErr != nil:
+			return -1
+		case aErr != nil && bErr == nil:
+			return 1
+		case aErr != nil && bErr != nil:
+			if c := strings.Compare(aIdentifiers[i], bIdentifiers[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	switch {
+	case len(aIdentifiers) > len(bIdentifiers):
+		return 1
+	case len(aIdentifiers) < len(bIdentifiers):
+		return -1
 	default:
 		return 0
 	}
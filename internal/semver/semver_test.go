@@ -32,6 +32,13 @@ func TestSemver_Compare(t *testing.T) {
 		{s1: Version{Major: 0, Minor: 2, Patch: 0, Prerelease: "rc"}, s2: Version{Major: 0, Minor: 2, Patch: 0, Prerelease: "alpha"}, want: 1},
 		{s1: Version{Major: 0, Minor: 2, Patch: 0, Prerelease: "alpha"}, s2: Version{Major: 0, Minor: 2, Patch: 0, Prerelease: "beta"}, want: -1},
 		{s1: Version{Major: 0, Minor: 2, Patch: 0, Prerelease: "rc"}, s2: Version{Major: 0, Minor: 2, Patch: 0, Prerelease: "rc"}, want: 0},
+		{s1: Version{Major: 1, Minor: 10, Patch: 0}, s2: Version{Major: 1, Minor: 9, Patch: 0}, want: 1},
+		{s1: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "rc.10"}, s2: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "rc.9"}, want: 1},
+		{s1: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "rc.1"}, s2: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "rc.1.1"}, want: -1},
+		{s1: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "rc"}, s2: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "rc.1"}, want: -1},
+		{s1: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "1"}, s2: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "alpha"}, want: -1},
+		{s1: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "alpha.beta"}, s2: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "alpha.1"}, want: 1},
+		{s1: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "beta.11"}, s2: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "beta.2"}, want: 1},
 	}
 
 	for _, tc := range matrix {
@@ -142,3 +149,85 @@ func TestSemver_Bump(t *testing.T) {
 	assert.Empty(s.Prerelease, "version prerelease should be empty after bump")
 	assert.Empty(s.Metadata, "version metadata should be empty after bump")
 }
+
+func TestSemver_BumpPrerelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		have Version
+		bump func(v *Version)
+		want string
+	}
+
+	matrix := []test{
+		{have: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, bump: (*Version).BumpPatch, want: "1.2.3"},
+		{have: Version{Major: 1, Minor: 2, Patch: 0, Prerelease: "rc.1"}, bump: (*Version).BumpMinor, want: "1.2.0"},
+		{have: Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, bump: (*Version).BumpMinor, want: "1.3.0"},
+		{have: Version{Major: 2, Minor: 0, Patch: 0, Prerelease: "rc.1"}, bump: (*Version).BumpMajor, want: "2.0.0"},
+		{have: Version{Major: 2, Minor: 1, Patch: 0, Prerelease: "rc.1"}, bump: (*Version).BumpMajor, want: "3.0.0"},
+	}
+
+	for _, tc := range matrix {
+		tc.bump(&tc.have)
+		assert.Equal(tc.want, tc.have.String(), "bumped prerelease should be equal")
+	}
+}
+
+func TestSemver_PrereleaseNumber(t *testing.T) {
+	assert := assertion.New(t)
+
+	type test struct {
+		prerelease string
+		number     int
+		ok         bool
+	}
+
+	matrix := []test{
+		{prerelease: "rc", number: 0, ok: true},
+		{prerelease: "rc.3", number: 3, ok: true},
+		{prerelease: "rc.foo", number: 0, ok: false},
+		{prerelease: "beta.1", number: 0, ok: false},
+		{prerelease: "", number: 0, ok: false},
+	}
+
+	for _, tc := range matrix {
+		v := Version{Major: 1, Prerelease: tc.prerelease}
+
+		number, ok := v.PrereleaseNumber("rc")
+		assert.Equal(tc.number, number, "prerelease number should be equal")
+		assert.Equal(tc.ok, ok, "prerelease match should be equal")
+	}
+}
+
+func TestSemver_HighestInLines(t *testing.T) {
+	assert := assertion.New(t)
+
+	versions := []*Version{
+		{Major: 1, Minor: 2, Patch: 4},
+		{Major: 1, Minor: 5, Patch: 0},
+		{Major: 1, Minor: 6, Patch: 0, Prerelease: "rc.1"},
+		{Major: 2, Minor: 0, Patch: 0},
+	}
+
+	type test struct {
+		version *Version
+		major   bool
+		minor   bool
+	}
+
+	matrix := []test{
+		{version: &Version{Major: 1, Minor: 5, Patch: 1}, major: true, minor: true},
+		{version: &Version{Major: 1, Minor: 2, Patch: 5}, major: false, minor: true},
+		{version: &Version{Major: 1, Minor: 2, Patch: 3}, major: false, minor: false},
+		{version: &Version{Major: 1, Minor: 5, Patch: 0}, major: true, minor: true},
+		{version: &Version{Major: 3, Minor: 0, Patch: 0}, major: true, minor: true},
+		{version: &Version{Major: 1, Minor: 7, Patch: 0, Prerelease: "rc.1"}, major: false, minor: false},
+	}
+
+	for _, tc := range matrix {
+		major, minor := HighestInLines(tc.version, versions)
+
+		assert.Equal(tc.major, major, "version %s should be the highest of its major line: %t", tc.version, tc.major)
+		assert.Equal(tc.minor, minor, "version %s should be the highest of its minor line: %t", tc.version, tc.minor)
+	}
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/s0ders/go-semver-release/v6/internal/metrics"
+	"github.com/s0ders/go-semver-release/v6/internal/output"
+)
+
+// maxRequestSize is the maximum size of the analysis requests.
+const maxRequestSize = 1 << 20
+
+const AnalyzePath = "/analyze"
+
+var (
+	ErrUnauthorized   = errors.New("missing or invalid API token")
+	ErrInvalidRequest = errors.New("invalid analysis request")
+)
+
+// AnalyzeRequest is the body of the requests to AnalyzePath.
+type AnalyzeRequest struct {
+	// Repository is the URL of the analyzed repository.
+	Repository string `json:"repository"`
+	// Branch restricts the analysis to a release branch, every release branch is analyzed if empty.
+	Branch string `json:"branch,omitempty"`
+}
+
+// Analysis is the next release of a branch, and project if the repository is a monorepo, as computed by the release
+// command.
+type Analysis struct {
+	Version string `json:"version"`
+	// PreviousVersion is the version of the latest release, empty if there is none.
+	PreviousVersion string `json:"previous-version,omitempty"`
+	Branch          string `json:"branch"`
+	Channel         string `json:"channel,omitempty"`
+	Project         string `json:"project,omitempty"`
+	NewRelease      bool   `json:"new-release"`
+	// Commits is the classification of every commit considered.
+	Commits []output.CommitReport `json:"commits"`
+}
+
+// AnalyzeResponse is the body of the successful responses of AnalyzePath.
+type AnalyzeResponse struct {
+	Releases []Analysis `json:"releases"`
+}
+
+// AnalyzeFunc computes the next releases of a repository. Errors wrapping ErrInvalidRequest are reported to the client
+// as bad requests.
+type AnalyzeFunc func(ctx context.Context, request AnalyzeRequest) ([]Analysis, error)
+
+// WithAnalyzer enables the analysis endpoint on AnalyzePath, whose requests must carry the given token as a bearer
+// token. The next releases of the requested repository, which must be one of the repositories served, are computed by
+// the given function and returned as JSON.
+func WithAnalyzer(token string, analyze AnalyzeFunc) OptionFunc {
+	return func(e *endpoints) {
+		e.token = token
+		e.analyze = analyze
+	}
+}
+
+func analyzeHandler(e *endpoints, logger zerolog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(e.token)) != 1 {
+			logger.Warn().Str("remote", r.RemoteAddr).Msg("analysis request rejected")
+			e.metrics.Error(metrics.ErrorUnauthorized)
+			writeError(w, ErrUnauthorized, http.StatusUnauthorized)
+			return
+		}
+
+		var request AnalyzeRequest
+
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&request); err != nil {
+			e.metrics.Error(metrics.ErrorInvalidRequest)
+			writeError(w, fmt.Errorf("%w: %w", ErrInvalidRequest, err), http.StatusBadRequest)
+			return
+		}
+
+		if request.Repository == "" {
+			e.metrics.Error(metrics.ErrorInvalidRequest)
+			writeError(w, fmt.Errorf("%w: no repository", ErrInvalidRequest), http.StatusBadRequest)
+			return
+		}
+
+		// The configured URL is cloned rather than the requested one, so that the credentials of the server are never
+		// sent to another host
+		repository, ok := e.repository(request.Repository)
+		if !ok {
+			logger.Warn().Str("repository", request.Repository).Str("remote", r.RemoteAddr).Msg("analysis of an unknown repository rejected")
+			e.metrics.Error(metrics.ErrorUnknownRepository)
+			writeError(w, fmt.Errorf("%w: %q", ErrUnknownRepository, request.Repository), http.StatusForbidden)
+			return
+		}
+
+		request.Repository = repository
+
+		releases, err := e.analyze(r.Context(), request)
+		if err != nil {
+			status, errorType := http.StatusInternalServerError, metrics.ErrorAnalysis
+			if errors.Is(err, ErrInvalidRequest) {
+				status, errorType = http.StatusBadRequest, metrics.ErrorInvalidRequest
+			}
+
+			e.metrics.Error(errorType)
+
+			logger.Error().Err(err).Str("repository", request.Repository).Msg("analysis failed")
+			writeError(w, err, status)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, AnalyzeResponse{Releases: releases})
+	}
+}
+
+// writeError writes the given error as a JSON object with an error field.
+func writeError(w http.ResponseWriter, err error, status int) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/metrics"
+	"github.com/s0ders/go-semver-release/v6/internal/output"
+)
+
+func TestServer_Analyze(t *testing.T) {
+	assert := assertion.New(t)
+
+	var requests []AnalyzeRequest
+
+	analyze := func(_ context.Context, request AnalyzeRequest) ([]Analysis, error) {
+		requests = append(requests, request)
+
+		switch request.Branch {
+		case "unknown":
+			return nil, fmt.Errorf("%w: unknown branch", ErrInvalidRequest)
+		case "broken":
+			return nil, errors.New("cloning repository")
+		}
+
+		return []Analysis{{
+			Version:         "1.1.0",
+			PreviousVersion: "1.0.0",
+			Branch:          "main",
+			NewRelease:      true,
+			Commits:         []output.CommitReport{{Hash: "abc123", Type: "feat", Rule: "minor", Bump: "minor"}},
+		}}, nil
+	}
+
+	m := metrics.New()
+
+	handler := Handler(zerolog.Nop(), WithAnalyzer("token", analyze), WithRepositories([]string{"https://github.com/owner/name.git"}), WithMetrics(m))
+
+	send := func(body, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, AnalyzePath, strings.NewReader(body))
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		return recorder
+	}
+
+	recorder := send(`{"repository": "https://github.com/Owner/Name", "branch": "main"}`, "token")
+	assert.Equal(http.StatusOK, recorder.Code)
+	assert.Equal("application/json", recorder.Header().Get("Content-Type"))
+
+	var response AnalyzeResponse
+
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	checkErr(t, "decoding response", err)
+
+	assert.Len(response.Releases, 1)
+	assert.Equal("1.1.0", response.Releases[0].Version)
+	assert.Equal("minor", response.Releases[0].Commits[0].Bump)
+	assert.Equal([]AnalyzeRequest{{Repository: "https://github.com/owner/name.git", Branch: "main"}}, requests)
+
+	type test struct {
+		body  string
+		token string
+		want  int
+	}
+
+	tests := []test{
+		{body: `{"repository": "https://github.com/owner/name.git"}`, token: "", want: http.StatusUnauthorized},
+		{body: `{"repository": "https://github.com/owner/name.git"}`, token: "other", want: http.StatusUnauthorized},
+		{body: `{"branch": "main"}`, token: "token", want: http.StatusBadRequest},
+		{body: `not json`, token: "token", want: http.StatusBadRequest},
+		{body: `{"repository": "https://github.com/owner/name.git", "branch": "unknown"}`, token: "token", want: http.StatusBadRequest},
+		{body: `{"repository": "https://github.com/owner/name.git", "branch": "broken"}`, token: "token", want: http.StatusInternalServerError},
+		{body: `{"repository": "https://internal.example.com/owner/name.git"}`, token: "token", want: http.StatusForbidden},
+		{body: `{"repository": "http://169.254.169.254/latest/meta-data"}`, token: "token", want: http.StatusForbidden},
+	}
+
+	for i, tc := range tests {
+		recorder = send(tc.body, tc.token)
+		assert.Equal(tc.want, recorder.Code, "test %d", i)
+		assert.Contains(recorder.Body.String(), `"error"`, "test %d", i)
+	}
+
+	assert.Len(requests, 3, "only authorized and valid requests should be analyzed")
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+
+	assert.Contains(recorder.Body.String(), `go_semver_release_errors_total{type="analysis"} 1`)
+	assert.Contains(recorder.Body.String(), `go_semver_release_errors_total{type="invalid_request"} 3`)
+	assert.Contains(recorder.Body.String(), `go_semver_release_errors_total{type="unauthorized"} 2`)
+	assert.Contains(recorder.Body.String(), `go_semver_release_errors_total{type="unknown_repository"} 2`)
+}
+
+func TestServer_DisabledEndpoints(t *testing.T) {
+	assert := assertion.New(t)
+
+	handler := Handler(zerolog.Nop())
+
+	for _, path := range []string{WebhookPath, AnalyzePath, MetricsPath} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}")))
+		assert.Equal(http.StatusNotFound, recorder.Code, path)
+	}
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrQueueFull   = errors.New("job queue is full")
+	ErrQueueClosed = errors.New("job queue is closed")
+)
+
+// Job is a release of a repository requested by a push to one of its branches.
+type Job struct {
+	// Repository is the clone URL of the repository.
+	Repository string
+	Branch     string
+	// Commit is the hash of the head of the branch after the push.
+	Commit string
+}
+
+// HandlerFunc runs a job.
+type HandlerFunc func(ctx context.Context, job Job) error
+
+// Queue runs jobs concurrently on a fixed number of workers. The jobs of a same repository are run one at a time, in
+// the order they were pushed, so that concurrent releases of a repository do not conflict.
+type Queue struct {
+	jobs    chan Job
+	handler HandlerFunc
+	logger  zerolog.Logger
+	workers int
+	wg      sync.WaitGroup
+	// locks serialize the jobs of each repository
+	locks   map[string]*sync.Mutex
+	mu      sync.Mutex
+	closed  bool
+	closeMu sync.RWMutex
+}
+
+// NewQueue returns a queue of the given capacity whose jobs are run by the given handler on the given number of
+// workers, at least one.
+func NewQueue(workers, capacity int, handler HandlerFunc, logger zerolog.Logger) *Queue {
+	return &Queue{
+		jobs:    make(chan Job, capacity),
+		handler: handler,
+		logger:  logger,
+		workers: max(workers, 1),
+		locks:   make(map[string]*sync.Mutex),
+	}
+}
+
+// Start starts the workers of the queue, they stop once the queue is closed and its remaining jobs are run. The given
+// context is given to the handler.
+func (q *Queue) Start(ctx context.Context) {
+	for range q.workers {
+		q.wg.Add(1)
+
+		go func() {
+			defer q.wg.Done()
+
+			for job := range q.jobs {
+				q.run(ctx, job)
+			}
+		}()
+	}
+}
+
+// Push adds a job to the queue without blocking, it fails if the queue is full or closed.
+func (q *Queue) Push(job Job) error {
+	q.closeMu.RLock()
+	defer q.closeMu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.jobs <- job:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting jobs and waits for the queued ones to be run.
+func (q *Queue) Close() {
+	q.closeMu.Lock()
+
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+
+	q.closeMu.Unlock()
+
+	q.wg.Wait()
+}
+
+func (q *Queue) run(ctx context.Context, job Job) {
+	lock := q.lock(job.Repository)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	logger := q.logger.With().Str("repository", job.Repository).Str("branch", job.Branch).Logger()
+
+	logger.Debug().Msg("running release job")
+
+	if err := q.handler(ctx, job); err != nil {
+		logger.Error().Err(err).Msg("release job failed")
+		return
+	}
+
+	logger.Debug().Msg("release job done")
+}
+
+// lock returns the lock of the given repository.
+func (q *Queue) lock(repository string) *sync.Mutex {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	lock, ok := q.locks[repository]
+	if !ok {
+		lock = new(sync.Mutex)
+		q.locks[repository] = lock
+	}
+
+	return lock
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	assertion "github.com/stretchr/testify/assert"
+)
+
+func TestQueue_SerializesRepositories(t *testing.T) {
+	assert := assertion.New(t)
+
+	var (
+		running    = make(map[string]int)
+		concurrent atomic.Int32
+		overlap    atomic.Bool
+		peak       atomic.Int32
+		mu         sync.Mutex
+	)
+
+	queue := NewQueue(4, 16, func(_ context.Context, job Job) error {
+		mu.Lock()
+		running[job.Repository]++
+		if running[job.Repository] > 1 {
+			overlap.Store(true)
+		}
+		mu.Unlock()
+
+		if n := concurrent.Add(1); n > peak.Load() {
+			peak.Store(n)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		concurrent.Add(-1)
+
+		mu.Lock()
+		running[job.Repository]--
+		mu.Unlock()
+
+		return errors.New("failures are logged")
+	}, zerolog.Nop())
+	queue.Start(context.Background())
+
+	for _, repository := range []string{"a", "a", "a", "b", "c"} {
+		err := queue.Push(Job{Repository: repository, Branch: "main"})
+		assert.NoError(err)
+	}
+
+	queue.Close()
+
+	assert.False(overlap.Load(), "jobs of a same repository should not run concurrently")
+	assert.Greater(peak.Load(), int32(1), "jobs of different repositories should run concurrently")
+}
+
+func TestQueue_Push(t *testing.T) {
+	assert := assertion.New(t)
+
+	queue := NewQueue(1, 1, func(context.Context, Job) error { return nil }, zerolog.Nop())
+
+	assert.NoError(queue.Push(Job{Repository: "a"}))
+	assert.ErrorIs(queue.Push(Job{Repository: "b"}), ErrQueueFull)
+
+	queue.Start(context.Background())
+	queue.Close()
+
+	assert.ErrorIs(queue.Push(Job{Repository: "a"}), ErrQueueClosed)
+}
@@ -0,0 +1,262 @@
+// Package server provides an HTTP server receiving the push webhooks of GitHub and GitLab and queuing a release of the
+// pushed repositories, and answering requests for the next releases of repositories.
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/s0ders/go-semver-release/v6/internal/metrics"
+	"github.com/s0ders/go-semver-release/v6/internal/webhook"
+)
+
+// maxPayloadSize is the maximum size of the webhook payloads.
+const maxPayloadSize = 25 << 20
+
+const (
+	WebhookPath = "/webhook"
+	HealthPath  = "/healthz"
+	MetricsPath = "/metrics"
+)
+
+var (
+	ErrInvalidSignature  = errors.New("invalid webhook signature")
+	ErrInvalidPayload    = errors.New("invalid webhook payload")
+	ErrUnknownRepository = errors.New("repository is not served")
+)
+
+// pushEvent holds the fields shared by the push events of GitHub and GitLab.
+type pushEvent struct {
+	Ref   string `json:"ref"`
+	After string `json:"after"`
+	// Repository is the repository of GitHub push events.
+	Repository struct {
+		CloneURL string `json:"clone_url"`
+	} `json:"repository"`
+	// Project is the repository of GitLab push events.
+	Project struct {
+		HTTPURL string `json:"git_http_url"`
+	} `json:"project"`
+}
+
+// OptionFunc enables an endpoint of the handler.
+type OptionFunc func(*endpoints)
+
+type endpoints struct {
+	secret       string
+	branches     []string
+	repositories []string
+	queue        *Queue
+	token        string
+	analyze      AnalyzeFunc
+	metrics      *metrics.Metrics
+}
+
+// WithWebhook enables the webhook endpoint on WebhookPath. Push webhooks must be signed with the given secret: GitHub
+// payloads using an HMAC-SHA256 signature, GitLab ones using the secret token. The pushes to one of the given branches
+// are queued as release jobs, the other events are acknowledged and ignored.
+func WithWebhook(secret string, branches []string, queue *Queue) OptionFunc {
+	return func(e *endpoints) {
+		e.secret = secret
+		e.branches = branches
+		e.queue = queue
+	}
+}
+
+// WithRepositories sets the URLs of the repositories served. The requests for any other repository are rejected, as
+// every request is if none is given, so that the credentials of the server are only used for these repositories.
+func WithRepositories(urls []string) OptionFunc {
+	return func(e *endpoints) {
+		e.repositories = urls
+	}
+}
+
+// WithMetrics enables the metrics endpoint on MetricsPath, exposing the given metrics in the Prometheus text format.
+// The rejected requests are recorded as errors.
+func WithMetrics(m *metrics.Metrics) OptionFunc {
+	return func(e *endpoints) {
+		e.metrics = m
+	}
+}
+
+// Handler returns the HTTP handler of the server, serving the endpoints enabled by the given options. The server
+// reports its health on HealthPath.
+func Handler(logger zerolog.Logger, options ...OptionFunc) http.Handler {
+	e := &endpoints{}
+
+	for _, option := range options {
+		option(e)
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+HealthPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if e.queue != nil {
+		mux.Handle("POST "+WebhookPath, webhookHandler(e, logger))
+	}
+
+	if e.analyze != nil {
+		mux.Handle("POST "+AnalyzePath, analyzeHandler(e, logger))
+	}
+
+	if e.metrics != nil {
+		mux.Handle("GET "+MetricsPath, e.metrics.Handler())
+	}
+
+	return mux
+}
+
+func webhookHandler(e *endpoints, logger zerolog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+		if err != nil {
+			e.metrics.Error(metrics.ErrorInvalidPayload)
+			http.Error(w, "reading payload", http.StatusBadRequest)
+			return
+		}
+
+		if err = verify(e.secret, r.Header, payload); err != nil {
+			logger.Warn().Err(err).Str("remote", r.RemoteAddr).Msg("webhook rejected")
+			e.metrics.Error(metrics.ErrorInvalidSignature)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		if !isPush(r.Header) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		job, err := parsePush(payload)
+		if err != nil {
+			e.metrics.Error(metrics.ErrorInvalidPayload)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		repository, ok := e.repository(job.Repository)
+		if !ok {
+			logger.Warn().Str("repository", job.Repository).Str("remote", r.RemoteAddr).Msg("webhook of an unknown repository rejected")
+			e.metrics.Error(metrics.ErrorUnknownRepository)
+			http.Error(w, fmt.Sprintf("%s: %q", ErrUnknownRepository, job.Repository), http.StatusForbidden)
+			return
+		}
+
+		// The configured URL is released rather than the one of the payload, which only has to match it
+		job.Repository = repository
+
+		if !slices.Contains(e.branches, job.Branch) {
+			logger.Debug().Str("repository", job.Repository).Str("branch", job.Branch).Msg("push to a non-release branch ignored")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if err = e.queue.Push(job); err != nil {
+			logger.Error().Err(err).Str("repository", job.Repository).Msg("release job dropped")
+			e.metrics.Error(metrics.ErrorQueueFull)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		logger.Debug().Str("repository", job.Repository).Str("branch", job.Branch).Msg("release job queued")
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// verify checks the signature of a GitHub payload, given in the X-Hub-Signature-256 header, or the secret token of a
+// GitLab one, given in the X-Gitlab-Token header.
+func verify(secret string, header http.Header, payload []byte) error {
+	if signature := header.Get("X-Hub-Signature-256"); signature != "" {
+		if !hmac.Equal([]byte(signature), []byte(webhook.Sign(secret, payload))) {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	}
+
+	if token := header.Get("X-Gitlab-Token"); token != "" {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: no signature nor token", ErrInvalidSignature)
+}
+
+// isPush returns true if the headers are those of a GitHub or GitLab push event.
+func isPush(header http.Header) bool {
+	return header.Get("X-GitHub-Event") == "push" || header.Get("X-Gitlab-Event") == "Push Hook"
+}
+
+// parsePush returns the release job of a push event. The branch of the job is empty if a tag was pushed.
+func parsePush(payload []byte) (Job, error) {
+	var event pushEvent
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return Job{}, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	job := Job{
+		Repository: event.Repository.CloneURL,
+		Commit:     event.After,
+	}
+
+	if job.Repository == "" {
+		job.Repository = event.Project.HTTPURL
+	}
+
+	if job.Repository == "" {
+		return Job{}, fmt.Errorf("%w: no repository URL", ErrInvalidPayload)
+	}
+
+	if branch, found := strings.CutPrefix(event.Ref, "refs/heads/"); found {
+		job.Branch = branch
+	}
+
+	return job, nil
+}
+
+// repository returns the configured URL of the served repository matching the given URL, compared once normalized.
+func (e *endpoints) repository(rawURL string) (string, bool) {
+	normalized := normalizeRepositoryURL(rawURL)
+
+	for _, repository := range e.repositories {
+		if normalizeRepositoryURL(repository) == normalized {
+			return repository, true
+		}
+	}
+
+	return "", false
+}
+
+// normalizeRepositoryURL returns the given repository URL lowercased, without credentials, query, trailing slash nor
+// ".git" suffix, so that the different URLs of a same repository (e.g., "https://github.com/Owner/Name.git" and
+// "https://github.com/owner/name") are equal.
+func normalizeRepositoryURL(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Scheme != "" && u.Host != "" {
+		u.User = nil
+		u.RawQuery = ""
+		u.Fragment = ""
+		rawURL = u.String()
+	}
+
+	rawURL = strings.ToLower(strings.TrimSpace(rawURL))
+
+	return strings.TrimSuffix(strings.TrimSuffix(rawURL, "/"), ".git")
+}
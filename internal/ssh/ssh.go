@@ -0,0 +1,224 @@
+// Package ssh provides functions to sign Git objects using SSH keys, following the format used by Git when
+// "gpg.format" is set to "ssh".
+package ssh
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// Namespace is the namespace used by Git for SSH signatures.
+	Namespace     = "git"
+	magicPreamble = "SSHSIG"
+	sigVersion    = 1
+	hashAlgorithm = "sha512"
+	armorType     = "SSH SIGNATURE"
+	armorWidth    = 70
+)
+
+var ErrInvalidSignature = errors.New("invalid SSH signature")
+
+// Signer signs messages using an SSH private key.
+type Signer struct {
+	signer ssh.Signer
+}
+
+// signedData is the blob actually signed by the private key, as described in the SSHSIG protocol.
+type signedData struct {
+	Magic         [6]byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          string
+}
+
+// signature is the blob embedded in the armored signature, as described in the SSHSIG protocol.
+type signature struct {
+	Magic         [6]byte
+	Version       uint32
+	PublicKey     string
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     string
+}
+
+// FromPEM reads an unencrypted OpenSSH private key and returns the corresponding Signer.
+func FromPEM(reader io.Reader) (*Signer, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+
+	return &Signer{signer: signer}, nil
+}
+
+// PublicKey returns the public key of the Signer.
+func (s *Signer) PublicKey() ssh.PublicKey {
+	return s.signer.PublicKey()
+}
+
+// Sign returns the armored SSH signature of a given message.
+func (s *Signer) Sign(message []byte) (string, error) {
+	data := ssh.Marshal(newSignedData(message))
+
+	var (
+		sig *ssh.Signature
+		err error
+	)
+
+	// RSA keys must not use the SHA-1 based default signature algorithm
+	algorithmSigner, ok := s.signer.(ssh.AlgorithmSigner)
+	if ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		sig, err = algorithmSigner.SignWithAlgorithm(rand.Reader, data, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = s.signer.Sign(rand.Reader, data)
+	}
+	if err != nil {
+		return "", fmt.Errorf("signing message: %w", err)
+	}
+
+	blob := signature{
+		Version:       sigVersion,
+		PublicKey:     string(s.signer.PublicKey().Marshal()),
+		Namespace:     Namespace,
+		HashAlgorithm: hashAlgorithm,
+		Signature:     string(ssh.Marshal(sig)),
+	}
+	copy(blob.Magic[:], magicPreamble)
+
+	return armor(ssh.Marshal(blob)), nil
+}
+
+// Verify checks that an armored SSH signature of a given message has been produced by the private key matching the
+// given public key.
+func Verify(publicKey ssh.PublicKey, message []byte, armoredSignature string) error {
+	block, _ := pem.Decode([]byte(armoredSignature))
+	if block == nil || block.Type != armorType {
+		return fmt.Errorf("%w: malformed armor", ErrInvalidSignature)
+	}
+
+	var blob signature
+	if err := ssh.Unmarshal(block.Bytes, &blob); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	if string(blob.Magic[:]) != magicPreamble || blob.Version != sigVersion || blob.Namespace != Namespace || blob.HashAlgorithm != hashAlgorithm {
+		return fmt.Errorf("%w: unsupported signature format", ErrInvalidSignature)
+	}
+
+	if !bytes.Equal([]byte(blob.PublicKey), publicKey.Marshal()) {
+		return fmt.Errorf("%w: signed by another key", ErrInvalidSignature)
+	}
+
+	sig := new(ssh.Signature)
+	if err := ssh.Unmarshal([]byte(blob.Signature), sig); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	if err := publicKey.Verify(ssh.Marshal(newSignedData(message)), sig); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return nil
+}
+
+// VerifyAny checks that an armored SSH signature of a given message has been produced by the private key matching one
+// of the given public keys and returns that public key.
+func VerifyAny(publicKeys []ssh.PublicKey, message []byte, armoredSignature string) (ssh.PublicKey, error) {
+	for _, publicKey := range publicKeys {
+		if err := Verify(publicKey, message, armoredSignature); err == nil {
+			return publicKey, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: not signed by any allowed key", ErrInvalidSignature)
+}
+
+// ParseAllowedSigners reads public keys from a file formatted as a Git allowed signers file (i.e., "<principals>
+// [options] <key type> <key>") or an authorized keys file. Empty lines and comments are ignored.
+func ParseAllowedSigners(reader io.Reader) ([]ssh.PublicKey, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading allowed signers: %w", err)
+	}
+
+	var publicKeys []ssh.PublicKey
+
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		publicKey, err := parseAllowedSigner(line)
+		if err != nil {
+			return nil, fmt.Errorf("parsing allowed signers line %d: %w", i+1, err)
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	return publicKeys, nil
+}
+
+// parseAllowedSigner returns the public key of an allowed signers line by skipping its leading principals and options
+// fields until a valid key is found.
+func parseAllowedSigner(line string) (ssh.PublicKey, error) {
+	fields := strings.Fields(line)
+
+	for i := range fields {
+		publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.Join(fields[i:], " ")))
+		if err == nil {
+			return publicKey, nil
+		}
+	}
+
+	return nil, errors.New("no public key found")
+}
+
+func newSignedData(message []byte) signedData {
+	hash := sha512.Sum512(message)
+
+	data := signedData{
+		Namespace:     Namespace,
+		HashAlgorithm: hashAlgorithm,
+		Hash:          string(hash[:]),
+	}
+	copy(data.Magic[:], magicPreamble)
+
+	return data
+}
+
+// armor encodes a signature blob the same way ssh-keygen does, that is, in base64 wrapped at 70 columns.
+func armor(blob []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(blob)
+
+	buf := new(strings.Builder)
+	buf.WriteString("-----BEGIN " + armorType + "-----\n")
+
+	for len(encoded) > armorWidth {
+		buf.WriteString(encoded[:armorWidth] + "\n")
+		encoded = encoded[armorWidth:]
+	}
+
+	buf.WriteString(encoded + "\n")
+	buf.WriteString("-----END " + armorType + "-----\n")
+
+	return buf.String()
+}
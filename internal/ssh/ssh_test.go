@@ -0,0 +1,123 @@
+package ssh
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSH_SignAndVerify(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, "generating ed25519 key", err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	checkErr(t, "generating rsa key", err)
+
+	message := []byte("object 4b825dc642cb6eb9a060e54bf8d69288fbee4904\ntype commit\ntag v1.0.0\n")
+
+	for _, key := range []any{ed25519Key, rsaKey} {
+		signer := newSigner(t, key)
+
+		armored, err := signer.Sign(message)
+		checkErr(t, "signing message", err)
+
+		assert.True(strings.HasPrefix(armored, "-----BEGIN SSH SIGNATURE-----\n"))
+		assert.True(strings.HasSuffix(armored, "-----END SSH SIGNATURE-----\n"))
+
+		assert.NoError(Verify(signer.PublicKey(), message, armored), "signature should be valid")
+		assert.ErrorIs(Verify(signer.PublicKey(), []byte("tampered"), armored), ErrInvalidSignature, "tampered message should be rejected")
+	}
+}
+
+func TestSSH_VerifyOtherKey(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, firstKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, "generating ed25519 key", err)
+
+	_, secondKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, "generating ed25519 key", err)
+
+	message := []byte("message")
+
+	armored, err := newSigner(t, firstKey).Sign(message)
+	checkErr(t, "signing message", err)
+
+	err = Verify(newSigner(t, secondKey).PublicKey(), message, armored)
+	assert.ErrorIs(err, ErrInvalidSignature)
+
+	err = Verify(newSigner(t, firstKey).PublicKey(), message, "not a signature")
+	assert.ErrorIs(err, ErrInvalidSignature)
+}
+
+func TestSSH_FromPEM_InvalidKey(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := FromPEM(strings.NewReader("not a key"))
+	assert.ErrorContains(err, "parsing private key")
+}
+
+func TestSSH_ParseAllowedSigners(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, firstKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, "generating ed25519 key", err)
+
+	_, secondKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, "generating ed25519 key", err)
+
+	firstSigner := newSigner(t, firstKey)
+	secondSigner := newSigner(t, secondKey)
+
+	allowedSigners := "# allowed signers\n\n" +
+		"john.doe@example.com " + string(ssh.MarshalAuthorizedKey(firstSigner.PublicKey())) +
+		`jane.doe@example.com,*@example.org namespaces="git" ` + string(ssh.MarshalAuthorizedKey(secondSigner.PublicKey()))
+
+	publicKeys, err := ParseAllowedSigners(strings.NewReader(allowedSigners))
+	checkErr(t, "parsing allowed signers", err)
+
+	assert.Len(publicKeys, 2)
+
+	message := []byte("message")
+
+	armored, err := secondSigner.Sign(message)
+	checkErr(t, "signing message", err)
+
+	signer, err := VerifyAny(publicKeys, message, armored)
+	checkErr(t, "verifying signature", err)
+	assert.Equal(secondSigner.PublicKey().Marshal(), signer.Marshal())
+
+	_, err = VerifyAny(publicKeys[:1], message, armored)
+	assert.ErrorIs(err, ErrInvalidSignature)
+
+	_, err = ParseAllowedSigners(strings.NewReader("john.doe@example.com not-a-key"))
+	assert.ErrorContains(err, "parsing allowed signers")
+}
+
+func newSigner(t *testing.T, key any) *Signer {
+	t.Helper()
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	checkErr(t, "marshalling private key", err)
+
+	signer, err := FromPEM(bytes.NewReader(pem.EncodeToMemory(block)))
+	checkErr(t, "loading private key", err)
+
+	return signer
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
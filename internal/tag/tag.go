@@ -4,6 +4,8 @@ package tag
 import (
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
@@ -12,9 +14,13 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 
 	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
 )
 
-var ErrTagAlreadyExists = errors.New("tag already exists")
+var (
+	ErrTagAlreadyExists  = errors.New("tag already exists")
+	ErrVersionNotGreater = errors.New("version is not greater than the latest version")
+)
 
 type OptionFunc func(t *Tagger)
 
@@ -30,11 +36,54 @@ func WithSignKey(key *openpgp.Entity) OptionFunc {
 	}
 }
 
+// WithSSHSigner signs the tags using an SSH key instead of a GPG key.
+func WithSSHSigner(signer *ssh.Signer) OptionFunc {
+	return func(t *Tagger) {
+		t.SSHSigner = signer
+	}
+}
+
+// WithForce replaces existing tags instead of failing, and skips the check that new versions are greater than the
+// latest one.
+func WithForce(force bool) OptionFunc {
+	return func(t *Tagger) {
+		t.Force = force
+	}
+}
+
+// WithLightweight creates lightweight tags, simple references to the tagged commit, instead of annotated tags.
+// Lightweight tags cannot be signed.
+func WithLightweight(lightweight bool) OptionFunc {
+	return func(t *Tagger) {
+		t.Lightweight = lightweight
+	}
+}
+
+// WithClock sets the clock giving the date of the tags, read once when the Tagger is created, defaults to time.Now.
+func WithClock(clock func() time.Time) OptionFunc {
+	return func(t *Tagger) {
+		t.Clock = clock
+	}
+}
+
+// WithCommitDate dates the annotated tags with the committer date of the tagged commit instead of the date given by
+// the clock of the Tagger, so that tagging the same commit always gives the same tag.
+func WithCommitDate(commitDate bool) OptionFunc {
+	return func(t *Tagger) {
+		t.CommitDate = commitDate
+	}
+}
+
 type Tagger struct {
 	TagPrefix    string
 	ProjectName  string
 	GitSignature object.Signature
 	SignKey      *openpgp.Entity
+	SSHSigner    *ssh.Signer
+	Clock        func() time.Time
+	Force        bool
+	Lightweight  bool
+	CommitDate   bool
 }
 
 func NewTagger(name, email string, options ...OptionFunc) *Tagger {
@@ -42,14 +91,16 @@ func NewTagger(name, email string, options ...OptionFunc) *Tagger {
 		GitSignature: object.Signature{
 			Name:  name,
 			Email: email,
-			When:  time.Now(),
 		},
+		Clock: time.Now,
 	}
 
 	for _, option := range options {
 		option(tagger)
 	}
 
+	tagger.GitSignature.When = tagger.Clock()
+
 	return tagger
 }
 
@@ -57,6 +108,10 @@ func (t *Tagger) SetProjectName(name string) {
 	t.ProjectName = name
 }
 
+func (t *Tagger) SetTagPrefix(prefix string) {
+	t.TagPrefix = prefix
+}
+
 // TagFromSemver creates a new Git annotated tag from a semantic version number.
 func (t *Tagger) TagFromSemver(semver *semver.Version, hash plumbing.Hash) *object.Tag {
 	tag := &object.Tag{
@@ -83,37 +138,250 @@ func Exists(repository *git.Repository, tagName string) (bool, error) {
 	return exists, nil
 }
 
-// TagRepository AddTagToRepository create a new annotated tag on the repository with a name corresponding to the semver passed as a
-// parameter.
+// CheckRelease checks that a release of the given semver can be tagged, i.e. that its version is strictly greater than
+// the latest one, if any, and that its tag does not exist yet. Nothing is checked if the Tagger is forced.
+func (t *Tagger) CheckRelease(repository *git.Repository, version *semver.Version, latest *semver.Version) error {
+	if t.Force {
+		return nil
+	}
+
+	if latest != nil && semver.Compare(version, latest) != 1 {
+		return fmt.Errorf("%w: %q is not greater than %q", ErrVersionNotGreater, version, latest)
+	}
+
+	tagName := t.Format(version)
+
+	exists, err := Exists(repository, tagName)
+	if err != nil {
+		return fmt.Errorf("checking if tag exists: %w", err)
+	}
+
+	if exists {
+		return fmt.Errorf("%w: %q", ErrTagAlreadyExists, tagName)
+	}
+
+	return nil
+}
+
+// TagRepository AddTagToRepository create a new tag on the repository with a name corresponding to the semver passed as a
+// parameter. If the tag already exists, it is replaced if the Tagger is forced.
 func (t *Tagger) TagRepository(repository *git.Repository, semver *semver.Version, commitHash plumbing.Hash) error {
+	return t.TagRepositoryWithNotes(repository, semver, commitHash, "")
+}
+
+// TagRepositoryWithNotes creates a new tag on the repository as TagRepository does, the given release notes, if any,
+// being the body of the message of the annotated tag, after its name.
+func (t *Tagger) TagRepositoryWithNotes(repository *git.Repository, semver *semver.Version, commitHash plumbing.Hash, notes string) error {
 	if semver == nil {
 		return fmt.Errorf("semver is nil")
 	}
 
-	tagMessage := t.Format(semver)
+	tagName := t.Format(semver)
 
-	tagOpts := &git.CreateTagOptions{
-		Message: tagMessage,
-		SignKey: t.SignKey,
-		Tagger:  &t.GitSignature,
+	exists, err := Exists(repository, tagName)
+	if err != nil {
+		return fmt.Errorf("checking if tag exists: %w", err)
 	}
 
-	if exists, err := Exists(repository, tagOpts.Message); err != nil {
-		return fmt.Errorf("checking if tag exists: %w", err)
-	} else if exists {
+	switch {
+	case exists && !t.Force:
 		return ErrTagAlreadyExists
+	case exists:
+		if err = repository.DeleteTag(tagName); err != nil {
+			return fmt.Errorf("deleting tag %q: %w", tagName, err)
+		}
+	}
+
+	message := tagName
+	if notes = strings.TrimSpace(notes); notes != "" {
+		message += "\n\n" + notes
 	}
 
-	if _, err := repository.CreateTag(tagOpts.Message, commitHash, tagOpts); err != nil {
+	if err := t.createTag(repository, tagName, commitHash, message); err != nil {
 		return fmt.Errorf("creating tag on repository: %w", err)
 	}
 
 	return nil
 }
 
+// AliasRepository creates, or moves if they already exist, the floating tags pointing to the major and minor versions
+// of the given semver (e.g., "v1" and "v1.2" for "v1.2.3"). An alias is only moved if the semver is the highest
+// release of its line, so that a release made on a maintenance branch does not move the aliases back to an older
+// version. Prerelease versions have no alias. The names of the alias tags created or moved are returned.
+func (t *Tagger) AliasRepository(repository *git.Repository, semver *semver.Version, commitHash plumbing.Hash) ([]string, error) {
+	if semver == nil {
+		return nil, fmt.Errorf("semver is nil")
+	}
+
+	aliases, err := t.highestAliases(repository, semver)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, alias := range aliases {
+		exists, err := Exists(repository, alias)
+		if err != nil {
+			return nil, fmt.Errorf("checking if tag exists: %w", err)
+		}
+
+		if exists {
+			if err = repository.DeleteTag(alias); err != nil {
+				return nil, fmt.Errorf("deleting tag %q: %w", alias, err)
+			}
+		}
+
+		if err = t.createTag(repository, alias, commitHash, alias); err != nil {
+			return nil, fmt.Errorf("creating tag %q on repository: %w", alias, err)
+		}
+	}
+
+	return aliases, nil
+}
+
+// highestAliases returns the aliases of the given semver whose line it is the highest release of.
+func (t *Tagger) highestAliases(repository *git.Repository, version *semver.Version) ([]string, error) {
+	aliases := t.FormatAliases(version)
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+
+	versions, err := t.Versions(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	major, minor := semver.HighestInLines(version, versions)
+
+	var highest []string
+
+	if major {
+		highest = append(highest, aliases[0])
+	}
+
+	if minor {
+		highest = append(highest, aliases[1])
+	}
+
+	return highest, nil
+}
+
+// Versions returns the versions of the releases tagged in the repository, whose tags are named by the Tagger.
+func (t *Tagger) Versions(repository *git.Repository) ([]*semver.Version, error) {
+	references, err := repository.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("fetching tags: %w", err)
+	}
+
+	var versions []*semver.Version
+
+	prefix := t.format(t.TagPrefix)
+
+	err = references.ForEach(func(reference *plumbing.Reference) error {
+		name := reference.Name().Short()
+
+		version, err := semver.NewFromString(strings.TrimPrefix(name, prefix))
+		if err != nil || t.Format(version) != name {
+			return nil
+		}
+
+		versions = append(versions, version)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("iterating over tags: %w", err)
+	}
+
+	return versions, nil
+}
+
+// createTag creates an annotated tag with the given message, signed with the GPG or SSH key of the Tagger if any. A
+// lightweight tag, without message, is created instead if the Tagger is configured to do so.
+func (t *Tagger) createTag(repository *git.Repository, tagName string, commitHash plumbing.Hash, message string) error {
+	if t.Lightweight {
+		_, err := repository.CreateTag(tagName, commitHash, nil)
+		return err
+	}
+
+	commit, err := repository.CommitObject(commitHash)
+	if err != nil {
+		return fmt.Errorf("fetching commit: %w", err)
+	}
+
+	signature := t.GitSignature
+	if t.CommitDate {
+		signature.When = commit.Committer.When
+	}
+
+	if t.SSHSigner == nil {
+		_, err = repository.CreateTag(tagName, commitHash, &git.CreateTagOptions{
+			Message: message,
+			SignKey: t.SignKey,
+			Tagger:  &signature,
+		})
+
+		return err
+	}
+
+	tag := &object.Tag{
+		Name:       tagName,
+		Tagger:     signature,
+		Message:    message + "\n",
+		TargetType: plumbing.CommitObject,
+		Target:     commit.Hash,
+	}
+
+	unsigned := repository.Storer.NewEncodedObject()
+	if err = tag.EncodeWithoutSignature(unsigned); err != nil {
+		return fmt.Errorf("encoding tag: %w", err)
+	}
+
+	reader, err := unsigned.Reader()
+	if err != nil {
+		return fmt.Errorf("reading encoded tag: %w", err)
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("reading encoded tag: %w", err)
+	}
+
+	// Git stores SSH signatures in the same header as PGP signatures
+	tag.PGPSignature, err = t.SSHSigner.Sign(content)
+	if err != nil {
+		return fmt.Errorf("signing tag: %w", err)
+	}
+
+	signed := repository.Storer.NewEncodedObject()
+	if err = tag.Encode(signed); err != nil {
+		return fmt.Errorf("encoding signed tag: %w", err)
+	}
+
+	hash, err := repository.Storer.SetEncodedObject(signed)
+	if err != nil {
+		return fmt.Errorf("storing tag: %w", err)
+	}
+
+	return repository.Storer.SetReference(plumbing.NewHashReference(plumbing.NewTagReferenceName(tagName), hash))
+}
+
 func (t *Tagger) Format(semver *semver.Version) string {
-	tag := t.TagPrefix + semver.String()
+	return t.format(t.TagPrefix + semver.String())
+}
+
+// FormatAliases returns the names of the major and minor alias tags of a given semver, or nothing if the semver is a
+// prerelease.
+func (t *Tagger) FormatAliases(semver *semver.Version) []string {
+	if semver.Prerelease != "" {
+		return nil
+	}
+
+	return []string{
+		t.format(fmt.Sprintf("%s%d", t.TagPrefix, semver.Major)),
+		t.format(fmt.Sprintf("%s%d.%d", t.TagPrefix, semver.Major, semver.Minor)),
+	}
+}
 
+func (t *Tagger) format(tag string) string {
 	if t.ProjectName != "" {
 		tag = t.ProjectName + "-" + tag
 	}
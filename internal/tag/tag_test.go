@@ -1,8 +1,14 @@
 package tag
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/ProtonMail/go-crypto/openpgp/packet"
@@ -10,9 +16,11 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	assertion "github.com/stretchr/testify/assert"
+	cryptossh "golang.org/x/crypto/ssh"
 
 	"github.com/s0ders/go-semver-release/v6/internal/gittest"
 	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
 )
 
 var (
@@ -76,6 +84,112 @@ func TestTag_AddTagToRepository(t *testing.T) {
 	assert.Equal(tagExists, true, "tag should have been found")
 }
 
+func TestTag_TagRepositoryWithNotes(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	notes := "## v1.0.0 (2024-01-01)\n\n### Features\n\n* add foo\n\n"
+
+	tagger := NewTagger(taggerName, taggerEmail, WithTagPrefix("v"))
+
+	err = tagger.TagRepositoryWithNotes(testRepository.Repository, &semver.Version{Major: 1}, head.Hash(), notes)
+	checkErr(t, "tagging repository", err)
+
+	err = tagger.TagRepositoryWithNotes(testRepository.Repository, &semver.Version{Major: 2}, head.Hash(), "")
+	checkErr(t, "tagging repository", err)
+
+	type test struct {
+		tagName, want string
+	}
+
+	matrix := []test{
+		{tagName: "v1.0.0", want: "v1.0.0\n\n## v1.0.0 (2024-01-01)\n\n### Features\n\n* add foo\n"},
+		{tagName: "v2.0.0", want: "v2.0.0\n"},
+	}
+
+	for _, tc := range matrix {
+		reference, err := testRepository.Reference(plumbing.NewTagReferenceName(tc.tagName), true)
+		checkErr(t, "fetching tag reference", err)
+
+		actualTag, err := testRepository.TagObject(reference.Hash())
+		checkErr(t, "fetching tag from reference", err)
+
+		assert.Equal(tc.want, actualTag.Message, tc.tagName)
+	}
+}
+
+func TestTag_Clock(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	date := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.FixedZone("", 2*60*60))
+
+	tagger := NewTagger(taggerName, taggerEmail, WithClock(func() time.Time { return date }))
+
+	err = tagger.TagRepository(testRepository.Repository, &semver.Version{Major: 1}, head.Hash())
+	checkErr(t, "tagging repository", err)
+
+	reference, err := testRepository.Tag("1.0.0")
+	checkErr(t, "fetching tag", err)
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, "fetching tag object", err)
+
+	assert.Equal(taggerName, tagObject.Tagger.Name)
+	assert.Equal(taggerEmail, tagObject.Tagger.Email)
+	assert.True(date.Equal(tagObject.Tagger.When), "tag should be dated by the clock")
+}
+
+func TestTag_CommitDate(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	commit, err := testRepository.CommitObject(head.Hash())
+	checkErr(t, "fetching commit", err)
+
+	date := commit.Committer.When.Add(24 * time.Hour)
+
+	tagger := NewTagger(taggerName, taggerEmail, WithClock(func() time.Time { return date }), WithCommitDate(true))
+
+	err = tagger.TagRepository(testRepository.Repository, &semver.Version{Major: 1}, head.Hash())
+	checkErr(t, "tagging repository", err)
+
+	reference, err := testRepository.Tag("1.0.0")
+	checkErr(t, "fetching tag", err)
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, "fetching tag object", err)
+
+	assert.True(commit.Committer.When.Equal(tagObject.Tagger.When), "tag should be dated by the tagged commit")
+}
+
 func TestTag_AddExistingTagToRepository(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -100,6 +214,117 @@ func TestTag_AddExistingTagToRepository(t *testing.T) {
 	assert.Error(err, "should not have been able to add tag to repository")
 }
 
+func TestTag_ForceExistingTag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	version := &semver.Version{Major: 1}
+
+	tagger := NewTagger(taggerName, taggerEmail)
+
+	err = tagger.TagRepository(testRepository.Repository, version, head.Hash())
+	checkErr(t, "tagging repository", err)
+
+	hash, err := testRepository.AddCommit("fix")
+	checkErr(t, "adding commit", err)
+
+	tagger = NewTagger(taggerName, taggerEmail, WithForce(true))
+
+	err = tagger.TagRepository(testRepository.Repository, version, hash)
+	checkErr(t, "replacing tag", err)
+
+	reference, err := testRepository.Tag(version.String())
+	checkErr(t, "fetching tag", err)
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, "fetching tag object", err)
+
+	assert.Equal(hash, tagObject.Target, "tag should have been moved")
+}
+
+func TestTag_Lightweight(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	version := &semver.Version{Major: 1}
+
+	tagger := NewTagger(taggerName, taggerEmail, WithLightweight(true))
+
+	err = tagger.TagRepository(testRepository.Repository, version, head.Hash())
+	checkErr(t, "tagging repository", err)
+
+	reference, err := testRepository.Tag(version.String())
+	checkErr(t, "fetching tag", err)
+
+	assert.Equal(head.Hash(), reference.Hash(), "tag should directly point to the commit")
+
+	_, err = testRepository.TagObject(reference.Hash())
+	assert.ErrorIs(err, plumbing.ErrObjectNotFound, "no tag object should have been created")
+}
+
+func TestTag_CheckRelease(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.AddTag("v1.2.0", head.Hash())
+	checkErr(t, "adding tag", err)
+
+	type test struct {
+		version *semver.Version
+		latest  *semver.Version
+		want    error
+	}
+
+	matrix := []test{
+		{version: &semver.Version{Major: 1, Minor: 2, Patch: 1}, latest: &semver.Version{Major: 1, Minor: 2}, want: nil},
+		{version: &semver.Version{Major: 0, Minor: 0, Patch: 1}, latest: nil, want: nil},
+		{version: &semver.Version{Major: 1, Minor: 1}, latest: &semver.Version{Major: 1, Minor: 2}, want: ErrVersionNotGreater},
+		{version: &semver.Version{Major: 1, Minor: 2}, latest: &semver.Version{Major: 1, Minor: 2}, want: ErrVersionNotGreater},
+		{version: &semver.Version{Major: 1, Minor: 2}, latest: &semver.Version{Major: 1, Minor: 1}, want: ErrTagAlreadyExists},
+	}
+
+	tagger := NewTagger(taggerName, taggerEmail, WithTagPrefix("v"))
+
+	for _, tc := range matrix {
+		err = tagger.CheckRelease(testRepository.Repository, tc.version, tc.latest)
+		assert.ErrorIs(err, tc.want, "version: %s", tc.version)
+	}
+
+	tagger = NewTagger(taggerName, taggerEmail, WithTagPrefix("v"), WithForce(true))
+
+	for _, tc := range matrix {
+		err = tagger.CheckRelease(testRepository.Repository, tc.version, tc.latest)
+		assert.NoError(err, "forced tagger should not check version %s", tc.version)
+	}
+}
+
 func TestTag_NewTagFromSemver(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -180,6 +405,57 @@ func TestTag_SignKey(t *testing.T) {
 	assert.NotEqual("", actualTag.PGPSignature, "PGP signature should not be empty")
 }
 
+func TestTag_SSHSigner(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, "generating ed25519 key", err)
+
+	block, err := cryptossh.MarshalPrivateKey(privateKey, "")
+	checkErr(t, "marshalling private key", err)
+
+	signer, err := ssh.FromPEM(bytes.NewReader(pem.EncodeToMemory(block)))
+	checkErr(t, "loading private key", err)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	version := &semver.Version{Major: 1}
+
+	tagger := NewTagger(taggerName, taggerEmail, WithSSHSigner(signer))
+
+	err = tagger.TagRepository(testRepository.Repository, version, head.Hash())
+	checkErr(t, "tagging repository", err)
+
+	reference, err := testRepository.Reference(plumbing.NewTagReferenceName(version.String()), true)
+	checkErr(t, "fetching tag reference", err)
+
+	actualTag, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, "fetching tag from reference", err)
+
+	assert.Equal(head.Hash(), actualTag.Target)
+	assert.Equal(version.String()+"\n", actualTag.Message)
+
+	unsigned := &plumbing.MemoryObject{}
+	err = actualTag.EncodeWithoutSignature(unsigned)
+	checkErr(t, "encoding tag", err)
+
+	reader, err := unsigned.Reader()
+	checkErr(t, "reading encoded tag", err)
+
+	content, err := io.ReadAll(reader)
+	checkErr(t, "reading encoded tag", err)
+
+	assert.NoError(ssh.Verify(signer.PublicKey(), content, actualTag.PGPSignature), "SSH signature should be valid")
+}
+
 func TestTag_Format(t *testing.T) {
 	assert := assertion.New(t)
 
@@ -225,6 +501,120 @@ func TestTag_AddTagToRepositoryWithProject(t *testing.T) {
 	assert.Equal(tagExists, true, "tag should have been found")
 }
 
+func TestTag_FormatAliases(t *testing.T) {
+	assert := assertion.New(t)
+
+	tagger := NewTagger(taggerName, taggerEmail, WithTagPrefix("v"))
+
+	assert.Equal([]string{"v1", "v1.2"}, tagger.FormatAliases(&semver.Version{Major: 1, Minor: 2, Patch: 3}))
+	assert.Empty(tagger.FormatAliases(&semver.Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}), "prerelease should not have aliases")
+
+	tagger.SetProjectName("foo")
+
+	assert.Equal([]string{"foo-v1", "foo-v1.2"}, tagger.FormatAliases(&semver.Version{Major: 1, Minor: 2, Patch: 3}))
+}
+
+func TestTag_AliasRepository(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	firstHash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	tagger := NewTagger(taggerName, taggerEmail, WithTagPrefix("v"))
+
+	aliases, err := tagger.AliasRepository(testRepository.Repository, &semver.Version{Major: 1, Minor: 2, Patch: 0}, firstHash)
+	checkErr(t, "aliasing repository", err)
+	assert.Equal([]string{"v1", "v1.2"}, aliases)
+
+	secondHash, err := testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	_, err = tagger.AliasRepository(testRepository.Repository, &semver.Version{Major: 1, Minor: 3, Patch: 0}, secondHash)
+	checkErr(t, "aliasing repository", err)
+
+	want := map[string]plumbing.Hash{
+		"v1":   secondHash,
+		"v1.2": firstHash,
+		"v1.3": secondHash,
+	}
+
+	for alias, hash := range want {
+		reference, err := testRepository.Reference(plumbing.NewTagReferenceName(alias), true)
+		checkErr(t, "fetching tag reference", err)
+
+		tagObject, err := testRepository.TagObject(reference.Hash())
+		checkErr(t, "fetching tag object", err)
+
+		assert.Equal(hash, tagObject.Target, "tag %q should point to the right commit", alias)
+	}
+}
+
+func TestTag_AliasRepository_HighestOfLine(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	tagger := NewTagger(taggerName, taggerEmail, WithTagPrefix("v"))
+
+	release := func(version *semver.Version) (plumbing.Hash, []string) {
+		t.Helper()
+
+		hash, err := testRepository.AddCommit("fix")
+		checkErr(t, "adding commit", err)
+
+		err = tagger.TagRepository(testRepository.Repository, version, hash)
+		checkErr(t, "tagging repository", err)
+
+		aliases, err := tagger.AliasRepository(testRepository.Repository, version, hash)
+		checkErr(t, "aliasing repository", err)
+
+		return hash, aliases
+	}
+
+	_, _ = release(&semver.Version{Major: 1, Minor: 2, Patch: 4})
+	latestHash, _ := release(&semver.Version{Major: 1, Minor: 5, Patch: 0})
+
+	// A patch of an older minor version, released from a maintenance branch, only moves the alias of its minor line
+	patchHash, aliases := release(&semver.Version{Major: 1, Minor: 2, Patch: 5})
+	assert.Equal([]string{"v1.2"}, aliases)
+
+	_, aliases = release(&semver.Version{Major: 1, Minor: 6, Patch: 0, Prerelease: "rc.1"})
+	assert.Empty(aliases, "prerelease should not move any alias")
+
+	want := map[string]plumbing.Hash{
+		"v1":   latestHash,
+		"v1.2": patchHash,
+		"v1.5": latestHash,
+	}
+
+	for alias, hash := range want {
+		reference, err := testRepository.Reference(plumbing.NewTagReferenceName(alias), true)
+		checkErr(t, "fetching tag reference", err)
+
+		tagObject, err := testRepository.TagObject(reference.Hash())
+		checkErr(t, "fetching tag object", err)
+
+		assert.Equal(hash, tagObject.Target, "tag %q should point to the right commit", alias)
+	}
+
+	versions, err := tagger.Versions(testRepository.Repository)
+	checkErr(t, "fetching versions", err)
+
+	assert.Len(versions, 4, "alias tags should not be listed as versions")
+}
+
 func checkErr(t *testing.T, msg string, err error) {
 	t.Helper()
 	if err != nil {
@@ -0,0 +1,76 @@
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
+)
+
+var (
+	ErrUnsignedTag      = errors.New("tag is not signed")
+	ErrInvalidSignature = errors.New("tag signature is invalid")
+)
+
+const sshSignaturePrefix = "-----BEGIN SSH SIGNATURE-----"
+
+// Verifier checks the GPG or SSH signature of annotated tags against a set of trusted public keys.
+type Verifier struct {
+	ArmoredKeyRing string
+	SSHKeys        []cryptossh.PublicKey
+}
+
+// Verify checks that a given annotated tag is signed by one of the trusted keys of the Verifier. ErrUnsignedTag is
+// returned if the tag has no signature and ErrInvalidSignature if the signature cannot be verified.
+func (v *Verifier) Verify(tag *object.Tag) error {
+	if tag.PGPSignature == "" {
+		return ErrUnsignedTag
+	}
+
+	if strings.HasPrefix(tag.PGPSignature, sshSignaturePrefix) {
+		return v.verifySSH(tag)
+	}
+
+	if v.ArmoredKeyRing == "" {
+		return fmt.Errorf("%w: no GPG keyring to verify GPG signature", ErrInvalidSignature)
+	}
+
+	if _, err := tag.Verify(v.ArmoredKeyRing); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return nil
+}
+
+func (v *Verifier) verifySSH(tag *object.Tag) error {
+	if len(v.SSHKeys) == 0 {
+		return fmt.Errorf("%w: no allowed SSH keys to verify SSH signature", ErrInvalidSignature)
+	}
+
+	unsigned := &plumbing.MemoryObject{}
+	if err := tag.EncodeWithoutSignature(unsigned); err != nil {
+		return fmt.Errorf("encoding tag: %w", err)
+	}
+
+	reader, err := unsigned.Reader()
+	if err != nil {
+		return fmt.Errorf("reading encoded tag: %w", err)
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("reading encoded tag: %w", err)
+	}
+
+	if _, err = ssh.VerifyAny(v.SSHKeys, content, tag.PGPSignature); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package tag
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/ssh"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	assert := assertion.New(t)
+
+	entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, "creating openpgp entity", err)
+
+	otherEntity, err := openpgp.NewEntity("Jane Doe", "", "jane.doe@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	checkErr(t, "creating openpgp entity", err)
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	checkErr(t, "generating ed25519 key", err)
+
+	block, err := cryptossh.MarshalPrivateKey(privateKey, "")
+	checkErr(t, "marshalling private key", err)
+
+	sshSigner, err := ssh.FromPEM(bytes.NewReader(pem.EncodeToMemory(block)))
+	checkErr(t, "loading private key", err)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	err = testRepository.AddTag("0.1.0", head.Hash())
+	checkErr(t, "adding tag", err)
+
+	taggers := map[string]*Tagger{
+		"1.0.0": NewTagger(taggerName, taggerEmail, WithSignKey(entity)),
+		"2.0.0": NewTagger(taggerName, taggerEmail, WithSignKey(otherEntity)),
+		"3.0.0": NewTagger(taggerName, taggerEmail, WithSSHSigner(sshSigner)),
+	}
+
+	for version, tagger := range taggers {
+		v, err := semver.NewFromString(version)
+		checkErr(t, "parsing version", err)
+
+		err = tagger.TagRepository(testRepository.Repository, v, head.Hash())
+		checkErr(t, "tagging repository", err)
+	}
+
+	verifier := &Verifier{
+		ArmoredKeyRing: armoredPublicKey(t, entity),
+		SSHKeys:        []cryptossh.PublicKey{sshSigner.PublicKey()},
+	}
+
+	want := map[string]error{
+		"0.1.0": ErrUnsignedTag,
+		"1.0.0": nil,
+		"2.0.0": ErrInvalidSignature,
+		"3.0.0": nil,
+	}
+
+	for tagName, wantErr := range want {
+		tagObject := fetchTag(t, testRepository, tagName)
+
+		err = verifier.Verify(tagObject)
+		if wantErr == nil {
+			assert.NoError(err, "tag %q should have been verified", tagName)
+		} else {
+			assert.ErrorIs(err, wantErr, "tag %q should not have been verified", tagName)
+		}
+	}
+
+	err = (&Verifier{}).Verify(fetchTag(t, testRepository, "3.0.0"))
+	assert.ErrorIs(err, ErrInvalidSignature, "SSH signature should not be verified without allowed keys")
+}
+
+func armoredPublicKey(t *testing.T, entity *openpgp.Entity) string {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+
+	armorWriter, err := armor.Encode(buf, openpgp.PublicKeyType, map[string]string{})
+	checkErr(t, "encoding armor", err)
+
+	err = entity.Serialize(armorWriter)
+	checkErr(t, "serializing public key", err)
+
+	err = armorWriter.Close()
+	checkErr(t, "closing armor writer", err)
+
+	return buf.String()
+}
+
+func fetchTag(t *testing.T, testRepository *gittest.TestRepository, tagName string) *object.Tag {
+	t.Helper()
+
+	reference, err := testRepository.Reference(plumbing.NewTagReferenceName(tagName), true)
+	checkErr(t, "fetching tag reference", err)
+
+	tagObject, err := testRepository.TagObject(reference.Hash())
+	checkErr(t, "fetching tag object", err)
+
+	return tagObject
+}
@@ -0,0 +1,160 @@
+// Package webhook notifies HTTP endpoints of new releases, typically to trigger downstream deployments.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+const (
+	// SignatureHeader is the header holding the HMAC-SHA256 signature of the payload, of the form "sha256=<hex>".
+	SignatureHeader = "X-Go-Semver-Release-Signature"
+	// EventHeader is the header holding the event the payload describes.
+	EventHeader = "X-Go-Semver-Release-Event"
+	// ReleaseEvent is the event sent when a new release is made.
+	ReleaseEvent = "release"
+	// defaultRetryDelay is the delay before the first retry, doubled on every subsequent retry.
+	defaultRetryDelay = time.Second
+)
+
+// Payload is the JSON body sent to the webhooks.
+type Payload struct {
+	Repository      string `json:"repository"`
+	Branch          string `json:"branch"`
+	Channel         string `json:"channel,omitempty"`
+	Project         string `json:"project,omitempty"`
+	Tag             string `json:"tag"`
+	Version         string `json:"version"`
+	PreviousVersion string `json:"previous_version,omitempty"`
+	Commit          string `json:"commit"`
+	// Changelog is the Markdown changelog of the release.
+	Changelog string `json:"changelog"`
+	// Issues lists the issues and tickets referenced by the commits of the release (e.g., "#12", "JIRA-456").
+	Issues []string `json:"issues,omitempty"`
+}
+
+type Notifier struct {
+	httpClient *http.Client
+	urls       []string
+	secret     string
+	retries    int
+	retryDelay time.Duration
+}
+
+type OptionFunc func(n *Notifier)
+
+// WithSecret signs the payloads with the given secret, see Sign.
+func WithSecret(secret string) OptionFunc {
+	return func(n *Notifier) {
+		n.secret = secret
+	}
+}
+
+// WithRetries retries a failed notification the given number of times, waiting twice as long before every retry.
+func WithRetries(retries int, delay time.Duration) OptionFunc {
+	return func(n *Notifier) {
+		n.retries = retries
+		n.retryDelay = delay
+	}
+}
+
+// WithHTTPClient sends the requests using the given HTTP client instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) OptionFunc {
+	return func(n *Notifier) {
+		n.httpClient = httpClient
+	}
+}
+
+// NewNotifier returns a notifier of the webhooks at the given URLs.
+func NewNotifier(urls []string, options ...OptionFunc) *Notifier {
+	n := &Notifier{
+		httpClient: http.DefaultClient,
+		urls:       urls,
+		retryDelay: defaultRetryDelay,
+	}
+
+	for _, option := range options {
+		option(n)
+	}
+
+	return n
+}
+
+// Notify posts the given payload to every webhook. A webhook failing does not prevent the others from being notified,
+// the errors of all the failed webhooks are returned.
+func (n *Notifier) Notify(ctx context.Context, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	var errs []error
+
+	for _, url := range n.urls {
+		if err = n.post(ctx, url, body); err != nil {
+			errs = append(errs, fmt.Errorf("notifying %q: %w", url, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// post posts the given body to the given URL, retrying on network errors and server errors.
+func (n *Notifier) post(ctx context.Context, url string, body []byte) error {
+	api := restapi.New(url)
+	api.HTTPClient = n.httpClient
+	api.Header.Set(EventHeader, ReleaseEvent)
+
+	if n.secret != "" {
+		api.Header.Set(SignatureHeader, Sign(n.secret, body))
+	}
+
+	delay := n.retryDelay
+
+	for attempt := 0; ; attempt++ {
+		err := api.Send(ctx, http.MethodPost, "", "application/json", bytes.NewReader(body), nil, http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent)
+		if err == nil || attempt >= n.retries || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// retryable returns true if the given error may not happen again, that is if it is not a client error other than
+// 408 Request Timeout or 429 Too Many Requests.
+func retryable(err error) bool {
+	var responseError *restapi.ResponseError
+	if !errors.As(err, &responseError) {
+		return true
+	}
+
+	code := responseError.StatusCode
+
+	return code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+}
+
+// Sign returns the signature of the given payload, of the form "sha256=<hex>" where <hex> is the hexadecimal
+// HMAC-SHA256 of the payload keyed with the given secret.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
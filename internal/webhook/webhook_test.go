@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/restapi"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	assert := assertion.New(t)
+
+	var payloads []Payload
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Header.Get(SignatureHeader) != Sign("secret", body) || r.Header.Get(EventHeader) != ReleaseEvent {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		var payload Payload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		payloads = append(payloads, payload)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	payload := Payload{
+		Repository:      "https://github.com/owner/name.git",
+		Branch:          "main",
+		Tag:             "v1.1.0",
+		Version:         "1.1.0",
+		PreviousVersion: "1.0.0",
+		Commit:          "abc123",
+		Changelog:       "## v1.1.0",
+	}
+
+	err := NewNotifier([]string{server.URL, server.URL + "/other"}, WithSecret("secret")).Notify(context.Background(), payload)
+	checkErr(t, "notifying webhooks", err)
+
+	assert.Equal([]Payload{payload, payload}, payloads, "every webhook should have been notified")
+
+	err = NewNotifier([]string{server.URL}, WithSecret("wrong")).Notify(context.Background(), payload)
+	assert.ErrorIs(err, restapi.ErrUnexpectedResponse)
+	assert.ErrorContains(err, "invalid signature")
+}
+
+func TestNotifier_Notify_Retries(t *testing.T) {
+	assert := assertion.New(t)
+
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		switch {
+		case r.URL.Path == "/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+		case attempts < 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	err := NewNotifier([]string{server.URL}, WithRetries(1, 0)).Notify(context.Background(), Payload{})
+	assert.True(restapi.HasStatus(err, http.StatusServiceUnavailable), "notification should fail once retries are exhausted")
+	assert.Equal(2, attempts)
+
+	attempts = 0
+
+	err = NewNotifier([]string{server.URL}, WithRetries(3, 0)).Notify(context.Background(), Payload{})
+	checkErr(t, "notifying webhook", err)
+	assert.Equal(3, attempts, "notification should stop being retried once it succeeds")
+
+	attempts = 0
+
+	err = NewNotifier([]string{server.URL + "/unauthorized"}, WithRetries(3, 0)).Notify(context.Background(), Payload{})
+	assert.True(restapi.HasStatus(err, http.StatusUnauthorized))
+	assert.Equal(1, attempts, "client errors should not be retried")
+}
+
+func TestSign(t *testing.T) {
+	assertion.Equal(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", Sign("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/s0ders/go-semver-release/v6/cmd"
 	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
@@ -11,8 +14,12 @@ func main() {
 	ctx := appcontext.New()
 	rootCmd := cmd.NewRootCommand(ctx)
 
-	err := rootCmd.Execute()
-	if err != nil {
-		os.Exit(1)
-	}
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	err := rootCmd.ExecuteContext(signalCtx)
+	stop()
+
+	_ = cmd.WriteError(os.Stderr, err)
+
+	os.Exit(cmd.ExitCode(err))
 }
@@ -0,0 +1,408 @@
+// Package release provides a public API to compute the next semantic version of a Git repository from its Conventional
+// Commits history, so that Go programs can embed the version computation done by the go-semver-release CLI.
+//
+// A minimal usage looks like this:
+//
+//	analyzer, err := release.NewAnalyzer(release.WithBranches(release.Branch{Name: "main"}))
+//	if err != nil {
+//		return err
+//	}
+//
+//	results, err := analyzer.Analyze(context.Background(), repository)
+//
+// The analysis does not modify the repository. The new releases can then be tagged with Tag, only locally: pushing the
+// tags, as well as the changelogs, forge releases and other publication steps of the CLI, is left to the caller.
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/s0ders/go-semver-release/v6/internal/appcontext"
+	"github.com/s0ders/go-semver-release/v6/internal/branch"
+	"github.com/s0ders/go-semver-release/v6/internal/commit"
+	"github.com/s0ders/go-semver-release/v6/internal/logging"
+	"github.com/s0ders/go-semver-release/v6/internal/monorepo"
+	"github.com/s0ders/go-semver-release/v6/internal/parser"
+	"github.com/s0ders/go-semver-release/v6/internal/rule"
+	"github.com/s0ders/go-semver-release/v6/internal/scheme"
+	"github.com/s0ders/go-semver-release/v6/internal/semver"
+	"github.com/s0ders/go-semver-release/v6/internal/tag"
+)
+
+const (
+	defaultRemoteName = "origin"
+	defaultTagPrefix  = "v"
+)
+
+// CacheRef is the reference of the Git notes caching the analysis of the commit history, see WithCache.
+const CacheRef = parser.NotesRef
+
+var (
+	ErrNoBranch              = errors.New("no release branch configured")
+	ErrNoBranchName          = errors.New("release branch has no name")
+	ErrInvalidProject        = errors.New("project must have a name and a path")
+	ErrNonConventionalCommit = parser.ErrNonConventionalCommit
+	ErrUnknownCommitType     = parser.ErrUnknownCommitType
+	ErrInvalidRevision       = parser.ErrInvalidRevision
+	ErrCommitNotOnBranch     = parser.ErrCommitNotOnBranch
+	ErrShallowHistory        = commit.ErrShallowHistory
+	ErrNoNewRelease          = errors.New("result is not a new release")
+	ErrTagAlreadyExists      = tag.ErrTagAlreadyExists
+)
+
+// Branch is a release branch. Prerelease branches produce versions suffixed by their prerelease identifier, which is
+// their name unless PrereleaseID is set.
+type Branch = branch.Branch
+
+// Project is a project of a monorepo, located at Path relatively to the repository root.
+type Project = monorepo.Project
+
+// Version is a semantic version number.
+type Version = semver.Version
+
+// Commit is a Conventional Commit that triggered a release.
+type Commit = parser.Commit
+
+// Result is the outcome of the analysis of a release branch, or of a project on a release branch in monorepo mode.
+type Result struct {
+	// Version is the next version if NewRelease is true, the latest released version otherwise.
+	Version *Version
+	Branch  string
+	Channel string
+	// Project is the name of the analyzed project, empty if not in monorepo mode.
+	Project string
+	// ReleaseAs is the version forced by a Release-As commit footer, if any.
+	ReleaseAs string
+	// Forced is true if the version was set or bumped explicitly instead of being computed from the commits.
+	Forced bool
+	// Commits lists the commits that triggered the release.
+	Commits []Commit
+	// CommitHash is the hash of the commit the release tag should point to.
+	CommitHash plumbing.Hash
+	// BumpedBy lists the projects whose release triggered the release of the project, which depends on them.
+	BumpedBy []string
+	// Skipped is true if the release is suppressed by a ".skip-release" file found at the root of the repository.
+	Skipped bool
+	// TagPrefix is the prefix of the version in the release tags (e.g., "v").
+	TagPrefix string
+	// From is the commit at which the analysis of the history stopped, excluded, zero if the whole history was
+	// analyzed.
+	From plumbing.Hash
+	// To is the commit from which the history was analyzed.
+	To         plumbing.Hash
+	NewRelease bool
+}
+
+type OptionFunc func(a *Analyzer)
+
+// WithBranches sets the release branches to analyze.
+func WithBranches(branches ...Branch) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.Branches = append(a.ctx.Branches, branches...)
+	}
+}
+
+// WithProjects enables the monorepo mode and sets the projects to analyze on each release branch.
+func WithProjects(projects ...Project) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.Projects = append(a.ctx.Projects, projects...)
+	}
+}
+
+// WithRules sets the release rules as a map of release types (i.e., "minor", "patch" or "none") to commit types. The
+// default rules are used if this option is not set.
+func WithRules(rules map[string][]string) OptionFunc {
+	return func(a *Analyzer) {
+		a.rules = rules
+	}
+}
+
+// WithBuildMetadata appends the given build metadata to the computed versions. The metadata may be a Go template
+// rendered for every release, such as "{{ .ShortSHA }}.{{ .RunNumber }}".
+func WithBuildMetadata(metadata string) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.BuildMetadataFlag = metadata
+	}
+}
+
+// WithInitialVersion sets the version of the first release, when the repository has no release yet. Otherwise, the first
+// release is computed from the commit history.
+func WithInitialVersion(version Version) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.InitialVersion = &version
+	}
+}
+
+// WithCalVer uses the Calendar Versioning scheme with the given format, such as "YYYY.MM.MICRO" (default if empty) or
+// "YY.MM.MICRO", instead of the Semantic Versioning. The commits decide whether a new release is made and how the
+// components following the date are incremented.
+func WithCalVer(format string) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.VersionSchemeFlag = scheme.CalVerName
+		a.ctx.CalVerFormatFlag = format
+	}
+}
+
+// WithMinorOnBreakingInDev only bumps the minor version on breaking changes while the major version is zero (i.e.,
+// 0.y.z), following the common practice of projects in initial development.
+func WithMinorOnBreakingInDev() OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.MajorOnBreakingInDevFlag = false
+	}
+}
+
+// WithRemoteName sets the name of the remote whose branches are analyzed, defaults to "origin".
+func WithRemoteName(name string) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.RemoteNameFlag = name
+	}
+}
+
+// WithFirstParentOnly only follows the first parent of merge commits when parsing the commit history.
+func WithFirstParentOnly() OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.FirstParentFlag = true
+	}
+}
+
+// WithSquashedCommits also parses the Conventional Commits listed in the body of squashed commits.
+func WithSquashedCommits() OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.SquashedCommitsFlag = true
+	}
+}
+
+// WithStrict fails the analysis on commits that do not follow the Conventional Commits specification or whose type has
+// no release rule.
+func WithStrict() OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.StrictFlag = true
+	}
+}
+
+// WithSkipReleaseMarkers sets the markers excluding a commit from the version computation when found in its subject or
+// footers, defaults to "[skip release]" and "[no release]".
+func WithSkipReleaseMarkers(markers ...string) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.SkipReleaseMarkersFlag = markers
+	}
+}
+
+// WithTagPrefix sets the prefix of the version in the release tags, defaults to "v". Only the tags with this prefix are
+// considered as releases.
+func WithTagPrefix(prefix string) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.TagPrefixFlag = prefix
+	}
+}
+
+// WithDetectTagPrefix uses the prefix of the tag of the highest version found in the repository instead of the one set
+// by WithTagPrefix, which is only used if the repository has no release yet.
+func WithDetectTagPrefix() OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.DetectTagPrefixFlag = true
+	}
+}
+
+// WithTagIgnorePatterns ignores the tags whose name matches one of the given patterns when looking for the latest
+// released version, such as nightly builds or tags created by other tools.
+func WithTagIgnorePatterns(patterns ...*regexp.Regexp) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.TagIgnorePatterns = append(a.ctx.TagIgnorePatterns, patterns...)
+	}
+}
+
+// WithRange only analyzes the commits reachable from the "to" revision but not from the "from" revision, instead of
+// the commits of the release branches since their latest release. Revisions can be tags, branches or commit hashes, an
+// empty revision keeps the default bound.
+func WithRange(from, to string) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.FromFlag = from
+		a.ctx.ToFlag = to
+	}
+}
+
+// WithCommit analyzes the given commit, which must be reachable from every release branch, instead of the head of the
+// release branches. The revision can be a commit hash or HEAD for the commit checked out in the analyzed repository.
+func WithCommit(revision string) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.CommitFlag = revision
+	}
+}
+
+// WithCache resumes the analysis from the state cached in the Git notes of the repository, under CacheRef, and caches
+// the new analysis state there as notes made by the given signature. Notes are only stored in the analyzed repository,
+// fetching and pushing them is left to the caller.
+func WithCache(signature object.Signature) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.CacheFlag = true
+		a.cacheSignature = signature
+	}
+}
+
+// WithLogger sets the logger used to report the analysis details, nothing is logged by default.
+func WithLogger(logger zerolog.Logger) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.Logger = logger
+	}
+}
+
+// WithSlogHandler sets the log/slog handler to which the analysis details are reported, nothing is logged by default.
+// The handler decides which levels are enabled.
+func WithSlogHandler(handler slog.Handler) OptionFunc {
+	return func(a *Analyzer) {
+		a.ctx.Logger = logging.FromSlog(handler)
+	}
+}
+
+// Analyzer computes the next semantic versions of a Git repository.
+type Analyzer struct {
+	ctx            *appcontext.AppContext
+	rules          map[string][]string
+	cacheSignature object.Signature
+}
+
+// NewAnalyzer returns an Analyzer configured with the given options. At least one release branch is required.
+func NewAnalyzer(options ...OptionFunc) (*Analyzer, error) {
+	a := &Analyzer{
+		ctx: &appcontext.AppContext{
+			Viper:                    viper.New(),
+			Logger:                   zerolog.Nop(),
+			RemoteNameFlag:           defaultRemoteName,
+			TagPrefixFlag:            defaultTagPrefix,
+			SkipReleaseMarkersFlag:   parser.DefaultSkipReleaseMarkers,
+			MajorOnBreakingInDevFlag: true,
+		},
+	}
+
+	for _, option := range options {
+		option(a)
+	}
+
+	if len(a.ctx.Branches) == 0 {
+		return nil, ErrNoBranch
+	}
+
+	for _, b := range a.ctx.Branches {
+		if b.Name == "" {
+			return nil, ErrNoBranchName
+		}
+	}
+
+	for _, project := range a.ctx.Projects {
+		if project.Name == "" || project.Path == "" {
+			return nil, ErrInvalidProject
+		}
+	}
+
+	versioning, err := scheme.New(a.ctx.VersionSchemeFlag, a.ctx.CalVerFormatFlag)
+	if err != nil {
+		return nil, fmt.Errorf("loading versioning scheme: %w", err)
+	}
+
+	a.ctx.Scheme = versioning
+	a.ctx.Rules = rule.Default
+
+	if a.rules != nil {
+		rules, err := rule.Unmarshall(a.rules)
+		if err != nil {
+			return nil, fmt.Errorf("loading rules: %w", err)
+		}
+
+		a.ctx.Rules = rules
+	}
+
+	return a, nil
+}
+
+// Analyze computes the next semantic version of every release branch, and every project in monorepo mode, of a given
+// repository. The release branches are read from the remote tracking branches of the repository, which therefore must
+// be a clone of the repository to analyze.
+//
+// The analysis is read-only: it runs on an in-memory copy of the references of the repository, so neither its branches,
+// HEAD nor its worktree are modified. Only the Git notes of the cache, if enabled, are written to the repository.
+func (a *Analyzer) Analyze(ctx context.Context, repository *git.Repository) ([]Result, error) {
+	isolated, err := isolate(repository)
+	if err != nil {
+		return nil, fmt.Errorf("isolating repository: %w", err)
+	}
+
+	p := parser.New(a.ctx)
+	defer p.Close()
+
+	outputs, err := p.Run(ctx, isolated)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.ctx.CacheFlag {
+		err = p.SaveCache(repository, a.cacheSignature)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]Result, len(outputs))
+
+	for i, output := range outputs {
+		results[i] = Result{
+			Version:    output.Semver,
+			Branch:     output.Branch,
+			Channel:    output.Channel,
+			Project:    output.Project.Name,
+			ReleaseAs:  output.ReleaseAs,
+			Forced:     output.Forced,
+			Commits:    output.Commits,
+			CommitHash: output.CommitHash,
+			BumpedBy:   output.BumpedBy,
+			Skipped:    output.Skipped,
+			TagPrefix:  output.TagPrefix,
+			From:       output.From,
+			To:         output.To,
+			NewRelease: output.NewRelease,
+		}
+	}
+
+	return results, nil
+}
+
+// Tag creates the annotated release tag of the given result, made by the given signature, on the commit the result was
+// computed for, and returns its name. The tag is only created in the given repository, pushing it is left to the
+// caller. ErrNoNewRelease is returned if the result is not a new release, ErrTagAlreadyExists if its tag exists.
+func Tag(repository *git.Repository, result Result, signature object.Signature) (string, error) {
+	if !result.NewRelease {
+		return "", ErrNoNewRelease
+	}
+
+	options := []tag.OptionFunc{tag.WithTagPrefix(result.TagPrefix)}
+
+	if !signature.When.IsZero() {
+		options = append(options, tag.WithClock(func() time.Time { return signature.When }))
+	}
+
+	tagger := tag.NewTagger(signature.Name, signature.Email, options...)
+	tagger.SetProjectName(result.Project)
+
+	err := tagger.TagRepository(repository, result.Version, result.CommitHash)
+	if err != nil {
+		return "", fmt.Errorf("tagging release: %w", err)
+	}
+
+	return tagger.Format(result.Version), nil
+}
+
+// ParseVersion parses a semantic version number such as "1.2.3-rc.1+build".
+func ParseVersion(version string) (*Version, error) {
+	return semver.NewFromString(version)
+}
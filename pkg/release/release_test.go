@@ -0,0 +1,234 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	assertion "github.com/stretchr/testify/assert"
+
+	"github.com/s0ders/go-semver-release/v6/internal/gittest"
+)
+
+func TestAnalyzer_Analyze(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	for _, commitType := range []string{"fix", "feat", "perf"} {
+		_, err = testRepository.AddCommit(commitType)
+		checkErr(t, "adding commit", err)
+	}
+
+	head, err := testRepository.Head()
+	checkErr(t, "fetching head", err)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	t.Cleanup(func() {
+		_ = clonedTestRepository.Remove()
+	})
+
+	analyzer, err := NewAnalyzer(
+		WithBranches(Branch{Name: "master"}),
+		WithRules(map[string][]string{"minor": {"feat", "perf"}, "patch": {"fix"}}),
+		WithBuildMetadata("build.1"),
+	)
+	checkErr(t, "creating analyzer", err)
+
+	results, err := analyzer.Analyze(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "analyzing repository", err)
+
+	assert.Len(results, 1)
+
+	result := results[0]
+
+	assert.True(result.NewRelease)
+	assert.Equal("0.2.0+build.1", result.Version.String())
+	assert.Equal("master", result.Branch)
+	assert.Equal("stable", result.Channel)
+	assert.Equal(head.Hash(), result.CommitHash)
+	assert.Len(result.Commits, 3)
+}
+
+func TestAnalyzer_Analyze_ReadOnly(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	t.Cleanup(func() {
+		_ = clonedTestRepository.Remove()
+	})
+
+	// A local commit, not pushed, and an uncommitted change of the developer's working repository
+	localCommit, err := clonedTestRepository.AddCommit("fix")
+	checkErr(t, "adding local commit", err)
+
+	dirtyPath := filepath.Join(clonedTestRepository.Path, "sample.txt")
+
+	err = os.WriteFile(dirtyPath, []byte("uncommitted"), 0o644)
+	checkErr(t, "writing uncommitted change", err)
+
+	analyzer, err := NewAnalyzer(WithBranches(Branch{Name: "master"}))
+	checkErr(t, "creating analyzer", err)
+
+	results, err := analyzer.Analyze(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "analyzing repository", err)
+
+	if assert.Len(results, 1) {
+		assert.Equal("0.1.0", results[0].Version.String(), "remote branch should have been analyzed")
+	}
+
+	content, err := os.ReadFile(dirtyPath)
+	checkErr(t, "reading uncommitted change", err)
+	assert.Equal("uncommitted", string(content), "uncommitted change should have been kept")
+
+	branch, err := clonedTestRepository.Storer.Reference(plumbing.NewBranchReferenceName("master"))
+	checkErr(t, "fetching local branch", err)
+	assert.Equal(plumbing.NewHashReference(branch.Name(), localCommit), branch, "local branch should not have been moved")
+
+	head, err := clonedTestRepository.Storer.Reference(plumbing.HEAD)
+	checkErr(t, "fetching head", err)
+	assert.Equal(plumbing.NewSymbolicReference(plumbing.HEAD, branch.Name()), head, "head should not have been moved")
+}
+
+func TestRelease_Tag(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	t.Cleanup(func() {
+		_ = clonedTestRepository.Remove()
+	})
+
+	analyzer, err := NewAnalyzer(WithBranches(Branch{Name: "master"}))
+	checkErr(t, "creating analyzer", err)
+
+	results, err := analyzer.Analyze(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "analyzing repository", err)
+
+	signature := object.Signature{Name: "Go Semver Release", Email: "go-semver@release.ci", When: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	name, err := Tag(clonedTestRepository.Repository, results[0], signature)
+	checkErr(t, "tagging release", err)
+	assert.Equal("v0.1.0", name)
+
+	ref, err := clonedTestRepository.Tag(name)
+	checkErr(t, "fetching tag", err)
+
+	tagObject, err := clonedTestRepository.TagObject(ref.Hash())
+	checkErr(t, "fetching tag object", err)
+	assert.Equal(results[0].CommitHash, tagObject.Target, "tag should point to the released commit")
+	assert.Equal(signature.When, tagObject.Tagger.When.UTC(), "tag should be dated by the signature")
+
+	_, err = Tag(clonedTestRepository.Repository, results[0], signature)
+	assert.ErrorIs(err, ErrTagAlreadyExists)
+
+	results[0].NewRelease = false
+
+	_, err = Tag(clonedTestRepository.Repository, results[0], signature)
+	assert.ErrorIs(err, ErrNoNewRelease)
+}
+
+func TestAnalyzer_SlogHandler(t *testing.T) {
+	assert := assertion.New(t)
+
+	testRepository, err := gittest.NewRepository()
+	checkErr(t, "creating repository", err)
+
+	t.Cleanup(func() {
+		_ = testRepository.Remove()
+	})
+
+	_, err = testRepository.AddCommit("feat")
+	checkErr(t, "adding commit", err)
+
+	clonedTestRepository, err := testRepository.Clone()
+	checkErr(t, "cloning test repository", err)
+
+	t.Cleanup(func() {
+		_ = clonedTestRepository.Remove()
+	})
+
+	buf := new(bytes.Buffer)
+
+	analyzer, err := NewAnalyzer(
+		WithBranches(Branch{Name: "master"}),
+		WithSlogHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	)
+	checkErr(t, "creating analyzer", err)
+
+	_, err = analyzer.Analyze(context.Background(), clonedTestRepository.Repository)
+	checkErr(t, "analyzing repository", err)
+
+	assert.Contains(buf.String(), `"level":"DEBUG"`, "analysis details should be reported to the slog handler")
+}
+
+func TestAnalyzer_NewAnalyzerErrors(t *testing.T) {
+	assert := assertion.New(t)
+
+	_, err := NewAnalyzer()
+	assert.ErrorIs(err, ErrNoBranch)
+
+	_, err = NewAnalyzer(WithBranches(Branch{}))
+	assert.ErrorIs(err, ErrNoBranchName)
+
+	_, err = NewAnalyzer(WithBranches(Branch{Name: "main"}), WithProjects(Project{Name: "foo"}))
+	assert.ErrorIs(err, ErrInvalidProject)
+
+	_, err = NewAnalyzer(WithBranches(Branch{Name: "main"}), WithRules(map[string][]string{"major": {"feat"}}))
+	assert.ErrorContains(err, "loading rules")
+
+	_, err = NewAnalyzer(WithBranches(Branch{Name: "main"}), WithCalVer("YYYY"))
+	assert.ErrorContains(err, "loading versioning scheme")
+}
+
+func TestRelease_ParseVersion(t *testing.T) {
+	assert := assertion.New(t)
+
+	version, err := ParseVersion("1.2.3-rc.1")
+	checkErr(t, "parsing version", err)
+
+	assert.Equal(&Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"}, version)
+}
+
+func checkErr(t *testing.T, msg string, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %s", msg, err)
+	}
+}
@@ -0,0 +1,98 @@
+package release
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// isolatedStorage reads the objects of a repository but keeps its references and index in memory, so that the
+// analysis can check out the release branches without moving the branches of the repository.
+type isolatedStorage struct {
+	storage.Storer
+	refs  memory.ReferenceStorage
+	index memory.IndexStorage
+}
+
+// isolate returns a bare repository sharing the objects of the given repository, along with a copy of its references.
+// Analyzing it neither moves the branches nor touches the worktree of the given repository.
+func isolate(repository *git.Repository) (*git.Repository, error) {
+	s := &isolatedStorage{
+		Storer: repository.Storer,
+		refs:   make(memory.ReferenceStorage),
+	}
+
+	refs, err := repository.Storer.IterReferences()
+	if err != nil {
+		return nil, fmt.Errorf("listing references: %w", err)
+	}
+
+	err = refs.ForEach(s.refs.SetReference)
+	if err != nil {
+		return nil, fmt.Errorf("copying references: %w", err)
+	}
+
+	head, err := repository.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return nil, fmt.Errorf("fetching head: %w", err)
+	}
+
+	err = s.refs.SetReference(head)
+	if err != nil {
+		return nil, fmt.Errorf("copying head: %w", err)
+	}
+
+	return git.Open(s, nil)
+}
+
+// Filesystem returns the filesystem of the underlying storage, if any, so that its commit-graph file can be read.
+func (s *isolatedStorage) Filesystem() billy.Filesystem {
+	fs, ok := s.Storer.(interface{ Filesystem() billy.Filesystem })
+	if !ok {
+		return nil
+	}
+
+	return fs.Filesystem()
+}
+
+func (s *isolatedStorage) SetReference(ref *plumbing.Reference) error {
+	return s.refs.SetReference(ref)
+}
+
+func (s *isolatedStorage) CheckAndSetReference(ref, old *plumbing.Reference) error {
+	return s.refs.CheckAndSetReference(ref, old)
+}
+
+func (s *isolatedStorage) Reference(name plumbing.ReferenceName) (*plumbing.Reference, error) {
+	return s.refs.Reference(name)
+}
+
+func (s *isolatedStorage) IterReferences() (storer.ReferenceIter, error) {
+	return s.refs.IterReferences()
+}
+
+func (s *isolatedStorage) RemoveReference(name plumbing.ReferenceName) error {
+	return s.refs.RemoveReference(name)
+}
+
+func (s *isolatedStorage) CountLooseRefs() (int, error) {
+	return s.refs.CountLooseRefs()
+}
+
+func (s *isolatedStorage) PackRefs() error {
+	return s.refs.PackRefs()
+}
+
+func (s *isolatedStorage) SetIndex(idx *index.Index) error {
+	return s.index.SetIndex(idx)
+}
+
+func (s *isolatedStorage) Index() (*index.Index, error) {
+	return s.index.Index()
+}